@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,25 +17,36 @@ import (
 	"github.com/karirnusantara/api/internal/config"
 	"github.com/karirnusantara/api/internal/database"
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/account"
 	"github.com/karirnusantara/api/internal/modules/admin"
+	"github.com/karirnusantara/api/internal/modules/alerts"
 	"github.com/karirnusantara/api/internal/modules/applications"
 	"github.com/karirnusantara/api/internal/modules/auth"
 	"github.com/karirnusantara/api/internal/modules/chat"
 	"github.com/karirnusantara/api/internal/modules/company"
 	"github.com/karirnusantara/api/internal/modules/cvs"
 	"github.com/karirnusantara/api/internal/modules/dashboard"
+	"github.com/karirnusantara/api/internal/modules/interviews"
 	"github.com/karirnusantara/api/internal/modules/jobs"
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	"github.com/karirnusantara/api/internal/modules/notifications"
 	"github.com/karirnusantara/api/internal/modules/partner"
 	"github.com/karirnusantara/api/internal/modules/passwordreset"
+	"github.com/karirnusantara/api/internal/modules/pipelines"
 	"github.com/karirnusantara/api/internal/modules/policies"
 	"github.com/karirnusantara/api/internal/modules/profile"
 	"github.com/karirnusantara/api/internal/modules/quota"
 	"github.com/karirnusantara/api/internal/modules/recommendations"
+	"github.com/karirnusantara/api/internal/modules/talent"
 	"github.com/karirnusantara/api/internal/modules/tickets"
 	"github.com/karirnusantara/api/internal/modules/wishlist"
+	"github.com/karirnusantara/api/internal/shared/clientip"
 	"github.com/karirnusantara/api/internal/shared/email"
 	"github.com/karirnusantara/api/internal/shared/invoice"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/token"
 	"github.com/karirnusantara/api/internal/shared/validator"
 )
 
@@ -45,6 +57,9 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// Log as JSON; the log package writes through the same handler at info level
+	slog.SetDefault(logger.New(&cfg.App))
+
 	// Initialize database connection
 	db, err := database.NewMySQL(cfg.Database)
 	if err != nil {
@@ -59,14 +74,63 @@ func main() {
 
 	// Initialize email service first (needed by auth service)
 	emailConfig := email.LoadConfigFromEnv()
-	emailService := email.NewService(emailConfig)
+	emailConfig.URLs = email.URLs{
+		JobSeeker: cfg.Frontend.JobSeekerURL,
+		Company:   cfg.Frontend.CompanyURL,
+		Admin:     cfg.Frontend.AdminURL,
+		Partner:   cfg.Frontend.PartnerURL,
+	}
+	// Links sent by email are signed with their own secrets, not the JWT secret
+	for name, secret := range map[string]string{
+		"UNSUBSCRIBE_SECRET":        cfg.Signing.UnsubscribeSecret,
+		"EMAIL_VERIFICATION_SECRET": cfg.Signing.EmailVerificationSecret,
+	} {
+		if secret == "" || secret == cfg.JWT.Secret {
+			log.Fatalf("%s must be set to its own secret, separate from JWT_SECRET", name)
+		}
+	}
+	// Optional email carries a signed one-click unsubscribe link
+	emailConfig.UnsubscribeSecret = cfg.Signing.UnsubscribeSecret
+	emailConfig.UnsubscribeURL = cfg.App.PublicURL + "/api/v1/notifications/unsubscribe"
+	if cfg.App.Env == "production" {
+		if err := emailConfig.CheckProduction(); err != nil {
+			log.Fatalf("Invalid email configuration: %v", err)
+		}
+	}
+	emailTransport, err := email.NewTransport(emailConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize email transport: %v", err)
+	}
+	defer emailTransport.Close()
+	log.Printf("Email driver: %s", emailConfig.Driver)
+	// Outgoing email is queued in the database and delivered by the outbox worker,
+	// in the language each recipient chose
+	emailService := email.NewServiceWithOutbox(emailConfig, emailTransport, email.NewOutboxStore(db), email.NewRecipientStore(db))
+
+	// Access tokens and MFA challenges of every portal are issued and verified with the same key set
+	tokenManager := token.NewManager(&cfg.JWT)
+
+	// Initialize two-factor authentication (shared by auth, admin and partner logins)
+	mfaRepo := mfa.NewRepository(db)
+	if cfg.MFA.EncryptionKey == "" || cfg.MFA.EncryptionKey == cfg.JWT.Secret {
+		log.Fatalf("MFA_ENCRYPTION_KEY must be set to its own secret, separate from JWT_SECRET")
+	}
+	mfaService := mfa.NewService(mfaRepo, tokenManager, mfa.EncryptionKeys{
+		KeyID:    cfg.MFA.KeyID,
+		Key:      cfg.MFA.EncryptionKey,
+		Previous: cfg.MFA.PreviousKeys,
+	})
+
+	// Initialize brute-force protection for logins and password resets
+	loginGuardRepo := loginguard.NewRepository(db)
+	loginGuard := loginguard.NewService(loginGuardRepo, emailService)
 
 	// Initialize middleware - need authService for auth middleware
 	// Create auth service first for middleware initialization
 	authRepo := auth.NewRepository(db)
-	authService := auth.NewServiceWithEmail(authRepo, &cfg.JWT, emailService)
+	authService := auth.NewServiceWithVerificationSecret(authRepo, &cfg.JWT, emailService, mfaService, loginGuard, cfg.Signing.EmailVerificationSecret)
 
-	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware := middleware.NewAuthMiddleware(tokenManager)
 
 	// Initialize other repositories
 	jobsRepo := jobs.NewRepository(db)
@@ -78,42 +142,64 @@ func main() {
 	companyRepo := company.NewRepository(db)
 	chatRepo := chat.NewRepository(db)
 	profileRepo := profile.NewRepository(db)
+	accountRepo := account.NewRepository(db)
 	ticketsRepo := tickets.NewRepository(db)
 	passwordResetRepo := passwordreset.NewRepository(db)
 	partnerRepo := partner.NewRepository(db)
+	notificationsRepo := notifications.NewRepository(db)
+	alertsRepo := alerts.NewRepository(db)
+	pipelinesRepo := pipelines.NewRepository(db)
+	interviewsRepo := interviews.NewRepository(db)
+	talentRepo := talent.NewRepository(db)
 
 	// Initialize other services
+	notificationsService := notifications.NewService(notificationsRepo)
+	preferenceService := notifications.NewPreferenceService(notifications.NewPreferenceRepository(db), cfg.Signing.UnsubscribeSecret)
 	quotaService := quota.NewService(quotaRepo)
 	jobsService := jobs.NewServiceWithEmail(jobsRepo, companyRepo, quotaService, emailService)
 	cvsService := cvs.NewService(cvsRepo)
-	applicationsService := applications.NewService(applicationsRepo, cvsService, jobsService, emailService)
+	pipelinesService := pipelines.NewService(pipelinesRepo)
+	applicationsService := applications.NewServiceComplete(applicationsRepo, cvsService, jobsService, emailService, notificationsService, pipelinesService)
+	interviewsService := interviews.NewService(interviewsRepo, pipelinesService, emailService, notificationsService)
+	talentService := talent.NewService(talentRepo, cvsService, emailService, notificationsService)
 	wishlistService := wishlist.NewService(wishlistRepo)
-	dashboardService := dashboard.NewService(dashboardRepo)
+	alertsService := alerts.NewService(alertsRepo, jobsService, emailService)
+	dashboardService := dashboard.NewServiceWithPipelines(dashboardRepo, pipelinesService)
 	companyService := company.NewService(companyRepo)
-	chatService := chat.NewService(chatRepo)
+	chatHub := chat.NewHub()
+	chatService := chat.NewServiceWithHub(chatRepo, notificationsService, chatHub)
 	profileService := profile.NewService(profileRepo)
-	passwordResetService := passwordreset.NewService(passwordResetRepo, emailService)
-	ticketsService := tickets.NewService(ticketsRepo)
+	accountService := account.NewService(accountRepo, "./docs")
+	passwordResetService := passwordreset.NewServiceWithGuard(passwordResetRepo, emailService, loginGuard)
+	ticketsService := tickets.NewServiceWithNotifications(ticketsRepo, notificationsService)
 
 	// Create partner email adapter
 	partnerEmailAdapter := &PartnerEmailAdapter{emailService: emailService}
-	partnerService := partner.NewServiceWithEmail(partnerRepo, &cfg.JWT, "https://partner.karirnusantara.com", partnerEmailAdapter)
+	partnerService := partner.NewServiceWithSecurity(partnerRepo, &cfg.JWT, cfg.Frontend.PartnerURL, partnerEmailAdapter, mfaService, loginGuard)
 
 	// Initialize invoice service
 	invoiceService := invoice.NewService("./docs/invoices")
 
 	// Initialize handlers
 	authHandler := auth.NewHandler(authService, v, emailService)
+	mfaHandler := mfa.NewHandler(mfaService, v)
 	jobsHandler := jobs.NewHandler(jobsService, v)
 	cvsHandler := cvs.NewHandler(cvsService, v)
 	applicationsHandler := applications.NewHandler(applicationsService, v)
 	wishlistHandler := wishlist.NewHandler(wishlistService, v)
+	alertsHandler := alerts.NewHandler(alertsService, v)
+	pipelinesHandler := pipelines.NewHandler(pipelinesService, v)
+	interviewsHandler := interviews.NewHandler(interviewsService, v)
+	talentHandler := talent.NewHandler(talentService, v)
 	quotaHandler := quota.NewHandler(quotaService, v, companyService)
 	dashboardHandler := dashboard.NewHandler(dashboardService)
-	chatHandler := chat.NewHandler(chatService, v, "./docs")
+	chatHandler := chat.NewHandlerWithHub(chatService, v, "./docs", chatHub)
 	profileHandler := profile.NewHandler(profileService, v, "./docs")
+	accountHandler := account.NewHandler(accountService, v)
 	passwordResetHandler := passwordreset.NewHandler(passwordResetService)
 	ticketsHandler := tickets.NewHandler(ticketsService, v)
+	notificationsHandler := notifications.NewHandler(notificationsService)
+	preferenceHandler := notifications.NewPreferenceHandler(preferenceService, v)
 
 	// Initialize recommendations module
 	recommendationsService := recommendations.NewService()
@@ -121,21 +207,27 @@ func main() {
 
 	// Initialize partner module
 	partnerHandler := partner.NewHandler(partnerService, v)
-	partnerMiddleware := partner.NewPartnerMiddleware(partnerService)
+	partnerMiddleware := authMiddleware.ForPortal(token.PortalPartner)
 
 	// Initialize company file service
 	companyFileService := company.NewFileService("./docs/companies")
 	companyHandler := company.NewHandler(companyService, companyFileService)
 
+	// Client IPs for throttling and audit: forwarding headers count only from our own proxies
+	ipResolver, err := clientip.NewResolver(cfg.App.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Setup router
 	r := chi.NewRouter()
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(ipResolver.Middleware)
 	r.Use(middleware.RequestLogger)
 	r.Use(middleware.NewCORS(cfg.CORS.AllowedOrigins))
-	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.Recoverer)
 	r.Use(chimiddleware.Timeout(60 * time.Second))
 
 	// Health check
@@ -151,29 +243,65 @@ func main() {
 	fs := http.FileServer(http.Dir("./docs"))
 	r.Handle("/docs/*", http.StripPrefix("/docs/", fs))
 
+	// Rate limiting: strict for credentials and uploads, lenient for public job browsing
+	rateLimiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), authMiddleware.UserIDFromToken)
+	authLimit := middleware.RateLimitPolicy{Name: "auth", Requests: 10, Per: time.Minute, Burst: 10}
+	uploadLimit := middleware.RateLimitPolicy{Name: "upload", Requests: 20, Per: time.Hour, Burst: 5}
+	browseLimit := middleware.RateLimitPolicy{Name: "browse", Requests: 300, Per: time.Minute, Burst: 100}
+	defaultLimit := middleware.RateLimitPolicy{Name: "api", Requests: cfg.RateLimit.Requests, Per: cfg.RateLimit.Duration, Burst: cfg.RateLimit.Requests}
+	rateLimitRules := []middleware.RateLimitRule{
+		// Uploads first, so the logo upload under /auth is not counted as a login
+		{Method: http.MethodPost, Pattern: "/api/v1/auth/profile/logo", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/profile/avatar", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/profile/documents", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/companies/logo", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/companies/documents", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/*/chat/upload", Policy: uploadLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/auth/*", Policy: authLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/partner/auth/*", Policy: authLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/admin/auth/*", Policy: authLimit},
+		{Method: http.MethodPost, Pattern: "/api/v1/password-reset/*", Policy: authLimit},
+		{Method: http.MethodGet, Pattern: "/api/v1/jobs/*", Policy: browseLimit},
+	}
+
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
+		if cfg.RateLimit.Enabled {
+			r.Use(rateLimiter.ByRoute(rateLimitRules, defaultLimit))
+		}
+
+		// Admin module (its permission checks also guard the support routes of chat and tickets)
+		adminModule := admin.NewModuleWithAccounts(db, cfg, authMiddleware, quotaService, emailService, invoiceService, notificationsService, mfaService, mfaHandler, loginGuard, authService)
+		authMiddleware.CheckActive(token.PortalAdmin, adminModule.ActiveAdmins())
+		supportRead := adminModule.RequirePermission(admin.PermSupportRead)
+		supportReply := adminModule.RequirePermission(admin.PermSupportReply)
+
 		// Register module routes with middleware functions
-		auth.RegisterRoutes(r, authHandler, authMiddleware.Authenticate)
+		auth.RegisterRoutes(r, authHandler, authMiddleware.Authenticate, mfaHandler)
 		jobs.RegisterRoutes(r, jobsHandler, authMiddleware.Authenticate, authMiddleware.RequireCompany, authMiddleware.RequireJobSeeker)
 		cvs.RegisterRoutes(r, cvsHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker)
 		profile.RegisterRoutes(r, profileHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker)
+		account.RegisterRoutes(r, accountHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker)
 		applications.RegisterRoutes(r, applicationsHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker, authMiddleware.RequireCompany)
 		wishlist.RegisterRoutes(r, wishlistHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker)
+		alerts.RegisterRoutes(r, alertsHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker)
+		pipelines.RegisterRoutes(r, pipelinesHandler, authMiddleware.Authenticate, authMiddleware.RequireCompany)
+		interviews.RegisterRoutes(r, interviewsHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker, authMiddleware.RequireCompany)
+		talent.RegisterRoutes(r, talentHandler, authMiddleware.Authenticate, authMiddleware.RequireJobSeeker, authMiddleware.RequireCompany)
 		quota.RegisterRoutes(r, quotaHandler, authMiddleware.Authenticate, authMiddleware.RequireCompany)
 		dashboard.RegisterRoutes(r, dashboardHandler, authMiddleware.Authenticate, authMiddleware.RequireCompany)
 		company.RegisterRoutes(r, companyHandler, authMiddleware.Authenticate)
-		chat.RegisterRoutes(r, chatHandler, authMiddleware)
+		chat.RegisterRoutes(r, chatHandler, authMiddleware, supportRead, supportReply)
 		policies.RegisterRoutes(r)
 		recommendations.RegisterRoutes(r, recommendationsHandler, authMiddleware.Authenticate)
 		passwordreset.RegisterRoutes(r, passwordResetHandler)
-		tickets.RegisterRoutes(r, ticketsHandler, authMiddleware)
+		tickets.RegisterRoutes(r, ticketsHandler, authMiddleware, supportRead, supportReply)
+		notifications.RegisterRoutes(r, notificationsHandler, preferenceHandler, authMiddleware.Authenticate)
 
 		// Partner module routes
-		partner.RegisterRoutes(r, partnerHandler, partnerMiddleware)
+		partner.RegisterRoutes(r, partnerHandler, partnerMiddleware.Authenticate, mfaHandler)
 
 		// Admin module routes
-		adminModule := admin.NewModuleWithQuota(db, cfg, authMiddleware, quotaService, emailService, invoiceService)
 		adminModule.RegisterRoutes(r)
 
 		// Public announcements routes (for all frontends: company, partners, job seekers)
@@ -217,12 +345,38 @@ func main() {
 		}
 	}()
 
+	// Background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if cfg.Workers.JobSweepInterval > 0 {
+		go jobs.NewSweeper(jobsService, cfg.Workers.JobSweepInterval).Run(workerCtx)
+		log.Printf("Job sweeper started (interval: %s)", cfg.Workers.JobSweepInterval)
+	}
+	if cfg.Workers.JobAlertInterval > 0 {
+		go alerts.NewDigestWorker(alertsService, cfg.Workers.JobAlertInterval).Run(workerCtx)
+		log.Printf("Job alert digest worker started (interval: %s)", cfg.Workers.JobAlertInterval)
+	}
+	if cfg.Workers.EmailOutboxInterval > 0 {
+		go email.NewOutboxWorker(emailService, cfg.Workers.EmailWorkers, cfg.Workers.EmailOutboxInterval).Run(workerCtx)
+		log.Printf("Email outbox worker started (interval: %s, workers: %d)", cfg.Workers.EmailOutboxInterval, cfg.Workers.EmailWorkers)
+	}
+	if cfg.Workers.EmailOutboxSweepInterval > 0 && cfg.Workers.EmailOutboxRetention > 0 {
+		go email.NewOutboxSweeper(emailService, cfg.Workers.EmailOutboxRetention, cfg.Workers.EmailOutboxSweepInterval).Run(workerCtx)
+		log.Printf("Email outbox sweeper started (interval: %s, retention: %s)", cfg.Workers.EmailOutboxSweepInterval, cfg.Workers.EmailOutboxRetention)
+	}
+	if cfg.Workers.AccountPurgeInterval > 0 {
+		go account.NewPurger(accountService, cfg.Workers.AccountPurgeInterval).Run(workerCtx)
+		log.Printf("Account purge worker started (interval: %s)", cfg.Workers.AccountPurgeInterval)
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Server is shutting down...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
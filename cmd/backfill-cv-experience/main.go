@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/database"
+	"github.com/karirnusantara/api/internal/modules/cvs"
+)
+
+// Fills cvs.experience_months and cvs.experience_current_since for CVs saved
+// before talent search existed. CVs saved afterwards are kept up to date by the
+// CV service, so this only needs to run once after migration 011.
+func main() {
+	log.Println("Starting CV experience backfill...")
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := database.NewMySQL(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	rows, err := db.QueryContext(ctx, `SELECT id, experience FROM cvs ORDER BY id`)
+	if err != nil {
+		log.Fatalf("Failed to query CVs: %v", err)
+	}
+	defer rows.Close()
+
+	type cvExperience struct {
+		id         uint64
+		experience []byte
+	}
+
+	var pending []cvExperience
+	for rows.Next() {
+		var cv cvExperience
+		if err := rows.Scan(&cv.id, &cv.experience); err != nil {
+			log.Printf("Failed to scan CV: %v", err)
+			continue
+		}
+		pending = append(pending, cv)
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatalf("Failed to read CVs: %v", err)
+	}
+
+	now := time.Now()
+	updated := 0
+	failed := 0
+
+	for _, cv := range pending {
+		var experiences []cvs.Experience
+		if len(cv.experience) > 0 {
+			if err := json.Unmarshal(cv.experience, &experiences); err != nil {
+				log.Printf("Failed to parse experience of CV #%d: %v", cv.id, err)
+				failed++
+				continue
+			}
+		}
+
+		summary := cvs.SummarizeExperience(experiences, now)
+		// Keep the timestamps: the CV content itself does not change
+		_, err := db.ExecContext(ctx, `
+			UPDATE cvs SET
+				experience_months = ?, experience_current_since = ?,
+				last_updated_at = last_updated_at, updated_at = updated_at
+			WHERE id = ?`,
+			summary.Months, summary.CurrentSince, cv.id,
+		)
+		if err != nil {
+			log.Printf("Failed to update CV #%d: %v", cv.id, err)
+			failed++
+			continue
+		}
+		updated++
+	}
+
+	log.Printf("\n=== Summary ===")
+	log.Printf("Updated: %d CVs", updated)
+	log.Printf("Failed: %d CVs", failed)
+}
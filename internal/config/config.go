@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -10,11 +11,16 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
-	Email    EmailConfig
+	App       AppConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	MFA       MFAConfig
+	Signing   SigningConfig
+	CORS      CORSConfig
+	Frontend  FrontendConfig
+	Email     EmailConfig
+	Workers   WorkersConfig
+	RateLimit RateLimitConfig
 }
 
 // AppConfig holds application-specific configuration
@@ -23,6 +29,10 @@ type AppConfig struct {
 	Env   string
 	Port  string
 	Debug bool
+	// PublicURL is the externally reachable base URL of this API, used in links sent by email
+	PublicURL string
+	// TrustedProxies are the load balancer addresses or CIDR ranges whose X-Forwarded-For is believed
+	TrustedProxies []string
 }
 
 // DatabaseConfig holds database configuration
@@ -42,6 +52,29 @@ type JWTConfig struct {
 	Secret        string
 	AccessExpiry  time.Duration
 	RefreshExpiry time.Duration
+	// KeyID identifies Secret in the kid header of issued tokens
+	KeyID string
+	// PreviousKeys are retired secrets by key ID, still accepted for verification during rotation
+	PreviousKeys map[string]string
+}
+
+// MFAConfig holds the keys two-factor secrets are encrypted with
+type MFAConfig struct {
+	// EncryptionKey encrypts TOTP secrets at rest. Required; it must not be the JWT secret.
+	EncryptionKey string
+	// KeyID identifies EncryptionKey and is stored with every secret it encrypts
+	KeyID string
+	// PreviousKeys are retired encryption keys by key ID, still used to decrypt older secrets
+	PreviousKeys map[string]string
+}
+
+// SigningConfig holds the secrets that sign links sent by email. Each is required and must
+// not be the JWT secret, so a leak of one does not let anyone forge the others.
+type SigningConfig struct {
+	// UnsubscribeSecret signs one-click unsubscribe links
+	UnsubscribeSecret string
+	// EmailVerificationSecret signs email verification links
+	EmailVerificationSecret string
 }
 
 // CORSConfig holds CORS configuration
@@ -49,6 +82,14 @@ type CORSConfig struct {
 	AllowedOrigins []string
 }
 
+// FrontendConfig holds the base URLs of the web portals, used for links in emails
+type FrontendConfig struct {
+	JobSeekerURL string
+	CompanyURL   string
+	AdminURL     string
+	PartnerURL   string
+}
+
 // EmailConfig holds email configuration
 type EmailConfig struct {
 	SMTPHost     string
@@ -59,6 +100,31 @@ type EmailConfig struct {
 	FromName     string
 }
 
+// WorkersConfig holds background worker configuration
+type WorkersConfig struct {
+	// JobSweepInterval controls how often expired or full jobs are closed (0 disables)
+	JobSweepInterval time.Duration
+	// JobAlertInterval controls how often due saved search digests are checked (0 disables)
+	JobAlertInterval time.Duration
+	// AccountPurgeInterval controls how often accounts past their deletion grace period are purged (0 disables)
+	AccountPurgeInterval time.Duration
+	// EmailOutboxInterval controls how often queued email is picked up for delivery (0 disables)
+	EmailOutboxInterval time.Duration
+	// EmailWorkers is how many emails are delivered concurrently
+	EmailWorkers int
+	// EmailOutboxSweepInterval controls how often old sent and dead email is deleted (0 disables)
+	EmailOutboxSweepInterval time.Duration
+	// EmailOutboxRetention is how long sent and dead email, bodies included, is kept
+	EmailOutboxRetention time.Duration
+}
+
+// RateLimitConfig holds the default API rate limit. Stricter per-route policies are set in the router.
+type RateLimitConfig struct {
+	Enabled  bool
+	Requests int
+	Duration time.Duration
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file in development
@@ -70,10 +136,12 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		App: AppConfig{
-			Name:  getEnv("APP_NAME", "karir-nusantara-api"),
-			Env:   getEnv("APP_ENV", "development"),
-			Port:  getEnv("APP_PORT", "8081"),
-			Debug: getEnvBool("APP_DEBUG", true),
+			Name:           getEnv("APP_NAME", "karir-nusantara-api"),
+			Env:            getEnv("APP_ENV", "development"),
+			Port:           getEnv("APP_PORT", "8081"),
+			Debug:          getEnvBool("APP_DEBUG", true),
+			PublicURL:      strings.TrimSuffix(getEnv("APP_PUBLIC_URL", "http://localhost:8081"), "/"),
+			TrustedProxies: getEnvSlice("TRUSTED_PROXIES", nil),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", "localhost"),
@@ -89,10 +157,27 @@ func Load() (*Config, error) {
 			Secret:        getEnv("JWT_SECRET", "change-me-in-production"),
 			AccessExpiry:  getEnvDuration("JWT_ACCESS_EXPIRY", 15*time.Minute),
 			RefreshExpiry: getEnvDuration("JWT_REFRESH_EXPIRY", 7*24*time.Hour),
+			KeyID:         getEnv("JWT_KEY_ID", "default"),
+			PreviousKeys:  getEnvMap("JWT_PREVIOUS_KEYS"),
+		},
+		MFA: MFAConfig{
+			EncryptionKey: getEnv("MFA_ENCRYPTION_KEY", ""),
+			KeyID:         getEnv("MFA_KEY_ID", "default"),
+			PreviousKeys:  getEnvMap("MFA_PREVIOUS_KEYS"),
+		},
+		Signing: SigningConfig{
+			UnsubscribeSecret:       getEnv("UNSUBSCRIBE_SECRET", ""),
+			EmailVerificationSecret: getEnv("EMAIL_VERIFICATION_SECRET", ""),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: getEnvSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:5174", "http://localhost:5175", "http://localhost:5176"}),
 		},
+		Frontend: FrontendConfig{
+			JobSeekerURL: strings.TrimRight(getEnv("FRONTEND_JOBSEEKER_URL", "https://karirnusantara.com"), "/"),
+			CompanyURL:   strings.TrimRight(getEnv("FRONTEND_COMPANY_URL", "https://company.karirnusantara.com"), "/"),
+			AdminURL:     strings.TrimRight(getEnv("FRONTEND_ADMIN_URL", "https://admin.karirnusantara.com"), "/"),
+			PartnerURL:   strings.TrimRight(getEnv("FRONTEND_PARTNER_URL", "https://partner.karirnusantara.com"), "/"),
+		},
 		Email: EmailConfig{
 			SMTPHost:     getEnv("SMTP_HOST", "smtp.gmail.com"),
 			SMTPPort:     getEnv("SMTP_PORT", "587"),
@@ -101,6 +186,20 @@ func Load() (*Config, error) {
 			FromEmail:    getEnv("SMTP_FROM_EMAIL", "noreply@karirnusantara.com"),
 			FromName:     getEnv("SMTP_FROM_NAME", "Karir Nusantara"),
 		},
+		Workers: WorkersConfig{
+			JobSweepInterval:         getEnvDuration("JOB_SWEEP_INTERVAL", 15*time.Minute),
+			JobAlertInterval:         getEnvDuration("JOB_ALERT_INTERVAL", time.Hour),
+			AccountPurgeInterval:     getEnvDuration("ACCOUNT_PURGE_INTERVAL", time.Hour),
+			EmailOutboxInterval:      getEnvDuration("EMAIL_OUTBOX_INTERVAL", 10*time.Second),
+			EmailWorkers:             getEnvInt("EMAIL_WORKERS", 4),
+			EmailOutboxSweepInterval: getEnvDuration("EMAIL_OUTBOX_SWEEP_INTERVAL", time.Hour),
+			EmailOutboxRetention:     getEnvDuration("EMAIL_OUTBOX_RETENTION", 30*24*time.Hour),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  getEnvBool("RATE_LIMIT_ENABLED", true),
+			Requests: getEnvInt("RATE_LIMIT_REQUESTS", 100),
+			Duration: getEnvDuration("RATE_LIMIT_DURATION", time.Minute),
+		},
 	}
 
 	return config, nil
@@ -170,3 +269,15 @@ func getEnvSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// getEnvMap parses comma-separated key:value pairs, e.g. "2025-01:old-secret,2025-06:older-secret"
+func getEnvMap(key string) map[string]string {
+	result := map[string]string{}
+	for _, pair := range getEnvSlice(key, nil) {
+		k, v, ok := strings.Cut(pair, ":")
+		if k = strings.TrimSpace(k); ok && k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/karirnusantara/api/internal/modules/auth"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/token"
 )
 
 // ContextKey is a custom type for context keys
@@ -19,20 +21,45 @@ const (
 	UserEmailKey ContextKey = "user_email"
 	// UserRoleKey is the context key for user role
 	UserRoleKey ContextKey = "user_role"
+	// SessionIDKey is the context key for the session (refresh token family) of the access token
+	SessionIDKey ContextKey = "session_id"
 )
 
-// AuthMiddleware handles JWT authentication
+// ActiveFunc reports whether the user of a valid token may still use it
+type ActiveFunc func(ctx context.Context, userID uint64) (bool, error)
+
+// AuthMiddleware handles JWT authentication for one portal
 type AuthMiddleware struct {
-	authService auth.Service
+	tokens *token.Manager
+	portal token.Portal
+	active map[token.Portal]ActiveFunc
 }
 
-// NewAuthMiddleware creates a new auth middleware
-func NewAuthMiddleware(authService auth.Service) *AuthMiddleware {
+// NewAuthMiddleware creates a new auth middleware accepting app (job seeker and company) tokens
+func NewAuthMiddleware(tokens *token.Manager) *AuthMiddleware {
 	return &AuthMiddleware{
-		authService: authService,
+		tokens: tokens,
+		portal: token.PortalApp,
+		active: map[token.Portal]ActiveFunc{},
 	}
 }
 
+// ForPortal returns a middleware accepting only tokens issued for portal
+func (m *AuthMiddleware) ForPortal(portal token.Portal) *AuthMiddleware {
+	return &AuthMiddleware{
+		tokens: m.tokens,
+		portal: portal,
+		active: m.active,
+	}
+}
+
+// CheckActive makes every middleware of portal (including those already derived with ForPortal)
+// ask active on each request whether the token's user is still allowed in, so deactivated
+// accounts are shut out before their access tokens expire
+func (m *AuthMiddleware) CheckActive(portal token.Portal, active ActiveFunc) {
+	m.active[portal] = active
+}
+
 // Authenticate validates the JWT token and sets user info in context
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -43,33 +70,71 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		// Check Bearer prefix
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		tokenString, ok := bearerToken(authHeader)
+		if !ok {
 			response.Unauthorized(w, "Invalid authorization header format")
 			return
 		}
 
-		tokenString := parts[1]
-
 		// Validate token
-		claims, err := m.authService.ValidateAccessToken(tokenString)
+		claims, err := m.tokens.Parse(m.portal, tokenString)
 		if err != nil {
 			response.Unauthorized(w, "Invalid or expired token")
 			return
 		}
 
-		// Set user info in context
-		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
-		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+		active, err := m.isActive(r.Context(), claims)
+		if err != nil {
+			logger.FromContext(r.Context()).Error("failed to check account status", "user_id", claims.UserID, "error", err)
+			response.InternalServerError(w, "Failed to verify account")
+			return
+		}
+		if !active {
+			response.Unauthorized(w, "Account is deactivated")
+			return
+		}
 
-		// Also set with string key for compatibility
-		ctx = context.WithValue(ctx, "user_id", claims.UserID)
-		ctx = context.WithValue(ctx, "user_email", claims.Email)
-		ctx = context.WithValue(ctx, "user_role", claims.Role)
+		next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
+	})
+}
+
+// isActive reports whether the user of claims is still active, when the portal checks it
+func (m *AuthMiddleware) isActive(ctx context.Context, claims *token.Claims) (bool, error) {
+	active := m.active[m.portal]
+	if active == nil {
+		return true, nil
+	}
+	return active(ctx, claims.UserID)
+}
+
+// UserIDFromToken returns the user ID of a valid bearer token of any portal, or 0.
+// Unlike Authenticate it never rejects the request; the rate limiter uses it to key by user.
+func (m *AuthMiddleware) UserIDFromToken(r *http.Request) uint64 {
+	tokenString, ok := bearerToken(r.Header.Get("Authorization"))
+	if !ok {
+		return 0
+	}
+
+	for _, portal := range []token.Portal{m.portal, token.PortalApp, token.PortalAdmin, token.PortalPartner} {
+		if claims, err := m.tokens.Parse(portal, tokenString); err == nil {
+			return claims.UserID
+		}
+	}
+	return 0
+}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+// AuthenticateQueryToken works like Authenticate but also accepts the access token
+// from the access_token query parameter, for clients that cannot set headers
+// (e.g. the browser EventSource API used for Server-Sent Events)
+func (m *AuthMiddleware) AuthenticateQueryToken(next http.Handler) http.Handler {
+	authenticate := m.Authenticate(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			if token := r.URL.Query().Get("access_token"); token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		authenticate.ServeHTTP(w, r)
 	})
 }
 
@@ -120,36 +185,65 @@ func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
 // OptionalAuth tries to authenticate but doesn't fail if no token is provided
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
+		tokenString, ok := bearerToken(r.Header.Get("Authorization"))
+		if !ok {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		claims, err := m.tokens.Parse(m.portal, tokenString)
+		if err != nil {
 			next.ServeHTTP(w, r)
 			return
 		}
-
-		tokenString := parts[1]
-		claims, err := m.authService.ValidateAccessToken(tokenString)
-		if err != nil {
+		if active, err := m.isActive(r.Context(), claims); err != nil || !active {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
-		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
-		ctx = context.WithValue(ctx, "user_id", claims.UserID)
-		ctx = context.WithValue(ctx, "user_email", claims.Email)
-		ctx = context.WithValue(ctx, "user_role", claims.Role)
-
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(withClaims(r.Context(), claims)))
 	})
 }
 
+// bearerToken extracts the token from a "Bearer <token>" Authorization header
+func bearerToken(authHeader string) (string, bool) {
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// withClaims sets the user info of verified claims in ctx
+func withClaims(ctx context.Context, claims *token.Claims) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+	ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+	ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
+
+	// Also set with string key for compatibility
+	ctx = context.WithValue(ctx, "user_id", claims.UserID)
+	ctx = context.WithValue(ctx, "user_email", claims.Email)
+	ctx = context.WithValue(ctx, "user_role", claims.Role)
+	ctx = context.WithValue(ctx, "session_id", claims.SessionID)
+
+	// Partner portal
+	if claims.PartnerID != 0 {
+		ctx = context.WithValue(ctx, "partner_id", claims.PartnerID)
+		ctx = context.WithValue(ctx, "referral_code", claims.ReferralCode)
+	}
+
+	// Tag logs of the request with the caller
+	if user, ok := ctx.Value(requestUserKey{}).(*requestUser); ok {
+		user.userID, user.role = claims.UserID, claims.Role
+	}
+	args := []interface{}{"user_id", claims.UserID, "role", claims.Role}
+	if claims.PartnerID != 0 {
+		args = append(args, "partner_id", claims.PartnerID)
+	}
+	return logger.With(ctx, args...)
+}
+
 // Helper functions to get user info from context
 
 // GetUserID returns the user ID from context
@@ -168,6 +262,14 @@ func GetUserEmail(ctx context.Context) string {
 	return ""
 }
 
+// GetSessionID returns the session ID of the access token from context
+func GetSessionID(ctx context.Context) string {
+	if sessionID, ok := ctx.Value(SessionIDKey).(string); ok {
+		return sessionID
+	}
+	return ""
+}
+
 // GetUserRole returns the user role from context
 func GetUserRole(ctx context.Context) string {
 	if role, ok := ctx.Value(UserRoleKey).(string); ok {
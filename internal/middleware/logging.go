@@ -1,43 +1,67 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"net/http"
 	"runtime/debug"
 	"time"
 
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
+
+	"github.com/karirnusantara/api/internal/shared/clientip"
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
-// RequestLogger logs incoming requests
+// requestUser is filled in by AuthMiddleware so the access log can name the caller
+type requestUser struct {
+	userID uint64
+	role   string
+}
+
+type requestUserKey struct{}
+
+// RequestLogger gives each request a logger tagged with its request ID and logs the request
+// once it completes. Register it after chi's RequestID middleware to reuse its ID.
 func RequestLogger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
-		// Generate request ID
-		requestID := r.Header.Get("X-Request-ID")
+		requestID := chimiddleware.GetReqID(r.Context())
+		if requestID == "" {
+			requestID = r.Header.Get("X-Request-ID")
+		}
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 		w.Header().Set("X-Request-ID", requestID)
 
+		user := &requestUser{}
+		ctx := context.WithValue(r.Context(), requestUserKey{}, user)
+		ctx = logger.With(ctx, "request_id", requestID)
+
 		// Wrap response writer to capture status code
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		// Process request
-		next.ServeHTTP(wrapped, r)
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
 
-		// Log request
-		duration := time.Since(start)
-		log.Printf(
-			"[%s] %s %s %s %d %s",
-			requestID,
-			r.Method,
-			r.URL.Path,
-			r.RemoteAddr,
-			wrapped.statusCode,
-			duration,
-		)
+		attrs := []interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", wrapped.statusCode,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"ip", clientip.FromContext(ctx),
+		}
+		if user.userID != 0 {
+			attrs = append(attrs, "user_id", user.userID, "role", user.role)
+		}
+		level := slog.LevelInfo
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.FromContext(ctx).Log(ctx, level, "request completed", attrs...)
 	})
 }
 
@@ -52,14 +76,27 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// Recoverer recovers from panics
+// Unwrap exposes the underlying writer so http.ResponseController can flush
+// and adjust deadlines (needed for streaming responses)
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Recoverer recovers from panics and logs them with the request's logger
 func Recoverer(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Printf("[PANIC] Recovered for %s %s: %v", r.Method, r.URL.Path, err)
-				// Print stack trace for debugging
-				debug.PrintStack()
+				if err == http.ErrAbortHandler {
+					// Aborted responses are not errors; let net/http handle them
+					panic(err)
+				}
+				logger.FromContext(r.Context()).Error("panic recovered",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"panic", err,
+					"stack", string(debug.Stack()),
+				)
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			}
 		}()
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/clientip"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// RateLimitPolicy is a token bucket: Burst requests at once, refilled at Requests per Per
+type RateLimitPolicy struct {
+	// Name keeps the buckets of different policies apart
+	Name     string
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// refillInterval returns how long it takes to add one token
+func (p RateLimitPolicy) refillInterval() time.Duration {
+	return p.Per / time.Duration(p.Requests)
+}
+
+// RateLimitStore keeps token buckets. The in-memory store suits a single instance;
+// a shared store (e.g. Redis) can implement the same interface for several instances.
+type RateLimitStore interface {
+	// Take removes a token from the bucket for key. When no token is left it returns
+	// false and how long until the next one is available.
+	Take(ctx context.Context, key string, policy RateLimitPolicy, now time.Time) (bool, time.Duration, error)
+}
+
+// rateLimitBucket is one token bucket in the in-memory store
+type rateLimitBucket struct {
+	tokens float64
+	last   time.Time
+	policy RateLimitPolicy
+}
+
+// MemoryRateLimitStore is a RateLimitStore kept in process memory
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*rateLimitBucket)}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{tokens: float64(policy.Burst), last: now, policy: policy}
+		s.buckets[key] = bucket
+	}
+
+	// Refill for the time since the last request
+	interval := policy.refillInterval()
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens += float64(elapsed) / float64(interval)
+		if bucket.tokens > float64(policy.Burst) {
+			bucket.tokens = float64(policy.Burst)
+		}
+		bucket.last = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - bucket.tokens) * float64(interval)), nil
+}
+
+// sweep drops buckets that have refilled completely, at most once a minute
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		full := time.Duration(bucket.policy.Burst) * bucket.policy.refillInterval()
+		if now.Sub(bucket.last) > full {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// RateLimitRule applies a policy to requests matching Method (empty for any) and Pattern.
+// A pattern ending in "/*" matches everything below it; otherwise "*" matches one path segment.
+type RateLimitRule struct {
+	Method  string
+	Pattern string
+	Policy  RateLimitPolicy
+}
+
+// matches reports whether the rule applies to r
+func (rule RateLimitRule) matches(r *http.Request) bool {
+	if rule.Method != "" && rule.Method != r.Method {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(rule.Pattern, "/*"); ok {
+		return r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/")
+	}
+	matched, _ := path.Match(rule.Pattern, r.URL.Path)
+	return matched
+}
+
+// RateLimiter limits requests per user, or per client IP for anonymous requests
+type RateLimiter struct {
+	store    RateLimitStore
+	identify func(r *http.Request) uint64
+	now      func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter. identify may resolve the user of a request that has not
+// passed Authenticate yet (see AuthMiddleware.UserIDFromToken); it can be nil.
+func NewRateLimiter(store RateLimitStore, identify func(r *http.Request) uint64) *RateLimiter {
+	return &RateLimiter{
+		store:    store,
+		identify: identify,
+		now:      time.Now,
+	}
+}
+
+// Limit applies one policy to every request, e.g. with r.Use or r.With on a route group
+func (l *RateLimiter) Limit(policy RateLimitPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if l.allow(w, r, policy) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// ByRoute applies the policy of the first matching rule, or fallback when none matches
+func (l *RateLimiter) ByRoute(rules []RateLimitRule, fallback RateLimitPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy := fallback
+			for _, rule := range rules {
+				if rule.matches(r) {
+					policy = rule.Policy
+					break
+				}
+			}
+
+			if l.allow(w, r, policy) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// allow takes a token for the request, writing a 429 response when there is none.
+// Store errors let the request through so an outage of a shared store does not take the API down.
+func (l *RateLimiter) allow(w http.ResponseWriter, r *http.Request, policy RateLimitPolicy) bool {
+	if policy.Requests <= 0 || policy.Per <= 0 || policy.Burst <= 0 {
+		return true
+	}
+
+	key := policy.Name + ":" + l.key(r)
+	allowed, retryAfter, err := l.store.Take(r.Context(), key, policy, l.now())
+	if err != nil {
+		logger.FromContext(r.Context()).Error("rate limit store error", "key", key, "error", err)
+		return true
+	}
+	if allowed {
+		return true
+	}
+
+	seconds := int((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	response.Error(w, http.StatusTooManyRequests, apperrors.ErrCodeTooManyRequests,
+		fmt.Sprintf("Terlalu banyak permintaan. Silakan coba lagi dalam %d detik.", seconds))
+	return false
+}
+
+// key identifies who a request counts against
+func (l *RateLimiter) key(r *http.Request) string {
+	userID := GetUserID(r.Context())
+	if userID == 0 && l.identify != nil {
+		userID = l.identify(r)
+	}
+	if userID != 0 {
+		return "user:" + strconv.FormatUint(userID, 10)
+	}
+	if ip := clientip.FromContext(r.Context()); ip != "" {
+		return "ip:" + ip
+	}
+	return "ip:" + clientip.FromRequest(r)
+}
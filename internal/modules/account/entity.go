@@ -0,0 +1,88 @@
+package account
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DeletionGracePeriod is how long a deletion request can still be cancelled
+// before the account is purged
+const DeletionGracePeriod = 30 * 24 * time.Hour
+
+// Deletion statuses
+const (
+	DeletionStatusNone      = "none"
+	DeletionStatusScheduled = "scheduled"
+	DeletionStatusCompleted = "completed"
+)
+
+// DeletedUserName replaces the name of a purged account wherever it is still referenced
+const DeletedUserName = "Pengguna Terhapus"
+
+// Record is a database row exported as-is, keyed by column name
+type Record map[string]interface{}
+
+// ExportData holds everything stored about a job seeker
+type ExportData struct {
+	User         Record   `json:"user"`
+	Profile      Record   `json:"profile"`
+	CV           Record   `json:"cv"`
+	CVSnapshots  []Record `json:"cv_snapshots"`
+	Applications []Record `json:"applications"`
+	SavedJobs    []Record `json:"saved_jobs"`
+	Tickets      []Record `json:"tickets"`
+	Documents    []Record `json:"documents"`
+	// Emails lists the mail sent to the user, without message bodies
+	Emails                  []Record `json:"emails"`
+	NotificationPreferences []Record `json:"notification_preferences"`
+	// LoginThrottles are the failed sign-in and password reset counters kept for the user's email
+	LoginThrottles []Record `json:"login_throttles"`
+}
+
+// AccountUser is the part of a user row needed to handle deletion
+type AccountUser struct {
+	ID           uint64         `db:"id"`
+	Email        string         `db:"email"`
+	PasswordHash string         `db:"password_hash"`
+	Role         string         `db:"role"`
+	FullName     string         `db:"full_name"`
+	AvatarURL    sql.NullString `db:"avatar_url"`
+	DeletedAt    sql.NullTime   `db:"deleted_at"`
+}
+
+// DeletionRequest is a pending or finished account deletion
+type DeletionRequest struct {
+	ID          uint64         `db:"id"`
+	UserID      uint64         `db:"user_id"`
+	Reason      sql.NullString `db:"reason"`
+	RequestedAt time.Time      `db:"requested_at"`
+	PurgeAfter  time.Time      `db:"purge_after"`
+	CancelledAt sql.NullTime   `db:"cancelled_at"`
+	CompletedAt sql.NullTime   `db:"completed_at"`
+}
+
+// DeleteAccountRequest confirms an account deletion with the current password
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+	Reason   string `json:"reason" validate:"omitempty,max=1000"`
+}
+
+// DeletionResponse describes the deletion state of the signed-in account
+type DeletionResponse struct {
+	Status      string `json:"status"`
+	RequestedAt string `json:"requested_at,omitempty"`
+	PurgeAfter  string `json:"purge_after,omitempty"`
+}
+
+// ToResponse converts DeletionRequest to DeletionResponse
+func (d *DeletionRequest) ToResponse() *DeletionResponse {
+	status := DeletionStatusScheduled
+	if d.CompletedAt.Valid {
+		status = DeletionStatusCompleted
+	}
+	return &DeletionResponse{
+		Status:      status,
+		RequestedAt: d.RequestedAt.Format(time.RFC3339),
+		PurgeAfter:  d.PurgeAfter.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,124 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for account data export and deletion
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new account handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// ExportData downloads a ZIP archive of the user's personal data
+// GET /api/v1/account/export
+func (h *Handler) ExportData(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	out := &exportWriter{
+		w:        w,
+		filename: fmt.Sprintf("karirnusantara_data_%d_%s.zip", userID, time.Now().Format("20060102")),
+	}
+	if err := h.service.ExportData(r.Context(), userID, out); err != nil {
+		if out.started {
+			// Headers are already sent; the client receives a truncated archive
+			logger.FromContext(r.Context()).Error("data export failed after writing", "error", err)
+			return
+		}
+		handleError(w, err)
+	}
+}
+
+// RequestDeletion schedules the account for deletion
+// DELETE /api/v1/account
+func (h *Handler) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	var req DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+	if errs := h.validator.Validate(&req); errs != nil {
+		response.UnprocessableEntity(w, "Validation failed", errs)
+		return
+	}
+
+	deletion, err := h.service.RequestDeletion(r.Context(), middleware.GetUserID(r.Context()), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusAccepted, "Akun akan dihapus setelah masa tenggang berakhir", deletion)
+}
+
+// GetDeletion returns the deletion status of the account
+// GET /api/v1/account/deletion
+func (h *Handler) GetDeletion(w http.ResponseWriter, r *http.Request) {
+	deletion, err := h.service.GetDeletion(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Account deletion status retrieved", deletion)
+}
+
+// CancelDeletion cancels a scheduled deletion
+// DELETE /api/v1/account/deletion
+func (h *Handler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.CancelDeletion(r.Context(), middleware.GetUserID(r.Context())); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Penghapusan akun dibatalkan", nil)
+}
+
+// exportWriter sends the download headers on the first write, so errors found before
+// anything is written can still be returned as a normal JSON error response
+type exportWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", "application/zip")
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", e.filename))
+		e.w.Header().Set("Cache-Control", "no-store")
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+// handleError handles errors and sends appropriate response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
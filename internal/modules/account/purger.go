@@ -0,0 +1,62 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// purgeBatchSize limits how many accounts are purged per batch
+const purgeBatchSize = 50
+
+// Purger periodically carries out account deletions whose grace period has ended
+type Purger struct {
+	service  Service
+	interval time.Duration
+}
+
+// NewPurger creates a new account purger
+func NewPurger(service Service, interval time.Duration) *Purger {
+	return &Purger{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run purges once immediately and then on every interval until ctx is cancelled
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.purge(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.purge(ctx)
+		}
+	}
+}
+
+// purge carries out due deletions in batches until none are left
+func (p *Purger) purge(ctx context.Context) {
+	total := 0
+	for {
+		purged, err := p.service.PurgeDueDeletions(ctx, time.Now(), purgeBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("account purge failed", "error", err)
+			return
+		}
+		total += purged
+		if purged < purgeBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.FromContext(ctx).Info("accounts purged", "count", total)
+	}
+}
@@ -0,0 +1,428 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the account data export and deletion repository interface
+type Repository interface {
+	// Export
+	GetExportData(ctx context.Context, userID uint64) (*ExportData, error)
+
+	// Deletion
+	GetUser(ctx context.Context, userID uint64) (*AccountUser, error)
+	GetOpenDeletion(ctx context.Context, userID uint64) (*DeletionRequest, error)
+	CreateDeletion(ctx context.Context, deletion *DeletionRequest) error
+	CancelDeletion(ctx context.Context, id uint64) error
+	GetDueDeletions(ctx context.Context, now time.Time, limit int) ([]*DeletionRequest, error)
+	GetDocumentURLs(ctx context.Context, userID uint64) ([]string, error)
+	CompleteDeletion(ctx context.Context, id uint64) error
+
+	// PurgeUser erases a job seeker's personal data in one transaction. CV snapshots
+	// and applications companies still hold are kept but anonymized.
+	PurgeUser(ctx context.Context, userID uint64) error
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new account repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+// GetExportData collects every row stored about a user
+func (r *mysqlRepository) GetExportData(ctx context.Context, userID uint64) (*ExportData, error) {
+	data := &ExportData{}
+
+	users, err := r.queryRecords(ctx, `
+		SELECT id, email, role, full_name, phone, avatar_url, is_active, is_verified,
+			email_verified_at, created_at, updated_at
+		FROM users WHERE id = ?
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	data.User = users[0]
+
+	profiles, err := r.queryRecords(ctx, `SELECT * FROM applicant_profiles WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export profile: %w", err)
+	}
+	if len(profiles) > 0 {
+		data.Profile = profiles[0]
+	}
+
+	cvs, err := r.queryRecords(ctx, `SELECT * FROM cvs WHERE user_id = ?`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export cv: %w", err)
+	}
+	if len(cvs) > 0 {
+		data.CV = cvs[0]
+	}
+
+	if data.CVSnapshots, err = r.queryRecords(ctx,
+		`SELECT * FROM cv_snapshots WHERE user_id = ? ORDER BY id`, userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to export cv snapshots: %w", err)
+	}
+
+	if data.Applications, err = r.queryRecords(ctx, `
+		SELECT a.*, j.title as job_title, c.company_name
+		FROM applications a
+		LEFT JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN companies c ON c.id = j.company_id
+		WHERE a.user_id = ?
+		ORDER BY a.id
+	`, userID); err != nil {
+		return nil, fmt.Errorf("failed to export applications: %w", err)
+	}
+
+	timelines, err := r.queryRecords(ctx, `
+		SELECT t.id, t.application_id, t.status, t.note, t.scheduled_at, t.scheduled_location,
+			t.interview_type, t.meeting_link, t.meeting_platform, t.interview_address, t.created_at
+		FROM application_timelines t
+		JOIN applications a ON a.id = t.application_id
+		WHERE a.user_id = ? AND t.is_visible_to_applicant = 1
+		ORDER BY t.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export application timelines: %w", err)
+	}
+	attachChildren(data.Applications, "id", "timeline", timelines, "application_id")
+
+	answers, err := r.queryRecords(ctx, `
+		SELECT s.id, s.application_id, s.question, s.question_type, s.answer, s.created_at
+		FROM application_screening_answers s
+		JOIN applications a ON a.id = s.application_id
+		WHERE a.user_id = ?
+		ORDER BY s.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export screening answers: %w", err)
+	}
+	attachChildren(data.Applications, "id", "screening_answers", answers, "application_id")
+
+	interviews, err := r.queryRecords(ctx, `
+		SELECT i.id, i.application_id, i.round, i.title, i.status, i.scheduled_at, i.duration_minutes,
+			i.interview_type, i.meeting_platform, i.meeting_link, i.location, i.candidate_note,
+			i.responded_at, i.cancel_reason, i.cancelled_at, i.created_at
+		FROM interviews i
+		JOIN applications a ON a.id = i.application_id
+		WHERE a.user_id = ?
+		ORDER BY i.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export interviews: %w", err)
+	}
+	attachChildren(data.Applications, "id", "interviews", interviews, "application_id")
+
+	if data.SavedJobs, err = r.queryRecords(ctx, `
+		SELECT s.id, s.job_id, j.title as job_title, s.created_at
+		FROM saved_jobs s
+		LEFT JOIN jobs j ON j.id = s.job_id
+		WHERE s.user_id = ?
+		ORDER BY s.id
+	`, userID); err != nil {
+		return nil, fmt.Errorf("failed to export saved jobs: %w", err)
+	}
+
+	if data.Tickets, err = r.queryRecords(ctx,
+		`SELECT * FROM support_tickets WHERE user_id = ? ORDER BY id`, userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to export tickets: %w", err)
+	}
+	responses, err := r.queryRecords(ctx, `
+		SELECT tr.id, tr.ticket_id, tr.sender_type, tr.message, tr.created_at
+		FROM ticket_responses tr
+		JOIN support_tickets t ON t.id = tr.ticket_id
+		WHERE t.user_id = ?
+		ORDER BY tr.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export ticket responses: %w", err)
+	}
+	attachChildren(data.Tickets, "id", "responses", responses, "ticket_id")
+
+	if data.Documents, err = r.queryRecords(ctx,
+		`SELECT * FROM applicant_documents WHERE user_id = ? ORDER BY id`, userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to export documents: %w", err)
+	}
+
+	if data.Emails, err = r.queryRecords(ctx, `
+		SELECT o.id, o.recipient, o.subject, o.status, o.attempts, o.sent_at, o.created_at
+		FROM email_outbox o
+		JOIN users u ON u.email = o.recipient
+		WHERE u.id = ?
+		ORDER BY o.id
+	`, userID); err != nil {
+		return nil, fmt.Errorf("failed to export emails: %w", err)
+	}
+
+	if data.NotificationPreferences, err = r.queryRecords(ctx, `
+		SELECT category, email_enabled, created_at, updated_at
+		FROM notification_preferences WHERE user_id = ? ORDER BY category
+	`, userID); err != nil {
+		return nil, fmt.Errorf("failed to export notification preferences: %w", err)
+	}
+
+	if data.LoginThrottles, err = r.queryRecords(ctx, `
+		SELECT t.scope, t.key_value, t.failed_count, t.last_failed_at, t.locked_until, t.lockout_count,
+			t.unlocked_at, t.created_at
+		FROM auth_throttles t
+		JOIN users u ON t.key_value = LOWER(u.email)
+		WHERE u.id = ? AND t.key_type = 'account'
+		ORDER BY t.id
+	`, userID); err != nil {
+		return nil, fmt.Errorf("failed to export login throttles: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetUser returns the user row needed to handle a deletion
+func (r *mysqlRepository) GetUser(ctx context.Context, userID uint64) (*AccountUser, error) {
+	var user AccountUser
+	query := `SELECT id, email, password_hash, role, full_name, avatar_url, deleted_at FROM users WHERE id = ?`
+	if err := r.db.GetContext(ctx, &user, query, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+// GetOpenDeletion returns the user's deletion request that is neither cancelled nor completed
+func (r *mysqlRepository) GetOpenDeletion(ctx context.Context, userID uint64) (*DeletionRequest, error) {
+	var deletion DeletionRequest
+	query := `
+		SELECT id, user_id, reason, requested_at, purge_after, cancelled_at, completed_at
+		FROM account_deletions
+		WHERE user_id = ? AND cancelled_at IS NULL AND completed_at IS NULL
+		ORDER BY id DESC LIMIT 1
+	`
+	if err := r.db.GetContext(ctx, &deletion, query, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get account deletion: %w", err)
+	}
+	return &deletion, nil
+}
+
+// CreateDeletion stores a new deletion request
+func (r *mysqlRepository) CreateDeletion(ctx context.Context, deletion *DeletionRequest) error {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO account_deletions (user_id, reason, requested_at, purge_after) VALUES (?, ?, ?, ?)`,
+		deletion.UserID, deletion.Reason, deletion.RequestedAt, deletion.PurgeAfter,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account deletion: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get account deletion id: %w", err)
+	}
+	deletion.ID = uint64(id)
+	return nil
+}
+
+// CancelDeletion cancels a deletion request that has not been carried out yet
+func (r *mysqlRepository) CancelDeletion(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE account_deletions SET cancelled_at = NOW() WHERE id = ? AND cancelled_at IS NULL AND completed_at IS NULL`,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	return nil
+}
+
+// GetDueDeletions returns open deletion requests whose grace period ended before now
+func (r *mysqlRepository) GetDueDeletions(ctx context.Context, now time.Time, limit int) ([]*DeletionRequest, error) {
+	query := `
+		SELECT id, user_id, reason, requested_at, purge_after, cancelled_at, completed_at
+		FROM account_deletions
+		WHERE purge_after <= ? AND cancelled_at IS NULL AND completed_at IS NULL
+		ORDER BY purge_after
+		LIMIT ?
+	`
+	var deletions []*DeletionRequest
+	if err := r.db.SelectContext(ctx, &deletions, query, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due account deletions: %w", err)
+	}
+	return deletions, nil
+}
+
+// GetDocumentURLs returns the URLs of all documents a user uploaded
+func (r *mysqlRepository) GetDocumentURLs(ctx context.Context, userID uint64) ([]string, error) {
+	var urls []string
+	if err := r.db.SelectContext(ctx, &urls,
+		`SELECT document_url FROM applicant_documents WHERE user_id = ?`, userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get document urls: %w", err)
+	}
+	return urls, nil
+}
+
+// CompleteDeletion marks a deletion request as carried out
+func (r *mysqlRepository) CompleteDeletion(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE account_deletions SET completed_at = NOW(), reason = NULL WHERE id = ?`, id,
+	); err != nil {
+		return fmt.Errorf("failed to complete account deletion: %w", err)
+	}
+	return nil
+}
+
+// PurgeUser anonymizes what companies still hold and deletes everything else
+func (r *mysqlRepository) PurgeUser(ctx context.Context, userID uint64) error {
+	anonymized, err := json.Marshal(map[string]string{"full_name": DeletedUserName, "email": ""})
+	if err != nil {
+		return fmt.Errorf("failed to build anonymized personal info: %w", err)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	steps := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		// Applications stay with the companies that received them, without personal details
+		{"anonymize cv snapshots", `
+			UPDATE cv_snapshots SET personal_info = ?
+			WHERE user_id = ? AND id IN (SELECT cv_snapshot_id FROM applications WHERE user_id = ?)
+		`, []interface{}{string(anonymized), userID, userID}},
+		{"anonymize applications", `
+			UPDATE applications SET cover_letter = NULL, uploaded_document_id = NULL WHERE user_id = ?
+		`, []interface{}{userID}},
+		// Structured screening answers stay for the company's knockout decision; free text goes
+		{"anonymize screening answers", `
+			UPDATE application_screening_answers SET answer = ''
+			WHERE question_type = 'text' AND application_id IN (SELECT id FROM applications WHERE user_id = ?)
+		`, []interface{}{userID}},
+		{"anonymize interviews", `
+			UPDATE interviews SET candidate_note = NULL
+			WHERE application_id IN (SELECT id FROM applications WHERE user_id = ?)
+		`, []interface{}{userID}},
+		{"delete cv snapshots", `
+			DELETE FROM cv_snapshots
+			WHERE user_id = ? AND id NOT IN (SELECT cv_snapshot_id FROM applications WHERE user_id = ?)
+		`, []interface{}{userID, userID}},
+		// The CV row is kept empty while snapshots still reference it
+		{"clear cv", `
+			UPDATE cvs SET personal_info = ?, education = '[]', experience = '[]', skills = '[]',
+				certifications = '[]', languages = '[]', projects = '[]', completeness_score = 0
+			WHERE user_id = ?
+		`, []interface{}{string(anonymized), userID}},
+		{"delete cv", `
+			DELETE FROM cvs WHERE user_id = ? AND NOT EXISTS (SELECT 1 FROM cv_snapshots s WHERE s.cv_id = cvs.id)
+		`, []interface{}{userID}},
+		{"delete profile", `DELETE FROM applicant_profiles WHERE user_id = ?`, []interface{}{userID}},
+		{"delete documents", `DELETE FROM applicant_documents WHERE user_id = ?`, []interface{}{userID}},
+		{"delete saved jobs", `DELETE FROM saved_jobs WHERE user_id = ?`, []interface{}{userID}},
+		{"delete saved searches", `DELETE FROM saved_searches WHERE user_id = ?`, []interface{}{userID}},
+		{"delete tickets", `DELETE FROM support_tickets WHERE user_id = ?`, []interface{}{userID}},
+		{"delete notifications", `DELETE FROM notifications WHERE user_id = ?`, []interface{}{userID}},
+		{"delete talent pool entries", `DELETE FROM talent_pool_members WHERE user_id = ?`, []interface{}{userID}},
+		{"delete talent invitations", `DELETE FROM talent_invitations WHERE user_id = ?`, []interface{}{userID}},
+		{"delete refresh tokens", `DELETE FROM refresh_tokens WHERE user_id = ?`, []interface{}{userID}},
+		{"delete verification tokens", `DELETE FROM email_verification_tokens WHERE user_id = ?`, []interface{}{userID}},
+		{"delete password resets", `DELETE FROM password_resets WHERE user_id = ?`, []interface{}{userID}},
+		{"delete password reset tokens", `
+			DELETE FROM password_reset_tokens WHERE email = (SELECT email FROM users WHERE id = ?)
+		`, []interface{}{userID}},
+		{"delete mfa recovery codes", `DELETE FROM user_mfa_recovery_codes WHERE user_id = ?`, []interface{}{userID}},
+		{"delete mfa", `DELETE FROM user_mfa WHERE user_id = ?`, []interface{}{userID}},
+		{"delete notification preferences", `DELETE FROM notification_preferences WHERE user_id = ?`, []interface{}{userID}},
+		// Rows keyed by the email address go before the address is replaced below
+		{"delete outbox emails", `
+			DELETE FROM email_outbox WHERE recipient = (SELECT email FROM users WHERE id = ?)
+		`, []interface{}{userID}},
+		{"delete login throttles", `
+			DELETE FROM auth_throttles WHERE key_type = 'account' AND key_value = (SELECT LOWER(email) FROM users WHERE id = ?)
+		`, []interface{}{userID}},
+		// The users row stays so applications keep their foreign key, but can never sign in again
+		{"anonymize user", `
+			UPDATE users SET email = CONCAT('deleted-', id, '@deleted.invalid'), password_hash = '!',
+				full_name = ?, phone = NULL, avatar_url = NULL, is_active = 0, deleted_at = NOW(), updated_at = NOW()
+			WHERE id = ?
+		`, []interface{}{DeletedUserName, userID}},
+	}
+
+	for _, step := range steps {
+		if _, err := tx.ExecContext(ctx, step.query, step.args...); err != nil {
+			return fmt.Errorf("failed to %s: %w", step.name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// queryRecords runs query and returns each row as a Record
+func (r *mysqlRepository) queryRecords(ctx context.Context, query string, args ...interface{}) ([]Record, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		row := map[string]interface{}{}
+		if err := rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		for column, value := range row {
+			if b, ok := value.([]byte); ok {
+				row[column] = decodeColumn(b)
+			}
+		}
+		records = append(records, Record(row))
+	}
+	return records, rows.Err()
+}
+
+// decodeColumn keeps JSON columns as JSON and turns other text into strings
+func decodeColumn(b []byte) interface{} {
+	if len(b) > 0 && (b[0] == '{' || b[0] == '[') && json.Valid(b) {
+		return json.RawMessage(append([]byte(nil), b...))
+	}
+	return string(b)
+}
+
+// attachChildren nests children under their parent records as key
+func attachChildren(parents []Record, parentKey, key string, children []Record, foreignKey string) {
+	byParent := make(map[string][]Record, len(parents))
+	for _, child := range children {
+		id := fmt.Sprint(child[foreignKey])
+		byParent[id] = append(byParent[id], child)
+	}
+	for _, parent := range parents {
+		nested := byParent[fmt.Sprint(parent[parentKey])]
+		if nested == nil {
+			nested = []Record{}
+		}
+		parent[key] = nested
+	}
+}
@@ -0,0 +1,29 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the account data routes
+func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireJobSeeker MiddlewareFunc) {
+	r.Route("/account", func(r chi.Router) {
+		// All routes require authentication as job seeker
+		r.Use(authenticate)
+		r.Use(requireJobSeeker)
+
+		// Download all personal data as a ZIP archive
+		r.Get("/export", h.ExportData)
+
+		// Schedule account deletion (password confirmation required)
+		r.Delete("/", h.RequestDeletion)
+
+		// Deletion status and cancellation during the grace period
+		r.Get("/deletion", h.GetDeletion)
+		r.Delete("/deletion", h.CancelDeletion)
+	})
+}
@@ -0,0 +1,286 @@
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Service defines the account data export and deletion service interface
+type Service interface {
+	// ExportData writes a ZIP archive of everything stored about the user to w.
+	// Nothing is written to w if the data cannot be collected.
+	ExportData(ctx context.Context, userID uint64, w io.Writer) error
+
+	RequestDeletion(ctx context.Context, userID uint64, req *DeleteAccountRequest) (*DeletionResponse, error)
+	GetDeletion(ctx context.Context, userID uint64) (*DeletionResponse, error)
+	CancelDeletion(ctx context.Context, userID uint64) error
+
+	// PurgeDueDeletions carries out deletion requests whose grace period has ended
+	// and returns how many accounts were purged
+	PurgeDueDeletions(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type service struct {
+	repo     Repository
+	docsPath string
+}
+
+// NewService creates a new account service. docsPath is the directory served under /docs.
+func NewService(repo Repository, docsPath string) Service {
+	return &service{repo: repo, docsPath: docsPath}
+}
+
+// exportManifest describes the archive and lists files that could not be included
+type exportManifest struct {
+	GeneratedAt  string   `json:"generated_at"`
+	UserID       uint64   `json:"user_id"`
+	Files        []string `json:"files"`
+	MissingFiles []string `json:"missing_files"`
+}
+
+// ExportData builds the personal data archive
+func (s *service) ExportData(ctx context.Context, userID uint64, w io.Writer) error {
+	data, err := s.repo.GetExportData(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to export account data", err)
+	}
+	if data == nil {
+		return apperrors.NewNotFoundError("User")
+	}
+
+	archive := zip.NewWriter(w)
+	manifest := exportManifest{
+		GeneratedAt:  time.Now().Format(time.RFC3339),
+		UserID:       userID,
+		Files:        []string{},
+		MissingFiles: []string{},
+	}
+
+	sections := []struct {
+		name  string
+		value interface{}
+	}{
+		{"user.json", data.User},
+		{"profile.json", data.Profile},
+		{"cv.json", data.CV},
+		{"cv_snapshots.json", data.CVSnapshots},
+		{"applications.json", data.Applications},
+		{"saved_jobs.json", data.SavedJobs},
+		{"tickets.json", data.Tickets},
+		{"documents.json", data.Documents},
+		{"emails.json", data.Emails},
+		{"notification_preferences.json", data.NotificationPreferences},
+		{"login_throttles.json", data.LoginThrottles},
+	}
+	for _, section := range sections {
+		if err := writeJSONEntry(archive, section.name, section.value); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, section.name)
+	}
+
+	// Uploaded files
+	for _, document := range data.Documents {
+		url, _ := document["document_url"].(string)
+		name := fmt.Sprintf("documents/%v_%s", document["id"], path.Base(url))
+		if err := s.copyUpload(archive, url, name, &manifest); err != nil {
+			return err
+		}
+	}
+	if avatarURL, ok := data.User["avatar_url"].(string); ok && avatarURL != "" {
+		if err := s.copyUpload(archive, avatarURL, "avatar/"+path.Base(avatarURL), &manifest); err != nil {
+			return err
+		}
+	}
+
+	if err := writeJSONEntry(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// copyUpload adds the file behind a /docs URL to the archive, or records it as missing
+func (s *service) copyUpload(archive *zip.Writer, url, name string, manifest *exportManifest) error {
+	filePath, ok := s.localPath(url)
+	if !ok {
+		manifest.MissingFiles = append(manifest.MissingFiles, url)
+		return nil
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		manifest.MissingFiles = append(manifest.MissingFiles, url)
+		return nil
+	}
+	defer file.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	if _, err := io.Copy(entry, file); err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	manifest.Files = append(manifest.Files, name)
+	return nil
+}
+
+// localPath maps a /docs/... URL to a path under docsPath, refusing anything outside it
+func (s *service) localPath(url string) (string, bool) {
+	if !strings.HasPrefix(url, "/docs/") {
+		return "", false
+	}
+	relative := filepath.FromSlash(path.Clean(strings.TrimPrefix(url, "/docs/")))
+	if relative == "." || strings.HasPrefix(relative, "..") || filepath.IsAbs(relative) {
+		return "", false
+	}
+	return filepath.Join(s.docsPath, relative), true
+}
+
+// writeJSONEntry adds value to the archive as indented JSON
+func writeJSONEntry(archive *zip.Writer, name string, value interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to add %s to export: %w", name, err)
+	}
+	return nil
+}
+
+// RequestDeletion schedules the account for deletion after the grace period
+func (s *service) RequestDeletion(ctx context.Context, userID uint64, req *DeleteAccountRequest) (*DeletionResponse, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get user", err)
+	}
+	if user == nil || user.DeletedAt.Valid {
+		return nil, apperrors.NewNotFoundError("User")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, apperrors.NewBadRequestError("Password tidak sesuai")
+	}
+
+	existing, err := s.repo.GetOpenDeletion(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get account deletion", err)
+	}
+	if existing != nil {
+		return nil, apperrors.NewConflictError("Penghapusan akun sudah dijadwalkan")
+	}
+
+	now := time.Now()
+	deletion := &DeletionRequest{
+		UserID:      userID,
+		Reason:      sql.NullString{String: strings.TrimSpace(req.Reason), Valid: strings.TrimSpace(req.Reason) != ""},
+		RequestedAt: now,
+		PurgeAfter:  now.Add(DeletionGracePeriod),
+	}
+	if err := s.repo.CreateDeletion(ctx, deletion); err != nil {
+		return nil, apperrors.NewInternalError("Failed to schedule account deletion", err)
+	}
+
+	return deletion.ToResponse(), nil
+}
+
+// GetDeletion returns the deletion state of the account
+func (s *service) GetDeletion(ctx context.Context, userID uint64) (*DeletionResponse, error) {
+	deletion, err := s.repo.GetOpenDeletion(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get account deletion", err)
+	}
+	if deletion == nil {
+		return &DeletionResponse{Status: DeletionStatusNone}, nil
+	}
+	return deletion.ToResponse(), nil
+}
+
+// CancelDeletion cancels a scheduled deletion during the grace period
+func (s *service) CancelDeletion(ctx context.Context, userID uint64) error {
+	deletion, err := s.repo.GetOpenDeletion(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get account deletion", err)
+	}
+	if deletion == nil {
+		return apperrors.NewNotFoundError("Account deletion request")
+	}
+
+	if err := s.repo.CancelDeletion(ctx, deletion.ID); err != nil {
+		return apperrors.NewInternalError("Failed to cancel account deletion", err)
+	}
+	return nil
+}
+
+// PurgeDueDeletions anonymizes and erases accounts whose grace period has ended
+func (s *service) PurgeDueDeletions(ctx context.Context, now time.Time, limit int) (int, error) {
+	deletions, err := s.repo.GetDueDeletions(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, deletion := range deletions {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.purge(ctx, deletion); err != nil {
+			logger.FromContext(ctx).Error("failed to purge account", "deleted_user_id", deletion.UserID, "error", err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge erases one account. Files are removed only after the database changes committed.
+func (s *service) purge(ctx context.Context, deletion *DeletionRequest) error {
+	user, err := s.repo.GetUser(ctx, deletion.UserID)
+	if err != nil {
+		return err
+	}
+	documentURLs, err := s.repo.GetDocumentURLs(ctx, deletion.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.PurgeUser(ctx, deletion.UserID); err != nil {
+		return err
+	}
+	if err := s.repo.CompleteDeletion(ctx, deletion.ID); err != nil {
+		return err
+	}
+
+	files := documentURLs
+	if user != nil && user.AvatarURL.Valid {
+		files = append(files, user.AvatarURL.String)
+	}
+	for _, url := range files {
+		if filePath, ok := s.localPath(url); ok {
+			if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				logger.FromContext(ctx).Error("failed to remove file of purged account", "deleted_user_id", deletion.UserID, "path", filePath, "error", err)
+			}
+		}
+	}
+	applicantDir := filepath.Join(s.docsPath, "applicants", fmt.Sprint(deletion.UserID))
+	if err := os.RemoveAll(applicantDir); err != nil {
+		logger.FromContext(ctx).Error("failed to remove documents of purged account", "deleted_user_id", deletion.UserID, "path", applicantDir, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ============================================
+// ADMIN INVITATIONS
+// ============================================
+
+// AdminInvitationExpiry is how long an invitation link stays valid
+const AdminInvitationExpiry = 72 * time.Hour
+
+// Invitation statuses
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// AdminInvitation is an invitation to create an admin account
+type AdminInvitation struct {
+	ID             uint64         `db:"id"`
+	Email          string         `db:"email"`
+	FullName       string         `db:"full_name"`
+	TokenHash      string         `db:"token_hash"`
+	InvitedBy      sql.NullInt64  `db:"invited_by"`
+	InviterName    sql.NullString `db:"inviter_name"`
+	ExpiresAt      time.Time      `db:"expires_at"`
+	AcceptedAt     sql.NullTime   `db:"accepted_at"`
+	AcceptedUserID sql.NullInt64  `db:"accepted_user_id"`
+	RevokedAt      sql.NullTime   `db:"revoked_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	RoleIDs        []uint64       `db:"-"`
+}
+
+// Status returns the invitation status at now
+func (i *AdminInvitation) Status(now time.Time) string {
+	switch {
+	case i.AcceptedAt.Valid:
+		return InvitationStatusAccepted
+	case i.RevokedAt.Valid:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// AdminInvitationResponse represents an invitation in API responses
+type AdminInvitationResponse struct {
+	ID          uint64   `json:"id"`
+	Email       string   `json:"email"`
+	FullName    string   `json:"full_name"`
+	Status      string   `json:"status"`
+	RoleIDs     []uint64 `json:"role_ids"`
+	InvitedBy   uint64   `json:"invited_by,omitempty"`
+	InviterName string   `json:"inviter_name,omitempty"`
+	ExpiresAt   string   `json:"expires_at"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// ToResponse converts AdminInvitation to AdminInvitationResponse
+func (i *AdminInvitation) ToResponse(now time.Time) *AdminInvitationResponse {
+	roleIDs := i.RoleIDs
+	if roleIDs == nil {
+		roleIDs = []uint64{}
+	}
+	return &AdminInvitationResponse{
+		ID:          i.ID,
+		Email:       i.Email,
+		FullName:    i.FullName,
+		Status:      i.Status(now),
+		RoleIDs:     roleIDs,
+		InvitedBy:   uint64(i.InvitedBy.Int64),
+		InviterName: i.InviterName.String,
+		ExpiresAt:   i.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:   i.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// InvitationPreviewResponse is what an invitee sees before setting a password
+type InvitationPreviewResponse struct {
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// InviteAdminRequest represents a request to invite a new admin
+type InviteAdminRequest struct {
+	Email    string   `json:"email" validate:"required,email,max=255"`
+	FullName string   `json:"full_name" validate:"required,max=255"`
+	RoleIDs  []uint64 `json:"role_ids"`
+}
+
+// AcceptInvitationRequest sets the password of an invited admin
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,password"`
+}
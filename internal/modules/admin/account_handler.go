@@ -0,0 +1,201 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/auth"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// AccountHandler handles admin account and invitation HTTP requests
+type AccountHandler struct {
+	service   AccountService
+	validator *validator.Validator
+}
+
+// NewAccountHandler creates a new admin account handler
+func NewAccountHandler(service AccountService) *AccountHandler {
+	return &AccountHandler{service: service, validator: validator.New()}
+}
+
+// InviteAdmin invites a new admin by email
+// POST /api/v1/admin/admins/invitations
+func (h *AccountHandler) InviteAdmin(w http.ResponseWriter, r *http.Request) {
+	var req InviteAdminRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+	if errs := h.validator.Validate(&req); errs != nil {
+		response.UnprocessableEntity(w, "Validation failed", errs)
+		return
+	}
+
+	invitation, err := h.service.InviteAdmin(r.Context(), &req, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeAccountError(w, err, "INVITE_FAILED", "Gagal mengirim undangan admin")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Undangan admin berhasil dikirim", invitation)
+}
+
+// GetInvitations lists pending and expired invitations
+// GET /api/v1/admin/admins/invitations
+func (h *AccountHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	invitations, err := h.service.GetInvitations(r.Context())
+	if err != nil {
+		writeAccountError(w, err, "FETCH_FAILED", "Gagal mengambil daftar undangan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Daftar undangan berhasil diambil", invitations)
+}
+
+// ResendInvitation sends a new link for an invitation
+// POST /api/v1/admin/admins/invitations/{id}/resend
+func (h *AccountHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	invitation, err := h.service.ResendInvitation(r.Context(), id, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeAccountError(w, err, "RESEND_FAILED", "Gagal mengirim ulang undangan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Undangan berhasil dikirim ulang", invitation)
+}
+
+// RevokeInvitation cancels an invitation
+// DELETE /api/v1/admin/admins/invitations/{id}
+func (h *AccountHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	if err := h.service.RevokeInvitation(r.Context(), id, middleware.GetUserID(r.Context())); err != nil {
+		writeAccountError(w, err, "REVOKE_FAILED", "Gagal membatalkan undangan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Undangan berhasil dibatalkan", nil)
+}
+
+// PreviewInvitation shows who an invitation link is for
+// GET /api/v1/admin/auth/invitation?token=
+func (h *AccountHandler) PreviewInvitation(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.service.PreviewInvitation(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		writeAccountError(w, err, "FETCH_FAILED", "Gagal mengambil undangan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Undangan valid", preview)
+}
+
+// AcceptInvitation sets the invitee's password and creates their admin account
+// POST /api/v1/admin/auth/invitation/accept
+func (h *AccountHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+	if errs := h.validator.Validate(&req); errs != nil {
+		response.UnprocessableEntity(w, "Validation failed", errs)
+		return
+	}
+
+	admin, err := h.service.AcceptInvitation(r.Context(), &req)
+	if err != nil {
+		writeAccountError(w, err, "ACCEPT_FAILED", "Gagal menerima undangan")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Akun admin berhasil dibuat, silakan login", admin)
+}
+
+// DeactivateAdmin deactivates an admin account
+// POST /api/v1/admin/admins/{id}/deactivate
+func (h *AccountHandler) DeactivateAdmin(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	admin, err := h.service.DeactivateAdmin(r.Context(), id, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeAccountError(w, err, "DEACTIVATE_FAILED", "Gagal menonaktifkan admin")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Admin berhasil dinonaktifkan", admin)
+}
+
+// ReactivateAdmin reactivates an admin account
+// POST /api/v1/admin/admins/{id}/reactivate
+func (h *AccountHandler) ReactivateAdmin(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	admin, err := h.service.ReactivateAdmin(r.Context(), id, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeAccountError(w, err, "REACTIVATE_FAILED", "Gagal mengaktifkan kembali admin")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Admin berhasil diaktifkan kembali", admin)
+}
+
+// ChangePassword changes the signed-in admin's password
+// PUT /api/v1/admin/auth/password
+func (h *AccountHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req auth.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+	if errs := h.validator.Validate(&req); errs != nil {
+		response.UnprocessableEntity(w, "Validation failed", errs)
+		return
+	}
+
+	if err := h.service.ChangePassword(r.Context(), middleware.GetUserID(r.Context()), &req); err != nil {
+		writeAccountError(w, err, "CHANGE_PASSWORD_FAILED", "Gagal mengubah password")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Password berhasil diubah", nil)
+}
+
+// writeAccountError maps account errors to responses
+func writeAccountError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, ErrInvitationNotFound), errors.Is(err, ErrAdminNotFound), errors.Is(err, ErrRoleNotFound):
+		response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, ErrInvitationInvalid):
+		response.Error(w, http.StatusBadRequest, "INVITATION_INVALID", err.Error())
+	case errors.Is(err, ErrEmailRegistered):
+		response.Error(w, http.StatusConflict, "EMAIL_REGISTERED", err.Error())
+	case errors.Is(err, ErrOwnAccount), errors.Is(err, ErrLastSuperAdmin):
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, ErrPasswordChangeUnavailable):
+		response.Error(w, http.StatusServiceUnavailable, "PASSWORD_CHANGE_UNAVAILABLE", err.Error())
+	default:
+		writeAppError(w, err, fallbackCode, fallbackMessage)
+	}
+}
@@ -0,0 +1,272 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountRepository handles admin account and invitation database operations
+type AccountRepository interface {
+	// Invitations
+	CreateInvitation(ctx context.Context, invitation *AdminInvitation) error
+	GetInvitationByID(ctx context.Context, id uint64) (*AdminInvitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*AdminInvitation, error)
+	GetOpenInvitations(ctx context.Context) ([]*AdminInvitation, error)
+	UpdateInvitationToken(ctx context.Context, id uint64, tokenHash string, expiresAt time.Time) error
+	RevokeInvitation(ctx context.Context, id uint64) error
+	RevokeOpenInvitations(ctx context.Context, email string) error
+
+	// AcceptInvitation creates the admin account with the invitation's roles and marks it accepted.
+	// It returns the new user id, or 0 if the invitation was accepted or revoked concurrently.
+	AcceptInvitation(ctx context.Context, invitation *AdminInvitation, passwordHash string) (uint64, error)
+
+	// Accounts
+	EmailRegistered(ctx context.Context, email string) (bool, error)
+	SetAdminActive(ctx context.Context, id uint64, isActive bool) error
+	CountActiveAdminsWithRole(ctx context.Context, roleName string, excludeID uint64) (int, error)
+}
+
+type accountRepository struct {
+	db *sqlx.DB
+}
+
+// NewAccountRepository creates a new admin account repository
+func NewAccountRepository(db *sqlx.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+const invitationColumns = `
+	i.id, i.email, i.full_name, i.token_hash, i.invited_by, u.full_name as inviter_name,
+	i.expires_at, i.accepted_at, i.accepted_user_id, i.revoked_at, i.created_at
+`
+
+// CreateInvitation stores an invitation and the roles it grants
+func (r *accountRepository) CreateInvitation(ctx context.Context, invitation *AdminInvitation) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO admin_invitations (email, full_name, token_hash, invited_by, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, NOW(), NOW())
+	`, invitation.Email, invitation.FullName, invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get invitation id: %w", err)
+	}
+	invitation.ID = uint64(id)
+
+	for _, roleID := range invitation.RoleIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO admin_invitation_roles (invitation_id, role_id) VALUES (?, ?)`,
+			invitation.ID, roleID,
+		); err != nil {
+			return fmt.Errorf("failed to add invitation role: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetInvitationByID returns an invitation with its roles
+func (r *accountRepository) GetInvitationByID(ctx context.Context, id uint64) (*AdminInvitation, error) {
+	return r.getInvitation(ctx, `SELECT `+invitationColumns+` FROM admin_invitations i LEFT JOIN users u ON u.id = i.invited_by WHERE i.id = ?`, id)
+}
+
+// GetInvitationByTokenHash returns the invitation with the given token hash
+func (r *accountRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*AdminInvitation, error) {
+	return r.getInvitation(ctx, `SELECT `+invitationColumns+` FROM admin_invitations i LEFT JOIN users u ON u.id = i.invited_by WHERE i.token_hash = ?`, tokenHash)
+}
+
+func (r *accountRepository) getInvitation(ctx context.Context, query string, arg interface{}) (*AdminInvitation, error) {
+	var invitation AdminInvitation
+	if err := r.db.GetContext(ctx, &invitation, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if err := r.loadInvitationRoles(ctx, []*AdminInvitation{&invitation}); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetOpenInvitations returns invitations that were neither accepted nor revoked, including expired ones
+func (r *accountRepository) GetOpenInvitations(ctx context.Context) ([]*AdminInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+		FROM admin_invitations i
+		LEFT JOIN users u ON u.id = i.invited_by
+		WHERE i.accepted_at IS NULL AND i.revoked_at IS NULL
+		ORDER BY i.created_at DESC
+	`
+	var invitations []*AdminInvitation
+	if err := r.db.SelectContext(ctx, &invitations, query); err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	if err := r.loadInvitationRoles(ctx, invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// UpdateInvitationToken replaces the token of an invitation and extends it
+func (r *accountRepository) UpdateInvitationToken(ctx context.Context, id uint64, tokenHash string, expiresAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE admin_invitations SET token_hash = ?, expires_at = ?, updated_at = NOW() WHERE id = ?`,
+		tokenHash, expiresAt, id,
+	); err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+	return nil
+}
+
+// RevokeInvitation revokes an open invitation
+func (r *accountRepository) RevokeInvitation(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE admin_invitations SET revoked_at = NOW(), updated_at = NOW() WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL`,
+		id,
+	); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	return nil
+}
+
+// RevokeOpenInvitations revokes every open invitation for an email address
+func (r *accountRepository) RevokeOpenInvitations(ctx context.Context, email string) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE admin_invitations SET revoked_at = NOW(), updated_at = NOW() WHERE email = ? AND accepted_at IS NULL AND revoked_at IS NULL`,
+		email,
+	); err != nil {
+		return fmt.Errorf("failed to revoke invitations: %w", err)
+	}
+	return nil
+}
+
+// AcceptInvitation creates the admin account and assigns the invitation's roles in one transaction
+func (r *accountRepository) AcceptInvitation(ctx context.Context, invitation *AdminInvitation, passwordHash string) (uint64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Claim the invitation first so it can only be used once
+	result, err := tx.ExecContext(ctx, `
+		UPDATE admin_invitations SET accepted_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+	`, invitation.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return 0, nil
+	}
+
+	result, err = tx.ExecContext(ctx, `
+		INSERT INTO users (email, password_hash, role, full_name, is_active, is_verified, email_verified_at, created_at, updated_at)
+		VALUES (?, ?, 'admin', ?, 1, 1, NOW(), NOW(), NOW())
+	`, invitation.Email, passwordHash, invitation.FullName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create admin: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get admin id: %w", err)
+	}
+	userID := uint64(id)
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO admin_user_roles (user_id, role_id, assigned_by, created_at)
+		SELECT ?, role_id, ?, NOW() FROM admin_invitation_roles WHERE invitation_id = ?
+	`, userID, invitation.InvitedBy, invitation.ID); err != nil {
+		return 0, fmt.Errorf("failed to assign admin roles: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE admin_invitations SET accepted_user_id = ? WHERE id = ?`, userID, invitation.ID,
+	); err != nil {
+		return 0, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return userID, nil
+}
+
+// EmailRegistered reports whether any user account uses email
+func (r *accountRepository) EmailRegistered(ctx context.Context, email string) (bool, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM users WHERE email = ?`, email); err != nil {
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SetAdminActive activates or deactivates an admin account
+func (r *accountRepository) SetAdminActive(ctx context.Context, id uint64, isActive bool) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE users SET is_active = ?, updated_at = NOW() WHERE id = ? AND role = 'admin'`,
+		isActive, id,
+	); err != nil {
+		return fmt.Errorf("failed to update admin status: %w", err)
+	}
+	return nil
+}
+
+// CountActiveAdminsWithRole counts active admins other than excludeID holding the named role
+func (r *accountRepository) CountActiveAdminsWithRole(ctx context.Context, roleName string, excludeID uint64) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT u.id)
+		FROM users u
+		JOIN admin_user_roles ur ON ur.user_id = u.id
+		JOIN admin_roles r ON r.id = ur.role_id
+		WHERE u.role = 'admin' AND u.is_active = 1 AND r.name = ? AND u.id <> ?
+	`
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, roleName, excludeID); err != nil {
+		return 0, fmt.Errorf("failed to count admins: %w", err)
+	}
+	return count, nil
+}
+
+// loadInvitationRoles fills in the role ids of invitations
+func (r *accountRepository) loadInvitationRoles(ctx context.Context, invitations []*AdminInvitation) error {
+	if len(invitations) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(invitations))
+	byID := make(map[uint64]*AdminInvitation, len(invitations))
+	for _, invitation := range invitations {
+		ids = append(ids, invitation.ID)
+		byID[invitation.ID] = invitation
+		invitation.RoleIDs = []uint64{}
+	}
+
+	query, args, err := sqlx.In(`SELECT invitation_id, role_id FROM admin_invitation_roles WHERE invitation_id IN (?) ORDER BY role_id`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build invitation roles query: %w", err)
+	}
+
+	var rows []struct {
+		InvitationID uint64 `db:"invitation_id"`
+		RoleID       uint64 `db:"role_id"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to get invitation roles: %w", err)
+	}
+	for _, row := range rows {
+		byID[row.InvitationID].RoleIDs = append(byID[row.InvitationID].RoleIDs, row.RoleID)
+	}
+	return nil
+}
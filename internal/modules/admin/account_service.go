@@ -0,0 +1,392 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/karirnusantara/api/internal/modules/auth"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Account errors
+var (
+	ErrInvitationNotFound        = errors.New("undangan tidak ditemukan")
+	ErrInvitationInvalid         = errors.New("undangan tidak valid atau sudah kedaluwarsa")
+	ErrEmailRegistered           = errors.New("email sudah terdaftar")
+	ErrOwnAccount                = errors.New("anda tidak dapat menonaktifkan akun sendiri")
+	ErrLastSuperAdmin            = errors.New("tidak dapat menonaktifkan super admin aktif terakhir")
+	ErrPasswordChangeUnavailable = errors.New("perubahan password tidak tersedia")
+)
+
+// InvitationSender sends admin invitation emails; *email.Service implements it
+type InvitationSender interface {
+	SendAdminInvitationEmail(to string, fullName string, inviterName string, token string, expiresAt time.Time) error
+}
+
+// PasswordChanger changes a user's password after checking the current one; auth.Service implements it
+type PasswordChanger interface {
+	ChangePassword(ctx context.Context, userID uint64, req *auth.ChangePasswordRequest) error
+}
+
+// AccountService manages admin accounts: invitations, activation and passwords
+type AccountService interface {
+	InviteAdmin(ctx context.Context, req *InviteAdminRequest, adminID uint64) (*AdminInvitationResponse, error)
+	GetInvitations(ctx context.Context) ([]*AdminInvitationResponse, error)
+	ResendInvitation(ctx context.Context, id uint64, adminID uint64) (*AdminInvitationResponse, error)
+	RevokeInvitation(ctx context.Context, id uint64, adminID uint64) error
+
+	// PreviewInvitation and AcceptInvitation are used by the invitee, who is not signed in
+	PreviewInvitation(ctx context.Context, token string) (*InvitationPreviewResponse, error)
+	AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*AdminUserResponse, error)
+
+	DeactivateAdmin(ctx context.Context, targetID uint64, adminID uint64) (*AdminUserResponse, error)
+	ReactivateAdmin(ctx context.Context, targetID uint64, adminID uint64) (*AdminUserResponse, error)
+	ChangePassword(ctx context.Context, adminID uint64, req *auth.ChangePasswordRequest) error
+}
+
+type accountService struct {
+	repo      AccountRepository
+	roles     RoleRepository
+	audit     Repository
+	mailer    InvitationSender
+	passwords PasswordChanger
+}
+
+// NewAccountService creates a new admin account service. mailer and passwords may be nil,
+// in which case invitations are not emailed and password changes are unavailable.
+func NewAccountService(repo AccountRepository, roles RoleRepository, audit Repository, mailer InvitationSender, passwords PasswordChanger) AccountService {
+	return &accountService{repo: repo, roles: roles, audit: audit, mailer: mailer, passwords: passwords}
+}
+
+// InviteAdmin invites an email address to become an admin with the given roles.
+// Earlier open invitations for the same address are revoked.
+func (s *accountService) InviteAdmin(ctx context.Context, req *InviteAdminRequest, adminID uint64) (*AdminInvitationResponse, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	registered, err := s.repo.EmailRegistered(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		return nil, ErrEmailRegistered
+	}
+
+	roleIDs := make([]uint64, 0, len(req.RoleIDs))
+	seen := make(map[uint64]bool, len(req.RoleIDs))
+	for _, roleID := range req.RoleIDs {
+		if seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		role, err := s.roles.GetRoleByID(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, ErrRoleNotFound
+		}
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	if err := s.repo.RevokeOpenInvitations(ctx, email); err != nil {
+		return nil, err
+	}
+
+	invitation := &AdminInvitation{
+		Email:     email,
+		FullName:  strings.TrimSpace(req.FullName),
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(AdminInvitationExpiry),
+		InvitedBy: sql.NullInt64{Int64: int64(adminID), Valid: true},
+		RoleIDs:   roleIDs,
+	}
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	s.sendInvitation(ctx, invitation, token, adminID)
+	s.logAction(ctx, adminID, "invite_admin", "admin_invitation", invitation.ID, email, nil, invitation.ToResponse(time.Now()))
+	return s.getInvitation(ctx, invitation.ID)
+}
+
+// GetInvitations returns invitations that are pending or expired
+func (s *accountService) GetInvitations(ctx context.Context) ([]*AdminInvitationResponse, error) {
+	invitations, err := s.repo.GetOpenInvitations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]*AdminInvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		responses = append(responses, invitation.ToResponse(now))
+	}
+	return responses, nil
+}
+
+// ResendInvitation issues a new link for an open invitation, invalidating the previous one
+func (s *accountService) ResendInvitation(ctx context.Context, id uint64, adminID uint64) (*AdminInvitationResponse, error) {
+	invitation, err := s.openInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	invitation.TokenHash = tokenHash
+	invitation.ExpiresAt = time.Now().Add(AdminInvitationExpiry)
+	if err := s.repo.UpdateInvitationToken(ctx, id, tokenHash, invitation.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	s.sendInvitation(ctx, invitation, token, adminID)
+	s.logAction(ctx, adminID, "resend_invitation", "admin_invitation", id, invitation.Email, nil, nil)
+	return s.getInvitation(ctx, id)
+}
+
+// RevokeInvitation cancels an open invitation
+func (s *accountService) RevokeInvitation(ctx context.Context, id uint64, adminID uint64) error {
+	invitation, err := s.openInvitation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeInvitation(ctx, id); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, adminID, "revoke_invitation", "admin_invitation", id, invitation.Email, nil, nil)
+	return nil
+}
+
+// PreviewInvitation returns who an invitation is for, so the invitee can confirm before setting a password
+func (s *accountService) PreviewInvitation(ctx context.Context, token string) (*InvitationPreviewResponse, error) {
+	invitation, err := s.pendingInvitation(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &InvitationPreviewResponse{
+		Email:     invitation.Email,
+		FullName:  invitation.FullName,
+		ExpiresAt: invitation.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// AcceptInvitation creates the invited admin account with the chosen password
+func (s *accountService) AcceptInvitation(ctx context.Context, req *AcceptInvitationRequest) (*AdminUserResponse, error) {
+	invitation, err := s.pendingInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address may have registered another account since the invitation was sent
+	registered, err := s.repo.EmailRegistered(ctx, invitation.Email)
+	if err != nil {
+		return nil, err
+	}
+	if registered {
+		return nil, ErrEmailRegistered
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	userID, err := s.repo.AcceptInvitation(ctx, invitation, string(hashedPassword))
+	if err != nil {
+		return nil, err
+	}
+	if userID == 0 {
+		return nil, ErrInvitationInvalid
+	}
+
+	s.logAction(ctx, userID, "accept_invitation", "admin_invitation", invitation.ID, invitation.Email, nil, nil)
+
+	admin, err := s.audit.GetAdminByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin.ToResponse(), nil
+}
+
+// DeactivateAdmin blocks an admin from signing in, shuts out their current tokens
+// (see ActiveAdmins) and removes their permissions
+func (s *accountService) DeactivateAdmin(ctx context.Context, targetID uint64, adminID uint64) (*AdminUserResponse, error) {
+	if targetID == adminID {
+		return nil, ErrOwnAccount
+	}
+
+	admin, err := s.getAdmin(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Someone must remain able to manage admins
+	roles, err := s.roles.GetAdminRoles(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.Name != RoleSuperAdmin {
+			continue
+		}
+		others, err := s.repo.CountActiveAdminsWithRole(ctx, RoleSuperAdmin, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if others == 0 {
+			return nil, ErrLastSuperAdmin
+		}
+	}
+
+	return s.setActive(ctx, admin, false, "deactivate_admin", adminID)
+}
+
+// ReactivateAdmin lets a deactivated admin sign in again
+func (s *accountService) ReactivateAdmin(ctx context.Context, targetID uint64, adminID uint64) (*AdminUserResponse, error) {
+	admin, err := s.getAdmin(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	return s.setActive(ctx, admin, true, "reactivate_admin", adminID)
+}
+
+// ChangePassword changes the signed-in admin's password through the same path as other users
+func (s *accountService) ChangePassword(ctx context.Context, adminID uint64, req *auth.ChangePasswordRequest) error {
+	if s.passwords == nil {
+		return ErrPasswordChangeUnavailable
+	}
+	if err := s.passwords.ChangePassword(ctx, adminID, req); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, adminID, "change_password", "admin", adminID, "", nil, nil)
+	return nil
+}
+
+func (s *accountService) setActive(ctx context.Context, admin *AdminUser, isActive bool, action string, adminID uint64) (*AdminUserResponse, error) {
+	wasActive := admin.IsActive
+	if err := s.repo.SetAdminActive(ctx, admin.ID, isActive); err != nil {
+		return nil, err
+	}
+
+	s.logAction(ctx, adminID, action, "admin", admin.ID, admin.Email,
+		map[string]bool{"is_active": wasActive}, map[string]bool{"is_active": isActive})
+
+	admin.IsActive = isActive
+	return admin.ToResponse(), nil
+}
+
+func (s *accountService) getAdmin(ctx context.Context, id uint64) (*AdminUser, error) {
+	admin, err := s.audit.GetAdminByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return admin, nil
+}
+
+func (s *accountService) getInvitation(ctx context.Context, id uint64) (*AdminInvitationResponse, error) {
+	invitation, err := s.repo.GetInvitationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation.ToResponse(time.Now()), nil
+}
+
+// openInvitation returns an invitation that was neither accepted nor revoked; it may have expired
+func (s *accountService) openInvitation(ctx context.Context, id uint64) (*AdminInvitation, error) {
+	invitation, err := s.repo.GetInvitationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, ErrInvitationNotFound
+	}
+	if status := invitation.Status(time.Now()); status == InvitationStatusAccepted || status == InvitationStatusRevoked {
+		return nil, ErrInvitationInvalid
+	}
+	return invitation, nil
+}
+
+// pendingInvitation returns the usable invitation for a token
+func (s *accountService) pendingInvitation(ctx context.Context, token string) (*AdminInvitation, error) {
+	if token == "" {
+		return nil, ErrInvitationInvalid
+	}
+	invitation, err := s.repo.GetInvitationByTokenHash(ctx, hashInvitationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil || invitation.Status(time.Now()) != InvitationStatusPending {
+		return nil, ErrInvitationInvalid
+	}
+	return invitation, nil
+}
+
+// sendInvitation queues the invitation email with the link
+func (s *accountService) sendInvitation(ctx context.Context, invitation *AdminInvitation, token string, adminID uint64) {
+	if s.mailer == nil {
+		logger.FromContext(ctx).Warn("email not configured, admin invitation not sent", "invitation_id", invitation.ID, "to", invitation.Email)
+		return
+	}
+
+	inviterName := "Admin Karir Nusantara"
+	if inviter, err := s.audit.GetAdminByID(ctx, adminID); err == nil && inviter != nil {
+		inviterName = inviter.FullName
+	}
+
+	if err := s.mailer.SendAdminInvitationEmail(invitation.Email, invitation.FullName, inviterName, token, invitation.ExpiresAt); err != nil {
+		logger.FromContext(ctx).Error("failed to send admin invitation", "invitation_id", invitation.ID, "to", invitation.Email, "error", err)
+	}
+}
+
+func (s *accountService) logAction(ctx context.Context, adminID uint64, action, entityType string, entityID uint64, details string, before, after interface{}) {
+	if s.audit == nil {
+		return
+	}
+	// We don't fail if logging fails
+	entry := newActionLog(ctx, adminID, action, entityType, entityID, details).withSnapshots(before, after)
+	_ = s.audit.LogAdminAction(ctx, entry)
+}
+
+// newInvitationToken returns a random invitation token and the hash stored for it
+func newInvitationToken() (string, string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(bytes)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// ============================================
+// AUDIT LOG
+// ============================================
+
+// Audit log page sizes
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 200
+)
+
+// Audit log export formats
+const (
+	AuditExportCSV  = "csv"
+	AuditExportJSON = "json"
+)
+
+// AuditLogFilter represents filters for querying the audit log
+type AuditLogFilter struct {
+	AdminID    uint64
+	Action     string
+	EntityType string
+	EntityID   *uint64
+	DateFrom   string // YYYY-MM-DD, inclusive
+	DateTo     string // YYYY-MM-DD, inclusive
+	Cursor     uint64 // Only entries with a lower id are returned
+	Limit      int
+}
+
+// AuditLogResponse represents an audit log entry in API responses
+type AuditLogResponse struct {
+	ID         uint64          `json:"id"`
+	AdminID    uint64          `json:"admin_id"`
+	AdminName  string          `json:"admin_name"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   uint64          `json:"entity_id"`
+	Details    string          `json:"details,omitempty"`
+	Permission string          `json:"permission,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// ToResponse converts AdminActionLog to AuditLogResponse
+func (l *AdminActionLog) ToResponse() *AuditLogResponse {
+	resp := &AuditLogResponse{
+		ID:         l.ID,
+		AdminID:    l.AdminID,
+		AdminName:  l.AdminName,
+		Action:     l.Action,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Details:    l.Details.String,
+		Permission: l.Permission.String,
+		IPAddress:  l.IPAddress.String,
+		UserAgent:  l.UserAgent.String,
+		CreatedAt:  l.CreatedAt.Format(time.RFC3339),
+	}
+	if l.OldValues.Valid {
+		resp.Before = json.RawMessage(l.OldValues.String)
+	}
+	if l.NewValues.Valid {
+		resp.After = json.RawMessage(l.NewValues.String)
+	}
+	return resp
+}
+
+// AuditLogPage represents one page of the audit log, newest first
+type AuditLogPage struct {
+	Items      []*AuditLogResponse `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+}
+
+// withSnapshots stores JSON snapshots of the entity before and after the action.
+// A nil snapshot is left empty, e.g. before a create or after a delete.
+func (l *AdminActionLog) withSnapshots(before, after interface{}) *AdminActionLog {
+	l.OldValues = snapshotJSON(before)
+	l.NewValues = snapshotJSON(after)
+	return l
+}
+
+func snapshotJSON(v interface{}) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// encodeAuditCursor and decodeAuditCursor convert the id of the last entry on a page to a cursor
+func encodeAuditCursor(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func decodeAuditCursor(cursor string) (uint64, bool) {
+	if cursor == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// ============================================
+// AUDIT SNAPSHOTS
+// ============================================
+
+// Snapshots hold the fields an admin action can change, so the audit log shows
+// what an entity looked like before and after the action.
+
+func companySnapshot(c *CompanyAdmin) interface{} {
+	if c == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"company_status": c.CompanyStatus.String,
+		"is_active":      c.IsActive,
+	}
+}
+
+func jobSnapshot(j *JobAdmin) interface{} {
+	if j == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"status":       j.Status,
+		"admin_status": j.AdminStatus.String,
+		"admin_note":   j.AdminNote.String,
+	}
+}
+
+func paymentSnapshot(p *PaymentAdmin) interface{} {
+	if p == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"status":          p.Status,
+		"note":            p.Note.String,
+		"confirmed_by_id": p.ConfirmedByID.Int64,
+	}
+}
+
+func jobSeekerSnapshot(js *JobSeekerAdmin) interface{} {
+	if js == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"is_active": js.IsActive,
+	}
+}
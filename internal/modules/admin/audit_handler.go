@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// AuditHandler handles admin audit log HTTP requests
+type AuditHandler struct {
+	service AuditService
+}
+
+// NewAuditHandler creates a new audit log handler
+func NewAuditHandler(service AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetAuditLogs lists audit log entries, newest first
+// GET /api/v1/admin/audit-logs?admin_id=&action=&entity_type=&entity_id=&date_from=&date_to=&cursor=&limit=
+func (h *AuditHandler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseAuditLogFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Limit = parseIntOrDefault(r.URL.Query().Get("limit"), DefaultAuditLogLimit)
+
+	page, err := h.service.GetAuditLogs(r.Context(), filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeAuditError(w, err, "FETCH_FAILED", "Gagal mengambil log audit")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Log audit berhasil diambil", page)
+}
+
+// ExportAuditLogs downloads every matching audit log entry as CSV or JSON
+// GET /api/v1/admin/audit-logs/export?format=csv|json&...filters
+func (h *AuditHandler) ExportAuditLogs(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseAuditLogFilter(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = AuditExportCSV
+	}
+	contentType := "text/csv; charset=utf-8"
+	if format == AuditExportJSON {
+		contentType = "application/json"
+	}
+
+	out := &exportWriter{
+		w:           w,
+		contentType: contentType,
+		filename:    fmt.Sprintf("audit_logs_%s.%s", time.Now().Format("20060102_150405"), format),
+	}
+	if _, err := h.service.ExportAuditLogs(r.Context(), filter, format, out, middleware.GetUserID(r.Context())); err != nil {
+		if out.started {
+			// Headers are already sent; the client receives a truncated file
+			logger.FromContext(r.Context()).Error("audit log export failed after writing", "error", err)
+			return
+		}
+		writeAuditError(w, err, "EXPORT_FAILED", "Gagal mengekspor log audit")
+	}
+}
+
+// exportWriter sends the download headers on the first write, so errors found before
+// anything is written can still be returned as a normal JSON error response
+type exportWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", e.contentType)
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", e.filename))
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+// parseAuditLogFilter reads the audit log filters from the query string
+func parseAuditLogFilter(w http.ResponseWriter, r *http.Request) (AuditLogFilter, bool) {
+	q := r.URL.Query()
+	filter := AuditLogFilter{
+		AdminID:    parseUint64OrDefault(q.Get("admin_id"), 0),
+		Action:     q.Get("action"),
+		EntityType: q.Get("entity_type"),
+		DateFrom:   q.Get("date_from"),
+		DateTo:     q.Get("date_to"),
+	}
+	if raw := q.Get("entity_id"); raw != "" {
+		entityID, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "INVALID_ENTITY_ID", "entity_id tidak valid")
+			return filter, false
+		}
+		filter.EntityID = &entityID
+	}
+	return filter, true
+}
+
+// writeAuditError maps audit log errors to responses
+func writeAuditError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, ErrInvalidAuditCursor), errors.Is(err, ErrInvalidAuditDateRange), errors.Is(err, ErrInvalidExportFormat):
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	default:
+		writeAppError(w, err, fallbackCode, fallbackMessage)
+	}
+}
@@ -0,0 +1,234 @@
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Audit log errors
+var (
+	ErrInvalidAuditCursor    = errors.New("cursor tidak valid")
+	ErrInvalidAuditDateRange = errors.New("rentang tanggal tidak valid, gunakan format YYYY-MM-DD")
+	ErrInvalidExportFormat   = errors.New("format ekspor tidak didukung, gunakan csv atau json")
+)
+
+// auditExportBatchSize is the number of entries read per query while exporting
+const auditExportBatchSize = 500
+
+// auditCSVHeader lists the columns of a CSV export
+var auditCSVHeader = []string{
+	"id", "created_at", "admin_id", "admin_name", "action", "entity_type", "entity_id",
+	"permission", "details", "before", "after", "ip_address", "user_agent",
+}
+
+// AuditService reads the admin audit log
+type AuditService interface {
+	// GetAuditLogs returns a page of entries, newest first. cursor is the next_cursor of the previous page.
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter, cursor string) (*AuditLogPage, error)
+
+	// ExportAuditLogs writes every entry matching filter to w as CSV or JSON and records the export
+	ExportAuditLogs(ctx context.Context, filter AuditLogFilter, format string, w io.Writer, adminID uint64) (int, error)
+}
+
+type auditService struct {
+	repo Repository
+}
+
+// NewAuditService creates a new audit log service
+func NewAuditService(repo Repository) AuditService {
+	return &auditService{repo: repo}
+}
+
+// GetAuditLogs returns a page of audit log entries
+func (s *auditService) GetAuditLogs(ctx context.Context, filter AuditLogFilter, cursor string) (*AuditLogPage, error) {
+	if err := validateAuditDates(filter); err != nil {
+		return nil, err
+	}
+	id, ok := decodeAuditCursor(cursor)
+	if !ok {
+		return nil, ErrInvalidAuditCursor
+	}
+	filter.Cursor = id
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditLogLimit
+	}
+	if filter.Limit > MaxAuditLogLimit {
+		filter.Limit = MaxAuditLogLimit
+	}
+
+	// Read one extra entry to know whether there is a next page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	logs, err := s.repo.GetAuditLogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &AuditLogPage{Items: make([]*AuditLogResponse, 0, limit)}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		page.HasMore = true
+	}
+	for _, l := range logs {
+		page.Items = append(page.Items, l.ToResponse())
+	}
+	if page.HasMore {
+		page.NextCursor = encodeAuditCursor(logs[len(logs)-1].ID)
+	}
+	return page, nil
+}
+
+// ExportAuditLogs streams all matching entries, newest first, reading them in batches
+func (s *auditService) ExportAuditLogs(ctx context.Context, filter AuditLogFilter, format string, w io.Writer, adminID uint64) (int, error) {
+	if format != AuditExportCSV && format != AuditExportJSON {
+		return 0, ErrInvalidExportFormat
+	}
+	if err := validateAuditDates(filter); err != nil {
+		return 0, err
+	}
+
+	var write func(*AdminActionLog) error
+	var finish func() error
+	switch format {
+	case AuditExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(auditCSVHeader); err != nil {
+			return 0, err
+		}
+		write = func(l *AdminActionLog) error { return cw.Write(auditCSVRecord(l)) }
+		finish = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case AuditExportJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, err
+		}
+		first := true
+		write = func(l *AdminActionLog) error {
+			data, err := json.Marshal(l.ToResponse())
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			_, err = w.Write(data)
+			return err
+		}
+		finish = func() error {
+			_, err := io.WriteString(w, "]")
+			return err
+		}
+	}
+
+	count := 0
+	filter.Cursor = 0
+	filter.Limit = auditExportBatchSize
+	for {
+		logs, err := s.repo.GetAuditLogs(ctx, filter)
+		if err != nil {
+			return count, err
+		}
+		for _, l := range logs {
+			if err := write(l); err != nil {
+				return count, fmt.Errorf("failed to write audit log export: %w", err)
+			}
+			count++
+		}
+		if len(logs) < auditExportBatchSize {
+			break
+		}
+		filter.Cursor = logs[len(logs)-1].ID
+	}
+	if err := finish(); err != nil {
+		return count, fmt.Errorf("failed to write audit log export: %w", err)
+	}
+
+	// Exports are themselves audited, with the filters used
+	_ = s.repo.LogAdminAction(ctx, newActionLog(ctx, adminID, "export_audit_logs", "audit_log", 0,
+		fmt.Sprintf("format=%s rows=%d %s", format, count, describeAuditFilter(filter))))
+	return count, nil
+}
+
+// validateAuditDates checks the date filters are YYYY-MM-DD and in order
+func validateAuditDates(filter AuditLogFilter) error {
+	var from, to time.Time
+	var err error
+	if filter.DateFrom != "" {
+		if from, err = time.Parse("2006-01-02", filter.DateFrom); err != nil {
+			return ErrInvalidAuditDateRange
+		}
+	}
+	if filter.DateTo != "" {
+		if to, err = time.Parse("2006-01-02", filter.DateTo); err != nil {
+			return ErrInvalidAuditDateRange
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return ErrInvalidAuditDateRange
+	}
+	return nil
+}
+
+func auditCSVRecord(l *AdminActionLog) []string {
+	return []string{
+		strconv.FormatUint(l.ID, 10),
+		l.CreatedAt.Format(time.RFC3339),
+		strconv.FormatUint(l.AdminID, 10),
+		csvCell(l.AdminName),
+		csvCell(l.Action),
+		csvCell(l.EntityType),
+		strconv.FormatUint(l.EntityID, 10),
+		csvCell(l.Permission.String),
+		csvCell(l.Details.String),
+		csvCell(l.OldValues.String),
+		csvCell(l.NewValues.String),
+		csvCell(l.IPAddress.String),
+		csvCell(l.UserAgent.String),
+	}
+}
+
+// csvCell prefixes text that a spreadsheet would run as a formula with a quote.
+// Audit entries carry user input (names, reasons, user agents), so the export
+// must not let it execute when an admin opens the file.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// describeAuditFilter renders the filters of an export for its own audit entry
+func describeAuditFilter(filter AuditLogFilter) string {
+	var parts []string
+	if filter.AdminID > 0 {
+		parts = append(parts, fmt.Sprintf("admin_id=%d", filter.AdminID))
+	}
+	if filter.Action != "" {
+		parts = append(parts, "action="+filter.Action)
+	}
+	if filter.EntityType != "" {
+		parts = append(parts, "entity_type="+filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		parts = append(parts, fmt.Sprintf("entity_id=%d", *filter.EntityID))
+	}
+	if filter.DateFrom != "" {
+		parts = append(parts, "date_from="+filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		parts = append(parts, "date_to="+filter.DateTo)
+	}
+	return strings.Join(parts, " ")
+}
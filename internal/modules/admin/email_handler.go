@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// EmailHandler handles admin email outbox HTTP requests
+type EmailHandler struct {
+	service EmailService
+}
+
+// NewEmailHandler creates a new email outbox handler
+func NewEmailHandler(service EmailService) *EmailHandler {
+	return &EmailHandler{service: service}
+}
+
+// GetEmails lists outbox messages, failed ones by default
+// GET /api/v1/admin/emails?status=dead|pending|sending|sent|all&cursor=&limit=
+func (h *EmailHandler) GetEmails(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := h.service.GetEmails(r.Context(), q.Get("status"), q.Get("cursor"), parseIntOrDefault(q.Get("limit"), DefaultEmailListLimit))
+	if err != nil {
+		writeEmailError(w, err, "FETCH_FAILED", "Gagal mengambil daftar email")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Daftar email berhasil diambil", page)
+}
+
+// RetryEmail queues a failed email again
+// POST /api/v1/admin/emails/{id}/retry
+func (h *EmailHandler) RetryEmail(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	msg, err := h.service.RetryEmail(r.Context(), id, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeEmailError(w, err, "RETRY_FAILED", "Gagal mengirim ulang email")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Email dijadwalkan untuk dikirim ulang", msg)
+}
+
+// writeEmailError maps email outbox errors to responses
+func writeEmailError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, ErrEmailNotFound):
+		response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, ErrInvalidEmailStatus), errors.Is(err, ErrInvalidAuditCursor):
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case errors.Is(err, ErrEmailOutboxUnavailable):
+		response.Error(w, http.StatusServiceUnavailable, "EMAIL_OUTBOX_UNAVAILABLE", err.Error())
+	default:
+		writeAppError(w, err, fallbackCode, fallbackMessage)
+	}
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/karirnusantara/api/internal/shared/email"
+)
+
+// Email outbox errors
+var (
+	ErrEmailNotFound          = errors.New("email tidak ditemukan atau tidak dalam status gagal")
+	ErrEmailOutboxUnavailable = errors.New("antrian email tidak aktif")
+	ErrInvalidEmailStatus     = errors.New("status email tidak valid, gunakan pending, sending, sent, dead atau all")
+)
+
+// Email outbox list limits
+const (
+	DefaultEmailListLimit = 50
+	MaxEmailListLimit     = 200
+)
+
+// emailStatusAll lists messages of every status
+const emailStatusAll = "all"
+
+// EmailOutbox lists and retries queued email (implemented by *email.Service)
+type EmailOutbox interface {
+	ListOutbox(ctx context.Context, filter email.OutboxFilter) ([]*email.OutboxMessage, error)
+	RetryOutboxMessage(ctx context.Context, id uint64) (*email.OutboxMessage, error)
+}
+
+// EmailOutboxPage is a page of outbox messages, newest first
+type EmailOutboxPage struct {
+	Items      []*email.OutboxMessageResponse `json:"items"`
+	NextCursor string                         `json:"next_cursor,omitempty"`
+	HasMore    bool                           `json:"has_more"`
+}
+
+// EmailService lets admins inspect failed email and queue it again
+type EmailService interface {
+	// GetEmails returns a page of outbox messages with status (dead when empty, all for every status)
+	GetEmails(ctx context.Context, status, cursor string, limit int) (*EmailOutboxPage, error)
+	RetryEmail(ctx context.Context, id, adminID uint64) (*email.OutboxMessageResponse, error)
+}
+
+type emailService struct {
+	outbox EmailOutbox
+	audit  Repository
+}
+
+// NewEmailService creates a new admin email outbox service. outbox may be nil when email is not configured.
+func NewEmailService(outbox EmailOutbox, audit Repository) EmailService {
+	return &emailService{outbox: outbox, audit: audit}
+}
+
+// GetEmails lists outbox messages
+func (s *emailService) GetEmails(ctx context.Context, status, cursor string, limit int) (*EmailOutboxPage, error) {
+	if s.outbox == nil {
+		return nil, ErrEmailOutboxUnavailable
+	}
+
+	switch status {
+	case "":
+		status = email.OutboxStatusDead
+	case emailStatusAll:
+		status = ""
+	case email.OutboxStatusPending, email.OutboxStatusSending, email.OutboxStatusSent, email.OutboxStatusDead:
+	default:
+		return nil, ErrInvalidEmailStatus
+	}
+	id, ok := decodeAuditCursor(cursor)
+	if !ok {
+		return nil, ErrInvalidAuditCursor
+	}
+	if limit <= 0 {
+		limit = DefaultEmailListLimit
+	}
+	if limit > MaxEmailListLimit {
+		limit = MaxEmailListLimit
+	}
+
+	// Read one extra message to know whether there is a next page
+	messages, err := s.outbox.ListOutbox(ctx, email.OutboxFilter{Status: status, Cursor: id, Limit: limit + 1})
+	if err != nil {
+		return nil, mapOutboxError(err)
+	}
+
+	page := &EmailOutboxPage{Items: make([]*email.OutboxMessageResponse, 0, limit)}
+	if len(messages) > limit {
+		messages = messages[:limit]
+		page.HasMore = true
+	}
+	for _, m := range messages {
+		page.Items = append(page.Items, m.ToResponse())
+	}
+	if page.HasMore {
+		page.NextCursor = encodeAuditCursor(messages[len(messages)-1].ID)
+	}
+	return page, nil
+}
+
+// RetryEmail queues a dead-lettered email for delivery again
+func (s *emailService) RetryEmail(ctx context.Context, id, adminID uint64) (*email.OutboxMessageResponse, error) {
+	if s.outbox == nil {
+		return nil, ErrEmailOutboxUnavailable
+	}
+
+	msg, err := s.outbox.RetryOutboxMessage(ctx, id)
+	if err != nil {
+		return nil, mapOutboxError(err)
+	}
+	if msg == nil {
+		return nil, ErrEmailNotFound
+	}
+
+	if s.audit != nil {
+		// We don't fail if logging fails
+		entry := newActionLog(ctx, adminID, "retry_email", "email", id,
+			fmt.Sprintf("Retried email to %s: %s", msg.Recipient, msg.Subject)).
+			withSnapshots(map[string]interface{}{"status": email.OutboxStatusDead}, map[string]interface{}{"status": msg.Status})
+		_ = s.audit.LogAdminAction(ctx, entry)
+	}
+
+	return msg.ToResponse(), nil
+}
+
+// mapOutboxError converts email package errors to admin errors
+func mapOutboxError(err error) error {
+	switch {
+	case errors.Is(err, email.ErrOutboxMessageNotFound):
+		return ErrEmailNotFound
+	case errors.Is(err, email.ErrOutboxDisabled):
+		return ErrEmailOutboxUnavailable
+	default:
+		return err
+	}
+}
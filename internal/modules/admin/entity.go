@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/karirnusantara/api/internal/modules/mfa"
 	"github.com/karirnusantara/api/internal/shared/hashid"
 )
 
@@ -348,6 +349,7 @@ const (
 	PaymentStatusPending   = "pending"
 	PaymentStatusConfirmed = "confirmed"
 	PaymentStatusRejected  = "rejected"
+	PaymentStatusReversed  = "reversed"
 )
 
 // PaymentAdmin represents payment data for admin view
@@ -430,6 +432,8 @@ func getPaymentStatusLabel(status string) string {
 		return "Dikonfirmasi"
 	case PaymentStatusRejected:
 		return "Ditolak"
+	case PaymentStatusReversed:
+		return "Dibatalkan"
 	default:
 		return status
 	}
@@ -589,7 +593,11 @@ type AdminActionLog struct {
 	EntityType string         `db:"entity_type" json:"entity_type"`
 	EntityID   uint64         `db:"entity_id" json:"entity_id"`
 	Details    sql.NullString `db:"details" json:"details,omitempty"`
+	Permission sql.NullString `db:"permission" json:"permission,omitempty"`
 	IPAddress  sql.NullString `db:"ip_address" json:"ip_address,omitempty"`
+	UserAgent  sql.NullString `db:"user_agent" json:"user_agent,omitempty"`
+	OldValues  sql.NullString `db:"old_values" json:"old_values,omitempty"`
+	NewValues  sql.NullString `db:"new_values" json:"new_values,omitempty"`
 	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
 }
 
@@ -603,11 +611,30 @@ type AdminLoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
-// AdminAuthResponse represents admin authentication response
+// AdminAuthResponse represents admin authentication response.
+// When MFARequired is set, no token is issued until the MFA challenge is completed.
 type AdminAuthResponse struct {
-	Admin       *AdminUserResponse `json:"admin"`
-	AccessToken string             `json:"access_token"`
-	ExpiresIn   int64              `json:"expires_in"`
+	Admin         *AdminUserResponse `json:"admin"`
+	AccessToken   string             `json:"access_token"`
+	ExpiresIn     int64              `json:"expires_in"`
+	MFARequired   bool               `json:"mfa_required,omitempty"`
+	MFA           *mfa.Challenge     `json:"mfa,omitempty"`
+	RecoveryCodes []string           `json:"recovery_codes,omitempty"` // Only after enrolling during login
+}
+
+// Setting keys stored in system_settings
+const (
+	SettingRequireAdminMFA = "require_admin_mfa"
+)
+
+// SecuritySettings represents platform-wide security settings
+type SecuritySettings struct {
+	RequireAdminMFA bool `json:"require_admin_mfa"`
+}
+
+// UpdateSecuritySettingsRequest represents a security settings update
+type UpdateSecuritySettingsRequest struct {
+	RequireAdminMFA *bool `json:"require_admin_mfa"`
 }
 
 // CompanyVerificationRequest represents company verification action
@@ -628,10 +655,10 @@ type JobActionRequest struct {
 	Reason string `json:"reason,omitempty"`
 }
 
-// PaymentActionRequest represents payment approval/rejection action
+// PaymentActionRequest represents payment approval/rejection/reversal action
 type PaymentActionRequest struct {
-	Action string `json:"action" validate:"required,oneof=approve reject"`
-	Note   string `json:"note,omitempty" validate:"required_if=Action reject"`
+	Action string `json:"action" validate:"required,oneof=approve reject reverse"`
+	Note   string `json:"note,omitempty" validate:"required_unless=Action approve"`
 }
 
 // JobSeekerActionRequest represents job seeker moderation action
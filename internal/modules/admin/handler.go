@@ -9,6 +9,8 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 	"github.com/karirnusantara/api/internal/shared/response"
 )
 
@@ -51,13 +53,72 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 			response.Error(w, http.StatusForbidden, "ACCOUNT_INACTIVE", err.Error())
 			return
 		}
-		response.Error(w, http.StatusInternalServerError, "LOGIN_FAILED", "Gagal melakukan login")
+		writeAppError(w, err, "LOGIN_FAILED", "Gagal melakukan login")
+		return
+	}
+
+	if result.MFARequired {
+		response.Success(w, http.StatusOK, "Masukkan kode dari aplikasi autentikator Anda", result)
 		return
 	}
 
 	response.Success(w, http.StatusOK, "Login berhasil", result)
 }
 
+// LoginMFA completes an admin login with an authenticator or recovery code
+// POST /api/v1/admin/auth/login/mfa
+func (h *Handler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req mfa.ChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	if req.MFAToken == "" || req.Code == "" {
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Token MFA dan kode wajib diisi")
+		return
+	}
+
+	result, err := h.service.LoginMFA(r.Context(), &req)
+	if err != nil {
+		if errors.Is(err, ErrAdminNotFound) {
+			response.Error(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", err.Error())
+			return
+		}
+		if errors.Is(err, ErrAccountInactive) {
+			response.Error(w, http.StatusForbidden, "ACCOUNT_INACTIVE", err.Error())
+			return
+		}
+		writeAppError(w, err, "LOGIN_FAILED", "Gagal melakukan login")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Login berhasil", result)
+}
+
+// SetupLoginMFA starts authenticator enrollment for an admin who must enable MFA to log in
+// POST /api/v1/admin/auth/mfa/setup
+func (h *Handler) SetupLoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req mfa.ChallengeSetupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	if req.MFAToken == "" {
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Token MFA wajib diisi")
+		return
+	}
+
+	setup, err := h.service.SetupLoginMFA(r.Context(), &req)
+	if err != nil {
+		writeAppError(w, err, "MFA_SETUP_FAILED", "Gagal menyiapkan autentikasi dua faktor")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Pindai kode QR dengan aplikasi autentikator, lalu masukkan kode untuk login", setup)
+}
+
 // GetCurrentAdmin handles getting current admin info
 // GET /api/v1/admin/auth/me
 func (h *Handler) GetCurrentAdmin(w http.ResponseWriter, r *http.Request) {
@@ -426,7 +487,7 @@ func (h *Handler) GetPaymentByID(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, "Data pembayaran berhasil diambil", payment)
 }
 
-// ProcessPayment handles payment approval/rejection
+// ProcessPayment handles payment approval/rejection/reversal
 // POST /api/v1/admin/payments/{id}/process
 func (h *Handler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 	id := parseIDFromRequest(r)
@@ -442,7 +503,12 @@ func (h *Handler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.Action == "" {
-		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Action wajib diisi (approve/reject)")
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Action wajib diisi (approve/reject/reverse)")
+		return
+	}
+
+	if req.Action == "reverse" && req.Note == "" {
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", "Catatan wajib diisi untuk pembatalan pembayaran")
 		return
 	}
 
@@ -456,6 +522,10 @@ func (h *Handler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 			response.Error(w, http.StatusBadRequest, "INVALID_ACTION", err.Error())
 			return
 		}
+		if errors.Is(err, ErrPaymentAlreadyProcessed) || errors.Is(err, ErrPaymentNotConfirmed) {
+			response.Error(w, http.StatusConflict, "INVALID_PAYMENT_STATUS", err.Error())
+			return
+		}
 		response.Error(w, http.StatusInternalServerError, "UPDATE_FAILED", "Gagal memproses pembayaran")
 		return
 	}
@@ -550,10 +620,100 @@ func (h *Handler) UpdateJobSeekerStatus(w http.ResponseWriter, r *http.Request)
 	response.Success(w, http.StatusOK, "Status pencari kerja berhasil diubah", nil)
 }
 
+// ============================================
+// SECURITY SETTINGS
+// ============================================
+
+// GetSecuritySettings handles getting security settings
+// GET /api/v1/admin/settings/security
+func (h *Handler) GetSecuritySettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetSecuritySettings(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "FETCH_FAILED", "Gagal mengambil pengaturan keamanan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Pengaturan keamanan berhasil diambil", settings)
+}
+
+// UpdateSecuritySettings handles updating security settings
+// PUT /api/v1/admin/settings/security
+func (h *Handler) UpdateSecuritySettings(w http.ResponseWriter, r *http.Request) {
+	var req UpdateSecuritySettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	adminID := middleware.GetUserID(r.Context())
+	settings, err := h.service.UpdateSecuritySettings(r.Context(), &req, adminID)
+	if err != nil {
+		if errors.Is(err, ErrMFAUnavailable) {
+			response.Error(w, http.StatusBadRequest, "MFA_UNAVAILABLE", err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, "UPDATE_FAILED", "Gagal memperbarui pengaturan keamanan")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Pengaturan keamanan berhasil diperbarui", settings)
+}
+
+// GetLockouts handles listing active login lockouts
+// GET /api/v1/admin/security/lockouts?search=
+func (h *Handler) GetLockouts(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := h.service.GetLockouts(r.Context(), r.URL.Query().Get("search"))
+	if err != nil {
+		writeAppError(w, err, "FETCH_FAILED", "Gagal mengambil daftar akun terkunci")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Daftar akun terkunci berhasil diambil", lockouts)
+}
+
+// UnlockLockout handles lifting a login lockout
+// POST /api/v1/admin/security/lockouts/{id}/unlock
+func (h *Handler) UnlockLockout(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	adminID := middleware.GetUserID(r.Context())
+	if err := h.service.UnlockLockout(r.Context(), id, adminID); err != nil {
+		if errors.Is(err, ErrLockoutNotFound) {
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		writeAppError(w, err, "UNLOCK_FAILED", "Gagal membuka kunci login")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Kunci login berhasil dibuka", nil)
+}
+
 // ============================================
 // HELPERS
 // ============================================
 
+// writeAppError writes an AppError with its own status, or a 500 with the fallback code and message
+func writeAppError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	if errors.Is(err, ErrMFAUnavailable) {
+		response.Error(w, http.StatusBadRequest, "MFA_UNAVAILABLE", err.Error())
+		return
+	}
+	if appErr := apperrors.GetAppError(err); appErr != nil && appErr.Code != apperrors.ErrCodeInternal {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.Error(w, http.StatusInternalServerError, fallbackCode, fallbackMessage)
+}
+
 func parseIntOrDefault(s string, defaultVal int) int {
 	if s == "" {
 		return defaultVal
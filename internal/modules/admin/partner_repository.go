@@ -66,6 +66,7 @@ func (r *partnerRepository) GetPartners(ctx context.Context, status string, sear
 		LEFT JOIN (
 			SELECT partner_id, COALESCE(SUM(commission_amount), 0) as total_commission_calc
 			FROM partner_commissions
+			WHERE status != 'cancelled'
 			GROUP BY partner_id
 		) pc_sum ON rp.id = pc_sum.partner_id
 		WHERE u.deleted_at IS NULL
@@ -156,6 +157,7 @@ func (r *partnerRepository) GetPartnerByID(ctx context.Context, id uint64) (*Par
 		LEFT JOIN (
 			SELECT partner_id, COALESCE(SUM(commission_amount), 0) as total_commission_calc
 			FROM partner_commissions
+			WHERE status != 'cancelled'
 			GROUP BY partner_id
 		) pc_sum ON rp.id = pc_sum.partner_id
 		WHERE rp.id = ? AND u.deleted_at IS NULL
@@ -285,7 +287,7 @@ func (r *partnerRepository) GetReferredCompanyStats(ctx context.Context, company
 			COALESCE(SUM(transaction_amount), 0),
 			COALESCE(SUM(commission_amount), 0)
 		FROM partner_commissions
-		WHERE company_id = ?
+		WHERE company_id = ? AND status != 'cancelled'
 	`
 	var transactions int
 	var revenue, commission int64
@@ -530,7 +532,7 @@ func (r *partnerRepository) GetReferralStats(ctx context.Context) (*AdminReferra
 			(SELECT COUNT(*) FROM referral_partners rp JOIN users u ON rp.user_id = u.id WHERE rp.status = 'active' AND u.deleted_at IS NULL) as active_partners,
 			(SELECT COUNT(*) FROM referral_partners rp JOIN users u ON rp.user_id = u.id WHERE rp.status = 'pending' AND u.deleted_at IS NULL) as pending_partners,
 			(SELECT COUNT(*) FROM partner_referrals) as total_referred_companies,
-			(SELECT COALESCE(SUM(commission_amount), 0) FROM partner_commissions) as total_commission,
+			(SELECT COALESCE(SUM(commission_amount), 0) FROM partner_commissions WHERE status != 'cancelled') as total_commission,
 			(SELECT COALESCE(SUM(amount), 0) FROM partner_payouts WHERE status IN ('pending', 'processing')) as pending_payouts,
 			(SELECT COALESCE(SUM(paid_amount), 0) FROM referral_partners) as total_paid_out,
 			(SELECT COUNT(*) FROM referral_partners rp JOIN users u ON rp.user_id = u.id WHERE rp.available_balance > 0 AND u.deleted_at IS NULL) as partners_with_balance
@@ -563,7 +565,7 @@ func (r *partnerRepository) GetPayoutStats(ctx context.Context) (*AdminPayoutSta
 			(SELECT COUNT(*) FROM partner_payouts WHERE status = 'completed') as completed_payouts_count,
 			(SELECT COALESCE(SUM(amount), 0) FROM partner_payouts WHERE status = 'completed') as total_amount_paid,
 			(SELECT COALESCE(SUM(amount), 0) FROM partner_payouts WHERE status IN ('pending', 'processing')) as pending_amount,
-			(SELECT COALESCE(SUM(commission_amount), 0) FROM partner_commissions) as total_commission,
+			(SELECT COALESCE(SUM(commission_amount), 0) FROM partner_commissions WHERE status != 'cancelled') as total_commission,
 			(SELECT COUNT(*) FROM referral_partners rp JOIN users u ON rp.user_id = u.id WHERE rp.available_balance > 0 AND u.deleted_at IS NULL) as partners_with_balance
 	`
 
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+type permissionContextKey struct{}
+
+// withPermission records the permission a request was authorized with
+func withPermission(ctx context.Context, permission string) context.Context {
+	return context.WithValue(ctx, permissionContextKey{}, permission)
+}
+
+// permissionFromContext returns the permission the request was authorized with, or ""
+func permissionFromContext(ctx context.Context) string {
+	permission, _ := ctx.Value(permissionContextKey{}).(string)
+	return permission
+}
+
+// ActiveAdmins returns the check the admin portal's auth middleware runs on every request:
+// tokens of deactivated (or deleted) admins stop working immediately instead of at expiry
+func ActiveAdmins(repo Repository) middleware.ActiveFunc {
+	return func(ctx context.Context, userID uint64) (bool, error) {
+		admin, err := repo.GetAdminByID(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		return admin != nil && admin.IsActive, nil
+	}
+}
+
+// RequirePermission creates a middleware that lets an authenticated admin through only when
+// one of their roles grants permission. The permission is kept in the context for the audit log.
+func RequirePermission(roles RoleService, permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			adminID := middleware.GetUserID(r.Context())
+			if adminID == 0 {
+				response.Unauthorized(w, "Unauthorized")
+				return
+			}
+
+			allowed, err := roles.HasPermission(r.Context(), adminID, permission)
+			if err != nil {
+				logger.FromContext(r.Context()).Error("failed to check admin permission", "permission", permission, "error", err)
+				response.Error(w, http.StatusInternalServerError, "PERMISSION_CHECK_FAILED", "Gagal memeriksa izin akses")
+				return
+			}
+			if !allowed {
+				response.ErrorWithDetails(w, http.StatusForbidden, "PERMISSION_DENIED",
+					"Anda tidak memiliki izin untuk tindakan ini", map[string]string{"permission": permission})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withPermission(r.Context(), permission)))
+		})
+	}
+}
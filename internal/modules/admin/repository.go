@@ -35,12 +35,14 @@ type Repository interface {
 	GetJobs(ctx context.Context, filter JobFilter) ([]*JobAdmin, int, error)
 	GetJobByID(ctx context.Context, id uint64) (*JobAdmin, error)
 	UpdateJobStatus(ctx context.Context, id uint64, status string) error
+	CloseJob(ctx context.Context, id uint64, adminID uint64) error
 	UpdateJobAdminStatus(ctx context.Context, id uint64, adminStatus, note string) error
 
 	// Payment operations
 	GetPayments(ctx context.Context, filter PaymentFilter) ([]*PaymentAdmin, int, error)
 	GetPaymentByID(ctx context.Context, id uint64) (*PaymentAdmin, error)
 	UpdatePaymentStatus(ctx context.Context, id uint64, status, note string, confirmedByID uint64) error
+	GetCompanyUserID(ctx context.Context, companyID uint64) (uint64, error)
 
 	// Job seeker operations
 	GetJobSeekers(ctx context.Context, filter JobSeekerFilter) ([]*JobSeekerAdmin, int, error)
@@ -49,6 +51,11 @@ type Repository interface {
 
 	// Audit log
 	LogAdminAction(ctx context.Context, log *AdminActionLog) error
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]*AdminActionLog, error)
+
+	// System settings
+	GetSetting(ctx context.Context, key string) (string, error)
+	SetSetting(ctx context.Context, key, value string, adminID uint64) error
 }
 
 // repository implements the Repository interface
@@ -500,22 +507,16 @@ func (r *repository) UpdateCompanyStatus(ctx context.Context, id uint64, status
 		args = []interface{}{status, id}
 	}
 
-	fmt.Printf("[DEBUG] UpdateCompanyStatus: query=%s, status=%s, user_id=%d\n", query, status, id)
-
 	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		fmt.Printf("[DEBUG] ExecContext error: %v\n", err)
 		return fmt.Errorf("database error: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		fmt.Printf("[DEBUG] RowsAffected error: %v\n", err)
 		return fmt.Errorf("rows affected error: %w", err)
 	}
 
-	fmt.Printf("[DEBUG] Rows affected: %d\n", rowsAffected)
-
 	if rowsAffected == 0 {
 		return fmt.Errorf("no company found with user_id %d", id)
 	}
@@ -657,6 +658,18 @@ func (r *repository) UpdateJobStatus(ctx context.Context, id uint64, status stri
 	return err
 }
 
+// CloseJob closes a job and records the admin who closed it
+func (r *repository) CloseJob(ctx context.Context, id uint64, adminID uint64) error {
+	query := `
+		UPDATE jobs
+		SET status = 'closed', closed_at = NOW(), closed_by_type = 'admin', closed_by_id = ?,
+			closed_reason = 'manual', updated_at = NOW()
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, adminID, id)
+	return err
+}
+
 func (r *repository) UpdateJobAdminStatus(ctx context.Context, id uint64, adminStatus, note string) error {
 	query := `UPDATE jobs SET admin_status = ?, admin_note = ?, updated_at = NOW() WHERE id = ?`
 	_, err := r.db.ExecContext(ctx, query, adminStatus, note, id)
@@ -782,6 +795,20 @@ func (r *repository) UpdatePaymentStatus(ctx context.Context, id uint64, status,
 	return err
 }
 
+// GetCompanyUserID resolves the owning user ID of a company record
+func (r *repository) GetCompanyUserID(ctx context.Context, companyID uint64) (uint64, error) {
+	var userID uint64
+	err := r.db.QueryRowContext(ctx, `SELECT user_id FROM companies WHERE id = ?`, companyID).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
+
 // ============================================
 // JOB SEEKER OPERATIONS
 // ============================================
@@ -906,15 +933,95 @@ func (r *repository) UpdateJobSeekerActive(ctx context.Context, id uint64, isAct
 
 func (r *repository) LogAdminAction(ctx context.Context, log *AdminActionLog) error {
 	query := `
-		INSERT INTO audit_logs (user_id, action, entity_type, entity_id, details, ip_address, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, NOW())
+		INSERT INTO audit_logs (user_id, action, entity_type, entity_id, details, permission, ip_address, user_agent, old_values, new_values, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
 	`
 	_, err := r.db.ExecContext(ctx, query,
-		log.AdminID, log.Action, log.EntityType, log.EntityID, log.Details, log.IPAddress,
+		log.AdminID, log.Action, log.EntityType, log.EntityID, log.Details, log.Permission, log.IPAddress,
+		log.UserAgent, log.OldValues, log.NewValues,
 	)
 	return err
 }
 
+// GetAuditLogs returns audit log entries newest first, starting below filter.Cursor
+func (r *repository) GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]*AdminActionLog, error) {
+	query := `
+		SELECT a.id, COALESCE(a.user_id, 0) as admin_id, COALESCE(u.full_name, '') as admin_name,
+		       a.action, a.entity_type, COALESCE(a.entity_id, 0) as entity_id, a.details, a.permission,
+		       a.ip_address, a.user_agent, a.old_values, a.new_values, a.created_at
+		FROM audit_logs a
+		LEFT JOIN users u ON u.id = a.user_id
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if filter.AdminID > 0 {
+		query += " AND a.user_id = ?"
+		args = append(args, filter.AdminID)
+	}
+	if filter.Action != "" {
+		query += " AND a.action = ?"
+		args = append(args, filter.Action)
+	}
+	if filter.EntityType != "" {
+		query += " AND a.entity_type = ?"
+		args = append(args, filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query += " AND a.entity_id = ?"
+		args = append(args, *filter.EntityID)
+	}
+	if filter.DateFrom != "" {
+		query += " AND a.created_at >= ?"
+		args = append(args, filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query += " AND a.created_at <= ?"
+		args = append(args, filter.DateTo+" 23:59:59")
+	}
+	if filter.Cursor > 0 {
+		query += " AND a.id < ?"
+		args = append(args, filter.Cursor)
+	}
+
+	query += " ORDER BY a.id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	var logs []*AdminActionLog
+	if err := r.db.SelectContext(ctx, &logs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	return logs, nil
+}
+
+// ============================================
+// SYSTEM SETTINGS OPERATIONS
+// ============================================
+
+// GetSetting returns a system setting value, or an empty string if it is not set
+func (r *repository) GetSetting(ctx context.Context, key string) (string, error) {
+	var value string
+	err := r.db.GetContext(ctx, &value, `SELECT setting_value FROM system_settings WHERE setting_key = ?`, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+// SetSetting creates or updates a system setting
+func (r *repository) SetSetting(ctx context.Context, key, value string, adminID uint64) error {
+	query := `
+		INSERT INTO system_settings (setting_key, setting_value, updated_by, updated_at)
+		VALUES (?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE setting_value = VALUES(setting_value), updated_by = VALUES(updated_by), updated_at = NOW()
+	`
+	_, err := r.db.ExecContext(ctx, query, key, value, adminID)
+	return err
+}
+
 // ============================================
 // DASHBOARD DETAIL OPERATIONS
 // ============================================
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ============================================
+// PERMISSIONS
+// ============================================
+
+// Admin permissions. Each admin route requires one of these (see Module.RegisterRoutes);
+// only the admin's own account routes are open to every admin.
+const (
+	PermDashboardRead       = "dashboard.read"
+	PermCompaniesView       = "companies.view"
+	PermCompaniesVerify     = "companies.verify"
+	PermJobsView            = "jobs.view"
+	PermJobsModerate        = "jobs.moderate"
+	PermPaymentsView        = "payments.view"
+	PermPaymentsApprove     = "payments.approve"
+	PermJobSeekersView      = "job_seekers.view"
+	PermJobSeekersManage    = "job_seekers.manage"
+	PermPartnersView        = "partners.view"
+	PermPartnersManage      = "partners.manage"
+	PermPartnersPayout      = "partners.payout"
+	PermAnnouncementsManage = "announcements.manage"
+	PermSecurityManage      = "security.manage"
+	PermAdminsManage        = "admins.manage"
+	PermAuditLogsView       = "audit_logs.view"
+	PermEmailsManage        = "emails.manage"
+	PermSupportRead         = "support.read"
+	PermSupportReply        = "support.reply"
+)
+
+// Permissions lists every permission with its description, in display order
+var Permissions = []PermissionInfo{
+	{Key: PermDashboardRead, Description: "Melihat ringkasan dasbor admin"},
+	{Key: PermCompaniesView, Description: "Melihat data perusahaan"},
+	{Key: PermCompaniesVerify, Description: "Memverifikasi dan mengubah status perusahaan"},
+	{Key: PermJobsView, Description: "Melihat lowongan"},
+	{Key: PermJobsModerate, Description: "Memoderasi lowongan"},
+	{Key: PermPaymentsView, Description: "Melihat pembayaran"},
+	{Key: PermPaymentsApprove, Description: "Menyetujui atau menolak pembayaran"},
+	{Key: PermJobSeekersView, Description: "Melihat data pencari kerja"},
+	{Key: PermJobSeekersManage, Description: "Mengaktifkan dan menonaktifkan pencari kerja"},
+	{Key: PermPartnersView, Description: "Melihat mitra dan referral"},
+	{Key: PermPartnersManage, Description: "Menyetujui, mengubah dan menghapus mitra"},
+	{Key: PermPartnersPayout, Description: "Membuat dan memproses pencairan komisi mitra"},
+	{Key: PermAnnouncementsManage, Description: "Mengelola pengumuman, banner dan informasi"},
+	{Key: PermSecurityManage, Description: "Mengelola pengaturan keamanan dan kunci login"},
+	{Key: PermAdminsManage, Description: "Mengelola admin dan peran"},
+	{Key: PermAuditLogsView, Description: "Melihat dan mengekspor log audit"},
+	{Key: PermEmailsManage, Description: "Melihat dan mengirim ulang email yang gagal"},
+	{Key: PermSupportRead, Description: "Melihat percakapan chat dan tiket dukungan"},
+	{Key: PermSupportReply, Description: "Membalas dan mengubah status chat dan tiket dukungan"},
+}
+
+// PermissionInfo describes a permission
+type PermissionInfo struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// IsValidPermission reports whether key is a known permission
+func IsValidPermission(key string) bool {
+	for _, p := range Permissions {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================
+// ROLES
+// ============================================
+
+// RoleSuperAdmin is the built-in role holding every permission
+const RoleSuperAdmin = "super_admin"
+
+// AdminRole is a named set of permissions
+type AdminRole struct {
+	ID          uint64         `db:"id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	IsSystem    bool           `db:"is_system"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+	Permissions []string       `db:"-"`
+}
+
+// AdminRoleResponse represents a role in API responses
+type AdminRoleResponse struct {
+	ID          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// ToResponse converts AdminRole to AdminRoleResponse
+func (r *AdminRole) ToResponse() *AdminRoleResponse {
+	permissions := r.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &AdminRoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description.String,
+		IsSystem:    r.IsSystem,
+		Permissions: permissions,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// AdminRoleRequest represents a request to create or update a role
+type AdminRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// AssignAdminRolesRequest replaces the roles of an admin
+type AssignAdminRolesRequest struct {
+	RoleIDs []uint64 `json:"role_ids"`
+}
+
+// AdminAccessResponse represents an admin with their roles and effective permissions
+type AdminAccessResponse struct {
+	*AdminUserResponse
+	Roles       []*AdminRoleResponse `json:"roles"`
+	Permissions []string             `json:"permissions"`
+}
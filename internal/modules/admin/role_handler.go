@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// RoleHandler handles admin role and permission HTTP requests
+type RoleHandler struct {
+	service RoleService
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(service RoleService) *RoleHandler {
+	return &RoleHandler{service: service}
+}
+
+// GetMyAccess returns the signed-in admin's roles and permissions
+// GET /api/v1/admin/auth/permissions
+func (h *RoleHandler) GetMyAccess(w http.ResponseWriter, r *http.Request) {
+	access, err := h.service.GetAdminAccess(r.Context(), middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeRoleError(w, err, "FETCH_FAILED", "Gagal mengambil izin akses")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Izin akses berhasil diambil", access)
+}
+
+// GetPermissions returns the permission catalogue
+// GET /api/v1/admin/permissions
+func (h *RoleHandler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, http.StatusOK, "Daftar izin berhasil diambil", h.service.GetPermissions())
+}
+
+// GetRoles lists roles
+// GET /api/v1/admin/roles
+func (h *RoleHandler) GetRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.service.GetRoles(r.Context())
+	if err != nil {
+		writeRoleError(w, err, "FETCH_FAILED", "Gagal mengambil daftar peran")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Daftar peran berhasil diambil", roles)
+}
+
+// CreateRole creates a role
+// POST /api/v1/admin/roles
+func (h *RoleHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	var req AdminRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	role, err := h.service.CreateRole(r.Context(), &req, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeRoleError(w, err, "CREATE_FAILED", "Gagal membuat peran")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Peran berhasil dibuat", role)
+}
+
+// UpdateRole updates a role
+// PUT /api/v1/admin/roles/{id}
+func (h *RoleHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	var req AdminRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	role, err := h.service.UpdateRole(r.Context(), id, &req, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeRoleError(w, err, "UPDATE_FAILED", "Gagal memperbarui peran")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Peran berhasil diperbarui", role)
+}
+
+// DeleteRole deletes a role
+// DELETE /api/v1/admin/roles/{id}
+func (h *RoleHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	if err := h.service.DeleteRole(r.Context(), id, middleware.GetUserID(r.Context())); err != nil {
+		writeRoleError(w, err, "DELETE_FAILED", "Gagal menghapus peran")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Peran berhasil dihapus", nil)
+}
+
+// GetAdmins lists admins with their roles
+// GET /api/v1/admin/admins
+func (h *RoleHandler) GetAdmins(w http.ResponseWriter, r *http.Request) {
+	admins, err := h.service.GetAdmins(r.Context())
+	if err != nil {
+		writeRoleError(w, err, "FETCH_FAILED", "Gagal mengambil daftar admin")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Daftar admin berhasil diambil", admins)
+}
+
+// AssignRoles replaces the roles of an admin
+// PUT /api/v1/admin/admins/{id}/roles
+func (h *RoleHandler) AssignRoles(w http.ResponseWriter, r *http.Request) {
+	id := parseIDFromRequest(r)
+	if id == 0 {
+		response.Error(w, http.StatusBadRequest, "INVALID_ID", "ID tidak valid")
+		return
+	}
+
+	var req AssignAdminRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_REQUEST", "Format request tidak valid")
+		return
+	}
+
+	access, err := h.service.AssignRoles(r.Context(), id, &req, middleware.GetUserID(r.Context()))
+	if err != nil {
+		writeRoleError(w, err, "ASSIGN_FAILED", "Gagal mengubah peran admin")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Peran admin berhasil diperbarui", access)
+}
+
+// writeRoleError maps role errors to responses
+func writeRoleError(w http.ResponseWriter, err error, fallbackCode, fallbackMessage string) {
+	switch {
+	case errors.Is(err, ErrRoleNotFound), errors.Is(err, ErrAdminNotFound):
+		response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, ErrRoleNameTaken):
+		response.Error(w, http.StatusConflict, "ROLE_NAME_TAKEN", err.Error())
+	case errors.Is(err, ErrRoleNameRequired), errors.Is(err, ErrUnknownPermission):
+		response.Error(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+	case errors.Is(err, ErrSystemRole), errors.Is(err, ErrOwnRoles):
+		response.Error(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	default:
+		writeAppError(w, err, fallbackCode, fallbackMessage)
+	}
+}
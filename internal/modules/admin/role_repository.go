@@ -0,0 +1,248 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RoleRepository handles admin role and permission database operations
+type RoleRepository interface {
+	GetRoles(ctx context.Context) ([]*AdminRole, error)
+	GetRoleByID(ctx context.Context, id uint64) (*AdminRole, error)
+	GetRoleByName(ctx context.Context, name string) (*AdminRole, error)
+	CreateRole(ctx context.Context, role *AdminRole) error
+	UpdateRole(ctx context.Context, role *AdminRole) error
+	DeleteRole(ctx context.Context, id uint64) error
+
+	// Assignment
+	GetAdmins(ctx context.Context) ([]*AdminUser, error)
+	GetAdminRoles(ctx context.Context, adminID uint64) ([]*AdminRole, error)
+	SetAdminRoles(ctx context.Context, adminID uint64, roleIDs []uint64, assignedBy uint64) error
+	GetAdminPermissions(ctx context.Context, adminID uint64) ([]string, error)
+}
+
+type roleRepository struct {
+	db *sqlx.DB
+}
+
+// NewRoleRepository creates a new admin role repository
+func NewRoleRepository(db *sqlx.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+const roleColumns = `id, name, description, is_system, created_at, updated_at`
+
+// GetRoles returns every role with its permissions
+func (r *roleRepository) GetRoles(ctx context.Context) ([]*AdminRole, error) {
+	var roles []*AdminRole
+	if err := r.db.SelectContext(ctx, &roles, `SELECT `+roleColumns+` FROM admin_roles ORDER BY is_system DESC, name`); err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+	if err := r.loadPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetRoleByID returns a role with its permissions
+func (r *roleRepository) GetRoleByID(ctx context.Context, id uint64) (*AdminRole, error) {
+	return r.getRole(ctx, `SELECT `+roleColumns+` FROM admin_roles WHERE id = ?`, id)
+}
+
+// GetRoleByName returns a role with its permissions
+func (r *roleRepository) GetRoleByName(ctx context.Context, name string) (*AdminRole, error) {
+	return r.getRole(ctx, `SELECT `+roleColumns+` FROM admin_roles WHERE name = ?`, name)
+}
+
+func (r *roleRepository) getRole(ctx context.Context, query string, arg interface{}) (*AdminRole, error) {
+	var role AdminRole
+	if err := r.db.GetContext(ctx, &role, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	if err := r.loadPermissions(ctx, []*AdminRole{&role}); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// CreateRole creates a role and its permissions
+func (r *roleRepository) CreateRole(ctx context.Context, role *AdminRole) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO admin_roles (name, description, is_system, created_at, updated_at) VALUES (?, ?, 0, NOW(), NOW())`,
+		role.Name, role.Description,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get role id: %w", err)
+	}
+	role.ID = uint64(id)
+
+	if err := insertRolePermissions(ctx, tx, role.ID, role.Permissions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateRole updates a role and replaces its permissions
+func (r *roleRepository) UpdateRole(ctx context.Context, role *AdminRole) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE admin_roles SET name = ?, description = ?, updated_at = NOW() WHERE id = ?`,
+		role.Name, role.Description, role.ID,
+	); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM admin_role_permissions WHERE role_id = ?`, role.ID); err != nil {
+		return fmt.Errorf("failed to clear role permissions: %w", err)
+	}
+	if err := insertRolePermissions(ctx, tx, role.ID, role.Permissions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteRole deletes a role; its permissions and assignments are removed by cascade
+func (r *roleRepository) DeleteRole(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM admin_roles WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	return nil
+}
+
+// GetAdmins returns every admin account
+func (r *roleRepository) GetAdmins(ctx context.Context) ([]*AdminUser, error) {
+	query := `
+		SELECT id, email, password_hash, full_name, role, is_active, created_at, updated_at
+		FROM users
+		WHERE role = 'admin'
+		ORDER BY full_name
+	`
+	var admins []*AdminUser
+	if err := r.db.SelectContext(ctx, &admins, query); err != nil {
+		return nil, fmt.Errorf("failed to get admins: %w", err)
+	}
+	return admins, nil
+}
+
+// GetAdminRoles returns the roles assigned to an admin
+func (r *roleRepository) GetAdminRoles(ctx context.Context, adminID uint64) ([]*AdminRole, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.is_system, r.created_at, r.updated_at
+		FROM admin_roles r
+		JOIN admin_user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = ?
+		ORDER BY r.name
+	`
+	var roles []*AdminRole
+	if err := r.db.SelectContext(ctx, &roles, query, adminID); err != nil {
+		return nil, fmt.Errorf("failed to get admin roles: %w", err)
+	}
+	if err := r.loadPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// SetAdminRoles replaces the roles assigned to an admin
+func (r *roleRepository) SetAdminRoles(ctx context.Context, adminID uint64, roleIDs []uint64, assignedBy uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM admin_user_roles WHERE user_id = ?`, adminID); err != nil {
+		return fmt.Errorf("failed to clear admin roles: %w", err)
+	}
+	for _, roleID := range roleIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO admin_user_roles (user_id, role_id, assigned_by, created_at) VALUES (?, ?, ?, NOW())`,
+			adminID, roleID, assignedBy,
+		); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetAdminPermissions returns the union of the permissions of an admin's roles; deactivated admins have none
+func (r *roleRepository) GetAdminPermissions(ctx context.Context, adminID uint64) ([]string, error) {
+	query := `
+		SELECT DISTINCT p.permission
+		FROM admin_role_permissions p
+		JOIN admin_user_roles ur ON ur.role_id = p.role_id
+		JOIN users u ON u.id = ur.user_id
+		WHERE ur.user_id = ? AND u.is_active = 1
+		ORDER BY p.permission
+	`
+	var permissions []string
+	if err := r.db.SelectContext(ctx, &permissions, query, adminID); err != nil {
+		return nil, fmt.Errorf("failed to get admin permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// loadPermissions fills in the permissions of roles
+func (r *roleRepository) loadPermissions(ctx context.Context, roles []*AdminRole) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(roles))
+	byID := make(map[uint64]*AdminRole, len(roles))
+	for _, role := range roles {
+		ids = append(ids, role.ID)
+		byID[role.ID] = role
+		role.Permissions = []string{}
+	}
+
+	query, args, err := sqlx.In(`SELECT role_id, permission FROM admin_role_permissions WHERE role_id IN (?) ORDER BY permission`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build permissions query: %w", err)
+	}
+
+	var rows []struct {
+		RoleID     uint64 `db:"role_id"`
+		Permission string `db:"permission"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return fmt.Errorf("failed to get role permissions: %w", err)
+	}
+	for _, row := range rows {
+		byID[row.RoleID].Permissions = append(byID[row.RoleID].Permissions, row.Permission)
+	}
+	return nil
+}
+
+// insertRolePermissions adds permissions to a role within tx
+func insertRolePermissions(ctx context.Context, tx *sqlx.Tx, roleID uint64, permissions []string) error {
+	for _, permission := range permissions {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO admin_role_permissions (role_id, permission) VALUES (?, ?)`,
+			roleID, permission,
+		); err != nil {
+			return fmt.Errorf("failed to add role permission: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Role errors
+var (
+	ErrRoleNotFound      = errors.New("peran tidak ditemukan")
+	ErrRoleNameTaken     = errors.New("nama peran sudah digunakan")
+	ErrRoleNameRequired  = errors.New("nama peran wajib diisi")
+	ErrSystemRole        = errors.New("peran bawaan sistem tidak dapat diubah atau dihapus")
+	ErrUnknownPermission = errors.New("izin tidak dikenal")
+	ErrOwnRoles          = errors.New("anda tidak dapat mengubah peran akun sendiri")
+)
+
+// RoleService handles admin roles, their assignment and permission checks
+type RoleService interface {
+	GetPermissions() []PermissionInfo
+	GetRoles(ctx context.Context) ([]*AdminRoleResponse, error)
+	CreateRole(ctx context.Context, req *AdminRoleRequest, adminID uint64) (*AdminRoleResponse, error)
+	UpdateRole(ctx context.Context, id uint64, req *AdminRoleRequest, adminID uint64) (*AdminRoleResponse, error)
+	DeleteRole(ctx context.Context, id uint64, adminID uint64) error
+
+	GetAdmins(ctx context.Context) ([]*AdminAccessResponse, error)
+	GetAdminAccess(ctx context.Context, adminID uint64) (*AdminAccessResponse, error)
+	AssignRoles(ctx context.Context, targetID uint64, req *AssignAdminRolesRequest, adminID uint64) (*AdminAccessResponse, error)
+
+	// HasPermission reports whether an admin holds a permission through any of their roles
+	HasPermission(ctx context.Context, adminID uint64, permission string) (bool, error)
+}
+
+type roleService struct {
+	repo  RoleRepository
+	audit Repository
+}
+
+// NewRoleService creates a new admin role service. audit receives audit log entries.
+func NewRoleService(repo RoleRepository, audit Repository) RoleService {
+	return &roleService{repo: repo, audit: audit}
+}
+
+// GetPermissions returns the permission catalogue
+func (s *roleService) GetPermissions() []PermissionInfo {
+	return Permissions
+}
+
+// GetRoles returns every role
+func (s *roleService) GetRoles(ctx context.Context) ([]*AdminRoleResponse, error) {
+	roles, err := s.repo.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*AdminRoleResponse, 0, len(roles))
+	for _, role := range roles {
+		responses = append(responses, role.ToResponse())
+	}
+	return responses, nil
+}
+
+// CreateRole creates a role
+func (s *roleService) CreateRole(ctx context.Context, req *AdminRoleRequest, adminID uint64) (*AdminRoleResponse, error) {
+	role := &AdminRole{}
+	if err := s.applyRequest(ctx, role, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	created, err := s.getRole(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.logAction(ctx, adminID, "create_role", role.ID, role.Name+": "+strings.Join(role.Permissions, ","), nil, created)
+	return created, nil
+}
+
+// UpdateRole updates a role's name, description and permissions
+func (s *roleService) UpdateRole(ctx context.Context, id uint64, req *AdminRoleRequest, adminID uint64) (*AdminRoleResponse, error) {
+	role, err := s.editableRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := role.ToResponse()
+	if err := s.applyRequest(ctx, role, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.getRole(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.logAction(ctx, adminID, "update_role", role.ID, role.Name+": "+strings.Join(role.Permissions, ","), before, updated)
+	return updated, nil
+}
+
+// DeleteRole deletes a role, removing it from every admin
+func (s *roleService) DeleteRole(ctx context.Context, id uint64, adminID uint64) error {
+	role, err := s.editableRole(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, adminID, "delete_role", id, role.Name, role.ToResponse(), nil)
+	return nil
+}
+
+// GetAdmins returns every admin with their roles and permissions
+func (s *roleService) GetAdmins(ctx context.Context) ([]*AdminAccessResponse, error) {
+	admins, err := s.repo.GetAdmins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*AdminAccessResponse, 0, len(admins))
+	for _, admin := range admins {
+		access, err := s.access(ctx, admin)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, access)
+	}
+	return responses, nil
+}
+
+// GetAdminAccess returns an admin's roles and permissions
+func (s *roleService) GetAdminAccess(ctx context.Context, adminID uint64) (*AdminAccessResponse, error) {
+	admin, err := s.audit.GetAdminByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	return s.access(ctx, admin)
+}
+
+// AssignRoles replaces the roles of another admin
+func (s *roleService) AssignRoles(ctx context.Context, targetID uint64, req *AssignAdminRolesRequest, adminID uint64) (*AdminAccessResponse, error) {
+	// Prevents admins from locking themselves out or granting themselves more access
+	if targetID == adminID {
+		return nil, ErrOwnRoles
+	}
+
+	admin, err := s.audit.GetAdminByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+
+	current, err := s.repo.GetAdminRoles(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	previous := make([]string, 0, len(current))
+	for _, role := range current {
+		previous = append(previous, role.Name)
+	}
+
+	roleIDs := make([]uint64, 0, len(req.RoleIDs))
+	names := make([]string, 0, len(req.RoleIDs))
+	seen := make(map[uint64]bool, len(req.RoleIDs))
+	for _, roleID := range req.RoleIDs {
+		if seen[roleID] {
+			continue
+		}
+		seen[roleID] = true
+
+		role, err := s.repo.GetRoleByID(ctx, roleID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			return nil, ErrRoleNotFound
+		}
+		roleIDs = append(roleIDs, roleID)
+		names = append(names, role.Name)
+	}
+
+	if err := s.repo.SetAdminRoles(ctx, targetID, roleIDs, adminID); err != nil {
+		return nil, err
+	}
+
+	s.logActionOn(ctx, adminID, "assign_roles", "admin", targetID, strings.Join(names, ","),
+		map[string][]string{"roles": previous}, map[string][]string{"roles": names})
+	return s.access(ctx, admin)
+}
+
+// HasPermission reports whether an admin holds a permission through any of their roles
+func (s *roleService) HasPermission(ctx context.Context, adminID uint64, permission string) (bool, error) {
+	permissions, err := s.repo.GetAdminPermissions(ctx, adminID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyRequest validates a role request and copies it onto role
+func (s *roleService) applyRequest(ctx context.Context, role *AdminRole, req *AdminRoleRequest) error {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if name == "" {
+		return ErrRoleNameRequired
+	}
+
+	existing, err := s.repo.GetRoleByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != role.ID {
+		return ErrRoleNameTaken
+	}
+
+	permissions := make([]string, 0, len(req.Permissions))
+	seen := make(map[string]bool, len(req.Permissions))
+	for _, p := range req.Permissions {
+		if !IsValidPermission(p) {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+	sort.Strings(permissions)
+
+	role.Name = name
+	role.Description = sql.NullString{String: strings.TrimSpace(req.Description), Valid: strings.TrimSpace(req.Description) != ""}
+	role.Permissions = permissions
+	return nil
+}
+
+// editableRole returns a role that may be changed
+func (s *roleService) editableRole(ctx context.Context, id uint64) (*AdminRole, error) {
+	role, err := s.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	if role.IsSystem {
+		return nil, ErrSystemRole
+	}
+	return role, nil
+}
+
+func (s *roleService) getRole(ctx context.Context, id uint64) (*AdminRoleResponse, error) {
+	role, err := s.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role.ToResponse(), nil
+}
+
+// access builds an admin's roles and effective permissions
+func (s *roleService) access(ctx context.Context, admin *AdminUser) (*AdminAccessResponse, error) {
+	roles, err := s.repo.GetAdminRoles(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AdminAccessResponse{
+		AdminUserResponse: admin.ToResponse(),
+		Roles:             make([]*AdminRoleResponse, 0, len(roles)),
+		Permissions:       []string{},
+	}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, role.ToResponse())
+		for _, p := range role.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				resp.Permissions = append(resp.Permissions, p)
+			}
+		}
+	}
+	sort.Strings(resp.Permissions)
+	return resp, nil
+}
+
+func (s *roleService) logAction(ctx context.Context, adminID uint64, action string, roleID uint64, details string, before, after interface{}) {
+	s.logActionOn(ctx, adminID, action, "admin_role", roleID, details, before, after)
+}
+
+func (s *roleService) logActionOn(ctx context.Context, adminID uint64, action, entityType string, entityID uint64, details string, before, after interface{}) {
+	if s.audit == nil {
+		return
+	}
+	// We don't fail if logging fails
+	entry := newActionLog(ctx, adminID, action, entityType, entityID, details).withSnapshots(before, after)
+	_ = s.audit.LogAdminAction(ctx, entry)
+}
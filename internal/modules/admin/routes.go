@@ -1,21 +1,34 @@
 package admin
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/karirnusantara/api/internal/config"
 	"github.com/karirnusantara/api/internal/middleware"
 	"github.com/karirnusantara/api/internal/modules/announcements"
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	"github.com/karirnusantara/api/internal/modules/notifications"
 	"github.com/karirnusantara/api/internal/modules/quota"
 	"github.com/karirnusantara/api/internal/shared/email"
 	"github.com/karirnusantara/api/internal/shared/invoice"
+	"github.com/karirnusantara/api/internal/shared/token"
 )
 
 // Module represents the admin module
 type Module struct {
 	handler             *Handler
 	partnerHandler      *PartnerHandler
+	mfaHandler          *mfa.Handler
+	roleHandler         *RoleHandler
+	roleService         RoleService
+	repo                Repository
+	auditHandler        *AuditHandler
+	accountHandler      *AccountHandler
+	emailHandler        *EmailHandler
 	authMiddleware      *middleware.AuthMiddleware
 	announcementsModule *announcements.Module
 }
@@ -25,17 +38,67 @@ func NewModule(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthM
 	repo := NewRepository(db)
 	service := NewService(repo, cfg)
 	handler := NewHandler(service)
+	roleRepo := NewRoleRepository(db)
+	roleService := NewRoleService(roleRepo, repo)
 
 	return &Module{
 		handler:        handler,
+		roleHandler:    NewRoleHandler(roleService),
+		roleService:    roleService,
+		repo:           repo,
+		auditHandler:   NewAuditHandler(NewAuditService(repo)),
+		accountHandler: NewAccountHandler(NewAccountService(NewAccountRepository(db), roleRepo, repo, nil, nil)),
+		emailHandler:   NewEmailHandler(NewEmailService(nil, repo)),
 		authMiddleware: authMiddleware,
 	}
 }
 
 // NewModuleWithQuota creates a new admin module with quota service
-func NewModuleWithQuota(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service) *Module {
+func NewModuleWithQuota(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service) *Module {
+	repo := NewRepository(db)
+	service := NewServiceComplete(repo, cfg, quotaSvc, emailSvc, invoiceSvc, notificationSvc)
+	handler := NewHandler(service)
+
+	// Initialize partner management for admin
+	partnerRepo := NewPartnerRepository(db)
+	partnerService := NewPartnerService(partnerRepo)
+	partnerHandler := NewPartnerHandler(partnerService)
+
+	// Initialize admin roles and permissions
+	roleRepo := NewRoleRepository(db)
+	roleService := NewRoleService(roleRepo, repo)
+
+	// Initialize announcements module
+	announcementsModule := announcements.NewModule(db, authMiddleware)
+
+	return &Module{
+		handler:             handler,
+		partnerHandler:      partnerHandler,
+		roleHandler:         NewRoleHandler(roleService),
+		roleService:         roleService,
+		repo:                repo,
+		auditHandler:        NewAuditHandler(NewAuditService(repo)),
+		accountHandler:      NewAccountHandler(NewAccountService(NewAccountRepository(db), roleRepo, repo, invitationSender(emailSvc), nil)),
+		emailHandler:        NewEmailHandler(NewEmailService(emailOutbox(emailSvc), repo)),
+		authMiddleware:      authMiddleware,
+		announcementsModule: announcementsModule,
+	}
+}
+
+// NewModuleWithMFA creates a new admin module with quota service and two-factor authentication
+func NewModuleWithMFA(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service, mfaSvc mfa.Service, mfaHandler *mfa.Handler) *Module {
+	return NewModuleWithSecurity(db, cfg, authMiddleware, quotaSvc, emailSvc, invoiceSvc, notificationSvc, mfaSvc, mfaHandler, nil)
+}
+
+// NewModuleWithSecurity creates a new admin module with quota service, two-factor authentication and brute-force protection
+func NewModuleWithSecurity(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service, mfaSvc mfa.Service, mfaHandler *mfa.Handler, guard loginguard.Service) *Module {
+	return NewModuleWithAccounts(db, cfg, authMiddleware, quotaSvc, emailSvc, invoiceSvc, notificationSvc, mfaSvc, mfaHandler, guard, nil)
+}
+
+// NewModuleWithAccounts creates a new admin module with security features and admin password changes through passwords
+func NewModuleWithAccounts(db *sqlx.DB, cfg *config.Config, authMiddleware *middleware.AuthMiddleware, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service, mfaSvc mfa.Service, mfaHandler *mfa.Handler, guard loginguard.Service, passwords PasswordChanger) *Module {
 	repo := NewRepository(db)
-	service := NewServiceComplete(repo, cfg, quotaSvc, emailSvc, invoiceSvc)
+	service := NewServiceWithSecurity(repo, cfg, quotaSvc, emailSvc, invoiceSvc, notificationSvc, mfaSvc, guard)
 	handler := NewHandler(service)
 
 	// Initialize partner management for admin
@@ -43,12 +106,23 @@ func NewModuleWithQuota(db *sqlx.DB, cfg *config.Config, authMiddleware *middlew
 	partnerService := NewPartnerService(partnerRepo)
 	partnerHandler := NewPartnerHandler(partnerService)
 
+	// Initialize admin roles and permissions
+	roleRepo := NewRoleRepository(db)
+	roleService := NewRoleService(roleRepo, repo)
+
 	// Initialize announcements module
 	announcementsModule := announcements.NewModule(db, authMiddleware)
 
 	return &Module{
 		handler:             handler,
 		partnerHandler:      partnerHandler,
+		roleHandler:         NewRoleHandler(roleService),
+		roleService:         roleService,
+		repo:                repo,
+		auditHandler:        NewAuditHandler(NewAuditService(repo)),
+		accountHandler:      NewAccountHandler(NewAccountService(NewAccountRepository(db), roleRepo, repo, invitationSender(emailSvc), passwords)),
+		emailHandler:        NewEmailHandler(NewEmailService(emailOutbox(emailSvc), repo)),
+		mfaHandler:          mfaHandler,
 		authMiddleware:      authMiddleware,
 		announcementsModule: announcementsModule,
 	}
@@ -60,79 +134,146 @@ func (m *Module) RegisterRoutes(r chi.Router) {
 		// Public routes (login)
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/login", m.handler.Login)
+			r.Post("/login/mfa", m.handler.LoginMFA)
+			r.Post("/mfa/setup", m.handler.SetupLoginMFA)
+
+			// Invited admins set their password
+			r.Get("/invitation", m.accountHandler.PreviewInvitation)
+			r.Post("/invitation/accept", m.accountHandler.AcceptInvitation)
 		})
 
 		// Protected admin routes
 		r.Group(func(r chi.Router) {
-			// Require authentication and admin role
-			r.Use(m.authMiddleware.Authenticate)
-			r.Use(m.authMiddleware.RequireAdmin)
+			// Require an admin portal token and admin role
+			adminAuth := m.authMiddleware.ForPortal(token.PortalAdmin)
+			r.Use(adminAuth.Authenticate)
+			r.Use(adminAuth.RequireAdmin)
+
+			// Each route except the admin's own account requires a permission from one of their roles
+			require := func(permission string) func(http.Handler) http.Handler {
+				return RequirePermission(m.roleService, permission)
+			}
 
 			// Current admin info
 			r.Get("/auth/me", m.handler.GetCurrentAdmin)
+			r.Get("/auth/permissions", m.roleHandler.GetMyAccess)
+			r.Put("/auth/password", m.accountHandler.ChangePassword)
+
+			// Two-factor authentication for the signed-in admin
+			if m.mfaHandler != nil {
+				r.Route("/mfa", m.mfaHandler.Routes)
+			}
 
 			// Dashboard
-			r.Get("/dashboard/stats", m.handler.GetDashboardStats)
-			r.Get("/dashboard/pending-companies", m.handler.GetPendingCompanies)
-			r.Get("/dashboard/pending-payments", m.handler.GetPendingPayments)
-			r.Get("/dashboard/open-tickets", m.handler.GetOpenSupportTickets)
+			r.Route("/dashboard", func(r chi.Router) {
+				r.Use(require(PermDashboardRead))
+				r.Get("/stats", m.handler.GetDashboardStats)
+				r.Get("/pending-companies", m.handler.GetPendingCompanies)
+				r.Get("/pending-payments", m.handler.GetPendingPayments)
+				r.Get("/open-tickets", m.handler.GetOpenSupportTickets)
+			})
+
+			// Security settings
+			r.With(require(PermSecurityManage)).Get("/settings/security", m.handler.GetSecuritySettings)
+			r.With(require(PermSecurityManage)).Put("/settings/security", m.handler.UpdateSecuritySettings)
+
+			// Login lockouts
+			r.With(require(PermSecurityManage)).Get("/security/lockouts", m.handler.GetLockouts)
+			r.With(require(PermSecurityManage)).Post("/security/lockouts/{id}/unlock", m.handler.UnlockLockout)
+
+			// Admin accounts, invitations and roles
+			r.Group(func(r chi.Router) {
+				r.Use(require(PermAdminsManage))
+				r.Get("/permissions", m.roleHandler.GetPermissions)
+				r.Get("/roles", m.roleHandler.GetRoles)
+				r.Post("/roles", m.roleHandler.CreateRole)
+				r.Put("/roles/{id}", m.roleHandler.UpdateRole)
+				r.Delete("/roles/{id}", m.roleHandler.DeleteRole)
+				r.Get("/admins", m.roleHandler.GetAdmins)
+				r.Put("/admins/{id}/roles", m.roleHandler.AssignRoles)
+				r.Post("/admins/{id}/deactivate", m.accountHandler.DeactivateAdmin)
+				r.Post("/admins/{id}/reactivate", m.accountHandler.ReactivateAdmin)
+				r.Get("/admins/invitations", m.accountHandler.GetInvitations)
+				r.Post("/admins/invitations", m.accountHandler.InviteAdmin)
+				r.Post("/admins/invitations/{id}/resend", m.accountHandler.ResendInvitation)
+				r.Delete("/admins/invitations/{id}", m.accountHandler.RevokeInvitation)
+			})
+
+			// Audit log
+			r.Route("/audit-logs", func(r chi.Router) {
+				r.Use(require(PermAuditLogsView))
+				r.Get("/", m.auditHandler.GetAuditLogs)
+				r.Get("/export", m.auditHandler.ExportAuditLogs)
+			})
+
+			// Email outbox
+			r.Route("/emails", func(r chi.Router) {
+				r.Use(require(PermEmailsManage))
+				r.Get("/", m.emailHandler.GetEmails)
+				r.Post("/{id}/retry", m.emailHandler.RetryEmail)
+			})
 
 			// Company management
 			r.Route("/companies", func(r chi.Router) {
-				r.Get("/", m.handler.GetCompanies)
-				r.Get("/{id}", m.handler.GetCompanyByID)
-				r.Get("/{id}/detail", m.handler.GetCompanyDetail)
-				r.Post("/{id}/verify", m.handler.VerifyCompany)
-				r.Patch("/{id}/status", m.handler.UpdateCompanyStatus)
+				r.With(require(PermCompaniesView)).Get("/", m.handler.GetCompanies)
+				r.With(require(PermCompaniesView)).Get("/{id}", m.handler.GetCompanyByID)
+				r.With(require(PermCompaniesView)).Get("/{id}/detail", m.handler.GetCompanyDetail)
+				r.With(require(PermCompaniesVerify)).Post("/{id}/verify", m.handler.VerifyCompany)
+				r.With(require(PermCompaniesVerify)).Patch("/{id}/status", m.handler.UpdateCompanyStatus)
 			})
 
 			// Job management
 			r.Route("/jobs", func(r chi.Router) {
-				r.Get("/", m.handler.GetJobs)
-				r.Get("/{id}", m.handler.GetJobByID)
-				r.Post("/{id}/moderate", m.handler.ModerateJob)
+				r.With(require(PermJobsView)).Get("/", m.handler.GetJobs)
+				r.With(require(PermJobsView)).Get("/{id}", m.handler.GetJobByID)
+				r.With(require(PermJobsModerate)).Post("/{id}/moderate", m.handler.ModerateJob)
 			})
 
 			// Payment management
 			r.Route("/payments", func(r chi.Router) {
-				r.Get("/", m.handler.GetPayments)
-				r.Get("/{id}", m.handler.GetPaymentByID)
-				r.Post("/{id}/process", m.handler.ProcessPayment)
+				r.With(require(PermPaymentsView)).Get("/", m.handler.GetPayments)
+				r.With(require(PermPaymentsView)).Get("/{id}", m.handler.GetPaymentByID)
+				r.With(require(PermPaymentsApprove)).Post("/{id}/process", m.handler.ProcessPayment)
 			})
 
 			// Job seeker management
 			r.Route("/job-seekers", func(r chi.Router) {
-				r.Get("/", m.handler.GetJobSeekers)
-				r.Get("/{id}", m.handler.GetJobSeekerByID)
-				r.Patch("/{id}/status", m.handler.UpdateJobSeekerStatus)
+				r.With(require(PermJobSeekersView)).Get("/", m.handler.GetJobSeekers)
+				r.With(require(PermJobSeekersView)).Get("/{id}", m.handler.GetJobSeekerByID)
+				r.With(require(PermJobSeekersManage)).Patch("/{id}/status", m.handler.UpdateJobSeekerStatus)
 			})
 
 			// Announcements management (notifications, banners, information)
 			if m.announcementsModule != nil {
-				m.announcementsModule.RegisterAdminRoutes(r)
+				r.Group(func(r chi.Router) {
+					r.Use(require(PermAnnouncementsManage))
+					m.announcementsModule.RegisterAdminRoutes(r)
+				})
 			}
 
 			// Partner management (referral & affiliate)
 			if m.partnerHandler != nil {
 				// Partner management
 				r.Route("/partners", func(r chi.Router) {
-					r.Get("/", m.partnerHandler.GetPartners)
-					r.Get("/{id}", m.partnerHandler.GetPartnerByID)
-					r.Put("/{id}", m.partnerHandler.EditPartner)
-					r.Delete("/{id}", m.partnerHandler.DeletePartner)
-					r.Patch("/{id}/status", m.partnerHandler.UpdatePartnerStatus)
-					r.Post("/{id}/approve", m.partnerHandler.ApprovePartner)
-					r.Post("/{id}/reject", m.partnerHandler.RejectPartner)
+					r.With(require(PermPartnersView)).Get("/", m.partnerHandler.GetPartners)
+					r.With(require(PermPartnersView)).Get("/{id}", m.partnerHandler.GetPartnerByID)
+					r.With(require(PermPartnersManage)).Put("/{id}", m.partnerHandler.EditPartner)
+					r.With(require(PermPartnersManage)).Delete("/{id}", m.partnerHandler.DeletePartner)
+					r.With(require(PermPartnersManage)).Patch("/{id}/status", m.partnerHandler.UpdatePartnerStatus)
+					r.With(require(PermPartnersManage)).Post("/{id}/approve", m.partnerHandler.ApprovePartner)
+					r.With(require(PermPartnersManage)).Post("/{id}/reject", m.partnerHandler.RejectPartner)
 				})
 
 				// Referral management
 				r.Route("/referrals", func(r chi.Router) {
+					r.Use(require(PermPartnersView))
 					r.Get("/companies", m.partnerHandler.GetReferredCompanies)
 					r.Get("/stats", m.partnerHandler.GetReferralStats)
 				})
 
 				// Payout management
 				r.Route("/payouts", func(r chi.Router) {
+					r.Use(require(PermPartnersPayout))
 					r.Get("/", m.partnerHandler.GetPayouts)
 					r.Get("/stats", m.partnerHandler.GetPayoutStats)
 					r.Get("/balances", m.partnerHandler.GetPartnerBalances)
@@ -144,6 +285,33 @@ func (m *Module) RegisterRoutes(r chi.Router) {
 	})
 }
 
+// RequirePermission returns a middleware admitting admins whose roles grant permission,
+// for admin routes registered by other modules (support chat and tickets)
+func (m *Module) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return RequirePermission(m.roleService, permission)
+}
+
+// ActiveAdmins returns the check that keeps deactivated admins out of the admin portal
+func (m *Module) ActiveAdmins() middleware.ActiveFunc {
+	return ActiveAdmins(m.repo)
+}
+
+// invitationSender returns emailSvc as an InvitationSender, or nil when email is not configured
+func invitationSender(emailSvc *email.Service) InvitationSender {
+	if emailSvc == nil {
+		return nil
+	}
+	return emailSvc
+}
+
+// emailOutbox returns emailSvc as an EmailOutbox, or nil when email is not configured
+func emailOutbox(emailSvc *email.Service) EmailOutbox {
+	if emailSvc == nil {
+		return nil
+	}
+	return emailSvc
+}
+
 // GetAnnouncementsModule returns the announcements module for public routes registration
 func (m *Module) GetAnnouncementsModule() *announcements.Module {
 	return m.announcementsModule
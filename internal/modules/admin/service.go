@@ -5,15 +5,21 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	"github.com/karirnusantara/api/internal/modules/notifications"
 	"github.com/karirnusantara/api/internal/modules/quota"
+	"github.com/karirnusantara/api/internal/shared/clientip"
 	"github.com/karirnusantara/api/internal/shared/email"
 	"github.com/karirnusantara/api/internal/shared/invoice"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/token"
 )
 
 // Common errors
@@ -26,14 +32,29 @@ var (
 	ErrPaymentNotFound    = errors.New("pembayaran tidak ditemukan")
 	ErrJobSeekerNotFound  = errors.New("pencari kerja tidak ditemukan")
 	ErrInvalidAction      = errors.New("aksi tidak valid")
+	ErrMFAUnavailable     = errors.New("autentikasi dua faktor tidak tersedia")
+	ErrLockoutNotFound    = errors.New("kunci login tidak ditemukan")
+
+	ErrPaymentAlreadyProcessed = errors.New("pembayaran sudah diproses")
+	ErrPaymentNotConfirmed     = errors.New("hanya pembayaran yang sudah dikonfirmasi yang dapat dibatalkan")
 )
 
 // Service defines the admin service interface
 type Service interface {
 	// Authentication
 	Login(ctx context.Context, req *AdminLoginRequest) (*AdminAuthResponse, error)
+	LoginMFA(ctx context.Context, req *mfa.ChallengeRequest) (*AdminAuthResponse, error)
+	SetupLoginMFA(ctx context.Context, req *mfa.ChallengeSetupRequest) (*mfa.SetupResponse, error)
 	GetCurrentAdmin(ctx context.Context, adminID uint64) (*AdminUserResponse, error)
 
+	// Security settings
+	GetSecuritySettings(ctx context.Context) (*SecuritySettings, error)
+	UpdateSecuritySettings(ctx context.Context, req *UpdateSecuritySettingsRequest, adminID uint64) (*SecuritySettings, error)
+
+	// Login lockouts
+	GetLockouts(ctx context.Context, search string) ([]*loginguard.LockoutResponse, error)
+	UnlockLockout(ctx context.Context, id uint64, adminID uint64) error
+
 	// Dashboard
 	GetDashboardStats(ctx context.Context) (*DashboardStats, error)
 	GetPendingCompanies(ctx context.Context, limit int) ([]*CompanyAdminResponse, error)
@@ -70,6 +91,10 @@ type service struct {
 	quotaService   *quota.Service
 	emailService   *email.Service
 	invoiceService *invoice.Service
+	notifications  notifications.Service
+	mfaService     mfa.Service
+	guard          loginguard.Service
+	tokens         *token.Manager
 }
 
 // NewService creates a new admin service
@@ -77,6 +102,7 @@ func NewService(repo Repository, cfg *config.Config) Service {
 	return &service{
 		repo:   repo,
 		config: cfg,
+		tokens: token.NewManager(&cfg.JWT),
 	}
 }
 
@@ -86,17 +112,40 @@ func NewServiceWithQuota(repo Repository, cfg *config.Config, quotaSvc *quota.Se
 		repo:         repo,
 		config:       cfg,
 		quotaService: quotaSvc,
+		tokens:       token.NewManager(&cfg.JWT),
 	}
 }
 
 // NewServiceComplete creates a new admin service with all dependencies
-func NewServiceComplete(repo Repository, cfg *config.Config, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service) Service {
+func NewServiceComplete(repo Repository, cfg *config.Config, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service) Service {
+	return &service{
+		repo:           repo,
+		config:         cfg,
+		quotaService:   quotaSvc,
+		emailService:   emailSvc,
+		invoiceService: invoiceSvc,
+		notifications:  notificationSvc,
+		tokens:         token.NewManager(&cfg.JWT),
+	}
+}
+
+// NewServiceWithMFA creates a new admin service with all dependencies and two-factor authentication
+func NewServiceWithMFA(repo Repository, cfg *config.Config, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service, mfaSvc mfa.Service) Service {
+	return NewServiceWithSecurity(repo, cfg, quotaSvc, emailSvc, invoiceSvc, notificationSvc, mfaSvc, nil)
+}
+
+// NewServiceWithSecurity creates a new admin service with all dependencies, two-factor authentication and brute-force protection
+func NewServiceWithSecurity(repo Repository, cfg *config.Config, quotaSvc *quota.Service, emailSvc *email.Service, invoiceSvc *invoice.Service, notificationSvc notifications.Service, mfaSvc mfa.Service, guard loginguard.Service) Service {
 	return &service{
 		repo:           repo,
 		config:         cfg,
 		quotaService:   quotaSvc,
 		emailService:   emailSvc,
 		invoiceService: invoiceSvc,
+		notifications:  notificationSvc,
+		mfaService:     mfaSvc,
+		guard:          guard,
+		tokens:         token.NewManager(&cfg.JWT),
 	}
 }
 
@@ -105,26 +154,102 @@ func NewServiceComplete(repo Repository, cfg *config.Config, quotaSvc *quota.Ser
 // ============================================
 
 func (s *service) Login(ctx context.Context, req *AdminLoginRequest) (*AdminAuthResponse, error) {
+	// Reject while the account or IP is throttled
+	if s.guard != nil {
+		if err := s.guard.Check(ctx, loginguard.ScopeLogin, req.Email); err != nil {
+			return nil, err
+		}
+	}
+
 	// Find admin by email
 	admin, err := s.repo.GetAdminByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find admin: %w", err)
 	}
 	if admin == nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, ErrInvalidCredentials
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(req.Password)); err != nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, ErrInvalidCredentials
 	}
+	if s.guard != nil {
+		s.guard.RecordSuccess(ctx, loginguard.ScopeLogin, req.Email)
+	}
 
 	// Check if active
 	if !admin.IsActive {
 		return nil, ErrAccountInactive
 	}
 
-	// Generate JWT token
+	// Ask for the second factor (or enrollment when it is required) before issuing a token
+	if s.mfaService != nil {
+		settings, err := s.GetSecuritySettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		challenge, err := s.mfaService.BeginLogin(ctx, admin.ID, admin.Email, mfa.PortalAdmin, settings.RequireAdminMFA)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			return &AdminAuthResponse{MFARequired: true, MFA: challenge}, nil
+		}
+	}
+
+	return s.issueAuthResponse(admin)
+}
+
+// recordLoginFailure counts a failed password attempt when brute-force protection is enabled
+func (s *service) recordLoginFailure(ctx context.Context, email string) {
+	if s.guard != nil {
+		s.guard.RecordFailure(ctx, loginguard.ScopeLogin, email)
+	}
+}
+
+// LoginMFA finishes a login that returned an MFA challenge
+func (s *service) LoginMFA(ctx context.Context, req *mfa.ChallengeRequest) (*AdminAuthResponse, error) {
+	if s.mfaService == nil {
+		return nil, ErrMFAUnavailable
+	}
+
+	adminID, recoveryCodes, err := s.mfaService.CompleteChallenge(ctx, req, mfa.PortalAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.repo.GetAdminByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin: %w", err)
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	if !admin.IsActive {
+		return nil, ErrAccountInactive
+	}
+
+	resp, err := s.issueAuthResponse(admin)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// SetupLoginMFA starts enrollment for an admin who must enable MFA before logging in
+func (s *service) SetupLoginMFA(ctx context.Context, req *mfa.ChallengeSetupRequest) (*mfa.SetupResponse, error) {
+	if s.mfaService == nil {
+		return nil, ErrMFAUnavailable
+	}
+	return s.mfaService.SetupChallenge(ctx, req.MFAToken, mfa.PortalAdmin)
+}
+
+// issueAuthResponse generates the admin access token
+func (s *service) issueAuthResponse(admin *AdminUser) (*AdminAuthResponse, error) {
 	expiresIn := time.Hour * 24 // 24 hours for admin tokens
 	token, err := s.generateAccessToken(admin, expiresIn)
 	if err != nil {
@@ -150,6 +275,64 @@ func (s *service) GetCurrentAdmin(ctx context.Context, adminID uint64) (*AdminUs
 	return admin.ToResponse(), nil
 }
 
+// ============================================
+// SECURITY SETTINGS
+// ============================================
+
+func (s *service) GetSecuritySettings(ctx context.Context) (*SecuritySettings, error) {
+	requireMFA, err := s.repo.GetSetting(ctx, SettingRequireAdminMFA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security settings: %w", err)
+	}
+
+	return &SecuritySettings{
+		RequireAdminMFA: requireMFA == "true",
+	}, nil
+}
+
+func (s *service) UpdateSecuritySettings(ctx context.Context, req *UpdateSecuritySettingsRequest, adminID uint64) (*SecuritySettings, error) {
+	if req.RequireAdminMFA != nil {
+		if *req.RequireAdminMFA && s.mfaService == nil {
+			return nil, ErrMFAUnavailable
+		}
+
+		previous, err := s.repo.GetSetting(ctx, SettingRequireAdminMFA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security settings: %w", err)
+		}
+
+		value := strconv.FormatBool(*req.RequireAdminMFA)
+		if err := s.repo.SetSetting(ctx, SettingRequireAdminMFA, value, adminID); err != nil {
+			return nil, fmt.Errorf("failed to update security settings: %w", err)
+		}
+		s.logChange(ctx, adminID, "update_setting", "system_setting", 0, SettingRequireAdminMFA+"="+value,
+			map[string]string{SettingRequireAdminMFA: previous}, map[string]string{SettingRequireAdminMFA: value})
+	}
+
+	return s.GetSecuritySettings(ctx)
+}
+
+// GetLockouts lists accounts and IPs that are currently locked out of login or password reset
+func (s *service) GetLockouts(ctx context.Context, search string) ([]*loginguard.LockoutResponse, error) {
+	if s.guard == nil {
+		return []*loginguard.LockoutResponse{}, nil
+	}
+	return s.guard.ListLockouts(ctx, search)
+}
+
+// UnlockLockout lifts a lockout before it expires
+func (s *service) UnlockLockout(ctx context.Context, id uint64, adminID uint64) error {
+	if s.guard == nil {
+		return ErrLockoutNotFound
+	}
+	if err := s.guard.Unlock(ctx, id, adminID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, adminID, "unlock_login", "auth_throttle", id, "")
+	return nil
+}
+
 // ============================================
 // DASHBOARD
 // ============================================
@@ -234,36 +417,45 @@ func (s *service) VerifyCompany(ctx context.Context, id uint64, req *CompanyVeri
 
 	// Update status
 	if err := s.repo.UpdateCompanyStatus(ctx, id, newStatus); err != nil {
-		// Log the actual error for debugging
-		fmt.Printf("DEBUG: UpdateCompanyStatus failed for id=%d, status=%s, error=%v\n", id, newStatus, err)
+		logger.FromContext(ctx).Error("failed to update company status", "company_user_id", id, "status", newStatus, "error", err)
 		return err
 	}
 
-	// Log admin action (optional)
-	if req.Reason != "" {
-		s.logAction(ctx, adminID, action, "company", id, req.Reason)
+	// Log admin action
+	s.logChange(ctx, adminID, action, "company", id, req.Reason, companySnapshot(company), s.companyAfter(ctx, id))
+
+	// In-app notification for the company account
+	if isApproved {
+		s.notify(ctx, company.ID, notifications.TypeCompanyVerified,
+			"Perusahaan terverifikasi",
+			"Selamat! Perusahaan Anda telah diverifikasi dan dapat mulai memposting lowongan.",
+			map[string]interface{}{"company_id": id})
+	} else {
+		message := "Verifikasi perusahaan Anda ditolak."
+		if req.Reason != "" {
+			message = fmt.Sprintf("Verifikasi perusahaan Anda ditolak. Alasan: %s", req.Reason)
+		}
+		s.notify(ctx, company.ID, notifications.TypeCompanyRejected,
+			"Verifikasi perusahaan ditolak", message,
+			map[string]interface{}{"company_id": id})
 	}
 
-	// Send verification email notification (async)
+	// Queue verification email notification
 	if s.emailService != nil && company.Email != "" {
-		go func() {
-			companyName := ""
-			if company.CompanyName.Valid {
-				companyName = company.CompanyName.String
-			}
-			err := s.emailService.SendCompanyVerificationEmail(
-				company.Email,
-				companyName,
-				company.FullName,
-				isApproved,
-				req.Reason,
-			)
-			if err != nil {
-				fmt.Printf("DEBUG: Failed to send verification email to %s: %v\n", company.Email, err)
-			} else {
-				fmt.Printf("DEBUG: Verification email sent successfully to %s\n", company.Email)
-			}
-		}()
+		companyName := ""
+		if company.CompanyName.Valid {
+			companyName = company.CompanyName.String
+		}
+		err := s.emailService.SendCompanyVerificationEmail(
+			company.Email,
+			companyName,
+			company.FullName,
+			isApproved,
+			req.Reason,
+		)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to send company verification email", "company_user_id", id, "to", company.Email, "error", err)
+		}
 	}
 
 	return nil
@@ -307,7 +499,7 @@ func (s *service) UpdateCompanyStatus(ctx context.Context, id uint64, req *Compa
 	}
 
 	// Log admin action
-	s.logAction(ctx, adminID, action, "company", id, req.Reason)
+	s.logChange(ctx, adminID, action, "company", id, req.Reason, companySnapshot(company), s.companyAfter(ctx, id))
 
 	return nil
 }
@@ -380,7 +572,11 @@ func (s *service) ModerateJob(ctx context.Context, id uint64, req *JobActionRequ
 	}
 
 	// Update job status if needed
-	if newStatus != "" {
+	if newStatus == JobStatusClosed {
+		if err := s.repo.CloseJob(ctx, id, adminID); err != nil {
+			return fmt.Errorf("failed to close job: %w", err)
+		}
+	} else if newStatus != "" {
 		if err := s.repo.UpdateJobStatus(ctx, id, newStatus); err != nil {
 			return fmt.Errorf("failed to update job status: %w", err)
 		}
@@ -394,7 +590,7 @@ func (s *service) ModerateJob(ctx context.Context, id uint64, req *JobActionRequ
 	}
 
 	// Log admin action
-	s.logAction(ctx, adminID, action, "job", id, req.Reason)
+	s.logChange(ctx, adminID, action, "job", id, req.Reason, jobSnapshot(job), s.jobAfter(ctx, id))
 
 	return nil
 }
@@ -447,6 +643,9 @@ func (s *service) ProcessPayment(ctx context.Context, id uint64, req *PaymentAct
 		// Use quota service to confirm and add quota
 		if s.quotaService != nil {
 			if err := s.quotaService.ConfirmPayment(id, adminID, req.Note); err != nil {
+				if errors.Is(err, quota.ErrPaymentAlreadyProcessed) {
+					return ErrPaymentAlreadyProcessed
+				}
 				return fmt.Errorf("failed to confirm payment: %w", err)
 			}
 		} else {
@@ -457,15 +656,18 @@ func (s *service) ProcessPayment(ctx context.Context, id uint64, req *PaymentAct
 		}
 		action = "payment_approved"
 
-		// Send confirmation email with invoice PDF (async)
+		// Queue confirmation email with invoice PDF
 		if s.emailService != nil && s.invoiceService != nil {
-			go s.sendPaymentConfirmationWithInvoice(payment, req.Note)
+			s.sendPaymentConfirmationWithInvoice(ctx, payment, req.Note)
 		}
 
 	case "reject":
 		// Use quota service to reject
 		if s.quotaService != nil {
 			if err := s.quotaService.RejectPayment(id, adminID, req.Note); err != nil {
+				if errors.Is(err, quota.ErrPaymentAlreadyProcessed) {
+					return ErrPaymentAlreadyProcessed
+				}
 				return fmt.Errorf("failed to reject payment: %w", err)
 			}
 		} else {
@@ -474,21 +676,69 @@ func (s *service) ProcessPayment(ctx context.Context, id uint64, req *PaymentAct
 			}
 		}
 		action = "payment_rejected"
+
+	case "reverse":
+		// Reversal takes back quota and cancels the partner commission, so it needs the quota service
+		if s.quotaService == nil {
+			return ErrInvalidAction
+		}
+		if err := s.quotaService.ReversePayment(id, adminID, req.Note); err != nil {
+			if errors.Is(err, quota.ErrPaymentNotConfirmed) {
+				return ErrPaymentNotConfirmed
+			}
+			return fmt.Errorf("failed to reverse payment: %w", err)
+		}
+		action = "payment_reversed"
 	default:
 		return ErrInvalidAction
 	}
 
 	// Log admin action
-	s.logAction(ctx, adminID, action, "payment", id, req.Note)
+	s.logChange(ctx, adminID, action, "payment", id, req.Note, paymentSnapshot(payment), s.paymentAfter(ctx, id))
+
+	// In-app notification for the paying company
+	s.notifyPaymentProcessed(ctx, payment, req.Action, req.Note)
 
 	return nil
 }
 
-// sendPaymentConfirmationWithInvoice generates invoice PDF and sends confirmation email
-func (s *service) sendPaymentConfirmationWithInvoice(payment *PaymentAdmin, adminNote string) {
-	// Get company email - need to query the users table
-	ctx := context.Background()
+// notifyPaymentProcessed notifies the company owner that a payment was approved, rejected or reversed
+func (s *service) notifyPaymentProcessed(ctx context.Context, payment *PaymentAdmin, action, note string) {
+	if s.notifications == nil {
+		return
+	}
+
+	userID, err := s.repo.GetCompanyUserID(ctx, payment.CompanyID)
+	if err != nil || userID == 0 {
+		logger.FromContext(ctx).Error("failed to resolve company user for payment notification", "payment_id", payment.ID, "error", err)
+		return
+	}
+
+	data := map[string]interface{}{"payment_id": payment.ID, "amount": payment.Amount}
+	switch action {
+	case "approve":
+		s.notify(ctx, userID, notifications.TypePaymentConfirmed,
+			"Pembayaran dikonfirmasi",
+			fmt.Sprintf("Pembayaran sebesar Rp %d telah dikonfirmasi. Kuota lowongan Anda sudah ditambahkan.", payment.Amount),
+			data)
+		return
+	case "reverse":
+		s.notify(ctx, userID, notifications.TypePaymentReversed,
+			"Pembayaran dibatalkan",
+			fmt.Sprintf("Konfirmasi pembayaran sebesar Rp %d dibatalkan dan kuota terkait telah ditarik. Catatan: %s", payment.Amount, note),
+			data)
+		return
+	}
+
+	message := fmt.Sprintf("Pembayaran sebesar Rp %d ditolak.", payment.Amount)
+	if note != "" {
+		message = fmt.Sprintf("Pembayaran sebesar Rp %d ditolak. Catatan: %s", payment.Amount, note)
+	}
+	s.notify(ctx, userID, notifications.TypePaymentRejected, "Pembayaran ditolak", message, data)
+}
 
+// sendPaymentConfirmationWithInvoice generates invoice PDF and sends confirmation email
+func (s *service) sendPaymentConfirmationWithInvoice(ctx context.Context, payment *PaymentAdmin, adminNote string) {
 	// Generate invoice number
 	invoiceNumber := fmt.Sprintf("INV/%s/%05d",
 		time.Now().Format("2006/01"),
@@ -511,7 +761,7 @@ func (s *service) sendPaymentConfirmationWithInvoice(payment *PaymentAdmin, admi
 	// Get company details for email
 	companyUser, err := s.repo.GetUserByID(ctx, payment.CompanyID)
 	if err != nil {
-		fmt.Printf("Error getting company user for email: %v\n", err)
+		logger.FromContext(ctx).Error("failed to get company user for payment confirmation", "payment_id", payment.ID, "error", err)
 		return
 	}
 
@@ -520,7 +770,7 @@ func (s *service) sendPaymentConfirmationWithInvoice(payment *PaymentAdmin, admi
 	// Generate PDF invoice
 	pdfPath, err := s.invoiceService.GeneratePaymentInvoice(invoiceData)
 	if err != nil {
-		fmt.Printf("Error generating invoice PDF: %v\n", err)
+		logger.FromContext(ctx).Error("failed to generate invoice", "payment_id", payment.ID, "invoice", invoiceNumber, "error", err)
 		return
 	}
 
@@ -534,12 +784,11 @@ func (s *service) sendPaymentConfirmationWithInvoice(payment *PaymentAdmin, admi
 	)
 
 	if err != nil {
-		fmt.Printf("Error sending payment confirmation email: %v\n", err)
+		logger.FromContext(ctx).Error("failed to send payment confirmation email", "payment_id", payment.ID, "to", companyUser.Email, "error", err)
 		return
 	}
 
-	fmt.Printf("Payment confirmation email sent successfully to %s with invoice %s\n",
-		companyUser.Email, invoiceNumber)
+	logger.FromContext(ctx).Info("payment confirmation email sent", "payment_id", payment.ID, "to", companyUser.Email, "invoice", invoiceNumber)
 }
 
 // ============================================
@@ -603,7 +852,7 @@ func (s *service) UpdateJobSeekerStatus(ctx context.Context, id uint64, req *Job
 	}
 
 	// Log admin action
-	s.logAction(ctx, adminID, action, "job_seeker", id, req.Reason)
+	s.logChange(ctx, adminID, action, "job_seeker", id, req.Reason, jobSeekerSnapshot(jobSeeker), s.jobSeekerAfter(ctx, id))
 
 	return nil
 }
@@ -613,31 +862,88 @@ func (s *service) UpdateJobSeekerStatus(ctx context.Context, id uint64, req *Job
 // ============================================
 
 func (s *service) logAction(ctx context.Context, adminID uint64, action, entityType string, entityID uint64, details string) {
-	log := &AdminActionLog{
+	// We don't fail if logging fails, just log it
+	_ = s.repo.LogAdminAction(ctx, newActionLog(ctx, adminID, action, entityType, entityID, details))
+}
+
+// logChange records an admin action with snapshots of the entity before and after it
+func (s *service) logChange(ctx context.Context, adminID uint64, action, entityType string, entityID uint64, details string, before, after interface{}) {
+	entry := newActionLog(ctx, adminID, action, entityType, entityID, details).withSnapshots(before, after)
+	_ = s.repo.LogAdminAction(ctx, entry)
+}
+
+// companyAfter, jobAfter, paymentAfter and jobSeekerAfter re-read an entity for the audit log;
+// the snapshot is left empty if it cannot be read
+func (s *service) companyAfter(ctx context.Context, id uint64) interface{} {
+	company, err := s.repo.GetCompanyByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return companySnapshot(company)
+}
+
+func (s *service) jobAfter(ctx context.Context, id uint64) interface{} {
+	job, err := s.repo.GetJobByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return jobSnapshot(job)
+}
+
+func (s *service) paymentAfter(ctx context.Context, id uint64) interface{} {
+	payment, err := s.repo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return paymentSnapshot(payment)
+}
+
+func (s *service) jobSeekerAfter(ctx context.Context, id uint64) interface{} {
+	jobSeeker, err := s.repo.GetJobSeekerByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return jobSeekerSnapshot(jobSeeker)
+}
+
+// newActionLog builds an audit log entry with the permission and client IP of the request
+func newActionLog(ctx context.Context, adminID uint64, action, entityType string, entityID uint64, details string) *AdminActionLog {
+	permission := permissionFromContext(ctx)
+	ip := clientip.FromContext(ctx)
+	userAgent := clientip.UserAgentFromContext(ctx)
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+	return &AdminActionLog{
 		AdminID:    adminID,
 		Action:     action,
 		EntityType: entityType,
 		EntityID:   entityID,
 		Details:    sql.NullString{String: details, Valid: details != ""},
+		Permission: sql.NullString{String: permission, Valid: permission != ""},
+		IPAddress:  sql.NullString{String: ip, Valid: ip != ""},
+		UserAgent:  sql.NullString{String: userAgent, Valid: userAgent != ""},
 	}
+}
 
-	// We don't fail if logging fails, just log it
-	_ = s.repo.LogAdminAction(ctx, log)
+// notify creates an in-app notification, logging instead of failing on error
+func (s *service) notify(ctx context.Context, userID uint64, notifType, title, message string, data map[string]interface{}) {
+	if s.notifications == nil {
+		return
+	}
+
+	if err := s.notifications.Notify(ctx, userID, notifType, title, message, data); err != nil {
+		logger.FromContext(ctx).Error("failed to create notification", "recipient_id", userID, "type", notifType, "error", err)
+	}
 }
 
 // generateAccessToken generates a new access token for admin
 func (s *service) generateAccessToken(admin *AdminUser, expiry time.Duration) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":    admin.ID,
-		"email":      admin.Email,
-		"role":       admin.Role,
-		"token_type": "access",
-		"exp":        time.Now().Add(expiry).Unix(),
-		"iat":        time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.JWT.Secret))
+	return s.tokens.Issue(token.PortalAdmin, token.Claims{
+		UserID: admin.ID,
+		Email:  admin.Email,
+		Role:   admin.Role,
+	}, expiry)
 }
 
 // ============================================
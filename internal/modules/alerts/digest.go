@@ -0,0 +1,55 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// digestBatchSize limits how many saved searches are processed per batch
+const digestBatchSize = 100
+
+// DigestWorker periodically sends job alert digests for due saved searches
+type DigestWorker struct {
+	service  Service
+	interval time.Duration
+}
+
+// NewDigestWorker creates a new digest worker
+func NewDigestWorker(service Service, interval time.Duration) *DigestWorker {
+	return &DigestWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run processes due digests immediately and then on every interval until ctx is cancelled
+func (w *DigestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.runOnce(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+// runOnce sends all currently due digests
+func (w *DigestWorker) runOnce(ctx context.Context) {
+	now := time.Now()
+	sent, err := w.service.SendDueDigests(ctx, now, digestBatchSize)
+	if err != nil {
+		logger.FromContext(ctx).Error("job alert digest run failed", "error", err)
+		return
+	}
+	if sent > 0 {
+		logger.FromContext(ctx).Info("job alert digests sent", "count", sent)
+	}
+}
@@ -0,0 +1,168 @@
+package alerts
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/karirnusantara/api/internal/modules/jobs"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+)
+
+// Alert frequencies
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+)
+
+// maxMatchesPerDigest caps how many jobs are listed in one alert email
+const maxMatchesPerDigest = 20
+
+// SavedSearch represents a named job search saved by a job seeker
+type SavedSearch struct {
+	ID              uint64         `db:"id" json:"id"`
+	UserID          uint64         `db:"user_id" json:"user_id"`
+	Name            string         `db:"name" json:"name"`
+	Search          sql.NullString `db:"search" json:"search,omitempty"`
+	City            sql.NullString `db:"city" json:"city,omitempty"`
+	Province        sql.NullString `db:"province" json:"province,omitempty"`
+	JobType         sql.NullString `db:"job_type" json:"job_type,omitempty"`
+	ExperienceLevel sql.NullString `db:"experience_level" json:"experience_level,omitempty"`
+	IsRemote        sql.NullBool   `db:"is_remote" json:"is_remote,omitempty"`
+	SalaryMin       sql.NullInt64  `db:"salary_min" json:"salary_min,omitempty"`
+	SalaryMax       sql.NullInt64  `db:"salary_max" json:"salary_max,omitempty"`
+	Frequency       string         `db:"frequency" json:"frequency"`
+	IsActive        bool           `db:"is_active" json:"is_active"`
+	LastRunAt       sql.NullTime   `db:"last_run_at" json:"last_run_at,omitempty"`
+	LastSentAt      sql.NullTime   `db:"last_sent_at" json:"last_sent_at,omitempty"`
+	CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// DueSearch is a saved search that is due for a digest, with its owner's contact info
+type DueSearch struct {
+	SavedSearch
+	UserEmail    string `db:"user_email"`
+	UserFullName string `db:"user_full_name"`
+}
+
+// SavedSearchRequest represents the create/update request for a saved search
+type SavedSearchRequest struct {
+	Name            string `json:"name" validate:"required,min=2,max=100"`
+	Search          string `json:"search,omitempty" validate:"omitempty,max=255"`
+	City            string `json:"city,omitempty" validate:"omitempty,max=100"`
+	Province        string `json:"province,omitempty" validate:"omitempty,max=100"`
+	JobType         string `json:"job_type,omitempty" validate:"omitempty,oneof=full_time part_time contract internship freelance"`
+	ExperienceLevel string `json:"experience_level,omitempty" validate:"omitempty,oneof=entry junior mid senior lead executive"`
+	IsRemote        *bool  `json:"is_remote,omitempty"`
+	SalaryMin       *int64 `json:"salary_min,omitempty" validate:"omitempty,gte=0"`
+	SalaryMax       *int64 `json:"salary_max,omitempty" validate:"omitempty,gte=0"`
+	Frequency       string `json:"frequency" validate:"required,oneof=daily weekly"`
+	IsActive        *bool  `json:"is_active,omitempty"`
+}
+
+// SavedSearchResponse represents the saved search API response
+type SavedSearchResponse struct {
+	ID              uint64     `json:"id"`
+	HashID          string     `json:"hash_id"`
+	Name            string     `json:"name"`
+	Search          string     `json:"search,omitempty"`
+	City            string     `json:"city,omitempty"`
+	Province        string     `json:"province,omitempty"`
+	JobType         string     `json:"job_type,omitempty"`
+	ExperienceLevel string     `json:"experience_level,omitempty"`
+	IsRemote        *bool      `json:"is_remote,omitempty"`
+	SalaryMin       *int64     `json:"salary_min,omitempty"`
+	SalaryMax       *int64     `json:"salary_max,omitempty"`
+	Frequency       string     `json:"frequency"`
+	IsActive        bool       `json:"is_active"`
+	LastSentAt      *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ToResponse converts SavedSearch to SavedSearchResponse
+func (s *SavedSearch) ToResponse() *SavedSearchResponse {
+	resp := &SavedSearchResponse{
+		ID:              s.ID,
+		HashID:          hashid.Encode(s.ID),
+		Name:            s.Name,
+		Search:          s.Search.String,
+		City:            s.City.String,
+		Province:        s.Province.String,
+		JobType:         s.JobType.String,
+		ExperienceLevel: s.ExperienceLevel.String,
+		Frequency:       s.Frequency,
+		IsActive:        s.IsActive,
+		CreatedAt:       s.CreatedAt,
+	}
+
+	if s.IsRemote.Valid {
+		resp.IsRemote = &s.IsRemote.Bool
+	}
+	if s.SalaryMin.Valid {
+		resp.SalaryMin = &s.SalaryMin.Int64
+	}
+	if s.SalaryMax.Valid {
+		resp.SalaryMax = &s.SalaryMax.Int64
+	}
+	if s.LastSentAt.Valid {
+		resp.LastSentAt = &s.LastSentAt.Time
+	}
+
+	return resp
+}
+
+// ToJobListParams builds the jobs list filter equivalent to this saved search
+func (s *SavedSearch) ToJobListParams() jobs.JobListParams {
+	params := jobs.DefaultJobListParams()
+	params.Search = s.Search.String
+	params.City = s.City.String
+	params.Province = s.Province.String
+	params.JobType = s.JobType.String
+	params.ExperienceLevel = s.ExperienceLevel.String
+
+	if s.IsRemote.Valid {
+		remote := s.IsRemote.Bool
+		params.IsRemote = &remote
+	}
+	if s.SalaryMin.Valid {
+		min := s.SalaryMin.Int64
+		params.SalaryMin = &min
+	}
+	if s.SalaryMax.Valid {
+		max := s.SalaryMax.Int64
+		params.SalaryMax = &max
+	}
+
+	return params
+}
+
+// applyRequest copies request fields onto the saved search
+func (s *SavedSearch) applyRequest(req *SavedSearchRequest) {
+	s.Name = req.Name
+	s.Search = nullString(req.Search)
+	s.City = nullString(req.City)
+	s.Province = nullString(req.Province)
+	s.JobType = nullString(req.JobType)
+	s.ExperienceLevel = nullString(req.ExperienceLevel)
+	s.Frequency = req.Frequency
+
+	s.IsRemote = sql.NullBool{}
+	if req.IsRemote != nil {
+		s.IsRemote = sql.NullBool{Bool: *req.IsRemote, Valid: true}
+	}
+	s.SalaryMin = sql.NullInt64{}
+	if req.SalaryMin != nil {
+		s.SalaryMin = sql.NullInt64{Int64: *req.SalaryMin, Valid: true}
+	}
+	s.SalaryMax = sql.NullInt64{}
+	if req.SalaryMax != nil {
+		s.SalaryMax = sql.NullInt64{Int64: *req.SalaryMax, Valid: true}
+	}
+	if req.IsActive != nil {
+		s.IsActive = *req.IsActive
+	}
+}
+
+func nullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
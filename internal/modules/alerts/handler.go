@@ -0,0 +1,204 @@
+package alerts
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for saved searches
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new saved search handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// parseID parses an ID which can be either a numeric ID or a hash_id
+func parseID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	if strings.HasPrefix(idStr, "kn_") {
+		return hashid.Decode(idStr)
+	}
+
+	return 0, err
+}
+
+// Create handles saving a new search
+// POST /saved-searches
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	search, err := h.service.Create(r.Context(), userID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Created(w, "Search saved", search)
+}
+
+// List handles listing saved searches
+// GET /saved-searches
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	searches, err := h.service.List(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Saved searches retrieved", searches)
+}
+
+// Get handles getting a saved search
+// GET /saved-searches/{id}
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid saved search ID")
+		return
+	}
+
+	search, err := h.service.Get(r.Context(), id, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Saved search retrieved", search)
+}
+
+// Update handles updating a saved search
+// PUT /saved-searches/{id}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid saved search ID")
+		return
+	}
+
+	var req SavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	search, err := h.service.Update(r.Context(), id, userID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Saved search updated", search)
+}
+
+// Delete handles deleting a saved search
+// DELETE /saved-searches/{id}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid saved search ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, userID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Saved search deleted", nil)
+}
+
+// Jobs handles running a saved search against current active jobs
+// GET /saved-searches/{id}/jobs
+func (h *Handler) Jobs(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid saved search ID")
+		return
+	}
+
+	page, perPage := 1, 20
+	if p := r.URL.Query().Get("page"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v > 0 {
+			page = v
+		}
+	}
+	if pp := r.URL.Query().Get("per_page"); pp != "" {
+		if v, err := strconv.Atoi(pp); err == nil && v > 0 && v <= 100 {
+			perPage = v
+		}
+	}
+
+	items, total, err := h.service.RunSearch(r.Context(), id, userID, page, perPage)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Jobs retrieved", items, meta)
+}
+
+// handleError handles service errors and returns appropriate HTTP response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
@@ -0,0 +1,176 @@
+package alerts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the saved search repository interface
+type Repository interface {
+	Create(ctx context.Context, search *SavedSearch) error
+	GetByID(ctx context.Context, id uint64) (*SavedSearch, error)
+	ListByUser(ctx context.Context, userID uint64) ([]*SavedSearch, error)
+	CountByUser(ctx context.Context, userID uint64) (int64, error)
+	Update(ctx context.Context, search *SavedSearch) error
+	Delete(ctx context.Context, id uint64) error
+
+	// Digest
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*DueSearch, error)
+	MarkRun(ctx context.Context, id uint64, runAt time.Time, sent bool) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new saved search repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const savedSearchColumns = `
+	id, user_id, name, search, city, province, job_type, experience_level, is_remote,
+	salary_min, salary_max, frequency, is_active, last_run_at, last_sent_at, created_at, updated_at
+`
+
+// Create inserts a new saved search
+func (r *repository) Create(ctx context.Context, search *SavedSearch) error {
+	query := `
+		INSERT INTO saved_searches (
+			user_id, name, search, city, province, job_type, experience_level, is_remote,
+			salary_min, salary_max, frequency, is_active, last_run_at, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW(), NOW())
+	`
+
+	// last_run_at starts at creation so the first digest only contains new jobs
+	result, err := r.db.ExecContext(ctx, query,
+		search.UserID, search.Name, search.Search, search.City, search.Province,
+		search.JobType, search.ExperienceLevel, search.IsRemote,
+		search.SalaryMin, search.SalaryMax, search.Frequency, search.IsActive,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get saved search ID: %w", err)
+	}
+	search.ID = uint64(id)
+
+	return nil
+}
+
+// GetByID retrieves a saved search by ID
+func (r *repository) GetByID(ctx context.Context, id uint64) (*SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE id = ?`
+
+	var search SavedSearch
+	if err := r.db.GetContext(ctx, &search, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+
+	return &search, nil
+}
+
+// ListByUser lists saved searches of a user
+func (r *repository) ListByUser(ctx context.Context, userID uint64) ([]*SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches WHERE user_id = ? ORDER BY created_at DESC`
+
+	searches := []*SavedSearch{}
+	if err := r.db.SelectContext(ctx, &searches, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// CountByUser counts saved searches of a user
+func (r *repository) CountByUser(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM saved_searches WHERE user_id = ?`, userID); err != nil {
+		return 0, fmt.Errorf("failed to count saved searches: %w", err)
+	}
+	return count, nil
+}
+
+// Update updates a saved search
+func (r *repository) Update(ctx context.Context, search *SavedSearch) error {
+	query := `
+		UPDATE saved_searches
+		SET name = ?, search = ?, city = ?, province = ?, job_type = ?, experience_level = ?,
+			is_remote = ?, salary_min = ?, salary_max = ?, frequency = ?, is_active = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		search.Name, search.Search, search.City, search.Province, search.JobType, search.ExperienceLevel,
+		search.IsRemote, search.SalaryMin, search.SalaryMax, search.Frequency, search.IsActive,
+		search.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved search
+func (r *repository) Delete(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+// ListDue lists active saved searches whose digest period has elapsed
+func (r *repository) ListDue(ctx context.Context, now time.Time, limit int) ([]*DueSearch, error) {
+	query := `
+		SELECT
+			s.id, s.user_id, s.name, s.search, s.city, s.province, s.job_type, s.experience_level, s.is_remote,
+			s.salary_min, s.salary_max, s.frequency, s.is_active, s.last_run_at, s.last_sent_at,
+			s.created_at, s.updated_at,
+			u.email AS user_email, u.full_name AS user_full_name
+		FROM saved_searches s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.is_active = 1
+			AND u.is_active = 1
+			AND (
+				s.last_run_at IS NULL
+				OR (s.frequency = 'daily' AND s.last_run_at <= ?)
+				OR (s.frequency = 'weekly' AND s.last_run_at <= ?)
+			)
+		ORDER BY s.last_run_at ASC, s.id ASC
+		LIMIT ?
+	`
+
+	due := []*DueSearch{}
+	err := r.db.SelectContext(ctx, &due, query, now.AddDate(0, 0, -1), now.AddDate(0, 0, -7), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due saved searches: %w", err)
+	}
+
+	return due, nil
+}
+
+// MarkRun records a digest run, and the send time when an email went out
+func (r *repository) MarkRun(ctx context.Context, id uint64, runAt time.Time, sent bool) error {
+	query := `UPDATE saved_searches SET last_run_at = ? WHERE id = ?`
+	args := []interface{}{runAt, id}
+	if sent {
+		query = `UPDATE saved_searches SET last_run_at = ?, last_sent_at = ? WHERE id = ?`
+		args = []interface{}{runAt, runAt, id}
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark saved search run: %w", err)
+	}
+	return nil
+}
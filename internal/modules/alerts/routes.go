@@ -0,0 +1,28 @@
+package alerts
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the saved search routes
+func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireJobSeeker MiddlewareFunc) {
+	r.Route("/saved-searches", func(r chi.Router) {
+		// All routes require authentication as job seeker
+		r.Use(authenticate)
+		r.Use(requireJobSeeker)
+
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+
+		// Run the search now
+		r.Get("/{id}/jobs", h.Jobs)
+	})
+}
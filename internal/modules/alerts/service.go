@@ -0,0 +1,261 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/karirnusantara/api/internal/modules/jobs"
+	"github.com/karirnusantara/api/internal/shared/email"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// maxSavedSearchesPerUser limits how many saved searches a job seeker can keep
+const maxSavedSearchesPerUser = 10
+
+// Service defines the saved search service interface
+type Service interface {
+	Create(ctx context.Context, userID uint64, req *SavedSearchRequest) (*SavedSearchResponse, error)
+	List(ctx context.Context, userID uint64) ([]*SavedSearchResponse, error)
+	Get(ctx context.Context, id, userID uint64) (*SavedSearchResponse, error)
+	Update(ctx context.Context, id, userID uint64, req *SavedSearchRequest) (*SavedSearchResponse, error)
+	Delete(ctx context.Context, id, userID uint64) error
+	RunSearch(ctx context.Context, id, userID uint64, page, perPage int) ([]*jobs.JobResponse, int64, error)
+
+	// SendDueDigests emails new matching jobs for every saved search that is due
+	SendDueDigests(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type service struct {
+	repo         Repository
+	jobService   jobs.Service
+	emailService *email.Service
+}
+
+// NewService creates a new saved search service
+func NewService(repo Repository, jobService jobs.Service, emailService *email.Service) Service {
+	return &service{
+		repo:         repo,
+		jobService:   jobService,
+		emailService: emailService,
+	}
+}
+
+// Create saves a new named search
+func (s *service) Create(ctx context.Context, userID uint64, req *SavedSearchRequest) (*SavedSearchResponse, error) {
+	if err := validateSalaryRange(req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to count saved searches", err)
+	}
+	if count >= maxSavedSearchesPerUser {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("You can save at most %d searches", maxSavedSearchesPerUser))
+	}
+
+	search := &SavedSearch{
+		UserID:   userID,
+		IsActive: true,
+	}
+	search.applyRequest(req)
+
+	if err := s.repo.Create(ctx, search); err != nil {
+		return nil, apperrors.NewInternalError("Failed to create saved search", err)
+	}
+
+	return s.Get(ctx, search.ID, userID)
+}
+
+// List lists saved searches of a user
+func (s *service) List(ctx context.Context, userID uint64) ([]*SavedSearchResponse, error) {
+	searches, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list saved searches", err)
+	}
+
+	responses := make([]*SavedSearchResponse, len(searches))
+	for i, search := range searches {
+		responses[i] = search.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// Get retrieves a saved search owned by the user
+func (s *service) Get(ctx context.Context, id, userID uint64) (*SavedSearchResponse, error) {
+	search, err := s.getOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return search.ToResponse(), nil
+}
+
+// Update updates a saved search owned by the user
+func (s *service) Update(ctx context.Context, id, userID uint64, req *SavedSearchRequest) (*SavedSearchResponse, error) {
+	if err := validateSalaryRange(req); err != nil {
+		return nil, err
+	}
+
+	search, err := s.getOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	search.applyRequest(req)
+
+	if err := s.repo.Update(ctx, search); err != nil {
+		return nil, apperrors.NewInternalError("Failed to update saved search", err)
+	}
+
+	return s.Get(ctx, id, userID)
+}
+
+// Delete removes a saved search owned by the user
+func (s *service) Delete(ctx context.Context, id, userID uint64) error {
+	if _, err := s.getOwned(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return apperrors.NewInternalError("Failed to delete saved search", err)
+	}
+
+	return nil
+}
+
+// RunSearch runs a saved search against all active jobs
+func (s *service) RunSearch(ctx context.Context, id, userID uint64, page, perPage int) ([]*jobs.JobResponse, int64, error) {
+	search, err := s.getOwned(ctx, id, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	params := search.ToJobListParams()
+	params.Page = page
+	params.PerPage = perPage
+
+	return s.jobService.List(ctx, params)
+}
+
+// SendDueDigests re-runs every due saved search against jobs published since its
+// last run and emails the matches. Returns the number of emails sent.
+func (s *service) SendDueDigests(ctx context.Context, now time.Time, limit int) (int, error) {
+	due, err := s.repo.ListDue(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, search := range due {
+		if ctx.Err() != nil {
+			break
+		}
+
+		delivered, err := s.sendDigest(ctx, search, now)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to send job alert digest", "saved_search_id", search.ID, "error", err)
+			continue
+		}
+
+		if err := s.repo.MarkRun(ctx, search.ID, now, delivered); err != nil {
+			logger.FromContext(ctx).Error("failed to mark saved search as run", "saved_search_id", search.ID, "error", err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// digestWindow returns the publish time range (since, until] covered by a digest at now.
+// It starts at the previous run but never more than one period back, so a run resuming
+// after downtime does not mail the whole backlog. It ends at now, so jobs published while
+// the run is in progress are left for the next digest instead of being mailed twice.
+func digestWindow(search *DueSearch, now time.Time) (since, until time.Time) {
+	since = search.CreatedAt
+	if search.LastRunAt.Valid {
+		since = search.LastRunAt.Time
+	}
+
+	earliest := now.AddDate(0, 0, -1)
+	if search.Frequency == FrequencyWeekly {
+		earliest = now.AddDate(0, 0, -7)
+	}
+	if since.Before(earliest) {
+		since = earliest
+	}
+
+	return since, now
+}
+
+// sendDigest emails new jobs for one saved search, returns whether an email was sent
+func (s *service) sendDigest(ctx context.Context, search *DueSearch, now time.Time) (bool, error) {
+	since, until := digestWindow(search, now)
+
+	params := search.ToJobListParams()
+	params.PerPage = maxMatchesPerDigest
+	params.PublishedAfter = &since
+	params.PublishedBefore = &until
+
+	matches, total, err := s.jobService.List(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	if total == 0 || s.emailService == nil || search.UserEmail == "" {
+		return false, nil
+	}
+
+	data := email.JobAlertData{
+		FullName:     search.UserFullName,
+		SearchName:   search.Name,
+		Frequency:    search.Frequency,
+		TotalMatches: total,
+		Jobs:         make([]email.JobAlertItem, 0, len(matches)),
+	}
+	for _, job := range matches {
+		item := email.JobAlertItem{
+			Title:    job.Title,
+			Location: job.Location.City,
+			JobType:  job.JobType,
+			Slug:     job.Slug,
+		}
+		if job.Company != nil {
+			item.CompanyName = job.Company.Name
+		}
+		data.Jobs = append(data.Jobs, item)
+	}
+
+	if err := s.emailService.SendJobAlertEmail(search.UserEmail, data); err != nil {
+		return false, err
+	}
+
+	logger.FromContext(ctx).Info("job alert digest sent", "saved_search_id", search.ID, "matches", total, "to", search.UserEmail)
+	return true, nil
+}
+
+// getOwned loads a saved search and verifies it belongs to the user
+func (s *service) getOwned(ctx context.Context, id, userID uint64) (*SavedSearch, error) {
+	search, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get saved search", err)
+	}
+	if search == nil || search.UserID != userID {
+		return nil, apperrors.NewNotFoundError("Saved search")
+	}
+	return search, nil
+}
+
+// validateSalaryRange checks that salary_max is not below salary_min
+func validateSalaryRange(req *SavedSearchRequest) error {
+	if req.SalaryMin != nil && req.SalaryMax != nil && *req.SalaryMax < *req.SalaryMin {
+		return apperrors.NewValidationError("Validation failed", map[string]string{
+			"salary_max": "salary_max must be greater than or equal to salary_min",
+		})
+	}
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/karirnusantara/api/internal/modules/pipelines"
 	"github.com/karirnusantara/api/internal/shared/hashid"
 )
 
-// Application statuses
+// Application statuses of the default pipeline.
+// Jobs with a custom pipeline use that pipeline's stage keys instead.
 const (
 	StatusSubmitted          = "submitted"
 	StatusViewed             = "viewed"
@@ -42,6 +44,12 @@ type Application struct {
 	Applicant  *ApplicantInfo  `db:"-" json:"applicant,omitempty"`
 	CVSnapshot *CVSnapshotInfo `db:"-" json:"cv_snapshot,omitempty"`
 	Timeline   []TimelineEvent `db:"-" json:"timeline,omitempty"`
+
+	// Answers to the job's screening questions (company view)
+	ScreeningAnswers []ScreeningAnswer `db:"-" json:"screening_answers,omitempty"`
+
+	// Pipeline the job follows (labels and status graph)
+	Pipeline *pipelines.Pipeline `db:"-" json:"-"`
 }
 
 // JobInfo represents minimal job information for application
@@ -53,6 +61,9 @@ type JobInfo struct {
 	City     string      `json:"city"`
 	Province string      `json:"province"`
 	Status   string      `json:"status"`
+
+	// PipelineID is the job's hiring pipeline, 0 for the default pipeline
+	PipelineID uint64 `json:"-"`
 }
 
 // CompanyInfo represents minimal company information
@@ -108,6 +119,20 @@ type TimelineEvent struct {
 	CreatedAt            time.Time      `db:"created_at" json:"created_at"`
 }
 
+// ScreeningAnswer is a candidate's answer to a screening question.
+// The question text is copied so later edits to the job do not change it.
+type ScreeningAnswer struct {
+	ID            uint64        `db:"id" json:"id"`
+	ApplicationID uint64        `db:"application_id" json:"application_id"`
+	QuestionID    sql.NullInt64 `db:"question_id" json:"question_id,omitempty"`
+	Question      string        `db:"question" json:"question"`
+	QuestionType  string        `db:"question_type" json:"question_type"`
+	Answer        string        `db:"answer" json:"answer"`
+	IsKnockout    bool          `db:"is_knockout" json:"is_knockout"`
+	Passed        bool          `db:"passed" json:"passed"`
+	CreatedAt     time.Time     `db:"created_at" json:"created_at"`
+}
+
 // Request DTOs
 
 // ApplyJobRequest represents a job application request
@@ -116,21 +141,56 @@ type ApplyJobRequest struct {
 	CoverLetter        string `json:"cover_letter,omitempty"`
 	CVSource           string `json:"cv_source,omitempty" validate:"omitempty,oneof=built uploaded"`                     // Optional: 'built' or 'uploaded', defaults to 'built'
 	UploadedDocumentID uint64 `json:"uploaded_document_id,omitempty" validate:"omitempty,required_if=CVSource uploaded"` // Required if cv_source='uploaded'
+
+	ScreeningAnswers []ScreeningAnswerRequest `json:"screening_answers,omitempty" validate:"omitempty,max=10,dive"`
+}
+
+// ScreeningAnswerRequest represents the answer to one screening question
+type ScreeningAnswerRequest struct {
+	QuestionID uint64 `json:"question_id" validate:"required"`
+	Answer     string `json:"answer"`
 }
 
 // UpdateStatusRequest represents a status update request (by company)
 type UpdateStatusRequest struct {
-	Status            string `json:"status" validate:"required,oneof=viewed shortlisted interview_scheduled interview_completed assessment offer_sent offer_accepted hired rejected submitted"`
-	Note              string `json:"note,omitempty"`
-	ScheduledAt       string `json:"scheduled_at,omitempty"`
-	ScheduledLocation string `json:"scheduled_location,omitempty"`
-	ScheduledNotes    string `json:"scheduled_notes,omitempty"`
-	InterviewType     string `json:"interview_type,omitempty"`    // online, offline, whatsapp_notification
-	MeetingLink       string `json:"meeting_link,omitempty"`      // Zoom/Google Meet link for online
-	MeetingPlatform   string `json:"meeting_platform,omitempty"`  // zoom, google_meet, microsoft_teams, etc.
-	InterviewAddress  string `json:"interview_address,omitempty"` // Full address for offline interview
-	ContactPerson     string `json:"contact_person,omitempty"`    // Contact person name
-	ContactPhone      string `json:"contact_phone,omitempty"`     // Contact phone number
+	Status string `json:"status" validate:"required,max=50"` // Stage key of the job's pipeline
+	Note   string `json:"note,omitempty"`
+}
+
+// MaxBulkStatusUpdate limits how many applications one bulk status update can touch
+const MaxBulkStatusUpdate = 200
+
+// BulkUpdateStatusRequest represents a status update for many applications of one job (by company).
+// The status and note apply to every listed application.
+type BulkUpdateStatusRequest struct {
+	ApplicationIDs []string `json:"application_ids" validate:"required,min=1,dive,required"` // Numeric IDs or hash IDs, at most MaxBulkStatusUpdate
+	UpdateStatusRequest
+}
+
+// BulkStatusResult is the outcome of a bulk status update for one application
+type BulkStatusResult struct {
+	ApplicationID string `json:"application_id"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"`
+	StatusLabel   string `json:"status_label,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// BulkStatusResponse represents the bulk status update response
+type BulkStatusResponse struct {
+	JobID     uint64              `json:"job_id"`
+	Status    string              `json:"status"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []*BulkStatusResult `json:"results"`
+}
+
+// StatusChange is one application moving to a new stage, together with its timeline event
+type StatusChange struct {
+	Application *Application
+	FromStatus  string
+	Event       *TimelineEvent
+	Applied     bool // Set by the repository once the change is written
 }
 
 // WithdrawRequest represents a withdrawal request
@@ -172,9 +232,22 @@ type ApplicationResponse struct {
 	CoverLetter      string                  `json:"cover_letter,omitempty"`
 	CurrentStatus    string                  `json:"current_status"`
 	StatusLabel      string                  `json:"status_label"`
+	StatusCategory   string                  `json:"status_category"`
 	AppliedAt        string                  `json:"applied_at"`
 	LastStatusUpdate string                  `json:"last_status_update"`
 	Timeline         []TimelineEventResponse `json:"timeline,omitempty"`
+
+	ScreeningAnswers []ScreeningAnswerResponse `json:"screening_answers,omitempty"`
+}
+
+// ScreeningAnswerResponse represents a screening answer response
+type ScreeningAnswerResponse struct {
+	QuestionID   uint64 `json:"question_id,omitempty"`
+	Question     string `json:"question"`
+	QuestionType string `json:"question_type"`
+	Answer       string `json:"answer"`
+	IsKnockout   bool   `json:"is_knockout"`
+	Passed       bool   `json:"passed"`
 }
 
 // TimelineEventResponse represents a timeline event response
@@ -198,6 +271,11 @@ type TimelineEventResponse struct {
 
 // ToResponse converts Application to ApplicationResponse
 func (a *Application) ToResponse() *ApplicationResponse {
+	pipeline := a.Pipeline
+	if pipeline == nil {
+		pipeline = pipelines.DefaultPipeline()
+	}
+
 	resp := &ApplicationResponse{
 		ID:               a.ID,
 		HashID:           hashid.Encode(a.ID),
@@ -205,7 +283,8 @@ func (a *Application) ToResponse() *ApplicationResponse {
 		Applicant:        a.Applicant,
 		CVSnapshot:       a.CVSnapshot,
 		CurrentStatus:    a.CurrentStatus,
-		StatusLabel:      GetStatusLabel(a.CurrentStatus),
+		StatusLabel:      pipeline.Label(a.CurrentStatus),
+		StatusCategory:   pipeline.Category(a.CurrentStatus),
 		AppliedAt:        a.AppliedAt.Format(time.RFC3339),
 		LastStatusUpdate: a.LastStatusUpdate.Format(time.RFC3339),
 	}
@@ -225,6 +304,21 @@ func (a *Application) ToResponse() *ApplicationResponse {
 		resp.CoverLetter = a.CoverLetter.String
 	}
 
+	// Convert screening answers
+	if len(a.ScreeningAnswers) > 0 {
+		resp.ScreeningAnswers = make([]ScreeningAnswerResponse, len(a.ScreeningAnswers))
+		for i, answer := range a.ScreeningAnswers {
+			resp.ScreeningAnswers[i] = ScreeningAnswerResponse{
+				QuestionID:   uint64(answer.QuestionID.Int64),
+				Question:     answer.Question,
+				QuestionType: answer.QuestionType,
+				Answer:       answer.Answer,
+				IsKnockout:   answer.IsKnockout,
+				Passed:       answer.Passed,
+			}
+		}
+	}
+
 	// Convert timeline
 	if len(a.Timeline) > 0 {
 		resp.Timeline = make([]TimelineEventResponse, len(a.Timeline))
@@ -232,7 +326,7 @@ func (a *Application) ToResponse() *ApplicationResponse {
 			resp.Timeline[i] = TimelineEventResponse{
 				ID:          event.ID,
 				Status:      event.Status,
-				StatusLabel: GetStatusLabel(event.Status),
+				StatusLabel: pipeline.Label(event.Status),
 				CreatedAt:   event.CreatedAt.Format(time.RFC3339),
 			}
 			if event.Note.Valid {
@@ -271,74 +365,3 @@ func (a *Application) ToResponse() *ApplicationResponse {
 
 	return resp
 }
-
-// GetStatusLabel returns human-readable status label
-func GetStatusLabel(status string) string {
-	labels := map[string]string{
-		StatusSubmitted:          "Lamaran Terkirim",
-		StatusViewed:             "Sedang Ditinjau",
-		StatusShortlisted:        "Masuk Shortlist",
-		StatusInterviewScheduled: "Interview Dijadwalkan",
-		StatusInterviewCompleted: "Interview Selesai",
-		StatusAssessment:         "Tahap Assessment",
-		StatusOfferSent:          "Penawaran Dikirim",
-		StatusOfferAccepted:      "Penawaran Diterima",
-		StatusHired:              "Diterima",
-		StatusRejected:           "Tidak Lolos",
-		StatusWithdrawn:          "Dibatalkan",
-	}
-
-	if label, ok := labels[status]; ok {
-		return label
-	}
-	return status
-}
-
-// IsTerminalStatus checks if status is terminal (no further updates)
-func IsTerminalStatus(status string) bool {
-	return status == StatusHired || status == StatusRejected || status == StatusWithdrawn
-}
-
-// IsValidStatusTransition checks if status transition is valid
-func IsValidStatusTransition(from, to string) bool {
-	// Define valid transitions - made more flexible for business needs
-	// Companies should be able to skip stages if needed
-	validTransitions := map[string][]string{
-		StatusSubmitted: {
-			StatusViewed, StatusShortlisted, StatusInterviewScheduled, StatusRejected,
-		},
-		StatusViewed: {
-			StatusShortlisted, StatusInterviewScheduled, StatusRejected,
-		},
-		StatusShortlisted: {
-			StatusInterviewScheduled, StatusRejected,
-		},
-		StatusInterviewScheduled: {
-			StatusInterviewCompleted, StatusRejected,
-		},
-		StatusInterviewCompleted: {
-			StatusAssessment, StatusOfferSent, StatusHired, StatusRejected,
-		},
-		StatusAssessment: {
-			StatusOfferSent, StatusHired, StatusRejected,
-		},
-		StatusOfferSent: {
-			StatusOfferAccepted, StatusRejected,
-		},
-		StatusOfferAccepted: {
-			StatusHired,
-		},
-	}
-
-	allowed, ok := validTransitions[from]
-	if !ok {
-		return false
-	}
-
-	for _, s := range allowed {
-		if s == to {
-			return true
-		}
-	}
-	return false
-}
@@ -211,6 +211,43 @@ func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, "Application status updated", app)
 }
 
+// BulkUpdateStatus handles updating the status of many applications of a job by company
+func (h *Handler) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	jobID, err := parseID(chi.URLParam(r, "jobId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid job ID")
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+
+	// Get company ID from user ID
+	companyID, err := h.service.GetCompanyIDByUserID(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	var req BulkUpdateStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	result, err := h.service.BulkUpdateStatus(r.Context(), jobID, companyID, req.ApplicationIDs, &req.UpdateStatusRequest)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Application statuses updated", result)
+}
+
 // Withdraw handles application withdrawal by applicant
 func (h *Handler) Withdraw(w http.ResponseWriter, r *http.Request) {
 	id, err := parseID(chi.URLParam(r, "id"))
@@ -12,12 +12,18 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// ErrJobNotAccepting is returned by Create when the job stopped accepting applications,
+// because it is no longer active, its deadline is before today or it reached its cap
+var ErrJobNotAccepting = errors.New("job is not accepting applications")
+
 // Repository defines the applications repository interface
 type Repository interface {
-	Create(ctx context.Context, app *Application) error
+	Create(ctx context.Context, app *Application, answers []ScreeningAnswer, events []*TimelineEvent, today string) error
 	GetByID(ctx context.Context, id uint64) (*Application, error)
 	GetByUserAndJob(ctx context.Context, userID, jobID uint64) (*Application, error)
 	Update(ctx context.Context, app *Application) error
+	ListByJobAndIDs(ctx context.Context, jobID uint64, ids []uint64) ([]*Application, error)
+	ApplyStatusChanges(ctx context.Context, changes []*StatusChange) error
 	List(ctx context.Context, params ApplicationListParams) ([]*Application, int64, error)
 
 	// Timeline
@@ -25,9 +31,13 @@ type Repository interface {
 	GetTimeline(ctx context.Context, applicationID uint64) ([]TimelineEvent, error)
 	GetTimelineForApplicant(ctx context.Context, applicationID uint64) ([]TimelineEvent, error)
 
+	// Screening answers
+	GetScreeningAnswers(ctx context.Context, applicationID uint64) ([]ScreeningAnswer, error)
+
 	// Related data
 	GetJobInfo(ctx context.Context, jobID uint64) (*JobInfo, error)
 	GetApplicantInfo(ctx context.Context, userID uint64) (*ApplicantInfo, error)
+	IsEmailVerified(ctx context.Context, userID uint64) (bool, error)
 	GetCVSnapshotInfo(ctx context.Context, snapshotID uint64) (*CVSnapshotInfo, error)
 	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
 }
@@ -41,8 +51,52 @@ func NewRepository(db *sqlx.DB) Repository {
 	return &mysqlRepository{db: db}
 }
 
-// Create creates a new application
-func (r *mysqlRepository) Create(ctx context.Context, app *Application) error {
+// Create creates a new application with its screening answers and first timeline events, and
+// counts it against the job's application cap, in a single transaction. The count is only
+// incremented while the job is active, its deadline is not before today and it is below its
+// cap, so concurrent applications cannot overshoot. An application created as rejected (it
+// failed a knockout question) only needs the job to be open and does not take a slot.
+func (r *mysqlRepository) Create(ctx context.Context, app *Application, answers []ScreeningAnswer, events []*TimelineEvent, today string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if app.CurrentStatus == StatusRejected {
+		openQuery := `
+			SELECT COUNT(*) FROM jobs
+			WHERE id = ? AND status = 'active' AND deleted_at IS NULL
+				AND (application_deadline IS NULL OR application_deadline >= ?)
+			LOCK IN SHARE MODE
+		`
+		var open int
+		if err := tx.GetContext(ctx, &open, openQuery, app.JobID, today); err != nil {
+			return fmt.Errorf("failed to check job: %w", err)
+		}
+		if open == 0 {
+			return ErrJobNotAccepting
+		}
+	} else {
+		slotQuery := `
+			UPDATE jobs SET applications_count = applications_count + 1
+			WHERE id = ? AND status = 'active' AND deleted_at IS NULL
+				AND (application_deadline IS NULL OR application_deadline >= ?)
+				AND (max_applications IS NULL OR max_applications = 0 OR applications_count < max_applications)
+		`
+		result, err := tx.ExecContext(ctx, slotQuery, app.JobID, today)
+		if err != nil {
+			return fmt.Errorf("failed to count application: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to count application: %w", err)
+		}
+		if rows == 0 {
+			return ErrJobNotAccepting
+		}
+	}
+
 	query := `
 		INSERT INTO applications (
 			user_id, job_id, cv_snapshot_id, cv_source, uploaded_document_id, cover_letter, current_status,
@@ -53,7 +107,7 @@ func (r *mysqlRepository) Create(ctx context.Context, app *Application) error {
 		)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		app.UserID, app.JobID, app.CVSnapshotID, app.CVSource, app.UploadedDocumentID, app.CoverLetter, app.CurrentStatus,
 	)
 	if err != nil {
@@ -65,6 +119,23 @@ func (r *mysqlRepository) Create(ctx context.Context, app *Application) error {
 		return fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	for i := range answers {
+		answers[i].ApplicationID = uint64(id)
+		if err := insertScreeningAnswer(ctx, tx, &answers[i]); err != nil {
+			return err
+		}
+	}
+	for _, event := range events {
+		event.ApplicationID = uint64(id)
+		if err := insertTimelineEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit application: %w", err)
+	}
+
 	app.ID = uint64(id)
 	return nil
 }
@@ -127,6 +198,80 @@ func (r *mysqlRepository) Update(ctx context.Context, app *Application) error {
 	return nil
 }
 
+// ListByJobAndIDs retrieves the applications of a job with the given IDs
+func (r *mysqlRepository) ListByJobAndIDs(ctx context.Context, jobID uint64, ids []uint64) ([]*Application, error) {
+	if len(ids) == 0 {
+		return []*Application{}, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT id, user_id, job_id, cv_snapshot_id, cv_source, uploaded_document_id, cover_letter, current_status,
+			   applied_at, last_status_update, created_at, updated_at
+		FROM applications
+		WHERE job_id = ? AND id IN (?)
+	`, jobID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build applications query: %w", err)
+	}
+
+	apps := []*Application{}
+	if err := r.db.SelectContext(ctx, &apps, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	return apps, nil
+}
+
+// ApplyStatusChanges updates the status of many applications and records their timeline events
+// in a single transaction. A change is only applied while the application is still in its
+// FromStatus; changes that lost a race with another update are left with Applied = false.
+func (r *mysqlRepository) ApplyStatusChanges(ctx context.Context, changes []*StatusChange) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE applications SET
+			current_status = ?,
+			last_status_update = NOW(),
+			updated_at = NOW()
+		WHERE id = ? AND current_status = ?
+	`
+
+	for _, change := range changes {
+		change.Applied = false
+
+		result, err := tx.ExecContext(ctx, query, change.Application.CurrentStatus, change.Application.ID, change.FromStatus)
+		if err != nil {
+			return fmt.Errorf("failed to update application %d: %w", change.Application.ID, err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to check application update: %w", err)
+		}
+		if rows == 0 {
+			continue
+		}
+
+		if err := insertTimelineEvent(ctx, tx, change.Event); err != nil {
+			return err
+		}
+		change.Applied = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		for _, change := range changes {
+			change.Applied = false
+		}
+		return fmt.Errorf("failed to commit status changes: %w", err)
+	}
+
+	return nil
+}
+
 // List retrieves applications with filtering
 func (r *mysqlRepository) List(ctx context.Context, params ApplicationListParams) ([]*Application, int64, error) {
 	var conditions []string
@@ -206,6 +351,11 @@ func (r *mysqlRepository) List(ctx context.Context, params ApplicationListParams
 
 // AddTimelineEvent adds a timeline event
 func (r *mysqlRepository) AddTimelineEvent(ctx context.Context, event *TimelineEvent) error {
+	return insertTimelineEvent(ctx, r.db, event)
+}
+
+// insertTimelineEvent inserts a timeline event using the given connection or transaction
+func insertTimelineEvent(ctx context.Context, exec sqlx.ExecerContext, event *TimelineEvent) error {
 	query := `
 		INSERT INTO application_timelines (
 			application_id, status, note, is_visible_to_applicant,
@@ -220,7 +370,7 @@ func (r *mysqlRepository) AddTimelineEvent(ctx context.Context, event *TimelineE
 		)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := exec.ExecContext(ctx, query,
 		event.ApplicationID, event.Status, event.Note, event.IsVisibleToApplicant,
 		event.UpdatedByType, event.UpdatedByID, event.ScheduledAt, event.ScheduledLocation, event.ScheduledNotes,
 		event.InterviewType, event.MeetingLink, event.MeetingPlatform, event.InterviewAddress, event.ContactPerson, event.ContactPhone,
@@ -243,7 +393,7 @@ func (r *mysqlRepository) GetTimeline(ctx context.Context, applicationID uint64)
 			   created_at
 		FROM application_timelines
 		WHERE application_id = ?
-		ORDER BY created_at ASC
+		ORDER BY created_at ASC, id ASC
 	`
 
 	var events []TimelineEvent
@@ -263,7 +413,7 @@ func (r *mysqlRepository) GetTimelineForApplicant(ctx context.Context, applicati
 			   created_at
 		FROM application_timelines
 		WHERE application_id = ? AND is_visible_to_applicant = TRUE
-		ORDER BY created_at ASC
+		ORDER BY created_at ASC, id ASC
 	`
 
 	var events []TimelineEvent
@@ -274,10 +424,45 @@ func (r *mysqlRepository) GetTimelineForApplicant(ctx context.Context, applicati
 	return events, nil
 }
 
+// insertScreeningAnswer stores a screening answer of an application using the given connection or transaction
+func insertScreeningAnswer(ctx context.Context, exec sqlx.ExecerContext, a *ScreeningAnswer) error {
+	query := `
+		INSERT INTO application_screening_answers (
+			application_id, question_id, question, question_type, answer, is_knockout, passed, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+
+	result, err := exec.ExecContext(ctx, query,
+		a.ApplicationID, a.QuestionID, a.Question, a.QuestionType, a.Answer, a.IsKnockout, a.Passed,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add screening answer: %w", err)
+	}
+	id, _ := result.LastInsertId()
+	a.ID = uint64(id)
+	return nil
+}
+
+// GetScreeningAnswers retrieves the screening answers of an application
+func (r *mysqlRepository) GetScreeningAnswers(ctx context.Context, applicationID uint64) ([]ScreeningAnswer, error) {
+	query := `
+		SELECT id, application_id, question_id, question, question_type, answer, is_knockout, passed, created_at
+		FROM application_screening_answers
+		WHERE application_id = ?
+		ORDER BY id ASC
+	`
+
+	var answers []ScreeningAnswer
+	if err := r.db.SelectContext(ctx, &answers, query, applicationID); err != nil {
+		return nil, fmt.Errorf("failed to get screening answers: %w", err)
+	}
+	return answers, nil
+}
+
 // GetJobInfo retrieves job info for an application
 func (r *mysqlRepository) GetJobInfo(ctx context.Context, jobID uint64) (*JobInfo, error) {
 	query := `
-		SELECT j.id, j.title, j.city, j.province, j.status,
+		SELECT j.id, j.title, j.city, j.province, j.status, j.pipeline_id,
 			   c.id as company_id, c.company_name, c.company_logo_url
 		FROM jobs j
 		JOIN companies c ON j.company_id = c.id
@@ -290,6 +475,7 @@ func (r *mysqlRepository) GetJobInfo(ctx context.Context, jobID uint64) (*JobInf
 		City        string         `db:"city"`
 		Province    string         `db:"province"`
 		Status      string         `db:"status"`
+		PipelineID  sql.NullInt64  `db:"pipeline_id"`
 		CompanyID   uint64         `db:"company_id"`
 		CompanyName sql.NullString `db:"company_name"`
 		CompanyLogo sql.NullString `db:"company_logo_url"`
@@ -303,11 +489,12 @@ func (r *mysqlRepository) GetJobInfo(ctx context.Context, jobID uint64) (*JobInf
 	}
 
 	return &JobInfo{
-		ID:       result.ID,
-		Title:    result.Title,
-		City:     result.City,
-		Province: result.Province,
-		Status:   result.Status,
+		ID:         result.ID,
+		Title:      result.Title,
+		City:       result.City,
+		Province:   result.Province,
+		Status:     result.Status,
+		PipelineID: uint64(result.PipelineID.Int64),
 		Company: CompanyInfo{
 			ID:      result.CompanyID,
 			Name:    result.CompanyName.String,
@@ -316,6 +503,19 @@ func (r *mysqlRepository) GetJobInfo(ctx context.Context, jobID uint64) (*JobInf
 	}, nil
 }
 
+// IsEmailVerified checks whether a user has verified their email address
+func (r *mysqlRepository) IsEmailVerified(ctx context.Context, userID uint64) (bool, error) {
+	var verified bool
+	query := `SELECT is_verified FROM users WHERE id = ? AND deleted_at IS NULL`
+	if err := r.db.GetContext(ctx, &verified, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check email verification: %w", err)
+	}
+	return verified, nil
+}
+
 // GetApplicantInfo retrieves applicant info
 func (r *mysqlRepository) GetApplicantInfo(ctx context.Context, userID uint64) (*ApplicantInfo, error) {
 	query := `SELECT id, full_name, email, phone, avatar_url FROM users WHERE id = ?`
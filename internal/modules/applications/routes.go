@@ -57,5 +57,6 @@ func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireJobSeeker, re
 		r.Use(requireCompany)
 
 		r.Get("/", h.ListJobApplications)
+		r.Patch("/status", h.BulkUpdateStatus)
 	})
 }
@@ -3,12 +3,17 @@ package applications
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/karirnusantara/api/internal/modules/cvs"
 	"github.com/karirnusantara/api/internal/modules/jobs"
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/modules/pipelines"
 	"github.com/karirnusantara/api/internal/shared/email"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
 // Service defines the applications service interface
@@ -19,15 +24,18 @@ type Service interface {
 	ListByCompany(ctx context.Context, companyID uint64, params ApplicationListParams) ([]*ApplicationResponse, int64, error)
 	ListByJob(ctx context.Context, jobID uint64, companyID uint64, params ApplicationListParams) ([]*ApplicationResponse, int64, error)
 	UpdateStatus(ctx context.Context, applicationID uint64, companyID uint64, req *UpdateStatusRequest) (*ApplicationResponse, error)
+	BulkUpdateStatus(ctx context.Context, jobID uint64, companyID uint64, applicationIDs []string, req *UpdateStatusRequest) (*BulkStatusResponse, error)
 	Withdraw(ctx context.Context, applicationID uint64, userID uint64, reason string) error
 	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
 }
 
 type service struct {
-	repo         Repository
-	cvService    cvs.Service
-	jobService   jobs.Service
-	emailService *email.Service
+	repo                Repository
+	cvService           cvs.Service
+	jobService          jobs.Service
+	emailService        *email.Service
+	notificationService notifications.Service
+	pipelineService     pipelines.Service
 }
 
 // NewService creates a new applications service
@@ -40,8 +48,40 @@ func NewService(repo Repository, cvService cvs.Service, jobService jobs.Service,
 	}
 }
 
+// NewServiceWithNotifications creates a new applications service with in-app notifications
+func NewServiceWithNotifications(repo Repository, cvService cvs.Service, jobService jobs.Service, emailService *email.Service, notificationService notifications.Service) Service {
+	return &service{
+		repo:                repo,
+		cvService:           cvService,
+		jobService:          jobService,
+		emailService:        emailService,
+		notificationService: notificationService,
+	}
+}
+
+// NewServiceComplete creates a new applications service with notifications and per-job hiring pipelines
+func NewServiceComplete(repo Repository, cvService cvs.Service, jobService jobs.Service, emailService *email.Service, notificationService notifications.Service, pipelineService pipelines.Service) Service {
+	return &service{
+		repo:                repo,
+		cvService:           cvService,
+		jobService:          jobService,
+		emailService:        emailService,
+		notificationService: notificationService,
+		pipelineService:     pipelineService,
+	}
+}
+
 // Apply submits a job application
 func (s *service) Apply(ctx context.Context, userID uint64, req *ApplyJobRequest) (*ApplicationResponse, error) {
+	// Only verified accounts can apply
+	verified, err := s.repo.IsEmailVerified(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to check email verification", err)
+	}
+	if !verified {
+		return nil, apperrors.NewEmailNotVerifiedError("Verifikasi email Anda sebelum melamar pekerjaan")
+	}
+
 	// Check if already applied
 	existing, err := s.repo.GetByUserAndJob(ctx, userID, req.JobID)
 	if err != nil {
@@ -56,8 +96,19 @@ func (s *service) Apply(ctx context.Context, userID uint64, req *ApplyJobRequest
 	if err != nil {
 		return nil, err
 	}
-	if job.Status != jobs.JobStatusActive {
-		return nil, apperrors.NewBadRequestError("This job is no longer accepting applications")
+	now := time.Now()
+	if err := notAcceptingError(job, now); err != nil {
+		return nil, err
+	}
+
+	// Check screening answers before anything is stored
+	questions, err := s.jobService.GetScreeningQuestions(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	answers, knockedOut, errs := evaluateScreeningAnswers(questions, req.ScreeningAnswers)
+	if errs != nil {
+		return nil, apperrors.NewValidationError("Invalid screening answers", errs)
 	}
 
 	// Create CV snapshot
@@ -84,6 +135,23 @@ func (s *service) Apply(ctx context.Context, userID uint64, req *ApplyJobRequest
 		CVSource:      cvSource,
 		CurrentStatus: StatusSubmitted,
 	}
+	events := []*TimelineEvent{{
+		Status:               StatusSubmitted,
+		Note:                 sql.NullString{String: "Lamaran berhasil dikirim", Valid: true},
+		IsVisibleToApplicant: true,
+		UpdatedByType:        "system",
+	}}
+
+	// Failing a knockout question rejects the application right away, without taking a slot
+	if knockedOut {
+		app.CurrentStatus = StatusRejected
+		events = append(events, &TimelineEvent{
+			Status:               StatusRejected,
+			Note:                 sql.NullString{String: "Lamaran otomatis ditolak karena jawaban pertanyaan penyaringan tidak memenuhi kriteria", Valid: true},
+			IsVisibleToApplicant: true,
+			UpdatedByType:        "system",
+		})
+	}
 
 	// Set uploaded document ID if cv_source is 'uploaded'
 	if cvSource == "uploaded" && req.UploadedDocumentID > 0 {
@@ -94,30 +162,104 @@ func (s *service) Apply(ctx context.Context, userID uint64, req *ApplyJobRequest
 		app.CoverLetter = sql.NullString{String: req.CoverLetter, Valid: true}
 	}
 
-	if err := s.repo.Create(ctx, app); err != nil {
+	if err := s.repo.Create(ctx, app, answers, events, jobs.Today(now)); err != nil {
+		if errors.Is(err, ErrJobNotAccepting) {
+			// Another application took the last slot or the job changed since it was read
+			if job, getErr := s.jobService.GetByID(ctx, req.JobID); getErr == nil {
+				if err := notAcceptingError(job, now); err != nil {
+					return nil, err
+				}
+			}
+			return nil, apperrors.NewApplicationLimitReachedError()
+		}
 		return nil, apperrors.NewInternalError("Failed to create application", err)
 	}
 
-	// Increment job applications count
-	if err := s.jobService.IncrementApplicationCount(ctx, req.JobID); err != nil {
-		// Log error but don't fail the application
-		// The application was created successfully
+	if knockedOut {
+		s.notifyKnockout(ctx, app)
 	}
 
-	// Add initial timeline event
-	event := &TimelineEvent{
-		ApplicationID:        app.ID,
-		Status:               StatusSubmitted,
-		Note:                 sql.NullString{String: "Lamaran berhasil dikirim", Valid: true},
-		IsVisibleToApplicant: true,
-		UpdatedByType:        "system",
+	return s.GetByID(ctx, app.ID, userID, false)
+}
+
+// notAcceptingError returns why a job does not accept applications at now, or nil if it does
+func notAcceptingError(job *jobs.JobResponse, now time.Time) error {
+	if job.Status != jobs.JobStatusActive {
+		return apperrors.NewBadRequestError("This job is no longer accepting applications")
 	}
+	if job.IsDeadlinePassed(now) {
+		return apperrors.NewApplicationDeadlinePassedError()
+	}
+	if job.HasReachedMaxApplications() {
+		return apperrors.NewApplicationLimitReachedError()
+	}
+	return nil
+}
 
-	if err := s.repo.AddTimelineEvent(ctx, event); err != nil {
-		return nil, apperrors.NewInternalError("Failed to add timeline event", err)
+// notifyKnockout tells the applicant that an application which failed a knockout question was rejected
+func (s *service) notifyKnockout(ctx context.Context, app *Application) {
+	logger.FromContext(ctx).Info("application rejected by knockout question", "application_id", app.ID, "job_id", app.JobID)
+
+	job, err := s.repo.GetJobInfo(ctx, app.JobID)
+	if err == nil && job != nil {
+		if app.Pipeline, err = s.pipelineFor(ctx, job); err == nil {
+			s.notifyStatusChange(ctx, app, job)
+		}
 	}
+}
 
-	return s.GetByID(ctx, app.ID, userID, false)
+// evaluateScreeningAnswers checks the answers against a job's screening questions. It returns
+// the answers to store, whether a knockout question failed, and field errors for missing,
+// unknown or malformed answers.
+func evaluateScreeningAnswers(questions []jobs.ScreeningQuestion, reqs []ScreeningAnswerRequest) ([]ScreeningAnswer, bool, map[string]string) {
+	errs := map[string]string{}
+
+	byQuestion := make(map[uint64]string, len(reqs))
+	for _, req := range reqs {
+		if _, dup := byQuestion[req.QuestionID]; dup {
+			errs[fmt.Sprintf("screening_answers.%d", req.QuestionID)] = "answered more than once"
+			continue
+		}
+		byQuestion[req.QuestionID] = req.Answer
+	}
+
+	answers := make([]ScreeningAnswer, 0, len(questions))
+	knockedOut := false
+	for i := range questions {
+		q := &questions[i]
+		raw := byQuestion[q.ID]
+		delete(byQuestion, q.ID)
+
+		result, msg := q.Evaluate(raw)
+		if msg != "" {
+			errs[fmt.Sprintf("screening_answers.%d", q.ID)] = msg
+			continue
+		}
+		if result.Answer == "" {
+			continue
+		}
+
+		answers = append(answers, ScreeningAnswer{
+			QuestionID:   sql.NullInt64{Int64: int64(q.ID), Valid: true},
+			Question:     q.Question,
+			QuestionType: q.Type,
+			Answer:       result.Answer,
+			IsKnockout:   q.IsKnockout,
+			Passed:       result.Passed,
+		})
+		if !result.Passed {
+			knockedOut = true
+		}
+	}
+
+	for id := range byQuestion {
+		errs[fmt.Sprintf("screening_answers.%d", id)] = "is not a question of this job"
+	}
+
+	if len(errs) > 0 {
+		return nil, false, errs
+	}
+	return answers, knockedOut, nil
 }
 
 // GetByID retrieves an application by ID
@@ -134,6 +276,9 @@ func (s *service) GetByID(ctx context.Context, id uint64, viewerID uint64, isCom
 	if err := s.loadApplicationRelations(ctx, app, isCompany); err != nil {
 		return nil, err
 	}
+	if err := s.resolvePipelines(ctx, []*Application{app}); err != nil {
+		return nil, err
+	}
 
 	// Authorization check
 	if !isCompany {
@@ -155,11 +300,17 @@ func (s *service) ListByUser(ctx context.Context, userID uint64, params Applicat
 		return nil, 0, apperrors.NewInternalError("Failed to list applications", err)
 	}
 
-	responses := make([]*ApplicationResponse, len(apps))
-	for i, app := range apps {
+	for _, app := range apps {
 		if err := s.loadApplicationRelations(ctx, app, false); err != nil {
 			return nil, 0, err
 		}
+	}
+	if err := s.resolvePipelines(ctx, apps); err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*ApplicationResponse, len(apps))
+	for i, app := range apps {
 		responses[i] = app.ToResponse()
 	}
 
@@ -175,11 +326,17 @@ func (s *service) ListByCompany(ctx context.Context, companyID uint64, params Ap
 		return nil, 0, apperrors.NewInternalError("Failed to list applications", err)
 	}
 
-	responses := make([]*ApplicationResponse, len(apps))
-	for i, app := range apps {
+	for _, app := range apps {
 		if err := s.loadApplicationRelations(ctx, app, true); err != nil {
 			return nil, 0, err
 		}
+	}
+	if err := s.resolvePipelines(ctx, apps); err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*ApplicationResponse, len(apps))
+	for i, app := range apps {
 		responses[i] = app.ToResponse()
 	}
 
@@ -204,11 +361,17 @@ func (s *service) ListByJob(ctx context.Context, jobID uint64, companyID uint64,
 		return nil, 0, apperrors.NewInternalError("Failed to list applications", err)
 	}
 
-	responses := make([]*ApplicationResponse, len(apps))
-	for i, app := range apps {
+	for _, app := range apps {
 		if err := s.loadApplicationRelations(ctx, app, true); err != nil {
 			return nil, 0, err
 		}
+	}
+	if err := s.resolvePipelines(ctx, apps); err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*ApplicationResponse, len(apps))
+	for i, app := range apps {
 		responses[i] = app.ToResponse()
 	}
 
@@ -235,14 +398,15 @@ func (s *service) UpdateStatus(ctx context.Context, applicationID uint64, compan
 		return nil, apperrors.NewForbiddenError("You don't have permission to update this application")
 	}
 
-	// Check if status is terminal
-	if IsTerminalStatus(app.CurrentStatus) {
-		return nil, apperrors.NewBadRequestError("Cannot update a terminal status")
+	// Resolve the pipeline the job follows
+	pipeline, err := s.pipelineFor(ctx, job)
+	if err != nil {
+		return nil, err
 	}
+	app.Pipeline = pipeline
 
-	// Validate status transition
-	if !IsValidStatusTransition(app.CurrentStatus, req.Status) {
-		return nil, apperrors.NewBadRequestError("Invalid status transition")
+	if _, err := checkStatusChange(pipeline, app, req.Status); err != nil {
+		return nil, err
 	}
 
 	// Update application status
@@ -253,108 +417,204 @@ func (s *service) UpdateStatus(ctx context.Context, applicationID uint64, compan
 	}
 
 	// Add timeline event
-	event := &TimelineEvent{
-		ApplicationID:        app.ID,
-		Status:               req.Status,
-		IsVisibleToApplicant: true,
-		UpdatedByType:        "company",
-		UpdatedByID:          sql.NullInt64{Int64: int64(companyID), Valid: true},
+	event := newStatusEvent(app.ID, companyID, req)
+	if err := s.repo.AddTimelineEvent(ctx, event); err != nil {
+		return nil, apperrors.NewInternalError("Failed to add timeline event", err)
 	}
 
-	if req.Note != "" {
-		event.Note = sql.NullString{String: req.Note, Valid: true}
+	// Notify applicant in-app
+	s.notifyStatusChange(ctx, app, job)
+
+	return s.GetByID(ctx, app.ID, companyID, true)
+}
+
+// BulkUpdateStatus moves many applications of one job to the same stage (company action).
+// Every transition is validated against the job's pipeline; the valid ones are written in one
+// transaction and produce the same timeline events, notifications and emails as UpdateStatus.
+func (s *service) BulkUpdateStatus(ctx context.Context, jobID uint64, companyID uint64, applicationIDs []string, req *UpdateStatusRequest) (*BulkStatusResponse, error) {
+	if len(applicationIDs) > MaxBulkStatusUpdate {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("At most %d applications can be updated at once", MaxBulkStatusUpdate))
 	}
 
-	// Handle interview scheduling
-	if req.Status == StatusInterviewScheduled {
-		// Parse and set scheduled time
-		if req.ScheduledAt != "" {
-			scheduledTime, err := time.Parse(time.RFC3339, req.ScheduledAt)
-			if err == nil {
-				event.ScheduledAt = sql.NullTime{Time: scheduledTime, Valid: true}
-			}
-		}
-		if req.ScheduledLocation != "" {
-			event.ScheduledLocation = sql.NullString{String: req.ScheduledLocation, Valid: true}
-		}
-		if req.ScheduledNotes != "" {
-			event.ScheduledNotes = sql.NullString{String: req.ScheduledNotes, Valid: true}
+	// Verify job belongs to company
+	job, err := s.repo.GetJobInfo(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get job", err)
+	}
+	if job == nil {
+		return nil, apperrors.NewNotFoundError("Job")
+	}
+	if job.Company.ID != companyID {
+		return nil, apperrors.NewForbiddenError("You don't have permission to update these applications")
+	}
+
+	// Resolve the pipeline the job follows
+	pipeline, err := s.pipelineFor(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := pipeline.Stage(req.Status)
+	if stage == nil || req.Status == pipelines.StageWithdrawn {
+		return nil, apperrors.NewBadRequestError("Unknown status for this job's hiring pipeline")
+	}
+
+	// Parse and de-duplicate the requested IDs, keeping the request order for the results
+	results := make([]*BulkStatusResult, 0, len(applicationIDs))
+	resultByID := make(map[uint64]*BulkStatusResult, len(applicationIDs))
+	ids := make([]uint64, 0, len(applicationIDs))
+	for _, raw := range applicationIDs {
+		result := &BulkStatusResult{ApplicationID: raw}
+		results = append(results, result)
+
+		id, err := parseID(raw)
+		if err != nil {
+			result.Error = "Invalid application ID"
+			continue
 		}
-		// Handle new interview type fields
-		if req.InterviewType != "" {
-			event.InterviewType = sql.NullString{String: req.InterviewType, Valid: true}
+		if _, seen := resultByID[id]; seen {
+			result.Error = "Duplicate application ID"
+			continue
 		}
-		if req.MeetingLink != "" {
-			event.MeetingLink = sql.NullString{String: req.MeetingLink, Valid: true}
+		resultByID[id] = result
+		ids = append(ids, id)
+	}
+
+	apps, err := s.repo.ListByJobAndIDs(ctx, jobID, ids)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get applications", err)
+	}
+	found := make(map[uint64]*Application, len(apps))
+	for _, app := range apps {
+		found[app.ID] = app
+	}
+
+	// Validate each transition
+	changes := make([]*StatusChange, 0, len(apps))
+	for _, id := range ids {
+		result := resultByID[id]
+		app, ok := found[id]
+		if !ok {
+			result.Error = "Application not found for this job"
+			continue
 		}
-		if req.MeetingPlatform != "" {
-			event.MeetingPlatform = sql.NullString{String: req.MeetingPlatform, Valid: true}
+		app.Pipeline = pipeline
+
+		if _, err := checkStatusChange(pipeline, app, req.Status); err != nil {
+			result.Error = apperrors.GetAppError(err).Message
+			continue
 		}
-		if req.InterviewAddress != "" {
-			event.InterviewAddress = sql.NullString{String: req.InterviewAddress, Valid: true}
+
+		changes = append(changes, &StatusChange{
+			Application: app,
+			FromStatus:  app.CurrentStatus,
+			Event:       newStatusEvent(app.ID, companyID, req),
+		})
+	}
+
+	// Apply the valid transitions together
+	for _, change := range changes {
+		change.Application.CurrentStatus = req.Status
+	}
+	if len(changes) > 0 {
+		if err := s.repo.ApplyStatusChanges(ctx, changes); err != nil {
+			return nil, apperrors.NewInternalError("Failed to update applications", err)
 		}
-		if req.ContactPerson != "" {
-			event.ContactPerson = sql.NullString{String: req.ContactPerson, Valid: true}
+	}
+
+	for _, change := range changes {
+		result := resultByID[change.Application.ID]
+		if !change.Applied {
+			result.Error = "Application status was changed by another update"
+			continue
 		}
-		if req.ContactPhone != "" {
-			event.ContactPhone = sql.NullString{String: req.ContactPhone, Valid: true}
+		result.Success = true
+		result.Status = req.Status
+		result.StatusLabel = pipeline.Label(req.Status)
+
+		s.notifyStatusChange(ctx, change.Application, job)
+	}
+
+	response := &BulkStatusResponse{
+		JobID:   jobID,
+		Status:  req.Status,
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
 		}
 	}
 
-	if err := s.repo.AddTimelineEvent(ctx, event); err != nil {
-		return nil, apperrors.NewInternalError("Failed to add timeline event", err)
+	logger.FromContext(ctx).Info("bulk application status change", "job_id", jobID, "status", req.Status, "succeeded", response.Succeeded, "failed", response.Failed)
+
+	return response, nil
+}
+
+// checkStatusChange validates that an application may move to the given stage of its pipeline
+func checkStatusChange(pipeline *pipelines.Pipeline, app *Application, status string) (*pipelines.Stage, error) {
+	// Only the applicant can withdraw
+	stage := pipeline.Stage(status)
+	if stage == nil || status == pipelines.StageWithdrawn {
+		return nil, apperrors.NewBadRequestError("Unknown status for this job's hiring pipeline")
 	}
 
-	// Send email notification if status changed to interview_scheduled
-	if req.Status == StatusInterviewScheduled {
-		// Get applicant info
-		applicant, err := s.repo.GetApplicantInfo(ctx, app.UserID)
-		if err == nil && applicant != nil && applicant.Email != "" {
-			// Prepare email data
-			emailData := email.InterviewScheduleData{
-				ApplicantName: applicant.Name,
-				JobTitle:      job.Title,
-				CompanyName:   job.Company.Name,
-			}
+	// Check if status is terminal
+	if pipeline.IsTerminal(app.CurrentStatus) {
+		return nil, apperrors.NewBadRequestError("Cannot update a terminal status")
+	}
 
-			// Add interview details
-			if event.InterviewType.Valid {
-				emailData.InterviewType = event.InterviewType.String
-			}
-			if event.ScheduledAt.Valid {
-				emailData.ScheduledAt = event.ScheduledAt.Time.Format("Monday, 02 January 2006 pukul 15:04 WIB")
-			}
-			if event.ScheduledLocation.Valid {
-				emailData.Location = event.ScheduledLocation.String
-			}
-			if event.MeetingLink.Valid {
-				emailData.MeetingLink = event.MeetingLink.String
-			}
-			if event.MeetingPlatform.Valid {
-				emailData.MeetingPlatform = event.MeetingPlatform.String
-			}
-			if event.ContactPerson.Valid {
-				emailData.ContactPerson = event.ContactPerson.String
-			}
-			if event.ContactPhone.Valid {
-				emailData.ContactPhone = event.ContactPhone.String
-			}
-			if event.ScheduledNotes.Valid {
-				emailData.Notes = event.ScheduledNotes.String
-			}
+	// Validate status transition
+	if !pipeline.CanTransition(app.CurrentStatus, status) {
+		return nil, apperrors.NewBadRequestError("Invalid status transition")
+	}
 
-			// Send email (non-blocking - don't fail if email fails)
-			go func() {
-				if err := s.emailService.SendInterviewScheduleEmail(applicant.Email, emailData); err != nil {
-					// Log error but don't fail the request
-					// In production, you might want to use proper logging
-					_ = err
-				}
-			}()
-		}
+	return stage, nil
+}
+
+// newStatusEvent builds the timeline event for a company status update.
+// Interviews are scheduled through the interviews module, which sends the calendar invite.
+func newStatusEvent(applicationID uint64, companyID uint64, req *UpdateStatusRequest) *TimelineEvent {
+	event := &TimelineEvent{
+		ApplicationID:        applicationID,
+		Status:               req.Status,
+		IsVisibleToApplicant: true,
+		UpdatedByType:        "company",
+		UpdatedByID:          sql.NullInt64{Int64: int64(companyID), Valid: true},
 	}
 
-	return s.GetByID(ctx, app.ID, companyID, true)
+	if req.Note != "" {
+		event.Note = sql.NullString{String: req.Note, Valid: true}
+	}
+
+	return event
+}
+
+// notifyStatusChange creates an in-app notification for the applicant
+func (s *service) notifyStatusChange(ctx context.Context, app *Application, job *JobInfo) {
+	if s.notificationService == nil {
+		return
+	}
+
+	title := "Status lamaran diperbarui"
+	pipeline := app.Pipeline
+	if pipeline == nil {
+		pipeline = pipelines.DefaultPipeline()
+	}
+
+	message := fmt.Sprintf("Lamaran Anda untuk posisi %s di %s kini berstatus: %s", job.Title, job.Company.Name, pipeline.Label(app.CurrentStatus))
+	data := map[string]interface{}{
+		"application_id":  app.ID,
+		"job_id":          app.JobID,
+		"status":          app.CurrentStatus,
+		"status_category": pipeline.Category(app.CurrentStatus),
+	}
+
+	if err := s.notificationService.Notify(ctx, app.UserID, notifications.TypeApplicationStatus, title, message, data); err != nil {
+		logger.FromContext(ctx).Error("failed to notify applicant of status change", "applicant_id", app.UserID, "application_id", app.ID, "error", err)
+	}
 }
 
 // Withdraw allows an applicant to withdraw their application
@@ -374,7 +634,17 @@ func (s *service) Withdraw(ctx context.Context, applicationID uint64, userID uin
 	}
 
 	// Check if already terminal
-	if IsTerminalStatus(app.CurrentStatus) {
+	job, err := s.repo.GetJobInfo(ctx, app.JobID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get job", err)
+	}
+	pipeline := pipelines.DefaultPipeline()
+	if job != nil {
+		if pipeline, err = s.pipelineFor(ctx, job); err != nil {
+			return err
+		}
+	}
+	if pipeline.IsTerminal(app.CurrentStatus) {
 		return apperrors.NewBadRequestError("Cannot withdraw an application that is already completed")
 	}
 
@@ -407,7 +677,8 @@ func (s *service) Withdraw(ctx context.Context, applicationID uint64, userID uin
 	return nil
 }
 
-// loadApplicationRelations loads related data for an application
+// loadApplicationRelations loads related data for an application. Pipelines are
+// resolved separately by resolvePipelines so a list page needs only one lookup.
 func (s *service) loadApplicationRelations(ctx context.Context, app *Application, isCompany bool) error {
 	// Load job info
 	job, err := s.repo.GetJobInfo(ctx, app.JobID)
@@ -416,13 +687,19 @@ func (s *service) loadApplicationRelations(ctx context.Context, app *Application
 	}
 	app.Job = job
 
-	// Load applicant info (only for company view)
+	// Load applicant info and screening answers (only for company view)
 	if isCompany {
 		applicant, err := s.repo.GetApplicantInfo(ctx, app.UserID)
 		if err != nil {
 			return apperrors.NewInternalError("Failed to load applicant info", err)
 		}
 		app.Applicant = applicant
+
+		answers, err := s.repo.GetScreeningAnswers(ctx, app.ID)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to load screening answers", err)
+		}
+		app.ScreeningAnswers = answers
 	}
 
 	// Load CV snapshot info
@@ -447,6 +724,43 @@ func (s *service) loadApplicationRelations(ctx context.Context, app *Application
 	return nil
 }
 
+// resolvePipelines sets the pipeline of each application's job (for status labels),
+// resolving every pipeline the applications use in one query
+func (s *service) resolvePipelines(ctx context.Context, apps []*Application) error {
+	if s.pipelineService == nil {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(apps))
+	for _, app := range apps {
+		if app.Job != nil {
+			ids = append(ids, app.Job.PipelineID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	resolved, err := s.pipelineService.ResolveAll(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if app.Job != nil {
+			app.Pipeline = resolved[app.Job.PipelineID]
+		}
+	}
+	return nil
+}
+
+// pipelineFor returns the hiring pipeline a job follows
+func (s *service) pipelineFor(ctx context.Context, job *JobInfo) (*pipelines.Pipeline, error) {
+	if s.pipelineService == nil || job.PipelineID == 0 {
+		return pipelines.DefaultPipeline(), nil
+	}
+	return s.pipelineService.Resolve(ctx, job.PipelineID)
+}
+
 // GetCompanyIDByUserID retrieves company ID for a given user ID
 func (s *service) GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error) {
 	return s.repo.GetCompanyIDByUserID(ctx, userID)
@@ -3,6 +3,8 @@ package auth
 import (
 	"database/sql"
 	"time"
+
+	"github.com/karirnusantara/api/internal/modules/mfa"
 )
 
 // User roles
@@ -21,6 +23,7 @@ type User struct {
 	FullName        string         `db:"full_name" json:"full_name"`
 	Phone           sql.NullString `db:"phone" json:"phone,omitempty"`
 	AvatarURL       sql.NullString `db:"avatar_url" json:"avatar_url,omitempty"`
+	Locale          string         `db:"locale" json:"locale"` // Email language: id or en
 	IsActive        bool           `db:"is_active" json:"is_active"`
 	IsVerified      bool           `db:"is_verified" json:"is_verified"`
 	EmailVerifiedAt sql.NullTime   `db:"email_verified_at" json:"email_verified_at,omitempty"`
@@ -37,6 +40,7 @@ type UserResponse struct {
 	FullName   string `json:"full_name"`
 	Phone      string `json:"phone,omitempty"`
 	AvatarURL  string `json:"avatar_url,omitempty"`
+	Locale     string `json:"locale"`
 	IsActive   bool   `json:"is_active"`
 	IsVerified bool   `json:"is_verified"`
 	CreatedAt  string `json:"created_at"`
@@ -50,6 +54,7 @@ type UserWithCompanyResponse struct {
 	FullName           string `json:"full_name"`
 	Phone              string `json:"phone,omitempty"`
 	AvatarURL          string `json:"avatar_url,omitempty"`
+	Locale             string `json:"locale"`
 	IsActive           bool   `json:"is_active"`
 	IsVerified         bool   `json:"is_verified"`
 	CreatedAt          string `json:"created_at"`
@@ -106,6 +111,7 @@ func (u *User) ToResponseWithCompany(company *CompanyData) *UserWithCompanyRespo
 		Email:      u.Email,
 		Role:       u.Role,
 		FullName:   u.FullName,
+		Locale:     u.Locale,
 		IsActive:   u.IsActive,
 		IsVerified: u.IsVerified,
 		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
@@ -192,6 +198,7 @@ func (u *User) ToResponse() *UserResponse {
 		Email:      u.Email,
 		Role:       u.Role,
 		FullName:   u.FullName,
+		Locale:     u.Locale,
 		IsActive:   u.IsActive,
 		IsVerified: u.IsVerified,
 		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
@@ -207,16 +214,66 @@ func (u *User) ToResponse() *UserResponse {
 	return resp
 }
 
-// RefreshToken represents a refresh token entity
+// RefreshToken represents a refresh token entity.
+// Tokens rotated from the same login share a FamilyID, which identifies the session.
 type RefreshToken struct {
-	ID         uint64       `db:"id"`
-	UserID     uint64       `db:"user_id"`
-	TokenHash  string       `db:"token_hash"`
-	ExpiresAt  time.Time    `db:"expires_at"`
-	RevokedAt  sql.NullTime `db:"revoked_at"`
+	ID            uint64         `db:"id"`
+	UserID        uint64         `db:"user_id"`
+	TokenHash     string         `db:"token_hash"`
+	FamilyID      string         `db:"family_id"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+	RevokedAt     sql.NullTime   `db:"revoked_at"`
+	RevokedReason sql.NullString `db:"revoked_reason"`
+	DeviceInfo    string         `db:"device_info"`
+	IPAddress     string         `db:"ip_address"`
+	LastUsedAt    sql.NullTime   `db:"last_used_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+// Refresh token revocation reasons
+const (
+	RevokedRotated         = "rotated"
+	RevokedLogout          = "logout"
+	RevokedBySession       = "revoked"
+	RevokedReuseDetected   = "reuse_detected"
+	RevokedPasswordChanged = "password_changed"
+)
+
+// Session is an active login on one device: the current token of a token family
+type Session struct {
+	FamilyID   string       `db:"family_id"`
 	DeviceInfo string       `db:"device_info"`
 	IPAddress  string       `db:"ip_address"`
-	CreatedAt  time.Time    `db:"created_at"`
+	StartedAt  time.Time    `db:"started_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	ExpiresAt  time.Time    `db:"expires_at"`
+}
+
+// SessionResponse represents a session in API responses
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	StartedAt  string `json:"started_at"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"`
+}
+
+// ToResponse converts Session to SessionResponse
+func (s *Session) ToResponse(currentID string) *SessionResponse {
+	resp := &SessionResponse{
+		ID:        s.FamilyID,
+		UserAgent: s.DeviceInfo,
+		IPAddress: s.IPAddress,
+		StartedAt: s.StartedAt.Format(time.RFC3339),
+		ExpiresAt: s.ExpiresAt.Format(time.RFC3339),
+		Current:   currentID != "" && s.FamilyID == currentID,
+	}
+	if s.LastUsedAt.Valid {
+		resp.LastUsedAt = s.LastUsedAt.Time.Format(time.RFC3339)
+	}
+	return resp
 }
 
 // PasswordResetToken represents a password reset token entity
@@ -229,6 +286,18 @@ type PasswordResetToken struct {
 	CreatedAt time.Time    `db:"created_at"`
 }
 
+// EmailVerificationToken represents an email verification token entity.
+// Only the SHA-256 hash of the token sent by email is stored.
+type EmailVerificationToken struct {
+	ID        uint64       `db:"id"`
+	UserID    uint64       `db:"user_id"`
+	Email     string       `db:"email"`
+	TokenHash string       `db:"token_hash"`
+	ExpiresAt time.Time    `db:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
 // Request DTOs
 
 // RegisterRequest represents a registration request
@@ -239,7 +308,8 @@ type RegisterRequest struct {
 	Phone        string `json:"phone,omitempty" validate:"omitempty,phone"`
 	Role         string `json:"role" validate:"required,oneof=job_seeker company"`
 	CompanyName  string `json:"company_name,omitempty" validate:"required_if=Role company"`
-	ReferralCode string `json:"referral_code,omitempty"` // Partner referral code (optional)
+	Locale       string `json:"locale,omitempty" validate:"omitempty,oneof=id en"` // Email language, defaults to id
+	ReferralCode string `json:"referral_code,omitempty"`                           // Partner referral code (optional)
 }
 
 // LoginRequest represents a login request
@@ -264,6 +334,16 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,password"`
 }
 
+// VerifyEmailRequest represents an email verification request
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ResendVerificationRequest represents a request to resend the verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 // ChangePasswordRequest represents a change password request (for logged-in users)
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" validate:"required"`
@@ -280,16 +360,21 @@ type UpdateProfileRequest struct {
 	CompanySize        string `json:"company_size,omitempty"`
 	CompanyLocation    string `json:"company_location,omitempty"`
 	CompanyIndustry    string `json:"company_industry,omitempty"`
+	Locale             string `json:"locale,omitempty" validate:"omitempty,oneof=id en"`
 }
 
 // Response DTOs
 
-// AuthResponse represents an authentication response
+// AuthResponse represents an authentication response.
+// When MFARequired is set, no tokens are issued until the MFA challenge is completed.
 type AuthResponse struct {
-	User         interface{} `json:"user"` // Can be UserResponse or UserWithCompanyResponse
-	AccessToken  string      `json:"access_token"`
-	RefreshToken string      `json:"refresh_token"`
-	ExpiresIn    int64       `json:"expires_in"`
+	User          interface{}    `json:"user"` // Can be UserResponse or UserWithCompanyResponse
+	AccessToken   string         `json:"access_token"`
+	RefreshToken  string         `json:"refresh_token"`
+	ExpiresIn     int64          `json:"expires_in"`
+	MFARequired   bool           `json:"mfa_required,omitempty"`
+	MFA           *mfa.Challenge `json:"mfa,omitempty"`
+	RecoveryCodes []string       `json:"recovery_codes,omitempty"` // Only after enrolling during login
 }
 
 // TokenClaims represents JWT token claims
@@ -298,6 +383,7 @@ type TokenClaims struct {
 	Email     string `json:"email"`
 	Role      string `json:"role"`
 	TokenType string `json:"token_type"`
+	SessionID string `json:"sid,omitempty"`
 }
 
 // IsJobSeeker checks if user is a job seeker
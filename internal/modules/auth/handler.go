@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/karirnusantara/api/internal/modules/mfa"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 	"github.com/karirnusantara/api/internal/shared/email"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
 	"github.com/karirnusantara/api/internal/shared/validator"
 )
@@ -48,19 +50,17 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Send welcome email for company registration (async, don't block)
+	// Queue welcome email for company registration
 	if req.Role == "company" && h.emailService != nil {
-		go func() {
-			companyName := req.CompanyName
-			if companyName == "" {
-				companyName = req.FullName
-			}
-			if err := h.emailService.SendWelcomeEmail(req.Email, companyName, req.FullName); err != nil {
-				// Log error but don't fail registration
-				// In production, use proper logging
-				println("Failed to send welcome email:", err.Error())
-			}
-		}()
+		companyName := req.CompanyName
+		if companyName == "" {
+			companyName = req.FullName
+		}
+		if err := h.emailService.SendWelcomeEmail(req.Email, companyName, req.FullName); err != nil {
+			// Log error but don't fail registration
+			// In production, use proper logging
+			println("Failed to send welcome email:", err.Error())
+		}
 	}
 
 	response.Created(w, "Registration successful", authResp)
@@ -88,6 +88,35 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if authResp.MFARequired {
+		response.OK(w, "Masukkan kode dari aplikasi autentikator Anda", authResp)
+		return
+	}
+
+	response.OK(w, "Login successful", authResp)
+}
+
+// LoginMFA completes a login with an authenticator or recovery code
+// POST /api/v1/auth/login/mfa
+func (h *Handler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req mfa.ChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	authResp, err := h.service.CompleteMFALogin(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
 	response.OK(w, "Login successful", authResp)
 }
 
@@ -137,6 +166,17 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Without a refresh token, end the session of the access token instead
+	if req.RefreshToken == "" {
+		if sessionID := getSessionIDFromContext(r); sessionID != "" {
+			err := h.service.RevokeSession(r.Context(), userID, sessionID)
+			if appErr := apperrors.GetAppError(err); err != nil && (appErr == nil || appErr.HTTPStatus != http.StatusNotFound) {
+				handleError(w, err)
+				return
+			}
+		}
+	}
+
 	response.OK(w, "Logged out successfully", nil)
 }
 
@@ -267,15 +307,13 @@ func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Send password reset email (async, don't block)
+	// Queue password reset email
 	if user != nil && token != "" && h.emailService != nil {
-		go func() {
-			if err := h.emailService.SendPasswordResetEmail(user.Email, token, user.FullName); err != nil {
-				// Log error but don't fail the request
-				// In production, use proper logging
-				println("Failed to send password reset email:", err.Error())
-			}
-		}()
+		if err := h.emailService.SendPasswordResetEmail(user.Email, token, user.FullName, user.Role); err != nil {
+			// Log error but don't fail the request
+			// In production, use proper logging
+			println("Failed to send password reset email:", err.Error())
+		}
 	}
 
 	// Always return success to avoid email enumeration
@@ -306,6 +344,61 @@ func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, "Password reset successful", nil)
 }
 
+// VerifyEmail handles email verification
+// POST /api/v1/auth/verify-email
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	if err := h.service.VerifyEmail(r.Context(), &req); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Email berhasil diverifikasi", nil)
+}
+
+// ResendVerification handles resending the email verification link
+// POST /api/v1/auth/resend-verification
+func (h *Handler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	user, token, err := h.service.ResendVerification(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// Queue verification email
+	if user != nil && token != "" && h.emailService != nil {
+		if err := h.emailService.SendEmailVerificationEmail(user.Email, user.FullName, user.Role, token); err != nil {
+			logger.FromContext(r.Context()).Error("failed to send verification email", "user_id", user.ID, "to", user.Email, "error", err)
+		}
+	}
+
+	// Always return success to avoid email enumeration
+	response.OK(w, "If your email is registered and not yet verified, you will receive a new verification link", nil)
+}
+
 // ChangePassword handles password change for logged-in users
 // PUT /api/v1/auth/change-password
 func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
@@ -340,19 +433,17 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Send password change confirmation email (async, don't block)
+	// Queue password change confirmation email
 	if h.emailService != nil {
-		go func() {
-			fullName := user.FullName
-			if fullName == "" {
-				fullName = user.Email
-			}
-			if err := h.emailService.SendPasswordChangeConfirmationEmail(user.Email, fullName); err != nil {
-				// Log error but don't fail the request
-				// In production, use proper logging
-				println("Failed to send password change confirmation email:", err.Error())
-			}
-		}()
+		fullName := user.FullName
+		if fullName == "" {
+			fullName = user.Email
+		}
+		if err := h.emailService.SendPasswordChangeConfirmationEmail(user.Email, fullName, user.Role); err != nil {
+			// Log error but don't fail the request
+			// In production, use proper logging
+			println("Failed to send password change confirmation email:", err.Error())
+		}
 	}
 
 	response.OK(w, "Password berhasil diubah. Silakan login kembali dengan password baru Anda.", nil)
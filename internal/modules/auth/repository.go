@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -33,11 +34,24 @@ type Repository interface {
 	RevokeAllUserTokens(ctx context.Context, userID uint64) error
 	CleanupExpiredTokens(ctx context.Context) error
 
+	// Session operations
+	// RotateRefreshToken marks an active token as rotated. Returns false if it was already revoked.
+	RotateRefreshToken(ctx context.Context, id uint64) (bool, error)
+	RevokeTokenFamily(ctx context.Context, userID uint64, familyID, reason string) (int64, error)
+	RevokeOtherSessions(ctx context.Context, userID uint64, keepFamilyID string) (int64, error)
+	GetActiveSessions(ctx context.Context, userID uint64) ([]*Session, error)
+
 	// Password reset operations
 	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
 	GetPasswordResetToken(ctx context.Context, tokenStr string) (*PasswordResetToken, error)
 	MarkPasswordResetTokenAsUsed(ctx context.Context, id uint64) error
 	DeleteExpiredResetTokens(ctx context.Context) error
+
+	// Email verification operations
+	CreateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+	CountEmailVerificationTokensSince(ctx context.Context, userID uint64, since time.Time) (int, error)
+	MarkEmailVerified(ctx context.Context, userID, tokenID uint64) error
 }
 
 // mysqlRepository implements Repository for MySQL
@@ -54,16 +68,16 @@ func NewRepository(db *sqlx.DB) Repository {
 func (r *mysqlRepository) CreateUser(ctx context.Context, user *User) error {
 	query := `
 		INSERT INTO users (
-			email, password_hash, role, full_name, phone, avatar_url,
+			email, password_hash, role, full_name, phone, avatar_url, locale,
 			is_active, is_verified, created_at, updated_at
 		) VALUES (
-			?, ?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?, ?, ?,
 			?, ?, NOW(), NOW()
 		)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
-		user.Email, user.PasswordHash, user.Role, user.FullName, user.Phone, user.AvatarURL,
+		user.Email, user.PasswordHash, user.Role, user.FullName, user.Phone, user.AvatarURL, user.Locale,
 		user.IsActive, user.IsVerified,
 	)
 	if err != nil {
@@ -82,7 +96,7 @@ func (r *mysqlRepository) CreateUser(ctx context.Context, user *User) error {
 // GetUserByID retrieves a user by ID
 func (r *mysqlRepository) GetUserByID(ctx context.Context, id uint64) (*User, error) {
 	query := `
-		SELECT id, email, password_hash, role, full_name, phone, avatar_url,
+		SELECT id, email, password_hash, role, full_name, phone, avatar_url, locale,
 			   is_active, is_verified, email_verified_at, created_at, updated_at, deleted_at
 		FROM users
 		WHERE id = ? AND deleted_at IS NULL
@@ -125,7 +139,7 @@ func (r *mysqlRepository) GetCompanyByUserID(ctx context.Context, userID uint64)
 // GetUserByEmail retrieves a user by email
 func (r *mysqlRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	query := `
-		SELECT id, email, password_hash, role, full_name, phone, avatar_url,
+		SELECT id, email, password_hash, role, full_name, phone, avatar_url, locale,
 			   is_active, is_verified, email_verified_at, created_at, updated_at, deleted_at
 		FROM users
 		WHERE email = ? AND deleted_at IS NULL
@@ -150,6 +164,7 @@ func (r *mysqlRepository) UpdateUser(ctx context.Context, user *User) error {
 			full_name = ?,
 			phone = ?,
 			avatar_url = ?,
+			locale = ?,
 			is_active = ?,
 			is_verified = ?,
 			updated_at = NOW()
@@ -157,7 +172,7 @@ func (r *mysqlRepository) UpdateUser(ctx context.Context, user *User) error {
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
-		user.PasswordHash, user.FullName, user.Phone, user.AvatarURL,
+		user.PasswordHash, user.FullName, user.Phone, user.AvatarURL, user.Locale,
 		user.IsActive, user.IsVerified, user.ID,
 	)
 	if err != nil {
@@ -190,12 +205,12 @@ func (r *mysqlRepository) EmailExists(ctx context.Context, email string) (bool,
 // CreateRefreshToken creates a new refresh token
 func (r *mysqlRepository) CreateRefreshToken(ctx context.Context, token *RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, device_info, ip_address, created_at)
-		VALUES (?, ?, ?, ?, ?, NOW())
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, device_info, ip_address, last_used_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
-		token.UserID, token.TokenHash, token.ExpiresAt, token.DeviceInfo, token.IPAddress,
+		token.UserID, token.TokenHash, token.FamilyID, token.ExpiresAt, token.DeviceInfo, token.IPAddress,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create refresh token: %w", err)
@@ -209,7 +224,8 @@ func (r *mysqlRepository) CreateRefreshToken(ctx context.Context, token *Refresh
 // GetRefreshTokenByHash retrieves a refresh token by hash
 func (r *mysqlRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, revoked_at, device_info, ip_address, created_at
+		SELECT id, user_id, token_hash, family_id, expires_at, revoked_at, revoked_reason,
+		       COALESCE(device_info, '') AS device_info, COALESCE(ip_address, '') AS ip_address, last_used_at, created_at
 		FROM refresh_tokens
 		WHERE token_hash = ?
 	`
@@ -227,7 +243,7 @@ func (r *mysqlRepository) GetRefreshTokenByHash(ctx context.Context, hash string
 
 // RevokeRefreshToken revokes a refresh token
 func (r *mysqlRepository) RevokeRefreshToken(ctx context.Context, hash string) error {
-	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE token_hash = ?`
+	query := `UPDATE refresh_tokens SET revoked_at = NOW(), revoked_reason = 'logout' WHERE token_hash = ? AND revoked_at IS NULL`
 	_, err := r.db.ExecContext(ctx, query, hash)
 	if err != nil {
 		return fmt.Errorf("failed to revoke refresh token: %w", err)
@@ -237,7 +253,7 @@ func (r *mysqlRepository) RevokeRefreshToken(ctx context.Context, hash string) e
 
 // RevokeAllUserTokens revokes all refresh tokens for a user
 func (r *mysqlRepository) RevokeAllUserTokens(ctx context.Context, userID uint64) error {
-	query := `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = ? AND revoked_at IS NULL`
+	query := `UPDATE refresh_tokens SET revoked_at = NOW(), revoked_reason = 'password_changed' WHERE user_id = ? AND revoked_at IS NULL`
 	_, err := r.db.ExecContext(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to revoke all user tokens: %w", err)
@@ -245,9 +261,10 @@ func (r *mysqlRepository) RevokeAllUserTokens(ctx context.Context, userID uint64
 	return nil
 }
 
-// CleanupExpiredTokens removes expired tokens
+// CleanupExpiredTokens removes expired tokens.
+// Rotated tokens are kept until they expire so that their reuse can still be detected.
 func (r *mysqlRepository) CleanupExpiredTokens(ctx context.Context) error {
-	query := `DELETE FROM refresh_tokens WHERE expires_at < NOW() OR revoked_at IS NOT NULL`
+	query := `DELETE FROM refresh_tokens WHERE expires_at < NOW() OR (revoked_at IS NOT NULL AND revoked_reason <> 'rotated')`
 	_, err := r.db.ExecContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("failed to cleanup expired tokens: %w", err)
@@ -255,6 +272,71 @@ func (r *mysqlRepository) CleanupExpiredTokens(ctx context.Context) error {
 	return nil
 }
 
+// RotateRefreshToken marks an active token as rotated
+func (r *mysqlRepository) RotateRefreshToken(ctx context.Context, id uint64) (bool, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(), revoked_reason = 'rotated', last_used_at = NOW()
+		WHERE id = ? AND revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// RevokeTokenFamily revokes every active token of a session
+func (r *mysqlRepository) RevokeTokenFamily(ctx context.Context, userID uint64, familyID, reason string) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(), revoked_reason = ?
+		WHERE user_id = ? AND family_id = ? AND revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, reason, userID, familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// RevokeOtherSessions revokes every active token of a user outside one session
+func (r *mysqlRepository) RevokeOtherSessions(ctx context.Context, userID uint64, keepFamilyID string) (int64, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(), revoked_reason = 'revoked'
+		WHERE user_id = ? AND family_id <> ? AND revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query, userID, keepFamilyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetActiveSessions lists a user's sessions, most recently used first
+func (r *mysqlRepository) GetActiveSessions(ctx context.Context, userID uint64) ([]*Session, error) {
+	query := `
+		SELECT t.family_id, COALESCE(t.device_info, '') AS device_info, COALESCE(t.ip_address, '') AS ip_address,
+		       t.last_used_at, t.expires_at,
+		       (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id) AS started_at
+		FROM refresh_tokens t
+		WHERE t.user_id = ? AND t.revoked_at IS NULL AND t.expires_at > NOW()
+		ORDER BY COALESCE(t.last_used_at, t.created_at) DESC
+	`
+
+	var sessions []*Session
+	if err := r.db.SelectContext(ctx, &sessions, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	return sessions, nil
+}
+
 // CreatePasswordResetToken creates a new password reset token
 func (r *mysqlRepository) CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error {
 	query := `
@@ -315,6 +397,112 @@ func (r *mysqlRepository) DeleteExpiredResetTokens(ctx context.Context) error {
 	return nil
 }
 
+// CreateEmailVerificationToken stores a new email verification token.
+// Unused tokens previously sent to the user stop working.
+func (r *mysqlRepository) CreateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = ? AND used_at IS NULL`,
+		token.UserID,
+	); err != nil {
+		return fmt.Errorf("failed to invalidate email verification tokens: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, NOW())
+	`
+
+	result, err := tx.ExecContext(ctx, query, token.UserID, token.Email, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	token.ID = uint64(id)
+	return nil
+}
+
+// GetEmailVerificationToken retrieves an unused, unexpired email verification token by its hash
+func (r *mysqlRepository) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, email, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE token_hash = ? AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	var token EmailVerificationToken
+	if err := r.db.GetContext(ctx, &token, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// CountEmailVerificationTokensSince counts the verification tokens issued to a user since the given time
+func (r *mysqlRepository) CountEmailVerificationTokensSince(ctx context.Context, userID uint64, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM email_verification_tokens WHERE user_id = ? AND created_at >= ?`
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, userID, since); err != nil {
+		return 0, fmt.Errorf("failed to count email verification tokens: %w", err)
+	}
+	return count, nil
+}
+
+// MarkEmailVerified consumes the token and marks the user's email as verified
+func (r *mysqlRepository) MarkEmailVerified(ctx context.Context, userID, tokenID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Only one request can consume the token
+	result, err := tx.ExecContext(ctx,
+		`UPDATE email_verification_tokens SET used_at = NOW() WHERE id = ? AND used_at IS NULL`,
+		tokenID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verification token as used: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET is_verified = 1, email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+		WHERE id = ? AND deleted_at IS NULL
+	`, userID); err != nil {
+		return fmt.Errorf("failed to mark email as verified: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // GetReferralPartnerByCode retrieves a referral partner by their code
 func (r *mysqlRepository) GetReferralPartnerByCode(ctx context.Context, code string) (*ReferralPartnerInfo, error) {
 	query := `
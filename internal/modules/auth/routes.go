@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/modules/mfa"
 )
 
 // MiddlewareFunc defines the middleware function type
@@ -11,14 +12,18 @@ type MiddlewareFunc func(http.Handler) http.Handler
 
 // RegisterRoutes registers auth routes
 // authMiddleware is passed as a function type to avoid circular dependency
-func RegisterRoutes(r chi.Router, h *Handler, authenticate MiddlewareFunc) {
+// mfaHandler may be nil when two-factor authentication is not configured
+func RegisterRoutes(r chi.Router, h *Handler, authenticate MiddlewareFunc, mfaHandler *mfa.Handler) {
 	r.Route("/auth", func(r chi.Router) {
 		// Public routes
 		r.Post("/register", h.Register)
 		r.Post("/login", h.Login)
+		r.Post("/login/mfa", h.LoginMFA)
 		r.Post("/refresh", h.RefreshToken)
 		r.Post("/forgot-password", h.ForgotPassword)
 		r.Post("/reset-password", h.ResetPassword)
+		r.Post("/verify-email", h.VerifyEmail)
+		r.Post("/resend-verification", h.ResendVerification)
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
@@ -28,6 +33,16 @@ func RegisterRoutes(r chi.Router, h *Handler, authenticate MiddlewareFunc) {
 			r.Put("/profile", h.UpdateProfile)
 			r.Post("/profile/logo", h.UploadLogo)
 			r.Put("/change-password", h.ChangePassword)
+
+			// Sessions (one per logged-in device)
+			r.Get("/sessions", h.ListSessions)
+			r.Post("/sessions/logout-others", h.RevokeOtherSessions)
+			r.Delete("/sessions/{id}", h.RevokeSession)
+
+			// Two-factor authentication
+			if mfaHandler != nil {
+				r.Route("/mfa", mfaHandler.Routes)
+			}
 		})
 	})
 }
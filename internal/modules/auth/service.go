@@ -6,15 +6,18 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
-	"fmt"
-	"log"
+	"errors"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	"github.com/karirnusantara/api/internal/shared/clientip"
 	"github.com/karirnusantara/api/internal/shared/email"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/token"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -30,29 +33,87 @@ type Service interface {
 	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) (*User, string, error)
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
 	ChangePassword(ctx context.Context, userID uint64, req *ChangePasswordRequest) error
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req *ResendVerificationRequest) (*User, string, error)
+	CompleteMFALogin(ctx context.Context, req *mfa.ChallengeRequest) (*AuthResponse, error)
+
+	// Sessions (one per device, identified by the refresh token family)
+	ListSessions(ctx context.Context, userID uint64, currentSessionID string) ([]*SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uint64, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID uint64, currentSessionID string) (int64, error)
 }
 
 // service implements Service
 type service struct {
 	repo         Repository
 	config       *config.JWTConfig
+	tokens       *token.Manager
 	emailService *email.Service
+	mfaService   mfa.Service
+	guard        loginguard.Service
+
+	// verificationSecret signs email verification tokens
+	verificationSecret string
 }
 
 // NewService creates a new auth service
 func NewService(repo Repository, cfg *config.JWTConfig) Service {
 	return &service{
-		repo:   repo,
-		config: cfg,
+		repo:               repo,
+		config:             cfg,
+		tokens:             token.NewManager(cfg),
+		verificationSecret: cfg.Secret,
 	}
 }
 
 // NewServiceWithEmail creates a new auth service with email support
 func NewServiceWithEmail(repo Repository, cfg *config.JWTConfig, emailSvc *email.Service) Service {
 	return &service{
-		repo:         repo,
-		config:       cfg,
-		emailService: emailSvc,
+		repo:               repo,
+		config:             cfg,
+		tokens:             token.NewManager(cfg),
+		emailService:       emailSvc,
+		verificationSecret: cfg.Secret,
+	}
+}
+
+// NewServiceWithMFA creates a new auth service with email and two-factor authentication support
+func NewServiceWithMFA(repo Repository, cfg *config.JWTConfig, emailSvc *email.Service, mfaSvc mfa.Service) Service {
+	return &service{
+		repo:               repo,
+		config:             cfg,
+		tokens:             token.NewManager(cfg),
+		emailService:       emailSvc,
+		mfaService:         mfaSvc,
+		verificationSecret: cfg.Secret,
+	}
+}
+
+// NewServiceWithSecurity creates a new auth service with email, two-factor authentication and brute-force protection
+func NewServiceWithSecurity(repo Repository, cfg *config.JWTConfig, emailSvc *email.Service, mfaSvc mfa.Service, guard loginguard.Service) Service {
+	return &service{
+		repo:               repo,
+		config:             cfg,
+		tokens:             token.NewManager(cfg),
+		emailService:       emailSvc,
+		mfaService:         mfaSvc,
+		guard:              guard,
+		verificationSecret: cfg.Secret,
+	}
+}
+
+// NewServiceWithVerificationSecret creates a new auth service with email, two-factor authentication
+// and brute-force protection that signs email verification links with their own secret. The other
+// constructors sign them with the JWT secret.
+func NewServiceWithVerificationSecret(repo Repository, cfg *config.JWTConfig, emailSvc *email.Service, mfaSvc mfa.Service, guard loginguard.Service, verificationSecret string) Service {
+	return &service{
+		repo:               repo,
+		config:             cfg,
+		tokens:             token.NewManager(cfg),
+		emailService:       emailSvc,
+		mfaService:         mfaSvc,
+		guard:              guard,
+		verificationSecret: verificationSecret,
 	}
 }
 
@@ -76,9 +137,9 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*AuthResp
 		}
 		// Note: We don't fail if referral code is invalid, just ignore it
 		if referralPartner == nil {
-			log.Printf("[REFERRAL] Invalid referral code '%s' provided during registration for %s", req.ReferralCode, req.Email)
+			logger.FromContext(ctx).Info("unknown referral code used at registration", "referral_code", req.ReferralCode, "email", req.Email)
 		} else {
-			log.Printf("[REFERRAL] Valid referral code '%s' used by %s (Partner ID: %d)", req.ReferralCode, req.Email, referralPartner.ID)
+			logger.FromContext(ctx).Info("referral code used at registration", "referral_code", req.ReferralCode, "email", req.Email, "partner_id", referralPartner.ID)
 		}
 	}
 
@@ -95,6 +156,7 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*AuthResp
 		Role:         req.Role,
 		FullName:     req.FullName,
 		Phone:        sql.NullString{String: req.Phone, Valid: req.Phone != ""},
+		Locale:       email.NormalizeLocale(req.Locale, email.LocaleID),
 		IsActive:     true,
 		IsVerified:   false,
 	}
@@ -113,44 +175,51 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*AuthResp
 		companyID, err := s.repo.CreateCompanyWithReferral(ctx, user.ID, req.CompanyName, partnerID, req.ReferralCode)
 		if err != nil {
 			// Log error but don't fail - user is created, company can be created later
-			log.Printf("[REGISTER] Failed to create company record for user %d: %v", user.ID, err)
+			logger.FromContext(ctx).Error("failed to create company record", "user_id", user.ID, "error", err)
 		} else if referralPartner != nil {
 			// Create partner_referrals record to link partner with company
 			if err := s.repo.CreatePartnerReferral(ctx, referralPartner.ID, companyID, req.ReferralCode); err != nil {
-				log.Printf("[REFERRAL] Failed to create partner referral record: %v", err)
+				logger.FromContext(ctx).Error("failed to create partner referral", "partner_id", referralPartner.ID, "company_id", companyID, "error", err)
 			} else {
 				// Increment partner's total_referrals count
 				if err := s.repo.IncrementPartnerReferralCount(ctx, referralPartner.ID); err != nil {
-					log.Printf("[REFERRAL] Failed to increment partner referral count: %v", err)
+					logger.FromContext(ctx).Error("failed to increment partner referral count", "partner_id", referralPartner.ID, "error", err)
 				}
-				log.Printf("[REFERRAL] Successfully linked company %d to partner %d", companyID, referralPartner.ID)
+				logger.FromContext(ctx).Info("company linked to referring partner", "company_id", companyID, "partner_id", referralPartner.ID)
 			}
 		}
 	}
 
-	// Send welcome email for job seekers (in background to not block response)
+	// Queue welcome email for job seekers
 	if s.emailService != nil && req.Role == "job_seeker" {
-		go func() {
-			if err := s.emailService.SendJobSeekerWelcomeEmail(req.Email, req.FullName); err != nil {
-				log.Printf("[EMAIL ERROR] Failed to send welcome email to %s: %v", req.Email, err)
-			} else {
-				log.Printf("[EMAIL] Welcome email sent successfully to %s", req.Email)
-			}
-		}()
+		if err := s.emailService.SendJobSeekerWelcomeEmail(req.Email, req.FullName); err != nil {
+			logger.FromContext(ctx).Error("failed to send welcome email", "user_id", user.ID, "to", req.Email, "error", err)
+		}
 	}
 
+	// Send email verification link
+	s.sendVerificationEmail(ctx, user)
+
 	// Generate tokens
 	return s.generateAuthResponse(ctx, user)
 }
 
 // Login authenticates a user
 func (s *service) Login(ctx context.Context, req *LoginRequest) (*AuthResponse, error) {
+	// Reject while the account or IP is throttled
+	if s.guard != nil {
+		if err := s.guard.Check(ctx, loginguard.ScopeLogin, req.Email); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get user by email
 	user, err := s.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to get user", err)
 	}
 	if user == nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, apperrors.NewInvalidCredentialsError()
 	}
 
@@ -161,14 +230,64 @@ func (s *service) Login(ctx context.Context, req *LoginRequest) (*AuthResponse,
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, apperrors.NewInvalidCredentialsError()
 	}
+	if s.guard != nil {
+		s.guard.RecordSuccess(ctx, loginguard.ScopeLogin, req.Email)
+	}
+
+	// Ask for the second factor before issuing tokens
+	if s.mfaService != nil {
+		challenge, err := s.mfaService.BeginLogin(ctx, user.ID, user.Email, mfa.PortalAuth, false)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			return &AuthResponse{MFARequired: true, MFA: challenge}, nil
+		}
+	}
 
 	// Generate tokens
 	return s.generateAuthResponse(ctx, user)
 }
 
-// RefreshToken generates new tokens using refresh token
+// recordLoginFailure counts a failed password attempt when brute-force protection is enabled
+func (s *service) recordLoginFailure(ctx context.Context, email string) {
+	if s.guard != nil {
+		s.guard.RecordFailure(ctx, loginguard.ScopeLogin, email)
+	}
+}
+
+// CompleteMFALogin finishes a login that returned an MFA challenge
+func (s *service) CompleteMFALogin(ctx context.Context, req *mfa.ChallengeRequest) (*AuthResponse, error) {
+	if s.mfaService == nil {
+		return nil, apperrors.NewBadRequestError("Two-factor authentication is not available")
+	}
+
+	userID, recoveryCodes, err := s.mfaService.CompleteChallenge(ctx, req, mfa.PortalAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get user", err)
+	}
+	if user == nil || !user.IsActive {
+		return nil, apperrors.NewUnauthorizedError("User not found or inactive")
+	}
+
+	resp, err := s.generateAuthResponse(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// RefreshToken rotates a refresh token within its session. Presenting a token that was
+// already rotated means it was copied, so the whole session is revoked.
 func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error) {
 	// Hash the token to find in database
 	tokenHash := hashToken(refreshToken)
@@ -184,6 +303,9 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*AuthR
 
 	// Check if token is revoked
 	if storedToken.RevokedAt.Valid {
+		if storedToken.RevokedReason.String == RevokedRotated {
+			s.revokeReusedFamily(ctx, storedToken)
+		}
 		return nil, apperrors.NewTokenInvalidError()
 	}
 
@@ -201,26 +323,104 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*AuthR
 		return nil, apperrors.NewUnauthorizedError("User not found or inactive")
 	}
 
-	// Revoke old refresh token
-	if err := s.repo.RevokeRefreshToken(ctx, tokenHash); err != nil {
+	// Rotate the old refresh token. Losing the race to a concurrent refresh counts as reuse.
+	rotated, err := s.repo.RotateRefreshToken(ctx, storedToken.ID)
+	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to revoke old token", err)
 	}
+	if !rotated {
+		s.revokeReusedFamily(ctx, storedToken)
+		return nil, apperrors.NewTokenInvalidError()
+	}
 
-	// Generate new tokens
-	return s.generateAuthResponse(ctx, user)
+	// Generate new tokens in the same session
+	familyID := storedToken.FamilyID
+	if familyID == "" {
+		familyID = uuid.New().String()
+	}
+	return s.generateSessionResponse(ctx, user, familyID)
+}
+
+// revokeReusedFamily revokes the session of a refresh token that was presented after rotation
+func (s *service) revokeReusedFamily(ctx context.Context, token *RefreshToken) {
+	if token.FamilyID == "" {
+		return
+	}
+
+	revoked, err := s.repo.RevokeTokenFamily(ctx, token.UserID, token.FamilyID, RevokedReuseDetected)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to revoke session after refresh token reuse", "user_id", token.UserID, "session_id", token.FamilyID, "error", err)
+		return
+	}
+	logger.FromContext(ctx).Warn("refresh token reuse detected, session revoked", "user_id", token.UserID, "session_id", token.FamilyID, "revoked", revoked)
 }
 
-// Logout revokes the refresh token
+// Logout revokes the session of the refresh token
 func (s *service) Logout(ctx context.Context, userID uint64, refreshToken string) error {
-	if refreshToken != "" {
-		tokenHash := hashToken(refreshToken)
+	if refreshToken == "" {
+		return nil
+	}
+
+	tokenHash := hashToken(refreshToken)
+	storedToken, err := s.repo.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get refresh token", err)
+	}
+	if storedToken == nil || storedToken.UserID != userID {
+		return nil
+	}
+
+	if storedToken.FamilyID == "" {
 		if err := s.repo.RevokeRefreshToken(ctx, tokenHash); err != nil {
 			return apperrors.NewInternalError("Failed to revoke token", err)
 		}
+		return nil
+	}
+	if _, err := s.repo.RevokeTokenFamily(ctx, userID, storedToken.FamilyID, RevokedLogout); err != nil {
+		return apperrors.NewInternalError("Failed to revoke token", err)
 	}
 	return nil
 }
 
+// ListSessions lists the user's active sessions, marking the one making the request
+func (s *service) ListSessions(ctx context.Context, userID uint64, currentSessionID string) ([]*SessionResponse, error) {
+	sessions, err := s.repo.GetActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get sessions", err)
+	}
+
+	responses := make([]*SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, session.ToResponse(currentSessionID))
+	}
+	return responses, nil
+}
+
+// RevokeSession signs one of the user's sessions out
+func (s *service) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	revoked, err := s.repo.RevokeTokenFamily(ctx, userID, sessionID, RevokedBySession)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to revoke session", err)
+	}
+	if revoked == 0 {
+		return apperrors.NewNotFoundError("Session")
+	}
+	return nil
+}
+
+// RevokeOtherSessions signs out every session except the current one
+func (s *service) RevokeOtherSessions(ctx context.Context, userID uint64, currentSessionID string) (int64, error) {
+	if currentSessionID == "" {
+		return 0, apperrors.NewBadRequestError("Sesi saat ini tidak diketahui. Silakan login ulang.")
+	}
+
+	revoked, err := s.repo.RevokeOtherSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		return 0, apperrors.NewInternalError("Failed to revoke sessions", err)
+	}
+	return revoked, nil
+}
+
 // GetCurrentUser retrieves the current user's information with company data if available
 func (s *service) GetCurrentUser(ctx context.Context, userID uint64) (*UserWithCompanyResponse, error) {
 	user, err := s.repo.GetUserByID(ctx, userID)
@@ -262,6 +462,9 @@ func (s *service) UpdateProfile(ctx context.Context, userID uint64, req *UpdateP
 	if req.Phone != "" {
 		user.Phone = sql.NullString{String: req.Phone, Valid: true}
 	}
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
 	// Note: Company information is now managed through the companies table
 	// These fields are kept in UpdateProfileRequest for API compatibility
 	// but are not stored in the users table anymore
@@ -276,54 +479,40 @@ func (s *service) UpdateProfile(ctx context.Context, userID uint64, req *UpdateP
 	return user.ToResponse(), nil
 }
 
-// ValidateAccessToken validates and parses an access token
+// ValidateAccessToken validates and parses an access token of the app portal
 func (s *service) ValidateAccessToken(tokenString string) (*TokenClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	})
-
+	claims, err := s.tokens.Parse(token.PortalApp, tokenString)
+	if errors.Is(err, token.ErrExpired) {
+		return nil, apperrors.NewTokenExpiredError()
+	}
 	if err != nil {
 		return nil, apperrors.NewTokenInvalidError()
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		// Check expiration
-		if exp, ok := claims["exp"].(float64); ok {
-			if time.Now().Unix() > int64(exp) {
-				return nil, apperrors.NewTokenExpiredError()
-			}
-		}
-
-		// Check token type
-		tokenType, _ := claims["token_type"].(string)
-		if tokenType != "access" {
-			return nil, apperrors.NewTokenInvalidError()
-		}
-
-		return &TokenClaims{
-			UserID:    uint64(claims["user_id"].(float64)),
-			Email:     claims["email"].(string),
-			Role:      claims["role"].(string),
-			TokenType: tokenType,
-		}, nil
-	}
-
-	return nil, apperrors.NewTokenInvalidError()
+	return &TokenClaims{
+		UserID:    claims.UserID,
+		Email:     claims.Email,
+		Role:      claims.Role,
+		TokenType: claims.TokenType,
+		SessionID: claims.SessionID,
+	}, nil
 }
 
-// generateAuthResponse generates tokens and auth response
+// generateAuthResponse starts a new session and generates its tokens and auth response
 func (s *service) generateAuthResponse(ctx context.Context, user *User) (*AuthResponse, error) {
+	return s.generateSessionResponse(ctx, user, uuid.New().String())
+}
+
+// generateSessionResponse generates tokens for a session and the auth response
+func (s *service) generateSessionResponse(ctx context.Context, user *User, familyID string) (*AuthResponse, error) {
 	// Generate access token
-	accessToken, err := s.generateAccessToken(user)
+	accessToken, err := s.generateAccessToken(user, familyID)
 	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to generate access token", err)
 	}
 
 	// Generate refresh token
-	refreshToken, err := s.generateRefreshToken(ctx, user.ID)
+	refreshToken, err := s.generateRefreshToken(ctx, user.ID, familyID)
 	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to generate refresh token", err)
 	}
@@ -345,32 +534,31 @@ func (s *service) generateAuthResponse(ctx context.Context, user *User) (*AuthRe
 	}, nil
 }
 
-// generateAccessToken generates a new access token
-func (s *service) generateAccessToken(user *User) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":    user.ID,
-		"email":      user.Email,
-		"role":       user.Role,
-		"token_type": "access",
-		"exp":        time.Now().Add(s.config.AccessExpiry).Unix(),
-		"iat":        time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.Secret))
+// generateAccessToken generates a new access token for a session
+func (s *service) generateAccessToken(user *User, sessionID string) (string, error) {
+	return s.tokens.Issue(token.PortalApp, token.Claims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
+	}, s.config.AccessExpiry)
 }
 
-// generateRefreshToken generates a new refresh token and stores it
-func (s *service) generateRefreshToken(ctx context.Context, userID uint64) (string, error) {
+// generateRefreshToken generates a new refresh token in a session and stores it
+// with the device and IP of the request
+func (s *service) generateRefreshToken(ctx context.Context, userID uint64, familyID string) (string, error) {
 	// Generate random token
 	tokenValue := uuid.New().String()
 	tokenHash := hashToken(tokenValue)
 
 	// Store in database
 	refreshToken := &RefreshToken{
-		UserID:    userID,
-		TokenHash: tokenHash,
-		ExpiresAt: time.Now().Add(s.config.RefreshExpiry),
+		UserID:     userID,
+		TokenHash:  tokenHash,
+		FamilyID:   familyID,
+		ExpiresAt:  time.Now().Add(s.config.RefreshExpiry),
+		DeviceInfo: truncate(clientip.UserAgentFromContext(ctx), 500),
+		IPAddress:  clientip.FromContext(ctx),
 	}
 
 	if err := s.repo.CreateRefreshToken(ctx, refreshToken); err != nil {
@@ -380,6 +568,14 @@ func (s *service) generateRefreshToken(ctx context.Context, userID uint64) (stri
 	return tokenValue, nil
 }
 
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
 // hashToken creates a SHA256 hash of a token
 func hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
@@ -388,6 +584,13 @@ func hashToken(token string) string {
 
 // ForgotPassword generates and stores password reset token
 func (s *service) ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) (*User, string, error) {
+	// Limit requests per email and IP, whether or not the email is registered
+	if s.guard != nil {
+		if err := s.guard.Attempt(ctx, loginguard.ScopePasswordReset, req.Email); err != nil {
+			return nil, "", err
+		}
+	}
+
 	// Check if user exists
 	user, err := s.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
@@ -462,7 +665,7 @@ func (s *service) ResetPassword(ctx context.Context, req *ResetPasswordRequest)
 	// Revoke all refresh tokens for security
 	if err := s.repo.RevokeAllUserTokens(ctx, user.ID); err != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Warning: Failed to revoke user tokens: %v\n", err)
+		logger.FromContext(ctx).Error("failed to revoke user sessions", "user_id", user.ID, "error", err)
 	}
 
 	return nil
@@ -505,7 +708,7 @@ func (s *service) ChangePassword(ctx context.Context, userID uint64, req *Change
 	// Revoke all refresh tokens for security (force re-login)
 	if err := s.repo.RevokeAllUserTokens(ctx, user.ID); err != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Warning: Failed to revoke user tokens: %v\n", err)
+		logger.FromContext(ctx).Error("failed to revoke user sessions", "user_id", user.ID, "error", err)
 	}
 
 	return nil
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// ListSessions lists the current user's active sessions
+// GET /api/v1/auth/sessions
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), userID, getSessionIDFromContext(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Sessions retrieved", sessions)
+}
+
+// RevokeSession signs one session out
+// DELETE /api/v1/auth/sessions/{id}
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Sesi berhasil diakhiri", nil)
+}
+
+// RevokeOtherSessions signs out every session except the current one
+// POST /api/v1/auth/sessions/logout-others
+func (h *Handler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	revoked, err := h.service.RevokeOtherSessions(r.Context(), userID, getSessionIDFromContext(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Semua sesi lain berhasil diakhiri", map[string]int64{"revoked": revoked})
+}
+
+// getSessionIDFromContext extracts the session ID of the access token from request context
+func getSessionIDFromContext(r *http.Request) string {
+	sessionID, _ := r.Context().Value("session_id").(string)
+	return sessionID
+}
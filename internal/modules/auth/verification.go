@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Email verification limits
+const (
+	EmailVerificationExpiry    = 24 * time.Hour
+	VerificationResendCooldown = time.Minute
+	VerificationMaxPerHour     = 5
+)
+
+// emailVerificationPurpose separates verification signatures from other uses of the secret
+const emailVerificationPurpose = "email-verification"
+
+// issueEmailVerificationToken creates a signed verification token for the user and stores its hash.
+// The token has the form "<random hex>.<HMAC-SHA256 hex>".
+func (s *service) issueEmailVerificationToken(ctx context.Context, user *User) (string, error) {
+	nonce, err := generateSecureToken(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := nonce + "." + s.signVerificationNonce(nonce)
+
+	record := &EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(EmailVerificationExpiry),
+	}
+	if err := s.repo.CreateEmailVerificationToken(ctx, record); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// signVerificationNonce returns the hex HMAC of a verification nonce
+func (s *service) signVerificationNonce(nonce string) string {
+	mac := hmac.New(sha256.New, []byte(s.verificationSecret))
+	mac.Write([]byte(emailVerificationPurpose + ":" + nonce))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validVerificationSignature checks the token signature without touching the database
+func (s *service) validVerificationSignature(token string) bool {
+	nonce, signature, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" || signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.signVerificationNonce(nonce)))
+}
+
+// VerifyEmail confirms a user's email address using a verification token
+func (s *service) VerifyEmail(ctx context.Context, req *VerifyEmailRequest) error {
+	invalidErr := apperrors.NewBadRequestError("Invalid or expired verification token")
+
+	if !s.validVerificationSignature(req.Token) {
+		return invalidErr
+	}
+
+	record, err := s.repo.GetEmailVerificationToken(ctx, hashToken(req.Token))
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get verification token", err)
+	}
+	if record == nil {
+		return invalidErr
+	}
+
+	user, err := s.repo.GetUserByID(ctx, record.UserID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get user", err)
+	}
+	if user == nil {
+		return apperrors.NewNotFoundError("User")
+	}
+
+	// The token only confirms the address it was sent to
+	if !strings.EqualFold(user.Email, record.Email) {
+		return invalidErr
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, user.ID, record.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return invalidErr
+		}
+		return apperrors.NewInternalError("Failed to verify email", err)
+	}
+
+	logger.FromContext(ctx).Info("email verified", "user_id", user.ID)
+	return nil
+}
+
+// ResendVerification issues a new verification token for an unverified account.
+// Returns a nil user when there is nothing to send, so callers cannot tell whether the email exists.
+func (s *service) ResendVerification(ctx context.Context, req *ResendVerificationRequest) (*User, string, error) {
+	user, err := s.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, "", apperrors.NewInternalError("Failed to check user", err)
+	}
+	if user == nil || user.IsVerified || !user.IsActive {
+		return nil, "", nil
+	}
+
+	// Rate limit per account
+	now := time.Now()
+	recent, err := s.repo.CountEmailVerificationTokensSince(ctx, user.ID, now.Add(-VerificationResendCooldown))
+	if err != nil {
+		return nil, "", apperrors.NewInternalError("Failed to check verification requests", err)
+	}
+	if recent > 0 {
+		return nil, "", apperrors.NewTooManyRequestsError("Tunggu sebentar sebelum meminta email verifikasi lagi")
+	}
+	hourly, err := s.repo.CountEmailVerificationTokensSince(ctx, user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return nil, "", apperrors.NewInternalError("Failed to check verification requests", err)
+	}
+	if hourly >= VerificationMaxPerHour {
+		return nil, "", apperrors.NewTooManyRequestsError("Terlalu banyak permintaan email verifikasi. Silakan coba lagi nanti")
+	}
+
+	token, err := s.issueEmailVerificationToken(ctx, user)
+	if err != nil {
+		return nil, "", apperrors.NewInternalError("Failed to create verification token", err)
+	}
+
+	return user, token, nil
+}
+
+// sendVerificationEmail issues a token and emails it to a newly registered user
+func (s *service) sendVerificationEmail(ctx context.Context, user *User) {
+	if s.emailService == nil {
+		return
+	}
+
+	token, err := s.issueEmailVerificationToken(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create email verification token", "user_id", user.ID, "error", err)
+		return
+	}
+
+	if err := s.emailService.SendEmailVerificationEmail(user.Email, user.FullName, user.Role, token); err != nil {
+		logger.FromContext(ctx).Error("failed to send verification email", "user_id", user.ID, "to", user.Email, "error", err)
+	}
+}
@@ -2,10 +2,10 @@ package chat
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -16,15 +16,23 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/jung-kurt/gofpdf"
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
 	"github.com/karirnusantara/api/internal/shared/validator"
 )
 
+// Stream timing
+const (
+	streamHeartbeat = 25 * time.Second
+	streamRetry     = 3 * time.Second
+)
+
 // Handler handles chat HTTP requests
 type Handler struct {
 	service      Service
 	validator    *validator.Validator
 	uploadFolder string
+	hub          *Hub
 }
 
 // NewHandler creates a new chat handler
@@ -36,6 +44,16 @@ func NewHandler(service Service, v *validator.Validator, uploadFolder string) *H
 	}
 }
 
+// NewHandlerWithHub creates a new chat handler that can serve realtime streams
+func NewHandlerWithHub(service Service, v *validator.Validator, uploadFolder string, hub *Hub) *Handler {
+	return &Handler{
+		service:      service,
+		validator:    v,
+		uploadFolder: uploadFolder,
+		hub:          hub,
+	}
+}
+
 // CreateConversation creates a new conversation
 // POST /company/chat/conversations
 func (h *Handler) CreateConversation(w http.ResponseWriter, r *http.Request) {
@@ -241,6 +259,86 @@ func (h *Handler) CloseConversation(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, "Conversation closed successfully", nil)
 }
 
+// Stream pushes realtime chat events using Server-Sent Events
+// GET /company/chat/stream or /admin/chat/stream
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == 0 {
+		response.Error(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+	
+	if h.hub == nil {
+		response.Error(w, http.StatusServiceUnavailable, "STREAM_UNAVAILABLE", "Realtime chat is not enabled")
+		return
+	}
+	
+	rc := http.NewResponseController(w)
+	
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	
+	if err := rc.Flush(); err != nil {
+		response.Error(w, http.StatusInternalServerError, "STREAM_UNSUPPORTED", "Streaming not supported")
+		return
+	}
+	
+	// The stream outlives the server write timeout
+	_ = rc.SetWriteDeadline(time.Time{})
+	
+	sub := h.hub.Subscribe(userID, middleware.GetUserRole(r.Context()))
+	defer sub.Close()
+	
+	// Tell EventSource how fast to reconnect and confirm the subscription
+	fmt.Fprintf(w, "retry: %d\n", streamRetry.Milliseconds())
+	fmt.Fprint(w, "event: ready\ndata: {}\n\n")
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	
+	// Close cleanly before the request timeout so the client simply reconnects
+	var deadline <-chan time.Time
+	if d, ok := r.Context().Deadline(); ok {
+		timer := time.NewTimer(time.Until(d) - streamRetry)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case evt, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(evt)
+			if err != nil {
+				logger.FromContext(r.Context()).Error("failed to encode chat stream event", "event", evt.Type, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Type, payload); err != nil {
+				return
+			}
+		}
+		
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
 // DownloadConversationPDF generates and downloads conversation as PDF
 // GET /company/chat/conversations/{id}/pdf or /admin/chat/conversations/{id}/pdf
 func (h *Handler) DownloadConversationPDF(w http.ResponseWriter, r *http.Request) {
@@ -265,7 +363,7 @@ func (h *Handler) DownloadConversationPDF(w http.ResponseWriter, r *http.Request
 	}
 	
 	// Generate PDF content
-	pdfContent := h.generateConversationPDF(conv, messages)
+	pdfContent := h.generateConversationPDF(r.Context(), conv, messages)
 	
 	// Set headers for PDF download
 	filename := fmt.Sprintf("conversation_%d_%s.pdf", conversationID, time.Now().Format("20060102"))
@@ -278,7 +376,7 @@ func (h *Handler) DownloadConversationPDF(w http.ResponseWriter, r *http.Request
 }
 
 // generateConversationPDF generates a professional PDF from conversation using gofpdf
-func (h *Handler) generateConversationPDF(conv *ConversationWithDetails, messages []*ChatMessageWithSender) []byte {
+func (h *Handler) generateConversationPDF(ctx context.Context, conv *ConversationWithDetails, messages []*ChatMessageWithSender) []byte {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 15)
 	pdf.AddPage()
@@ -444,7 +542,7 @@ func (h *Handler) generateConversationPDF(conv *ConversationWithDetails, message
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
 	if err != nil {
-		log.Printf("Error generating PDF: %v", err)
+		logger.FromContext(ctx).Error("failed to generate conversation PDF", "conversation_id", conv.ID, "error", err)
 		return []byte{}
 	}
 	
@@ -0,0 +1,107 @@
+package chat
+
+import (
+	"sync"
+)
+
+// Realtime event types pushed to chat streams
+const (
+	EventMessageCreated     = "message.created"
+	EventMessagesRead       = "messages.read"
+	EventConversationStatus = "conversation.status"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind
+// before new events are dropped for it
+const subscriberBuffer = 32
+
+// Event is a realtime chat event
+type Event struct {
+	Type           string      `json:"type"`
+	ConversationID uint64      `json:"conversation_id"`
+	Data           interface{} `json:"data,omitempty"`
+}
+
+// Subscription receives events for one connected client
+type Subscription struct {
+	hub    *Hub
+	userID uint64
+	role   string
+	events chan Event
+	once   sync.Once
+}
+
+// Events returns the channel events are delivered on.
+// The channel is closed when the subscription is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes from the hub
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub fans chat events out to connected company and admin clients.
+// Admins receive every event; a company only receives events for its own conversations.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+// NewHub creates a new in-process chat hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a client. role is "company" or "admin".
+func (h *Hub) Subscribe(userID uint64, role string) *Subscription {
+	sub := &Subscription{
+		hub:    h,
+		userID: userID,
+		role:   role,
+		events: make(chan Event, subscriberBuffer),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+// Publish delivers an event to all admins and to the company owning the conversation.
+// It never blocks; events are dropped for subscribers whose buffer is full.
+func (h *Hub) Publish(companyID uint64, evt Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if sub.role != "admin" && sub.userID != companyID {
+			continue
+		}
+		select {
+		case sub.events <- evt:
+		default:
+		}
+	}
+}
+
+// SubscriberCount returns the number of connected subscribers
+func (h *Hub) SubscriberCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// unsubscribe removes a subscriber and closes its channel
+func (h *Hub) unsubscribe(sub *Subscription) {
+	sub.once.Do(func() {
+		h.mu.Lock()
+		delete(h.subscribers, sub)
+		h.mu.Unlock()
+		close(sub.events)
+	})
+}
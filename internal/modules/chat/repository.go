@@ -21,7 +21,7 @@ type Repository interface {
 	// Messages
 	CreateMessage(ctx context.Context, msg *ChatMessage) error
 	ListMessagesByConversation(ctx context.Context, conversationID uint64) ([]*ChatMessageWithSender, error)
-	MarkMessagesAsRead(ctx context.Context, conversationID uint64, userID uint64) error
+	MarkMessagesAsRead(ctx context.Context, conversationID uint64, userID uint64) (int64, error)
 	GetUnreadCount(ctx context.Context, conversationID uint64, userID uint64) (int, error)
 }
 
@@ -248,7 +248,8 @@ func (r *repository) ListMessagesByConversation(ctx context.Context, conversatio
 }
 
 // MarkMessagesAsRead marks all messages as read for a user
-func (r *repository) MarkMessagesAsRead(ctx context.Context, conversationID uint64, userID uint64) error {
+// Returns the number of messages that were newly marked as read
+func (r *repository) MarkMessagesAsRead(ctx context.Context, conversationID uint64, userID uint64) (int64, error) {
 	query := `
 		UPDATE chat_messages 
 		SET is_read = TRUE 
@@ -257,12 +258,12 @@ func (r *repository) MarkMessagesAsRead(ctx context.Context, conversationID uint
 		  AND is_read = FALSE
 	`
 	
-	_, err := r.db.ExecContext(ctx, query, conversationID, userID)
+	result, err := r.db.ExecContext(ctx, query, conversationID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to mark messages as read: %w", err)
+		return 0, fmt.Errorf("failed to mark messages as read: %w", err)
 	}
 	
-	return nil
+	return result.RowsAffected()
 }
 
 // GetUnreadCount gets unread message count for a user in a conversation
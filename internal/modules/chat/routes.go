@@ -1,36 +1,61 @@
 package chat
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/token"
 )
 
-// RegisterRoutes registers chat routes
-func RegisterRoutes(r chi.Router, h *Handler, authMiddleware *middleware.AuthMiddleware) {
+// RegisterRoutes registers chat routes. canRead and canReply check the admin's support permissions.
+func RegisterRoutes(r chi.Router, h *Handler, authMiddleware *middleware.AuthMiddleware, canRead, canReply func(http.Handler) http.Handler) {
 	// Company routes - require company authentication
 	r.Route("/company/chat", func(r chi.Router) {
-		r.Use(authMiddleware.Authenticate)
-		r.Use(authMiddleware.RequireRole("company"))
-		
-		r.Post("/conversations", h.CreateConversation)
-		r.Get("/conversations", h.GetMyConversations)
-		r.Get("/conversations/{id}", h.GetConversation)
-		r.Post("/conversations/{id}/messages", h.SendMessage)
-		r.Patch("/conversations/{id}/close", h.CloseConversation)
-		r.Get("/conversations/{id}/pdf", h.DownloadConversationPDF)
-		r.Post("/upload", h.UploadAttachment)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate)
+			r.Use(authMiddleware.RequireRole("company"))
+
+			r.Post("/conversations", h.CreateConversation)
+			r.Get("/conversations", h.GetMyConversations)
+			r.Get("/conversations/{id}", h.GetConversation)
+			r.Post("/conversations/{id}/messages", h.SendMessage)
+			r.Patch("/conversations/{id}/close", h.CloseConversation)
+			r.Get("/conversations/{id}/pdf", h.DownloadConversationPDF)
+			r.Post("/upload", h.UploadAttachment)
+		})
+
+		// Realtime stream (SSE) - token may also be passed as ?access_token=
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.AuthenticateQueryToken)
+			r.Use(authMiddleware.RequireRole("company"))
+
+			r.Get("/stream", h.Stream)
+		})
 	})
-	
-	// Admin routes - require admin authentication
+
+	// Admin routes - require admin portal authentication
+	adminAuth := authMiddleware.ForPortal(token.PortalAdmin)
 	r.Route("/admin/chat", func(r chi.Router) {
-		r.Use(authMiddleware.Authenticate)
-		r.Use(authMiddleware.RequireRole("admin"))
-		
-		r.Get("/conversations", h.GetAllConversations)
-		r.Get("/conversations/{id}", h.GetConversation)
-		r.Post("/conversations/{id}/messages", h.SendMessage)
-		r.Patch("/conversations/{id}/status", h.UpdateConversationStatus)
-		r.Get("/conversations/{id}/pdf", h.DownloadConversationPDF)
-		r.Post("/upload", h.UploadAttachment)
+		r.Group(func(r chi.Router) {
+			r.Use(adminAuth.Authenticate)
+			r.Use(adminAuth.RequireRole("admin"))
+
+			r.With(canRead).Get("/conversations", h.GetAllConversations)
+			r.With(canRead).Get("/conversations/{id}", h.GetConversation)
+			r.With(canReply).Post("/conversations/{id}/messages", h.SendMessage)
+			r.With(canReply).Patch("/conversations/{id}/status", h.UpdateConversationStatus)
+			r.With(canRead).Get("/conversations/{id}/pdf", h.DownloadConversationPDF)
+			r.With(canReply).Post("/upload", h.UploadAttachment)
+		})
+
+		// Realtime stream (SSE) - token may also be passed as ?access_token=
+		r.Group(func(r chi.Router) {
+			r.Use(adminAuth.AuthenticateQueryToken)
+			r.Use(adminAuth.RequireRole("admin"))
+			r.Use(canRead)
+
+			r.Get("/stream", h.Stream)
+		})
 	})
 }
@@ -3,6 +3,9 @@ package chat
 import (
 	"context"
 	"fmt"
+
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
 // Service defines chat business logic
@@ -19,7 +22,9 @@ type Service interface {
 }
 
 type service struct {
-	repo Repository
+	repo                Repository
+	notificationService notifications.Service
+	hub                 *Hub
 }
 
 // NewService creates a new chat service
@@ -27,6 +32,23 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
+// NewServiceWithNotifications creates a new chat service with in-app notifications
+func NewServiceWithNotifications(repo Repository, notificationService notifications.Service) Service {
+	return &service{
+		repo:                repo,
+		notificationService: notificationService,
+	}
+}
+
+// NewServiceWithHub creates a new chat service that also pushes realtime events to the hub
+func NewServiceWithHub(repo Repository, notificationService notifications.Service, hub *Hub) Service {
+	return &service{
+		repo:                repo,
+		notificationService: notificationService,
+		hub:                 hub,
+	}
+}
+
 // CreateConversation creates a new conversation
 func (s *service) CreateConversation(ctx context.Context, companyID uint64, req *CreateConversationRequest) (*ConversationWithDetails, error) {
 	// Check if company has active conversation (ticketing mode)
@@ -98,7 +120,22 @@ func (s *service) GetConversation(ctx context.Context, conversationID uint64, us
 	}
 	
 	// Mark messages as read
-	_ = s.repo.MarkMessagesAsRead(ctx, conversationID, userID)
+	marked, err := s.repo.MarkMessagesAsRead(ctx, conversationID, userID)
+	if err == nil && marked > 0 {
+		readerType := "admin"
+		if conv.CompanyID == userID {
+			readerType = "company"
+		}
+		s.publish(conv.CompanyID, Event{
+			Type:           EventMessagesRead,
+			ConversationID: conversationID,
+			Data: map[string]interface{}{
+				"reader_id":   userID,
+				"reader_type": readerType,
+				"count":       marked,
+			},
+		})
+	}
 	
 	return conv, messages, nil
 }
@@ -149,6 +186,8 @@ func (s *service) SendMessage(ctx context.Context, conversationID uint64, userID
 		return nil, err
 	}
 	
+	s.notifyReply(ctx, conv, senderType)
+	
 	// Get message with sender info
 	messages, err := s.repo.ListMessagesByConversation(ctx, conversationID)
 	if err != nil {
@@ -158,6 +197,11 @@ func (s *service) SendMessage(ctx context.Context, conversationID uint64, userID
 	// Find the message we just created
 	for _, m := range messages {
 		if m.ID == msg.ID {
+			s.publish(conv.CompanyID, Event{
+				Type:           EventMessageCreated,
+				ConversationID: conversationID,
+				Data:           m,
+			})
 			return m, nil
 		}
 	}
@@ -165,6 +209,27 @@ func (s *service) SendMessage(ctx context.Context, conversationID uint64, userID
 	return nil, fmt.Errorf("failed to retrieve sent message")
 }
 
+// notifyReply notifies the other side of a conversation about a new message
+func (s *service) notifyReply(ctx context.Context, conv *ConversationWithDetails, senderType string) {
+	if s.notificationService == nil {
+		return
+	}
+	
+	data := map[string]interface{}{"conversation_id": conv.ID}
+	title := "Pesan baru"
+	message := fmt.Sprintf("Ada pesan baru pada percakapan: %s", conv.Title)
+	
+	var err error
+	if senderType == "admin" {
+		err = s.notificationService.Notify(ctx, conv.CompanyID, notifications.TypeChatReply, title, message, data)
+	} else {
+		err = s.notificationService.NotifyAdmins(ctx, notifications.TypeChatReply, title, message, data)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to notify chat reply", "conversation_id", conv.ID, "error", err)
+	}
+}
+
 // GetAllConversations gets all conversations (for admin)
 func (s *service) GetAllConversations(ctx context.Context) ([]*ConversationWithDetails, error) {
 	return s.repo.ListAllConversations(ctx)
@@ -172,5 +237,32 @@ func (s *service) GetAllConversations(ctx context.Context) ([]*ConversationWithD
 
 // UpdateConversationStatus updates conversation status
 func (s *service) UpdateConversationStatus(ctx context.Context, conversationID uint64, req *UpdateConversationStatusRequest) error {
-	return s.repo.UpdateConversationStatus(ctx, conversationID, req.Status)
+	conv, err := s.repo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	
+	if conv == nil {
+		return fmt.Errorf("conversation not found")
+	}
+	
+	if err := s.repo.UpdateConversationStatus(ctx, conversationID, req.Status); err != nil {
+		return err
+	}
+	
+	s.publish(conv.CompanyID, Event{
+		Type:           EventConversationStatus,
+		ConversationID: conversationID,
+		Data:           map[string]string{"status": req.Status},
+	})
+	
+	return nil
+}
+
+// publish pushes a realtime event when a hub is configured
+func (s *service) publish(companyID uint64, evt Event) {
+	if s.hub == nil {
+		return
+	}
+	s.hub.Publish(companyID, evt)
 }
@@ -1,7 +1,9 @@
 package cvs
 
 import (
+	"database/sql"
 	"encoding/json"
+	"sort"
 	"time"
 )
 
@@ -91,6 +93,10 @@ type CV struct {
 	CreatedAt         time.Time       `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time       `db:"updated_at" json:"updated_at"`
 
+	// Work experience totals used by talent search (see SummarizeExperience)
+	ExperienceMonths       int          `db:"experience_months" json:"-"`
+	ExperienceCurrentSince sql.NullTime `db:"experience_current_since" json:"-"`
+
 	// Parsed fields (not stored in DB)
 	PersonalInfoParsed   *PersonalInfo   `db:"-" json:"personal_info"`
 	EducationParsed      []Education     `db:"-" json:"education"`
@@ -209,6 +215,97 @@ func (s *CVSnapshot) ParseFields() error {
 	return nil
 }
 
+// ExperienceSummary is the total work experience of a CV. Months counts finished
+// periods; an ongoing job adds the months since CurrentSince, so the total stays
+// correct without rewriting the CV every month.
+type ExperienceSummary struct {
+	Months       int
+	CurrentSince sql.NullTime
+}
+
+// TotalMonths returns the experience in months at the given time
+func (e ExperienceSummary) TotalMonths(now time.Time) int {
+	total := e.Months
+	if e.CurrentSince.Valid {
+		total += monthIndex(now) - monthIndex(e.CurrentSince.Time)
+	}
+	return total
+}
+
+// SummarizeExperience adds up the months covered by the experience entries.
+// Overlapping jobs are counted once, current jobs run until now and entries
+// without a readable start date are skipped.
+func SummarizeExperience(experiences []Experience, now time.Time) ExperienceSummary {
+	type period struct {
+		start, end int // month indexes, end exclusive
+		current    bool
+	}
+
+	nowIdx := monthIndex(now)
+	periods := make([]period, 0, len(experiences))
+	for _, exp := range experiences {
+		start, ok := parseCVDate(exp.StartDate)
+		if !ok || monthIndex(start) > nowIdx {
+			continue
+		}
+		p := period{start: monthIndex(start), end: nowIdx, current: exp.IsCurrent}
+		if !exp.IsCurrent {
+			end, ok := parseCVDate(exp.EndDate)
+			if !ok {
+				continue
+			}
+			if idx := monthIndex(end); idx < nowIdx {
+				p.end = idx
+			}
+		}
+		if p.end < p.start {
+			continue
+		}
+		periods = append(periods, p)
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start < periods[j].start })
+
+	// Merge overlapping periods
+	merged := make([]period, 0, len(periods))
+	for _, p := range periods {
+		if n := len(merged); n > 0 && p.start <= merged[n-1].end {
+			last := &merged[n-1]
+			if p.end > last.end {
+				last.end = p.end
+			}
+			last.current = last.current || p.current
+			continue
+		}
+		merged = append(merged, p)
+	}
+
+	var summary ExperienceSummary
+	for _, p := range merged {
+		if p.current {
+			start := time.Date(p.start/12, time.Month(p.start%12+1), 1, 0, 0, 0, 0, time.UTC)
+			summary.CurrentSince = sql.NullTime{Time: start, Valid: true}
+			continue
+		}
+		summary.Months += p.end - p.start
+	}
+	return summary
+}
+
+// parseCVDate parses CV dates, which are stored as YYYY-MM-DD or YYYY-MM
+func parseCVDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02", "2006-01"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
 // Request DTOs
 
 // CreateCVRequest represents a CV creation/update request
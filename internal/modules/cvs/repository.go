@@ -38,10 +38,12 @@ func (r *mysqlRepository) Create(ctx context.Context, cv *CV) error {
 		INSERT INTO cvs (
 			user_id, personal_info, education, experience, skills,
 			certifications, languages, projects, completeness_score,
+			experience_months, experience_current_since,
 			last_updated_at, created_at, updated_at
 		) VALUES (
 			?, ?, ?, ?, ?,
 			?, ?, ?, ?,
+			?, ?,
 			NOW(), NOW(), NOW()
 		)
 	`
@@ -49,6 +51,7 @@ func (r *mysqlRepository) Create(ctx context.Context, cv *CV) error {
 	result, err := r.db.ExecContext(ctx, query,
 		cv.UserID, cv.PersonalInfo, cv.Education, cv.Experience, cv.Skills,
 		cv.Certifications, cv.Languages, cv.Projects, cv.CompletenessScore,
+		cv.ExperienceMonths, cv.ExperienceCurrentSince,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create CV: %w", err)
@@ -68,6 +71,7 @@ func (r *mysqlRepository) GetByUserID(ctx context.Context, userID uint64) (*CV,
 	query := `
 		SELECT id, user_id, personal_info, education, experience, skills,
 			   certifications, languages, projects, completeness_score,
+			   experience_months, experience_current_since,
 			   last_updated_at, created_at, updated_at
 		FROM cvs
 		WHERE user_id = ?
@@ -89,6 +93,7 @@ func (r *mysqlRepository) GetByID(ctx context.Context, id uint64) (*CV, error) {
 	query := `
 		SELECT id, user_id, personal_info, education, experience, skills,
 			   certifications, languages, projects, completeness_score,
+			   experience_months, experience_current_since,
 			   last_updated_at, created_at, updated_at
 		FROM cvs
 		WHERE id = ?
@@ -117,6 +122,8 @@ func (r *mysqlRepository) Update(ctx context.Context, cv *CV) error {
 			languages = ?,
 			projects = ?,
 			completeness_score = ?,
+			experience_months = ?,
+			experience_current_since = ?,
 			last_updated_at = NOW(),
 			updated_at = NOW()
 		WHERE id = ?
@@ -125,6 +132,7 @@ func (r *mysqlRepository) Update(ctx context.Context, cv *CV) error {
 	_, err := r.db.ExecContext(ctx, query,
 		cv.PersonalInfo, cv.Education, cv.Experience, cv.Skills,
 		cv.Certifications, cv.Languages, cv.Projects, cv.CompletenessScore,
+		cv.ExperienceMonths, cv.ExperienceCurrentSince,
 		cv.ID,
 	)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"time"
 
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 )
@@ -71,6 +72,10 @@ func (s *service) CreateOrUpdate(ctx context.Context, userID uint64, req *Create
 
 	cv.CompletenessScore = s.CalculateCompleteness(cv)
 
+	experience := SummarizeExperience(experienceData, time.Now())
+	cv.ExperienceMonths = experience.Months
+	cv.ExperienceCurrentSince = experience.CurrentSince
+
 	if existingCV != nil {
 		// Update existing CV
 		cv.ID = existingCV.ID
@@ -20,6 +20,7 @@ type RecentApplicant struct {
 	ApplicantPhoto string `json:"applicant_photo,omitempty"`
 	JobID          uint64 `json:"job_id"`
 	JobTitle       string `json:"job_title"`
+	PipelineID     uint64 `json:"-"`
 	Status         string `json:"status"`
 	StatusLabel    string `json:"status_label"`
 	AppliedAt      string `json:"applied_at"`
@@ -62,6 +62,26 @@ func (r *Repository) GetAcceptedCandidatesCount(companyID uint64) (int, error) {
 	return count, err
 }
 
+// StageCount is the number of applications in one stage of one pipeline
+type StageCount struct {
+	PipelineID uint64 `db:"pipeline_id"`
+	Status     string `db:"status"`
+	Count      int    `db:"count"`
+}
+
+// GetApplicationStageCounts returns application counts grouped by job pipeline and stage
+func (r *Repository) GetApplicationStageCounts(companyID uint64) ([]StageCount, error) {
+	var counts []StageCount
+	err := r.db.Select(&counts, `
+		SELECT COALESCE(j.pipeline_id, 0) as pipeline_id, a.current_status as status, COUNT(*) as count
+		FROM applications a
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.company_id = ? AND j.deleted_at IS NULL
+		GROUP BY COALESCE(j.pipeline_id, 0), a.current_status
+	`, companyID)
+	return counts, err
+}
+
 // recentApplicantRow is used for scanning database results
 type recentApplicantRow struct {
 	ID             uint64    `db:"id"`
@@ -69,6 +89,7 @@ type recentApplicantRow struct {
 	ApplicantPhoto string    `db:"applicant_photo"`
 	JobID          uint64    `db:"job_id"`
 	JobTitle       string    `db:"job_title"`
+	PipelineID     uint64    `db:"pipeline_id"`
 	Status         string    `db:"status"`
 	AppliedAt      time.Time `db:"applied_at"`
 }
@@ -83,6 +104,7 @@ func (r *Repository) GetRecentApplicants(companyID uint64, limit int) ([]RecentA
 			COALESCE(u.avatar_url, '') as applicant_photo,
 			j.id as job_id,
 			j.title as job_title,
+			COALESCE(j.pipeline_id, 0) as pipeline_id,
 			a.current_status as status,
 			a.applied_at
 		FROM applications a
@@ -105,6 +127,7 @@ func (r *Repository) GetRecentApplicants(companyID uint64, limit int) ([]RecentA
 			ApplicantPhoto: row.ApplicantPhoto,
 			JobID:          row.JobID,
 			JobTitle:       row.JobTitle,
+			PipelineID:     row.PipelineID,
 			Status:         row.Status,
 			StatusLabel:    getStatusLabel(row.Status),
 			AppliedAt:      row.AppliedAt.Format(time.RFC3339),
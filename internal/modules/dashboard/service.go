@@ -1,8 +1,15 @@
 package dashboard
 
+import (
+	"context"
+
+	"github.com/karirnusantara/api/internal/modules/pipelines"
+)
+
 // Service handles business logic for dashboard
 type Service struct {
-	repo *Repository
+	repo            *Repository
+	pipelineService pipelines.Service
 }
 
 // NewService creates a new dashboard service
@@ -10,6 +17,12 @@ func NewService(repo *Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// NewServiceWithPipelines creates a new dashboard service whose counters and
+// labels follow each job's hiring pipeline
+func NewServiceWithPipelines(repo *Repository, pipelineService pipelines.Service) *Service {
+	return &Service{repo: repo, pipelineService: pipelineService}
+}
+
 // GetDashboardStats returns all dashboard statistics for a company
 func (s *Service) GetDashboardStats(companyID uint64) (*DashboardStats, error) {
 	stats := &DashboardStats{}
@@ -27,20 +40,26 @@ func (s *Service) GetDashboardStats(companyID uint64) (*DashboardStats, error) {
 		return nil, err
 	}
 
-	// Get under review count
-	stats.UnderReview, err = s.repo.GetUnderReviewCount(companyID)
-	if err != nil {
-		return nil, err
-	}
+	// Get under review and accepted counts
+	if s.pipelineService != nil {
+		stats.UnderReview, stats.AcceptedCandidates, err = s.countByPipelineCategory(companyID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		stats.UnderReview, err = s.repo.GetUnderReviewCount(companyID)
+		if err != nil {
+			return nil, err
+		}
 
-	// Get accepted candidates
-	stats.AcceptedCandidates, err = s.repo.GetAcceptedCandidatesCount(companyID)
-	if err != nil {
-		return nil, err
+		stats.AcceptedCandidates, err = s.repo.GetAcceptedCandidatesCount(companyID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get recent applicants (limit 5)
-	stats.RecentApplicants, err = s.repo.GetRecentApplicants(companyID, 5)
+	stats.RecentApplicants, err = s.GetRecentApplicants(companyID, 5)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +83,61 @@ func (s *Service) GetRecentApplicants(companyID uint64, limit int) ([]RecentAppl
 	if limit <= 0 {
 		limit = 10
 	}
-	return s.repo.GetRecentApplicants(companyID, limit)
+
+	applicants, err := s.repo.GetRecentApplicants(companyID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.pipelineService != nil {
+		ids := make([]uint64, len(applicants))
+		for i := range applicants {
+			ids[i] = applicants[i].PipelineID
+		}
+		resolved, err := s.resolvePipelines(ids)
+		if err != nil {
+			return nil, err
+		}
+		for i := range applicants {
+			applicants[i].StatusLabel = resolved[applicants[i].PipelineID].Label(applicants[i].Status)
+		}
+	}
+
+	return applicants, nil
+}
+
+// countByPipelineCategory counts applications under review (new or in review) and
+// accepted, using the stage categories of each job's pipeline
+func (s *Service) countByPipelineCategory(companyID uint64) (underReview, accepted int, err error) {
+	counts, err := s.repo.GetApplicationStageCounts(companyID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ids := make([]uint64, len(counts))
+	for i, c := range counts {
+		ids[i] = c.PipelineID
+	}
+	resolved, err := s.resolvePipelines(ids)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, c := range counts {
+		switch resolved[c.PipelineID].Category(c.Status) {
+		case pipelines.CategoryNew, pipelines.CategoryReview:
+			underReview += c.Count
+		case pipelines.CategoryAccepted:
+			accepted += c.Count
+		}
+	}
+
+	return underReview, accepted, nil
+}
+
+// resolvePipelines resolves every pipeline a page uses in one query
+func (s *Service) resolvePipelines(pipelineIDs []uint64) (map[uint64]*pipelines.Pipeline, error) {
+	return s.pipelineService.ResolveAll(context.Background(), pipelineIDs)
 }
 
 // GetActiveJobsList returns paginated active jobs
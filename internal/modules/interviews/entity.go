@@ -0,0 +1,201 @@
+package interviews
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/hashid"
+)
+
+// Interview statuses
+const (
+	StatusScheduled = "scheduled"
+	StatusConfirmed = "confirmed"
+	StatusDeclined  = "declined"
+	StatusCancelled = "cancelled"
+)
+
+// Interview types
+const (
+	TypeOnline  = "online"
+	TypeOffline = "offline"
+	TypePhone   = "phone"
+)
+
+// DefaultDurationMinutes is used when a schedule request has no duration
+const DefaultDurationMinutes = 60
+
+// Interview represents one interview round of an application
+type Interview struct {
+	ID              uint64         `db:"id" json:"id"`
+	ApplicationID   uint64         `db:"application_id" json:"application_id"`
+	Round           int            `db:"round" json:"round"`
+	Title           string         `db:"title" json:"title"`
+	Status          string         `db:"status" json:"status"`
+	ScheduledAt     time.Time      `db:"scheduled_at" json:"scheduled_at"`
+	DurationMinutes int            `db:"duration_minutes" json:"duration_minutes"`
+	InterviewType   string         `db:"interview_type" json:"interview_type"`
+	MeetingPlatform sql.NullString `db:"meeting_platform" json:"meeting_platform,omitempty"`
+	MeetingLink     sql.NullString `db:"meeting_link" json:"meeting_link,omitempty"`
+	Location        sql.NullString `db:"location" json:"location,omitempty"`
+	InterviewersRaw sql.NullString `db:"interviewers" json:"-"`
+	ContactPerson   sql.NullString `db:"contact_person" json:"contact_person,omitempty"`
+	ContactPhone    sql.NullString `db:"contact_phone" json:"contact_phone,omitempty"`
+	Notes           sql.NullString `db:"notes" json:"notes,omitempty"`
+	UID             string         `db:"uid" json:"-"`
+	Sequence        int            `db:"sequence" json:"-"`
+	CandidateNote   sql.NullString `db:"candidate_note" json:"candidate_note,omitempty"`
+	RespondedAt     sql.NullTime   `db:"responded_at" json:"responded_at,omitempty"`
+	CancelReason    sql.NullString `db:"cancel_reason" json:"cancel_reason,omitempty"`
+	CancelledAt     sql.NullTime   `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	CreatedBy       uint64         `db:"created_by" json:"created_by"`
+	CreatedAt       time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at" json:"updated_at"`
+
+	Interviewers []string `db:"-" json:"interviewers"`
+}
+
+// EndsAt returns when the interview is planned to end
+func (i *Interview) EndsAt() time.Time {
+	return i.ScheduledAt.Add(time.Duration(i.DurationMinutes) * time.Minute)
+}
+
+// IsActive reports whether the interview can still be rescheduled, cancelled or answered
+func (i *Interview) IsActive() bool {
+	return i.Status == StatusScheduled || i.Status == StatusConfirmed
+}
+
+// decodeInterviewers loads the interviewer names from the JSON column
+func (i *Interview) decodeInterviewers() error {
+	i.Interviewers = []string{}
+	if !i.InterviewersRaw.Valid || i.InterviewersRaw.String == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(i.InterviewersRaw.String), &i.Interviewers); err != nil {
+		return fmt.Errorf("failed to decode interviewers: %w", err)
+	}
+	return nil
+}
+
+// encodeInterviewers stores the interviewer names in the JSON column
+func (i *Interview) encodeInterviewers() error {
+	if len(i.Interviewers) == 0 {
+		i.InterviewersRaw = sql.NullString{}
+		return nil
+	}
+	raw, err := json.Marshal(i.Interviewers)
+	if err != nil {
+		return fmt.Errorf("failed to encode interviewers: %w", err)
+	}
+	i.InterviewersRaw = sql.NullString{String: string(raw), Valid: true}
+	return nil
+}
+
+// ApplicationRef is the application an interview belongs to, with its job, company and candidate
+type ApplicationRef struct {
+	ApplicationID  uint64         `db:"application_id"`
+	ApplicantID    uint64         `db:"user_id"`
+	ApplicantName  string         `db:"applicant_name"`
+	ApplicantEmail string         `db:"applicant_email"`
+	CurrentStatus  string         `db:"current_status"`
+	JobID          uint64         `db:"job_id"`
+	JobTitle       string         `db:"job_title"`
+	PipelineID     sql.NullInt64  `db:"pipeline_id"`
+	CompanyID      uint64         `db:"company_id"`
+	CompanyUserID  uint64         `db:"company_user_id"`
+	CompanyName    sql.NullString `db:"company_name"`
+}
+
+// ScheduleRequest represents a request to schedule or reschedule an interview (by company)
+type ScheduleRequest struct {
+	Title           string   `json:"title" validate:"required,max=150"`
+	ScheduledAt     string   `json:"scheduled_at" validate:"required"` // RFC3339
+	DurationMinutes int      `json:"duration_minutes,omitempty" validate:"omitempty,min=15,max=480"`
+	InterviewType   string   `json:"interview_type" validate:"required,oneof=online offline phone"`
+	MeetingPlatform string   `json:"meeting_platform,omitempty" validate:"max=50"`
+	MeetingLink     string   `json:"meeting_link,omitempty" validate:"omitempty,url,max=500"`
+	Location        string   `json:"location,omitempty" validate:"max=500"`
+	Interviewers    []string `json:"interviewers,omitempty" validate:"max=10,dive,required,max=100"`
+	ContactPerson   string   `json:"contact_person,omitempty" validate:"max=100"`
+	ContactPhone    string   `json:"contact_phone,omitempty" validate:"max=30"`
+	Notes           string   `json:"notes,omitempty" validate:"max=2000"`
+	Reason          string   `json:"reason,omitempty" validate:"max=500"` // Shown to the candidate on reschedule
+}
+
+// CancelRequest represents an interview cancellation (by company)
+type CancelRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// RespondRequest represents a candidate confirming or declining an interview
+type RespondRequest struct {
+	Note string `json:"note,omitempty" validate:"max=500"`
+}
+
+// InterviewResponse represents the interview API response
+type InterviewResponse struct {
+	ID              uint64     `json:"id"`
+	HashID          string     `json:"hash_id"`
+	ApplicationID   uint64     `json:"application_id"`
+	Round           int        `json:"round"`
+	Title           string     `json:"title"`
+	Status          string     `json:"status"`
+	ScheduledAt     time.Time  `json:"scheduled_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	DurationMinutes int        `json:"duration_minutes"`
+	InterviewType   string     `json:"interview_type"`
+	MeetingPlatform string     `json:"meeting_platform,omitempty"`
+	MeetingLink     string     `json:"meeting_link,omitempty"`
+	Location        string     `json:"location,omitempty"`
+	Interviewers    []string   `json:"interviewers"`
+	ContactPerson   string     `json:"contact_person,omitempty"`
+	ContactPhone    string     `json:"contact_phone,omitempty"`
+	Notes           string     `json:"notes,omitempty"`
+	CandidateNote   string     `json:"candidate_note,omitempty"`
+	RespondedAt     *time.Time `json:"responded_at,omitempty"`
+	CancelReason    string     `json:"cancel_reason,omitempty"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts Interview to InterviewResponse
+func (i *Interview) ToResponse() *InterviewResponse {
+	resp := &InterviewResponse{
+		ID:              i.ID,
+		HashID:          hashid.Encode(i.ID),
+		ApplicationID:   i.ApplicationID,
+		Round:           i.Round,
+		Title:           i.Title,
+		Status:          i.Status,
+		ScheduledAt:     i.ScheduledAt,
+		EndsAt:          i.EndsAt(),
+		DurationMinutes: i.DurationMinutes,
+		InterviewType:   i.InterviewType,
+		MeetingPlatform: i.MeetingPlatform.String,
+		MeetingLink:     i.MeetingLink.String,
+		Location:        i.Location.String,
+		Interviewers:    i.Interviewers,
+		ContactPerson:   i.ContactPerson.String,
+		ContactPhone:    i.ContactPhone.String,
+		Notes:           i.Notes.String,
+		CandidateNote:   i.CandidateNote.String,
+		CancelReason:    i.CancelReason.String,
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+	}
+
+	if resp.Interviewers == nil {
+		resp.Interviewers = []string{}
+	}
+	if i.RespondedAt.Valid {
+		resp.RespondedAt = &i.RespondedAt.Time
+	}
+	if i.CancelledAt.Valid {
+		resp.CancelledAt = &i.CancelledAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,276 @@
+package interviews
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for interviews
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new interviews handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// parseID parses an ID which can be either a numeric ID or a hash_id
+func parseID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	if strings.HasPrefix(idStr, "kn_") {
+		return hashid.Decode(idStr)
+	}
+
+	return 0, err
+}
+
+// Schedule handles scheduling a new interview round
+// POST /applications/{applicationId}/interviews
+func (h *Handler) Schedule(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := parseID(chi.URLParam(r, "applicationId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid application ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	interview, err := h.service.Schedule(r.Context(), applicationID, companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Created(w, "Interview scheduled", interview)
+}
+
+// ListByApplication handles listing the interview rounds of an application
+// GET /applications/{applicationId}/interviews
+func (h *Handler) ListByApplication(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := parseID(chi.URLParam(r, "applicationId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid application ID")
+		return
+	}
+
+	viewerID, isCompany, ok := h.viewer(w, r)
+	if !ok {
+		return
+	}
+
+	interviews, err := h.service.ListByApplication(r.Context(), applicationID, viewerID, isCompany)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Interviews retrieved", interviews)
+}
+
+// GetByID handles getting an interview
+// GET /interviews/{id}
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid interview ID")
+		return
+	}
+
+	viewerID, isCompany, ok := h.viewer(w, r)
+	if !ok {
+		return
+	}
+
+	interview, err := h.service.GetByID(r.Context(), id, viewerID, isCompany)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Interview retrieved", interview)
+}
+
+// Reschedule handles changing an interview's schedule or details
+// PUT /interviews/{id}
+func (h *Handler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid interview ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	interview, err := h.service.Reschedule(r.Context(), id, companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Interview rescheduled", interview)
+}
+
+// Cancel handles cancelling an interview
+// POST /interviews/{id}/cancel
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid interview ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CancelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	interview, err := h.service.Cancel(r.Context(), id, companyID, req.Reason)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Interview cancelled", interview)
+}
+
+// Confirm handles the candidate confirming an interview
+// POST /interviews/{id}/confirm
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, true)
+}
+
+// Decline handles the candidate declining an interview
+// POST /interviews/{id}/decline
+func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, false)
+}
+
+func (h *Handler) respond(w http.ResponseWriter, r *http.Request, confirm bool) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid interview ID")
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+
+	var req RespondRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		// Empty body is acceptable
+		req = RespondRequest{}
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	if confirm {
+		interview, err := h.service.Confirm(r.Context(), id, userID, req.Note)
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		response.OK(w, "Interview confirmed", interview)
+		return
+	}
+
+	interview, err := h.service.Decline(r.Context(), id, userID, req.Note)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.OK(w, "Interview declined", interview)
+}
+
+// companyID resolves the authenticated user's company, writing an error response on failure
+func (h *Handler) companyID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	userID := middleware.GetUserID(r.Context())
+
+	companyID, err := h.service.GetCompanyIDByUserID(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return 0, false
+	}
+	return companyID, true
+}
+
+// viewer resolves who is looking at interviews: the company for company users, otherwise the candidate
+func (h *Handler) viewer(w http.ResponseWriter, r *http.Request) (uint64, bool, bool) {
+	if middleware.GetUserRole(r.Context()) != "company" {
+		return middleware.GetUserID(r.Context()), false, true
+	}
+
+	companyID, ok := h.companyID(w, r)
+	return companyID, true, ok
+}
+
+// handleError handles service errors and returns appropriate HTTP response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
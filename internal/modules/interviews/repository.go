@@ -0,0 +1,214 @@
+package interviews
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the interviews repository interface
+type Repository interface {
+	Create(ctx context.Context, interview *Interview) error
+	GetByID(ctx context.Context, id uint64) (*Interview, error)
+	ListByApplication(ctx context.Context, applicationID uint64) ([]*Interview, error)
+	NextRound(ctx context.Context, applicationID uint64) (int, error)
+	UpdateSchedule(ctx context.Context, interview *Interview) error
+	Cancel(ctx context.Context, interview *Interview) error
+	Respond(ctx context.Context, interview *Interview) error
+
+	// Related data
+	GetApplicationRef(ctx context.Context, applicationID uint64) (*ApplicationRef, error)
+	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new interviews repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+const interviewColumns = `id, application_id, round, title, status, scheduled_at, duration_minutes, interview_type,
+	meeting_platform, meeting_link, location, interviewers, contact_person, contact_phone, notes,
+	uid, sequence, candidate_note, responded_at, cancel_reason, cancelled_at, created_by, created_at, updated_at`
+
+// Create inserts a new interview round
+func (r *mysqlRepository) Create(ctx context.Context, interview *Interview) error {
+	if err := interview.encodeInterviewers(); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO interviews (
+			application_id, round, title, status, scheduled_at, duration_minutes, interview_type,
+			meeting_platform, meeting_link, location, interviewers, contact_person, contact_phone, notes,
+			uid, sequence, created_by, created_at, updated_at
+		) VALUES (
+			?, ?, ?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?, ?, ?,
+			?, ?, ?, NOW(), NOW()
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		interview.ApplicationID, interview.Round, interview.Title, interview.Status, interview.ScheduledAt, interview.DurationMinutes, interview.InterviewType,
+		interview.MeetingPlatform, interview.MeetingLink, interview.Location, interview.InterviewersRaw, interview.ContactPerson, interview.ContactPhone, interview.Notes,
+		interview.UID, interview.Sequence, interview.CreatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create interview: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get interview ID: %w", err)
+	}
+	interview.ID = uint64(id)
+
+	return nil
+}
+
+// GetByID retrieves an interview by ID
+func (r *mysqlRepository) GetByID(ctx context.Context, id uint64) (*Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews WHERE id = ?`
+
+	var interview Interview
+	if err := r.db.GetContext(ctx, &interview, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get interview: %w", err)
+	}
+
+	if err := interview.decodeInterviewers(); err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+// ListByApplication lists the interview rounds of an application
+func (r *mysqlRepository) ListByApplication(ctx context.Context, applicationID uint64) ([]*Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews WHERE application_id = ? ORDER BY round ASC, id ASC`
+
+	interviews := []*Interview{}
+	if err := r.db.SelectContext(ctx, &interviews, query, applicationID); err != nil {
+		return nil, fmt.Errorf("failed to list interviews: %w", err)
+	}
+
+	for _, interview := range interviews {
+		if err := interview.decodeInterviewers(); err != nil {
+			return nil, err
+		}
+	}
+	return interviews, nil
+}
+
+// NextRound returns the round number for the next interview of an application
+func (r *mysqlRepository) NextRound(ctx context.Context, applicationID uint64) (int, error) {
+	var round int
+	query := `SELECT COALESCE(MAX(round), 0) + 1 FROM interviews WHERE application_id = ? AND status != 'cancelled'`
+	if err := r.db.GetContext(ctx, &round, query, applicationID); err != nil {
+		return 0, fmt.Errorf("failed to get next interview round: %w", err)
+	}
+	return round, nil
+}
+
+// UpdateSchedule saves a rescheduled interview and resets the candidate's response
+func (r *mysqlRepository) UpdateSchedule(ctx context.Context, interview *Interview) error {
+	if err := interview.encodeInterviewers(); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE interviews SET
+			title = ?, status = ?, scheduled_at = ?, duration_minutes = ?, interview_type = ?,
+			meeting_platform = ?, meeting_link = ?, location = ?, interviewers = ?,
+			contact_person = ?, contact_phone = ?, notes = ?,
+			sequence = ?, candidate_note = NULL, responded_at = NULL, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		interview.Title, interview.Status, interview.ScheduledAt, interview.DurationMinutes, interview.InterviewType,
+		interview.MeetingPlatform, interview.MeetingLink, interview.Location, interview.InterviewersRaw,
+		interview.ContactPerson, interview.ContactPhone, interview.Notes,
+		interview.Sequence, interview.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+
+	return nil
+}
+
+// Cancel marks an interview as cancelled
+func (r *mysqlRepository) Cancel(ctx context.Context, interview *Interview) error {
+	query := `
+		UPDATE interviews SET
+			status = 'cancelled', cancel_reason = ?, cancelled_at = NOW(), sequence = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, interview.CancelReason, interview.Sequence, interview.ID); err != nil {
+		return fmt.Errorf("failed to cancel interview: %w", err)
+	}
+
+	return nil
+}
+
+// Respond saves the candidate's confirmation or decline
+func (r *mysqlRepository) Respond(ctx context.Context, interview *Interview) error {
+	query := `
+		UPDATE interviews SET
+			status = ?, candidate_note = ?, responded_at = NOW(), updated_at = NOW()
+		WHERE id = ?
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, interview.Status, interview.CandidateNote, interview.ID); err != nil {
+		return fmt.Errorf("failed to save interview response: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationRef retrieves the application an interview belongs to, with its job, company and candidate
+func (r *mysqlRepository) GetApplicationRef(ctx context.Context, applicationID uint64) (*ApplicationRef, error) {
+	query := `
+		SELECT a.id as application_id, a.user_id, u.full_name as applicant_name, u.email as applicant_email,
+			   a.current_status, j.id as job_id, j.title as job_title, j.pipeline_id,
+			   c.id as company_id, c.user_id as company_user_id, c.company_name
+		FROM applications a
+		JOIN users u ON a.user_id = u.id
+		JOIN jobs j ON a.job_id = j.id
+		JOIN companies c ON j.company_id = c.id
+		WHERE a.id = ?
+	`
+
+	var ref ApplicationRef
+	if err := r.db.GetContext(ctx, &ref, query, applicationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	return &ref, nil
+}
+
+// GetCompanyIDByUserID retrieves company ID for a given user ID
+func (r *mysqlRepository) GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error) {
+	var companyID uint64
+	query := `SELECT id FROM companies WHERE user_id = ? AND deleted_at IS NULL`
+	if err := r.db.GetContext(ctx, &companyID, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get company ID: %w", err)
+	}
+	return companyID, nil
+}
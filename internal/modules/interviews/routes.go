@@ -0,0 +1,47 @@
+package interviews
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the interview routes
+func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireJobSeeker, requireCompany MiddlewareFunc) {
+	// Interview rounds of an application
+	r.Route("/applications/{applicationId}/interviews", func(r chi.Router) {
+		r.Use(authenticate)
+
+		// Both job seeker and company
+		r.Get("/", h.ListByApplication)
+
+		// Company only
+		r.With(requireCompany).Post("/", h.Schedule)
+	})
+
+	r.Route("/interviews", func(r chi.Router) {
+		r.Use(authenticate)
+
+		// Both job seeker and company
+		r.Get("/{id}", h.GetByID)
+
+		// Company only routes
+		r.Group(func(r chi.Router) {
+			r.Use(requireCompany)
+
+			r.Put("/{id}", h.Reschedule)
+			r.Post("/{id}/cancel", h.Cancel)
+		})
+
+		// Job seeker only routes
+		r.Group(func(r chi.Router) {
+			r.Use(requireJobSeeker)
+
+			r.Post("/{id}/confirm", h.Confirm)
+			r.Post("/{id}/decline", h.Decline)
+		})
+	})
+}
@@ -0,0 +1,461 @@
+package interviews
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/modules/pipelines"
+	"github.com/karirnusantara/api/internal/shared/email"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Service defines the interviews service interface
+type Service interface {
+	// Company
+	Schedule(ctx context.Context, applicationID, companyID uint64, req *ScheduleRequest) (*InterviewResponse, error)
+	Reschedule(ctx context.Context, id, companyID uint64, req *ScheduleRequest) (*InterviewResponse, error)
+	Cancel(ctx context.Context, id, companyID uint64, reason string) (*InterviewResponse, error)
+
+	// Candidate
+	Confirm(ctx context.Context, id, userID uint64, note string) (*InterviewResponse, error)
+	Decline(ctx context.Context, id, userID uint64, note string) (*InterviewResponse, error)
+
+	// Both: viewerID is the company ID for companies and the user ID for candidates
+	ListByApplication(ctx context.Context, applicationID, viewerID uint64, isCompany bool) ([]*InterviewResponse, error)
+	GetByID(ctx context.Context, id, viewerID uint64, isCompany bool) (*InterviewResponse, error)
+
+	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
+}
+
+type service struct {
+	repo                Repository
+	pipelineService     pipelines.Service
+	emailService        *email.Service
+	notificationService notifications.Service
+	now                 func() time.Time
+}
+
+// NewService creates a new interviews service
+func NewService(repo Repository, pipelineService pipelines.Service, emailService *email.Service, notificationService notifications.Service) Service {
+	return &service{
+		repo:                repo,
+		pipelineService:     pipelineService,
+		emailService:        emailService,
+		notificationService: notificationService,
+		now:                 time.Now,
+	}
+}
+
+// Schedule creates the next interview round for an application and sends the calendar invite
+func (s *service) Schedule(ctx context.Context, applicationID, companyID uint64, req *ScheduleRequest) (*InterviewResponse, error) {
+	ref, err := s.getApplicationForCompany(ctx, applicationID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// No interviews once the application has reached a final stage
+	pipeline := pipelines.DefaultPipeline()
+	if s.pipelineService != nil {
+		pipeline, err = s.pipelineService.Resolve(ctx, uint64(ref.PipelineID.Int64))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if pipeline.IsTerminal(ref.CurrentStatus) {
+		return nil, apperrors.NewBadRequestError("Cannot schedule an interview for a closed application")
+	}
+
+	interview := &Interview{
+		ApplicationID: applicationID,
+		Status:        StatusScheduled,
+		UID:           fmt.Sprintf("interview-%s@karirnusantara.com", uuid.New().String()),
+		CreatedBy:     companyID,
+	}
+	if err := s.applySchedule(interview, req); err != nil {
+		return nil, err
+	}
+
+	interview.Round, err = s.repo.NextRound(ctx, applicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to schedule interview", err)
+	}
+
+	if err := s.repo.Create(ctx, interview); err != nil {
+		return nil, apperrors.NewInternalError("Failed to schedule interview", err)
+	}
+
+	s.notifyCandidate(ctx, ref, interview, email.InterviewInvite, "")
+
+	return s.reload(ctx, interview.ID)
+}
+
+// Reschedule changes an interview's schedule or details. The candidate has to confirm again.
+func (s *service) Reschedule(ctx context.Context, id, companyID uint64, req *ScheduleRequest) (*InterviewResponse, error) {
+	interview, ref, err := s.getForCompany(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !interview.IsActive() {
+		return nil, apperrors.NewBadRequestError("Only scheduled or confirmed interviews can be rescheduled")
+	}
+
+	if err := s.applySchedule(interview, req); err != nil {
+		return nil, err
+	}
+	interview.Status = StatusScheduled
+	interview.Sequence++
+
+	if err := s.repo.UpdateSchedule(ctx, interview); err != nil {
+		return nil, apperrors.NewInternalError("Failed to reschedule interview", err)
+	}
+
+	s.notifyCandidate(ctx, ref, interview, email.InterviewUpdate, req.Reason)
+
+	return s.reload(ctx, interview.ID)
+}
+
+// Cancel cancels an interview and withdraws the calendar event
+func (s *service) Cancel(ctx context.Context, id, companyID uint64, reason string) (*InterviewResponse, error) {
+	interview, ref, err := s.getForCompany(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if !interview.IsActive() {
+		return nil, apperrors.NewBadRequestError("Only scheduled or confirmed interviews can be cancelled")
+	}
+
+	interview.Status = StatusCancelled
+	interview.CancelReason = sql.NullString{String: reason, Valid: reason != ""}
+	interview.Sequence++
+
+	if err := s.repo.Cancel(ctx, interview); err != nil {
+		return nil, apperrors.NewInternalError("Failed to cancel interview", err)
+	}
+
+	s.notifyCandidate(ctx, ref, interview, email.InterviewCancel, reason)
+
+	return s.reload(ctx, interview.ID)
+}
+
+// Confirm records that the candidate will attend the interview
+func (s *service) Confirm(ctx context.Context, id, userID uint64, note string) (*InterviewResponse, error) {
+	return s.respond(ctx, id, userID, StatusConfirmed, note)
+}
+
+// Decline records that the candidate will not attend the interview
+func (s *service) Decline(ctx context.Context, id, userID uint64, note string) (*InterviewResponse, error) {
+	return s.respond(ctx, id, userID, StatusDeclined, note)
+}
+
+// respond saves the candidate's answer and notifies the company
+func (s *service) respond(ctx context.Context, id, userID uint64, status, note string) (*InterviewResponse, error) {
+	interview, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get interview", err)
+	}
+	if interview == nil {
+		return nil, apperrors.NewNotFoundError("Interview")
+	}
+
+	ref, err := s.repo.GetApplicationRef(ctx, interview.ApplicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get application", err)
+	}
+	if ref == nil || ref.ApplicantID != userID {
+		return nil, apperrors.NewNotFoundError("Interview")
+	}
+
+	// A confirmed interview can still be declined, but not the other way around
+	if interview.Status != StatusScheduled && !(interview.Status == StatusConfirmed && status == StatusDeclined) {
+		return nil, apperrors.NewBadRequestError("This interview can no longer be " + status)
+	}
+	if !interview.ScheduledAt.After(s.now()) {
+		return nil, apperrors.NewBadRequestError("This interview has already started")
+	}
+
+	interview.Status = status
+	interview.CandidateNote = sql.NullString{String: note, Valid: note != ""}
+
+	if err := s.repo.Respond(ctx, interview); err != nil {
+		return nil, apperrors.NewInternalError("Failed to save interview response", err)
+	}
+
+	s.notifyCompany(ctx, ref, interview)
+
+	return s.reload(ctx, interview.ID)
+}
+
+// ListByApplication lists the interview rounds of an application
+func (s *service) ListByApplication(ctx context.Context, applicationID, viewerID uint64, isCompany bool) ([]*InterviewResponse, error) {
+	ref, err := s.repo.GetApplicationRef(ctx, applicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get application", err)
+	}
+	if ref == nil || !canView(ref, viewerID, isCompany) {
+		return nil, apperrors.NewNotFoundError("Application")
+	}
+
+	interviews, err := s.repo.ListByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list interviews", err)
+	}
+
+	responses := make([]*InterviewResponse, len(interviews))
+	for i, interview := range interviews {
+		responses[i] = interview.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetByID retrieves an interview visible to the company or the candidate
+func (s *service) GetByID(ctx context.Context, id, viewerID uint64, isCompany bool) (*InterviewResponse, error) {
+	interview, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get interview", err)
+	}
+	if interview == nil {
+		return nil, apperrors.NewNotFoundError("Interview")
+	}
+
+	ref, err := s.repo.GetApplicationRef(ctx, interview.ApplicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get application", err)
+	}
+	if ref == nil || !canView(ref, viewerID, isCompany) {
+		return nil, apperrors.NewNotFoundError("Interview")
+	}
+
+	return interview.ToResponse(), nil
+}
+
+// GetCompanyIDByUserID retrieves company ID for a given user ID
+func (s *service) GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error) {
+	companyID, err := s.repo.GetCompanyIDByUserID(ctx, userID)
+	if err != nil {
+		return 0, apperrors.NewInternalError("Failed to get company", err)
+	}
+	if companyID == 0 {
+		return 0, apperrors.NewNotFoundError("Company")
+	}
+	return companyID, nil
+}
+
+// applySchedule validates a schedule request and copies it onto the interview
+func (s *service) applySchedule(interview *Interview, req *ScheduleRequest) error {
+	scheduledAt, err := time.Parse(time.RFC3339, req.ScheduledAt)
+	if err != nil {
+		return apperrors.NewValidationError("Invalid schedule", map[string]string{
+			"scheduled_at": "must be an RFC3339 date-time, e.g. 2026-11-02T09:00:00+07:00",
+		})
+	}
+	if !scheduledAt.After(s.now()) {
+		return apperrors.NewValidationError("Invalid schedule", map[string]string{
+			"scheduled_at": "must be in the future",
+		})
+	}
+
+	switch req.InterviewType {
+	case TypeOnline:
+		if req.MeetingLink == "" {
+			return apperrors.NewValidationError("Invalid schedule", map[string]string{
+				"meeting_link": "is required for online interviews",
+			})
+		}
+	case TypeOffline:
+		if req.Location == "" {
+			return apperrors.NewValidationError("Invalid schedule", map[string]string{
+				"location": "is required for offline interviews",
+			})
+		}
+	}
+
+	duration := req.DurationMinutes
+	if duration == 0 {
+		duration = DefaultDurationMinutes
+	}
+
+	interviewers := make([]string, 0, len(req.Interviewers))
+	for _, name := range req.Interviewers {
+		if name = strings.TrimSpace(name); name != "" {
+			interviewers = append(interviewers, name)
+		}
+	}
+
+	interview.Title = strings.TrimSpace(req.Title)
+	interview.ScheduledAt = scheduledAt
+	interview.DurationMinutes = duration
+	interview.InterviewType = req.InterviewType
+	interview.MeetingPlatform = nullString(req.MeetingPlatform)
+	interview.MeetingLink = nullString(req.MeetingLink)
+	interview.Location = nullString(req.Location)
+	interview.Interviewers = interviewers
+	interview.ContactPerson = nullString(req.ContactPerson)
+	interview.ContactPhone = nullString(req.ContactPhone)
+	interview.Notes = nullString(req.Notes)
+
+	return nil
+}
+
+// getApplicationForCompany loads an application and verifies its job belongs to the company
+func (s *service) getApplicationForCompany(ctx context.Context, applicationID, companyID uint64) (*ApplicationRef, error) {
+	ref, err := s.repo.GetApplicationRef(ctx, applicationID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get application", err)
+	}
+	if ref == nil {
+		return nil, apperrors.NewNotFoundError("Application")
+	}
+	if ref.CompanyID != companyID {
+		return nil, apperrors.NewForbiddenError("You don't have permission to manage interviews for this application")
+	}
+	return ref, nil
+}
+
+// getForCompany loads an interview and verifies it belongs to one of the company's jobs
+func (s *service) getForCompany(ctx context.Context, id, companyID uint64) (*Interview, *ApplicationRef, error) {
+	interview, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, apperrors.NewInternalError("Failed to get interview", err)
+	}
+	if interview == nil {
+		return nil, nil, apperrors.NewNotFoundError("Interview")
+	}
+
+	ref, err := s.getApplicationForCompany(ctx, interview.ApplicationID, companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return interview, ref, nil
+}
+
+// reload returns the stored interview as a response
+func (s *service) reload(ctx context.Context, id uint64) (*InterviewResponse, error) {
+	interview, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get interview", err)
+	}
+	if interview == nil {
+		return nil, apperrors.NewNotFoundError("Interview")
+	}
+	return interview.ToResponse(), nil
+}
+
+// notifyCandidate notifies the candidate in-app and emails the calendar invite, update or cancellation
+func (s *service) notifyCandidate(ctx context.Context, ref *ApplicationRef, interview *Interview, kind, reason string) {
+	companyName := ref.CompanyName.String
+
+	if s.notificationService != nil {
+		notifType := notifications.TypeInterviewInvite
+		title := "Undangan interview"
+		message := fmt.Sprintf("%s mengundang Anda interview %s untuk posisi %s pada %s.", companyName, interview.Title, ref.JobTitle, formatSchedule(interview.ScheduledAt))
+		switch kind {
+		case email.InterviewUpdate:
+			notifType = notifications.TypeInterviewUpdate
+			title = "Jadwal interview diperbarui"
+			message = fmt.Sprintf("Jadwal interview %s untuk posisi %s diubah ke %s.", interview.Title, ref.JobTitle, formatSchedule(interview.ScheduledAt))
+		case email.InterviewCancel:
+			notifType = notifications.TypeInterviewCancel
+			title = "Interview dibatalkan"
+			message = fmt.Sprintf("Interview %s untuk posisi %s dibatalkan oleh %s.", interview.Title, ref.JobTitle, companyName)
+		}
+
+		data := map[string]interface{}{
+			"interview_id":   interview.ID,
+			"application_id": ref.ApplicationID,
+			"job_id":         ref.JobID,
+			"round":          interview.Round,
+		}
+		if err := s.notificationService.Notify(ctx, ref.ApplicantID, notifType, title, message, data); err != nil {
+			logger.FromContext(ctx).Error("failed to notify applicant of interview", "applicant_id", ref.ApplicantID, "interview_id", interview.ID, "error", err)
+		}
+	}
+
+	if s.emailService == nil || ref.ApplicantEmail == "" {
+		return
+	}
+
+	data := email.InterviewCalendarData{
+		InterviewScheduleData: email.InterviewScheduleData{
+			ApplicantName:   ref.ApplicantName,
+			JobTitle:        ref.JobTitle,
+			CompanyName:     companyName,
+			InterviewType:   interview.InterviewType,
+			Location:        interview.Location.String,
+			MeetingLink:     interview.MeetingLink.String,
+			MeetingPlatform: interview.MeetingPlatform.String,
+			ContactPerson:   interview.ContactPerson.String,
+			ContactPhone:    interview.ContactPhone.String,
+			Notes:           interview.Notes.String,
+		},
+		Kind:         kind,
+		Round:        interview.Round,
+		Title:        interview.Title,
+		Duration:     interview.DurationMinutes,
+		Interviewers: strings.Join(interview.Interviewers, ", "),
+		Reason:       reason,
+		UID:          interview.UID,
+		Sequence:     interview.Sequence,
+		Start:        interview.ScheduledAt,
+		End:          interview.EndsAt(),
+	}
+
+	// Queue email (don't fail if email fails)
+	if err := s.emailService.SendInterviewCalendarEmail(ref.ApplicantEmail, data); err != nil {
+		logger.FromContext(ctx).Error("failed to send interview email", "kind", kind, "interview_id", interview.ID, "error", err)
+	}
+}
+
+// notifyCompany tells the company the candidate confirmed or declined an interview
+func (s *service) notifyCompany(ctx context.Context, ref *ApplicationRef, interview *Interview) {
+	if s.notificationService == nil {
+		return
+	}
+
+	title := "Kandidat mengonfirmasi interview"
+	verb := "mengonfirmasi kehadiran"
+	if interview.Status == StatusDeclined {
+		title = "Kandidat menolak interview"
+		verb = "menolak"
+	}
+	message := fmt.Sprintf("%s %s interview %s untuk posisi %s pada %s.", ref.ApplicantName, verb, interview.Title, ref.JobTitle, formatSchedule(interview.ScheduledAt))
+	if interview.CandidateNote.Valid {
+		message += " Catatan: " + interview.CandidateNote.String
+	}
+
+	data := map[string]interface{}{
+		"interview_id":   interview.ID,
+		"application_id": ref.ApplicationID,
+		"job_id":         ref.JobID,
+		"status":         interview.Status,
+	}
+	if err := s.notificationService.Notify(ctx, ref.CompanyUserID, notifications.TypeInterviewResponse, title, message, data); err != nil {
+		logger.FromContext(ctx).Error("failed to notify company of interview response", "company_user_id", ref.CompanyUserID, "interview_id", interview.ID, "error", err)
+	}
+}
+
+// canView reports whether the viewer may see an application's interviews
+func canView(ref *ApplicationRef, viewerID uint64, isCompany bool) bool {
+	if isCompany {
+		return ref.CompanyID == viewerID
+	}
+	return ref.ApplicantID == viewerID
+}
+
+// wib is the timezone interview times are shown in
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// formatSchedule formats an interview time for candidates
+func formatSchedule(t time.Time) string {
+	return t.In(wib).Format("Monday, 02 January 2006 pukul 15:04 WIB")
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -2,7 +2,6 @@ package jobs
 
 import (
 	"database/sql"
-	"log"
 	"time"
 
 	"github.com/karirnusantara/api/internal/shared/hashid"
@@ -36,6 +35,20 @@ const (
 	JobStatusFilled = "filled"
 )
 
+// Who closed a job
+const (
+	JobClosedByCompany = "company"
+	JobClosedByAdmin   = "admin"
+	JobClosedBySystem  = "system"
+)
+
+// Why a job was closed
+const (
+	JobCloseReasonManual          = "manual"
+	JobCloseReasonDeadlinePassed  = "deadline_passed"
+	JobCloseReasonMaxApplications = "max_applications_reached"
+)
+
 // Job represents a job posting
 type Job struct {
 	ID                  uint64         `db:"id" json:"id"`
@@ -65,13 +78,18 @@ type Job struct {
 	SharesCount         uint64         `db:"shares_count" json:"shares_count"`
 	EditCount           uint64         `db:"edit_count" json:"edit_count"`
 	PublishedAt         sql.NullTime   `db:"published_at" json:"published_at,omitempty"`
+	ClosedAt            sql.NullTime   `db:"closed_at" json:"closed_at,omitempty"`
+	ClosedByType        sql.NullString `db:"closed_by_type" json:"closed_by_type,omitempty"`
+	ClosedByID          sql.NullInt64  `db:"closed_by_id" json:"closed_by_id,omitempty"`
+	ClosedReason        sql.NullString `db:"closed_reason" json:"closed_reason,omitempty"`
 	CreatedAt           time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt           time.Time      `db:"updated_at" json:"updated_at"`
 	DeletedAt           sql.NullTime   `db:"deleted_at" json:"-"`
 
 	// Relationships (loaded separately)
-	Company *CompanyInfo `db:"-" json:"company,omitempty"`
-	Skills  []JobSkill   `db:"-" json:"skills,omitempty"`
+	Company            *CompanyInfo        `db:"-" json:"company,omitempty"`
+	Skills             []JobSkill          `db:"-" json:"skills,omitempty"`
+	ScreeningQuestions []ScreeningQuestion `db:"-" json:"screening_questions,omitempty"`
 }
 
 // CompanyInfo represents minimal company information for job listing
@@ -101,6 +119,21 @@ type JobSkill struct {
 	IsRequired bool   `db:"is_required" json:"is_required"`
 }
 
+// JobClosure describes who closed a job and why
+type JobClosure struct {
+	ClosedByType string
+	ClosedByID   sql.NullInt64
+	Reason       string
+}
+
+// AutoCloseCandidate is an open job that should be closed by the sweeper
+type AutoCloseCandidate struct {
+	ID        uint64 `db:"id"`
+	CompanyID uint64 `db:"company_id"`
+	Status    string `db:"status"`
+	Reason    string `db:"reason"`
+}
+
 // JobResponse represents the job response for API
 type JobResponse struct {
 	ID               uint64       `json:"id"`
@@ -123,7 +156,11 @@ type JobResponse struct {
 	IsSalaryVisible     bool         `json:"is_salary_visible"`
 	IsSalaryFixed       bool         `json:"is_salary_fixed"`
 	ApplicationDeadline string       `json:"application_deadline,omitempty"`
+	MaxApplications     *int64       `json:"max_applications,omitempty"`
 	Status              string       `json:"status"`
+	ClosedAt            string       `json:"closed_at,omitempty"`
+	ClosedBy            string       `json:"closed_by,omitempty"`
+	ClosedReason        string       `json:"closed_reason,omitempty"`
 	ViewsCount          uint64       `json:"views_count"`
 	ApplicationsCount   uint64       `json:"applications_count"`
 	SharesCount         uint64       `json:"shares_count"`
@@ -133,6 +170,8 @@ type JobResponse struct {
 	CreatedAt           string       `json:"created_at"`
 	Company             *CompanyInfo `json:"company,omitempty"`
 	Skills              []string     `json:"skills,omitempty"`
+
+	ScreeningQuestions []*ScreeningQuestionResponse `json:"screening_questions,omitempty"`
 }
 
 // LocationInfo represents job location
@@ -151,8 +190,6 @@ type SalaryInfo struct {
 
 // ToResponse converts Job to JobResponse
 func (j *Job) ToResponse() *JobResponse {
-	log.Printf("[DEBUG] ToResponse: Job ID=%d, Title=%s, CompanyID=%d", j.ID, j.Title, j.CompanyID)
-
 	resp := &JobResponse{
 		ID:          j.ID,
 		HashID:      hashid.Encode(j.ID),
@@ -182,10 +219,7 @@ func (j *Job) ToResponse() *JobResponse {
 
 	// Safely handle Company - check for nil before calling WithHashID
 	if j.Company != nil {
-		log.Printf("[DEBUG] ToResponse: Company is NOT nil, Name=%s", j.Company.Name)
 		resp.Company = j.Company.WithHashID()
-	} else {
-		log.Printf("[DEBUG] ToResponse: Company IS nil")
 	}
 
 	// Always populate raw salary fields if they exist
@@ -206,11 +240,19 @@ func (j *Job) ToResponse() *JobResponse {
 		resp.Benefits = j.Benefits.String
 	}
 	if j.ApplicationDeadline.Valid {
-		resp.ApplicationDeadline = j.ApplicationDeadline.Time.Format("2006-01-02")
+		resp.ApplicationDeadline = j.ApplicationDeadline.Time.Format(DeadlineLayout)
+	}
+	if j.MaxApplications.Valid {
+		resp.MaxApplications = &j.MaxApplications.Int64
 	}
 	if j.PublishedAt.Valid {
 		resp.PublishedAt = j.PublishedAt.Time.Format(time.RFC3339)
 	}
+	if j.ClosedAt.Valid {
+		resp.ClosedAt = j.ClosedAt.Time.Format(time.RFC3339)
+		resp.ClosedBy = j.ClosedByType.String
+		resp.ClosedReason = j.ClosedReason.String
+	}
 
 	// Include salary object if visible (for public display)
 	if j.IsSalaryVisible && j.SalaryMin.Valid {
@@ -238,9 +280,37 @@ func (j *Job) ToResponse() *JobResponse {
 		}
 	}
 
+	// Screening questions without knockout rules (public display)
+	if len(j.ScreeningQuestions) > 0 {
+		resp.ScreeningQuestions = make([]*ScreeningQuestionResponse, len(j.ScreeningQuestions))
+		for i := range j.ScreeningQuestions {
+			resp.ScreeningQuestions[i] = j.ScreeningQuestions[i].ToResponse(false)
+		}
+	}
+
 	return resp
 }
 
+// DeadlineLayout is the date layout of application deadlines
+const DeadlineLayout = "2006-01-02"
+
+// Today returns the date of now in the server's time zone. Applying and the job sweeper
+// both compare application deadlines against this date, never against the database clock.
+func Today(now time.Time) string {
+	return now.Local().Format(DeadlineLayout)
+}
+
+// IsDeadlinePassed reports whether the application deadline is over.
+// The deadline date itself is still open for applications.
+func (r *JobResponse) IsDeadlinePassed(now time.Time) bool {
+	return r.ApplicationDeadline != "" && r.ApplicationDeadline < Today(now)
+}
+
+// HasReachedMaxApplications reports whether the job hit its application cap
+func (r *JobResponse) HasReachedMaxApplications() bool {
+	return r.MaxApplications != nil && *r.MaxApplications > 0 && int64(r.ApplicationsCount) >= *r.MaxApplications
+}
+
 // Request DTOs
 
 // CreateJobRequest represents a job creation request
@@ -264,6 +334,8 @@ type CreateJobRequest struct {
 	ApplicationDeadline string   `json:"application_deadline,omitempty"`
 	Skills              []string `json:"skills,omitempty"`
 	Status              string   `json:"status,omitempty" validate:"omitempty,oneof=draft active"`
+
+	ScreeningQuestions []ScreeningQuestionRequest `json:"screening_questions,omitempty" validate:"omitempty,max=10,dive"`
 }
 
 // UpdateJobRequest represents a job update request
@@ -286,6 +358,9 @@ type UpdateJobRequest struct {
 	ApplicationDeadline *string  `json:"application_deadline,omitempty"`
 	Skills              []string `json:"skills,omitempty"`
 	Status              *string  `json:"status,omitempty" validate:"omitempty,oneof=draft active paused closed filled"`
+
+	// Replaces the job's screening questions when present (an empty list removes them)
+	ScreeningQuestions []ScreeningQuestionRequest `json:"screening_questions,omitempty" validate:"omitempty,max=10,dive"`
 }
 
 // JobListParams represents job list query parameters
@@ -305,6 +380,11 @@ type JobListParams struct {
 	Status          string   `json:"status"`
 	SortBy          string   `json:"sort_by"`
 	SortOrder       string   `json:"sort_order"`
+
+	// PublishedAfter and PublishedBefore limit results to jobs published after PublishedAfter
+	// and at or before PublishedBefore (used by job alerts)
+	PublishedAfter  *time.Time `json:"-"`
+	PublishedBefore *time.Time `json:"-"`
 }
 
 // DefaultJobListParams returns default list parameters
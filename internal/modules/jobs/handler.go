@@ -2,7 +2,6 @@ package jobs
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"strconv"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/karirnusantara/api/internal/middleware"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
 	"github.com/karirnusantara/api/internal/shared/validator"
 )
@@ -137,57 +137,44 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Printf("[DEBUG] Update handler: userID=%d", userID)
-
 	// Get company_id from companies table where user_id = userID
 	company, err := h.service.GetCompanyByUserID(r.Context(), userID)
 	if err != nil {
-		log.Printf("[ERROR] Failed to get company for userID=%d: %v", userID, err)
+		logger.FromContext(r.Context()).Error("failed to get company of user", "error", err)
 		response.InternalServerError(w, "Gagal mendapatkan data perusahaan")
 		return
 	}
 	if company == nil {
-		log.Printf("[WARN] Company not found for userID=%d", userID)
 		response.BadRequest(w, "Data perusahaan tidak ditemukan")
 		return
 	}
 	companyID := company.ID
 
-	log.Printf("[DEBUG] Update handler: companyID=%d", companyID)
-
 	idStr := chi.URLParam(r, "id")
 	id, err := parseID(idStr)
 	if err != nil {
-		log.Printf("[ERROR] Failed to parse ID '%s': %v", idStr, err)
 		response.BadRequest(w, "Invalid job ID")
 		return
 	}
 
-	log.Printf("[DEBUG] Update handler: jobID=%d (from '%s')", id, idStr)
-
 	var req UpdateJobRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("[ERROR] Failed to decode request body: %v", err)
 		response.BadRequest(w, "Invalid request body")
 		return
 	}
 
-	log.Printf("[DEBUG] Update request: %+v", req)
-
 	if errors := h.validator.Validate(&req); errors != nil {
-		log.Printf("[WARN] Validation failed: %v", errors)
 		response.UnprocessableEntity(w, "Validation failed", errors)
 		return
 	}
 
 	job, err := h.service.Update(r.Context(), id, companyID, &req)
 	if err != nil {
-		log.Printf("[ERROR] Service.Update failed: %v", err)
 		handleError(w, err)
 		return
 	}
 
-	log.Printf("[INFO] Job updated successfully: ID=%d", job.ID)
+	logger.FromContext(r.Context()).Info("job updated", "job_id", job.ID)
 	response.OK(w, "Job updated successfully", job)
 }
 
@@ -657,3 +644,37 @@ func (h *Handler) GetJobStats(w http.ResponseWriter, r *http.Request) {
 
 	response.OK(w, "Job statistics retrieved", stats)
 }
+
+// GetScreeningQuestions returns a job's screening questions with knockout rules (for the owning company)
+// GET /jobs/{id}/screening-questions
+func (h *Handler) GetScreeningQuestions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == 0 {
+		response.Unauthorized(w, "Unauthorized")
+		return
+	}
+
+	company, err := h.service.GetCompanyByUserID(r.Context(), userID)
+	if err != nil {
+		response.InternalServerError(w, "Gagal mendapatkan data perusahaan")
+		return
+	}
+	if company == nil {
+		response.BadRequest(w, "Data perusahaan tidak ditemukan")
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid job ID")
+		return
+	}
+
+	questions, err := h.service.GetScreeningQuestionsForCompany(r.Context(), id, company.ID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Screening questions retrieved", questions)
+}
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -21,16 +20,25 @@ type Repository interface {
 	List(ctx context.Context, params JobListParams) ([]*Job, int64, error)
 	ListByCompany(ctx context.Context, companyID uint64, params JobListParams) ([]*Job, int64, error)
 	IncrementViewCount(ctx context.Context, id uint64) error
-	IncrementApplicationCount(ctx context.Context, id uint64) error
 	IncrementShareCount(ctx context.Context, id uint64) error
 
+	// Closing
+	CloseJob(ctx context.Context, id uint64, fromStatus string, closure *JobClosure) (bool, error)
+	ClearClosure(ctx context.Context, id uint64) error
+	ListAutoCloseCandidates(ctx context.Context, today string, limit int) ([]*AutoCloseCandidate, error)
+
 	// Skills
 	AddSkills(ctx context.Context, jobID uint64, skills []string) error
 	GetSkills(ctx context.Context, jobID uint64) ([]JobSkill, error)
 	DeleteSkills(ctx context.Context, jobID uint64) error
 
+	// Screening questions
+	GetScreeningQuestions(ctx context.Context, jobID uint64) ([]ScreeningQuestion, error)
+	ReplaceScreeningQuestions(ctx context.Context, jobID uint64, questions []ScreeningQuestion) error
+
 	// Company info
 	GetCompanyInfo(ctx context.Context, companyID uint64) (*CompanyInfo, error)
+	IsCompanyEmailVerified(ctx context.Context, companyID uint64) (bool, error)
 
 	// Tracking
 	RecordView(ctx context.Context, jobID, userID uint64) (bool, error) // Returns true if new view
@@ -92,7 +100,8 @@ func (r *mysqlRepository) GetByID(ctx context.Context, id uint64) (*Job, error)
 			   city, province, is_remote, job_type, experience_level,
 			   salary_min, salary_max, salary_currency, is_salary_visible, is_salary_fixed,
 			   application_deadline, max_applications, status, views_count, applications_count, shares_count, edit_count,
-			   published_at, created_at, updated_at, deleted_at
+			   published_at, closed_at, closed_by_type, closed_by_id, closed_reason,
+			   created_at, updated_at, deleted_at
 		FROM jobs
 		WHERE id = ? AND deleted_at IS NULL
 	`
@@ -115,7 +124,8 @@ func (r *mysqlRepository) GetBySlug(ctx context.Context, slug string) (*Job, err
 			   city, province, is_remote, job_type, experience_level,
 			   salary_min, salary_max, salary_currency, is_salary_visible, is_salary_fixed,
 			   application_deadline, max_applications, status, views_count, applications_count, shares_count, edit_count,
-			   published_at, created_at, updated_at, deleted_at
+			   published_at, closed_at, closed_by_type, closed_by_id, closed_reason,
+			   created_at, updated_at, deleted_at
 		FROM jobs
 		WHERE slug = ? AND deleted_at IS NULL
 	`
@@ -144,9 +154,6 @@ func (r *mysqlRepository) Update(ctx context.Context, job *Job) error {
 		WHERE id = ? AND deleted_at IS NULL
 	`
 
-	log.Printf("[DEBUG] Updating job ID=%d, Title=%s, Category=%s, Status=%s, SalaryMin=%v, SalaryMax=%v, Incrementing edit_count",
-		job.ID, job.Title, job.Category, job.Status, job.SalaryMin, job.SalaryMax)
-
 	_, err := r.db.ExecContext(ctx, query,
 		job.Title, job.Category, job.Slug, job.Description, job.Requirements, job.Responsibilities, job.Benefits,
 		job.City, job.Province, job.IsRemote, job.JobType, job.ExperienceLevel,
@@ -155,7 +162,6 @@ func (r *mysqlRepository) Update(ctx context.Context, job *Job) error {
 		job.ID,
 	)
 	if err != nil {
-		log.Printf("[ERROR] Failed to update job ID=%d: %v", job.ID, err)
 		return fmt.Errorf("failed to update job: %w", err)
 	}
 
@@ -230,6 +236,15 @@ func (r *mysqlRepository) List(ctx context.Context, params JobListParams) ([]*Jo
 		args = append(args, *params.CompanyID)
 	}
 
+	if params.PublishedAfter != nil {
+		conditions = append(conditions, "published_at > ?")
+		args = append(args, *params.PublishedAfter)
+	}
+	if params.PublishedBefore != nil {
+		conditions = append(conditions, "published_at <= ?")
+		args = append(args, *params.PublishedBefore)
+	}
+
 	whereClause := strings.Join(conditions, " AND ")
 
 	// Count total
@@ -256,7 +271,8 @@ func (r *mysqlRepository) List(ctx context.Context, params JobListParams) ([]*Jo
 			   city, province, is_remote, job_type, experience_level,
 			   salary_min, salary_max, salary_currency, is_salary_visible, is_salary_fixed,
 			   application_deadline, max_applications, status, views_count, applications_count, shares_count, edit_count,
-			   published_at, created_at, updated_at, deleted_at
+			   published_at, closed_at, closed_by_type, closed_by_id, closed_reason,
+			   created_at, updated_at, deleted_at
 		FROM jobs
 		WHERE %s
 		ORDER BY %s
@@ -320,7 +336,8 @@ func (r *mysqlRepository) ListByCompany(ctx context.Context, companyID uint64, p
 			   city, province, is_remote, job_type, experience_level,
 			   salary_min, salary_max, salary_currency, is_salary_visible, is_salary_fixed,
 			   application_deadline, max_applications, status, views_count, applications_count, shares_count, edit_count,
-			   published_at, created_at, updated_at, deleted_at
+			   published_at, closed_at, closed_by_type, closed_by_id, closed_reason,
+			   created_at, updated_at, deleted_at
 		FROM jobs
 		WHERE %s
 		ORDER BY %s
@@ -344,11 +361,75 @@ func (r *mysqlRepository) IncrementViewCount(ctx context.Context, id uint64) err
 	return err
 }
 
-// IncrementApplicationCount increments the application count
-func (r *mysqlRepository) IncrementApplicationCount(ctx context.Context, id uint64) error {
-	query := `UPDATE jobs SET applications_count = applications_count + 1 WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+// CloseJob moves a job to closed and records who closed it and why.
+// The update only applies while the job is still in fromStatus.
+func (r *mysqlRepository) CloseJob(ctx context.Context, id uint64, fromStatus string, closure *JobClosure) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = ?, closed_at = NOW(), closed_by_type = ?, closed_by_id = ?, closed_reason = ?, updated_at = NOW()
+		WHERE id = ? AND status = ? AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		JobStatusClosed, closure.ClosedByType, closure.ClosedByID, closure.Reason,
+		id, fromStatus,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to close job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to close job: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// ClearClosure removes closure information when a job is reopened
+func (r *mysqlRepository) ClearClosure(ctx context.Context, id uint64) error {
+	query := `
+		UPDATE jobs
+		SET closed_at = NULL, closed_by_type = NULL, closed_by_id = NULL, closed_reason = NULL
+		WHERE id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to clear job closure: %w", err)
+	}
+	return nil
+}
+
+// ListAutoCloseCandidates lists open jobs whose deadline is before today or that reached max applications
+func (r *mysqlRepository) ListAutoCloseCandidates(ctx context.Context, today string, limit int) ([]*AutoCloseCandidate, error) {
+	query := `
+		SELECT id, company_id, status,
+			CASE
+				WHEN application_deadline IS NOT NULL AND application_deadline < ? THEN ?
+				ELSE ?
+			END AS reason
+		FROM jobs
+		WHERE deleted_at IS NULL
+			AND status IN (?, ?)
+			AND (
+				(application_deadline IS NOT NULL AND application_deadline < ?)
+				OR (max_applications IS NOT NULL AND max_applications > 0 AND applications_count >= max_applications)
+			)
+		ORDER BY id ASC
+		LIMIT ?
+	`
+
+	var candidates []*AutoCloseCandidate
+	err := r.db.SelectContext(ctx, &candidates, query,
+		today, JobCloseReasonDeadlinePassed, JobCloseReasonMaxApplications,
+		JobStatusActive, JobStatusPaused,
+		today,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auto-close candidates: %w", err)
+	}
+
+	return candidates, nil
 }
 
 // AddSkills adds skills to a job
@@ -383,6 +464,91 @@ func (r *mysqlRepository) DeleteSkills(ctx context.Context, jobID uint64) error
 	return err
 }
 
+// GetScreeningQuestions retrieves the screening questions of a job in order
+func (r *mysqlRepository) GetScreeningQuestions(ctx context.Context, jobID uint64) ([]ScreeningQuestion, error) {
+	query := `
+		SELECT id, job_id, position, question, question_type, options, is_required,
+			   is_knockout, accepted_answers, min_value, max_value, created_at
+		FROM job_screening_questions
+		WHERE job_id = ?
+		ORDER BY position ASC, id ASC
+	`
+
+	var questions []ScreeningQuestion
+	if err := r.db.SelectContext(ctx, &questions, query, jobID); err != nil {
+		return nil, fmt.Errorf("failed to get screening questions: %w", err)
+	}
+
+	for i := range questions {
+		if err := questions[i].decodeLists(); err != nil {
+			return nil, err
+		}
+	}
+	return questions, nil
+}
+
+// ReplaceScreeningQuestions replaces all screening questions of a job
+func (r *mysqlRepository) ReplaceScreeningQuestions(ctx context.Context, jobID uint64, questions []ScreeningQuestion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_screening_questions WHERE job_id = ?`, jobID); err != nil {
+		return fmt.Errorf("failed to delete screening questions: %w", err)
+	}
+
+	query := `
+		INSERT INTO job_screening_questions (
+			job_id, position, question, question_type, options, is_required,
+			is_knockout, accepted_answers, min_value, max_value, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+	for i := range questions {
+		q := &questions[i]
+		if err := q.encodeLists(); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, query,
+			jobID, q.Position, q.Question, q.Type, q.OptionsRaw, q.IsRequired,
+			q.IsKnockout, q.AcceptedRaw, q.MinValue, q.MaxValue,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add screening question: %w", err)
+		}
+
+		id, _ := result.LastInsertId()
+		q.ID = uint64(id)
+		q.JobID = jobID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit screening questions: %w", err)
+	}
+	return nil
+}
+
+// IsCompanyEmailVerified checks whether the company account owner has verified their email
+func (r *mysqlRepository) IsCompanyEmailVerified(ctx context.Context, companyID uint64) (bool, error) {
+	query := `
+		SELECT u.is_verified
+		FROM companies c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.id = ? AND c.deleted_at IS NULL
+	`
+
+	var verified bool
+	if err := r.db.GetContext(ctx, &verified, query, companyID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check company email verification: %w", err)
+	}
+	return verified, nil
+}
+
 // GetCompanyInfo retrieves company info for a job
 func (r *mysqlRepository) GetCompanyInfo(ctx context.Context, companyID uint64) (*CompanyInfo, error) {
 	query := `SELECT id, company_name, company_logo_url, company_website, company_city, company_province FROM companies WHERE id = ? AND deleted_at IS NULL`
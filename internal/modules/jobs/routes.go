@@ -52,6 +52,7 @@ func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireCompany, requ
 				r.Patch("/pause", h.Pause)
 				r.Patch("/reopen", h.Reopen)
 				r.Get("/stats", h.GetJobStats)
+				r.Get("/screening-questions", h.GetScreeningQuestions)
 			})
 		})
 
@@ -0,0 +1,347 @@
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Screening question types
+const (
+	QuestionTypeYesNo          = "yes_no"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeNumeric        = "numeric"
+	QuestionTypeText           = "text"
+)
+
+// Yes/no answers
+const (
+	AnswerYes = "yes"
+	AnswerNo  = "no"
+)
+
+const (
+	// MaxScreeningQuestions limits how many questions a job can ask
+	MaxScreeningQuestions = 10
+	// maxTextAnswerLength limits free text answers (in characters)
+	maxTextAnswerLength = 2000
+)
+
+// ScreeningQuestion is a question candidates answer when applying for a job.
+// Knockout questions reject the application when the answer does not pass.
+type ScreeningQuestion struct {
+	ID          uint64          `db:"id" json:"id"`
+	JobID       uint64          `db:"job_id" json:"job_id"`
+	Position    int             `db:"position" json:"position"`
+	Question    string          `db:"question" json:"question"`
+	Type        string          `db:"question_type" json:"type"`
+	OptionsRaw  sql.NullString  `db:"options" json:"-"`
+	IsRequired  bool            `db:"is_required" json:"is_required"`
+	IsKnockout  bool            `db:"is_knockout" json:"is_knockout"`
+	AcceptedRaw sql.NullString  `db:"accepted_answers" json:"-"`
+	MinValue    sql.NullFloat64 `db:"min_value" json:"min_value,omitempty"`
+	MaxValue    sql.NullFloat64 `db:"max_value" json:"max_value,omitempty"`
+	CreatedAt   time.Time       `db:"created_at" json:"created_at"`
+
+	Options         []string `db:"-" json:"options,omitempty"`
+	AcceptedAnswers []string `db:"-" json:"accepted_answers,omitempty"`
+}
+
+// ScreeningResult is the evaluated answer to a screening question
+type ScreeningResult struct {
+	Answer string // Normalized answer, empty when an optional question was skipped
+	Passed bool   // False when a knockout rule rejects the answer
+}
+
+// Evaluate checks an answer against the question. It returns an error message when the
+// answer is missing or malformed; otherwise the normalized answer and whether it passes
+// the knockout rule (non-knockout questions always pass).
+func (q *ScreeningQuestion) Evaluate(answer string) (*ScreeningResult, string) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		if q.IsRequired {
+			return nil, "is required"
+		}
+		return &ScreeningResult{Passed: true}, ""
+	}
+
+	result := &ScreeningResult{Answer: answer, Passed: true}
+
+	switch q.Type {
+	case QuestionTypeYesNo:
+		result.Answer = strings.ToLower(answer)
+		if result.Answer != AnswerYes && result.Answer != AnswerNo {
+			return nil, "must be yes or no"
+		}
+		if q.IsKnockout {
+			result.Passed = containsFold(q.AcceptedAnswers, result.Answer)
+		}
+
+	case QuestionTypeMultipleChoice:
+		option, ok := findFold(q.Options, answer)
+		if !ok {
+			return nil, "must be one of the options"
+		}
+		result.Answer = option
+		if q.IsKnockout {
+			result.Passed = containsFold(q.AcceptedAnswers, option)
+		}
+
+	case QuestionTypeNumeric:
+		value, err := strconv.ParseFloat(strings.ReplaceAll(answer, ",", "."), 64)
+		if err != nil {
+			return nil, "must be a number"
+		}
+		result.Answer = strconv.FormatFloat(value, 'f', -1, 64)
+		if q.IsKnockout {
+			if q.MinValue.Valid && value < q.MinValue.Float64 {
+				result.Passed = false
+			}
+			if q.MaxValue.Valid && value > q.MaxValue.Float64 {
+				result.Passed = false
+			}
+		}
+
+	case QuestionTypeText:
+		if utf8.RuneCountInString(answer) > maxTextAnswerLength {
+			return nil, fmt.Sprintf("must be at most %d characters", maxTextAnswerLength)
+		}
+	}
+
+	return result, ""
+}
+
+// decodeLists loads the JSON option and accepted answer columns
+func (q *ScreeningQuestion) decodeLists() error {
+	q.Options = nil
+	q.AcceptedAnswers = nil
+	if q.OptionsRaw.Valid && q.OptionsRaw.String != "" {
+		if err := json.Unmarshal([]byte(q.OptionsRaw.String), &q.Options); err != nil {
+			return fmt.Errorf("failed to decode screening options: %w", err)
+		}
+	}
+	if q.AcceptedRaw.Valid && q.AcceptedRaw.String != "" {
+		if err := json.Unmarshal([]byte(q.AcceptedRaw.String), &q.AcceptedAnswers); err != nil {
+			return fmt.Errorf("failed to decode accepted answers: %w", err)
+		}
+	}
+	return nil
+}
+
+// encodeLists stores the option and accepted answer lists in their JSON columns
+func (q *ScreeningQuestion) encodeLists() error {
+	encode := func(values []string) (sql.NullString, error) {
+		if len(values) == 0 {
+			return sql.NullString{}, nil
+		}
+		raw, err := json.Marshal(values)
+		if err != nil {
+			return sql.NullString{}, fmt.Errorf("failed to encode screening question: %w", err)
+		}
+		return sql.NullString{String: string(raw), Valid: true}, nil
+	}
+
+	var err error
+	if q.OptionsRaw, err = encode(q.Options); err != nil {
+		return err
+	}
+	q.AcceptedRaw, err = encode(q.AcceptedAnswers)
+	return err
+}
+
+// ScreeningQuestionRequest represents a screening question in a job create/update request
+type ScreeningQuestionRequest struct {
+	Question   string               `json:"question" validate:"required,max=500"`
+	Type       string               `json:"type" validate:"required,oneof=yes_no multiple_choice numeric text"`
+	Options    []string             `json:"options,omitempty" validate:"max=10,dive,required,max=200"`
+	IsRequired *bool                `json:"is_required,omitempty"` // Defaults to true
+	Knockout   *KnockoutRuleRequest `json:"knockout,omitempty"`
+}
+
+// KnockoutRuleRequest describes which answers pass a knockout question.
+// yes_no and multiple_choice use AcceptedAnswers; numeric uses Min and/or Max.
+type KnockoutRuleRequest struct {
+	AcceptedAnswers []string `json:"accepted_answers,omitempty" validate:"max=10,dive,required,max=200"`
+	Min             *float64 `json:"min,omitempty"`
+	Max             *float64 `json:"max,omitempty"`
+}
+
+// ValidateScreeningQuestions checks the type-specific rules of screening questions.
+// It returns nil when the questions are valid, otherwise field errors.
+func ValidateScreeningQuestions(reqs []ScreeningQuestionRequest) map[string]string {
+	errs := map[string]string{}
+	if len(reqs) > MaxScreeningQuestions {
+		errs["screening_questions"] = fmt.Sprintf("must have at most %d questions", MaxScreeningQuestions)
+	}
+
+	for i, req := range reqs {
+		field := fmt.Sprintf("screening_questions[%d]", i)
+
+		switch req.Type {
+		case QuestionTypeMultipleChoice:
+			if len(req.Options) < 2 {
+				errs[field+".options"] = "multiple choice questions need at least 2 options"
+			} else if hasDuplicateFold(req.Options) {
+				errs[field+".options"] = "options must be unique"
+			}
+		default:
+			if len(req.Options) > 0 {
+				errs[field+".options"] = "only multiple choice questions have options"
+			}
+		}
+
+		if req.Knockout == nil {
+			continue
+		}
+		rule := req.Knockout
+
+		switch req.Type {
+		case QuestionTypeYesNo:
+			if len(rule.AcceptedAnswers) != 1 || (rule.AcceptedAnswers[0] != AnswerYes && rule.AcceptedAnswers[0] != AnswerNo) {
+				errs[field+".knockout"] = "yes/no knockouts accept exactly one answer: yes or no"
+			}
+		case QuestionTypeMultipleChoice:
+			if len(rule.AcceptedAnswers) == 0 {
+				errs[field+".knockout"] = "accepted_answers is required"
+			}
+			for _, accepted := range rule.AcceptedAnswers {
+				if _, ok := findFold(req.Options, accepted); !ok {
+					errs[field+".knockout"] = "accepted answers must be among the options"
+					break
+				}
+			}
+		case QuestionTypeNumeric:
+			if rule.Min == nil && rule.Max == nil {
+				errs[field+".knockout"] = "numeric knockouts need a min and/or max"
+			} else if rule.Min != nil && rule.Max != nil && *rule.Min > *rule.Max {
+				errs[field+".knockout"] = "min must not be greater than max"
+			}
+		case QuestionTypeText:
+			errs[field+".knockout"] = "free text questions cannot be knockouts"
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// toScreeningQuestions converts validated requests into questions for a job
+func toScreeningQuestions(reqs []ScreeningQuestionRequest) []ScreeningQuestion {
+	questions := make([]ScreeningQuestion, len(reqs))
+	for i, req := range reqs {
+		q := ScreeningQuestion{
+			Position:   i,
+			Question:   strings.TrimSpace(req.Question),
+			Type:       req.Type,
+			IsRequired: req.IsRequired == nil || *req.IsRequired,
+		}
+		if req.Type == QuestionTypeMultipleChoice {
+			q.Options = trimAll(req.Options)
+		}
+
+		if rule := req.Knockout; rule != nil {
+			q.IsKnockout = true
+			switch req.Type {
+			case QuestionTypeYesNo:
+				q.AcceptedAnswers = trimAll(rule.AcceptedAnswers)
+			case QuestionTypeMultipleChoice:
+				for _, accepted := range rule.AcceptedAnswers {
+					option, _ := findFold(q.Options, accepted)
+					q.AcceptedAnswers = append(q.AcceptedAnswers, option)
+				}
+			case QuestionTypeNumeric:
+				if rule.Min != nil {
+					q.MinValue = sql.NullFloat64{Float64: *rule.Min, Valid: true}
+				}
+				if rule.Max != nil {
+					q.MaxValue = sql.NullFloat64{Float64: *rule.Max, Valid: true}
+				}
+			}
+		}
+
+		questions[i] = q
+	}
+	return questions
+}
+
+// ScreeningQuestionResponse represents a screening question for the API.
+// Knockout rules are only included for the company that owns the job.
+type ScreeningQuestionResponse struct {
+	ID         uint64        `json:"id"`
+	Question   string        `json:"question"`
+	Type       string        `json:"type"`
+	Options    []string      `json:"options,omitempty"`
+	IsRequired bool          `json:"is_required"`
+	Knockout   *KnockoutRule `json:"knockout,omitempty"`
+}
+
+// KnockoutRule represents a knockout rule in API responses
+type KnockoutRule struct {
+	AcceptedAnswers []string `json:"accepted_answers,omitempty"`
+	Min             *float64 `json:"min,omitempty"`
+	Max             *float64 `json:"max,omitempty"`
+}
+
+// ToResponse converts ScreeningQuestion to ScreeningQuestionResponse
+func (q *ScreeningQuestion) ToResponse(includeRules bool) *ScreeningQuestionResponse {
+	resp := &ScreeningQuestionResponse{
+		ID:         q.ID,
+		Question:   q.Question,
+		Type:       q.Type,
+		Options:    q.Options,
+		IsRequired: q.IsRequired,
+	}
+
+	if includeRules && q.IsKnockout {
+		resp.Knockout = &KnockoutRule{AcceptedAnswers: q.AcceptedAnswers}
+		if q.MinValue.Valid {
+			resp.Knockout.Min = &q.MinValue.Float64
+		}
+		if q.MaxValue.Valid {
+			resp.Knockout.Max = &q.MaxValue.Float64
+		}
+	}
+
+	return resp
+}
+
+func trimAll(values []string) []string {
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return trimmed
+}
+
+func findFold(values []string, target string) (string, bool) {
+	target = strings.TrimSpace(target)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), target) {
+			return strings.TrimSpace(v), true
+		}
+	}
+	return "", false
+}
+
+func containsFold(values []string, target string) bool {
+	_, ok := findFold(values, target)
+	return ok
+}
+
+func hasDuplicateFold(values []string) bool {
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		key := strings.ToLower(strings.TrimSpace(v))
+		if seen[key] {
+			return true
+		}
+		seen[key] = true
+	}
+	return false
+}
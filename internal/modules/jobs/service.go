@@ -4,7 +4,6 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
 	"regexp"
 	"strings"
 	"time"
@@ -14,6 +13,7 @@ import (
 	"github.com/karirnusantara/api/internal/shared/email"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
 // Service defines the jobs service interface
@@ -27,13 +27,19 @@ type Service interface {
 	List(ctx context.Context, params JobListParams) ([]*JobResponse, int64, error)
 	ListByCompany(ctx context.Context, companyID uint64, params JobListParams) ([]*JobResponse, int64, error)
 	IncrementViewCount(ctx context.Context, id uint64) error
-	IncrementApplicationCount(ctx context.Context, id uint64) error
 	GetCompanyByUserID(ctx context.Context, userID uint64) (*company.Company, error)
 
+	// CloseExpiredJobs closes jobs past their deadline or application cap, returns how many were closed
+	CloseExpiredJobs(ctx context.Context, limit int) (int, error)
+
 	// Tracking
 	TrackView(ctx context.Context, jobID, userID uint64) (bool, error) // Returns true if new view
 	TrackShare(ctx context.Context, jobID uint64, userID *uint64, platform string) error
 	GetJobStats(ctx context.Context, jobID, companyID uint64) (*JobStatsResponse, error)
+
+	// Screening questions
+	GetScreeningQuestions(ctx context.Context, jobID uint64) ([]ScreeningQuestion, error)
+	GetScreeningQuestionsForCompany(ctx context.Context, jobID, companyID uint64) ([]*ScreeningQuestionResponse, error)
 }
 
 type service struct {
@@ -41,21 +47,27 @@ type service struct {
 	companyRepo  company.Repository
 	quotaService *quota.Service
 	emailService *email.Service
+	now          func() time.Time
 }
 
 // NewService creates a new jobs service
 func NewService(repo Repository) Service {
-	return &service{repo: repo}
+	return &service{repo: repo, now: time.Now}
+}
+
+// NewServiceWithClock creates a new jobs service with a custom clock (used by tests)
+func NewServiceWithClock(repo Repository, now func() time.Time) Service {
+	return &service{repo: repo, now: now}
 }
 
 // NewServiceWithCompanyRepo creates a new jobs service with company repository
 func NewServiceWithCompanyRepo(repo Repository, companyRepo company.Repository) Service {
-	return &service{repo: repo, companyRepo: companyRepo}
+	return &service{repo: repo, companyRepo: companyRepo, now: time.Now}
 }
 
 // NewServiceWithQuota creates a new jobs service with company and quota repositories
 func NewServiceWithQuota(repo Repository, companyRepo company.Repository, quotaService *quota.Service) Service {
-	return &service{repo: repo, companyRepo: companyRepo, quotaService: quotaService}
+	return &service{repo: repo, companyRepo: companyRepo, quotaService: quotaService, now: time.Now}
 }
 
 // NewServiceWithEmail creates a new jobs service with email notification support
@@ -65,6 +77,7 @@ func NewServiceWithEmail(repo Repository, companyRepo company.Repository, quotaS
 		companyRepo:  companyRepo,
 		quotaService: quotaService,
 		emailService: emailService,
+		now:          time.Now,
 	}
 }
 
@@ -87,6 +100,10 @@ func (s *service) GetCompanyByUserID(ctx context.Context, userID uint64) (*compa
 
 // Create creates a new job posting
 func (s *service) Create(ctx context.Context, companyID uint64, userID uint64, req *CreateJobRequest) (*JobResponse, error) {
+	if errs := ValidateScreeningQuestions(req.ScreeningQuestions); errs != nil {
+		return nil, apperrors.NewValidationError("Invalid screening questions", errs)
+	}
+
 	// Validate company eligibility to create jobs
 	if s.companyRepo != nil {
 		canCreate, validationErr, err := s.companyRepo.CanCreateJobs(ctx, companyID)
@@ -157,7 +174,7 @@ func (s *service) Create(ctx context.Context, companyID uint64, userID uint64, r
 		job.SalaryCurrency = req.SalaryCurrency
 	}
 	if req.ApplicationDeadline != "" {
-		deadline, err := time.Parse("2006-01-02", req.ApplicationDeadline)
+		deadline, err := time.ParseInLocation(DeadlineLayout, req.ApplicationDeadline, time.Local)
 		if err == nil {
 			job.ApplicationDeadline = sql.NullTime{Time: deadline, Valid: true}
 		}
@@ -165,6 +182,10 @@ func (s *service) Create(ctx context.Context, companyID uint64, userID uint64, r
 
 	// Set status and published_at
 	if req.Status == JobStatusActive {
+		if err := s.ensureEmailVerified(ctx, companyID); err != nil {
+			return nil, err
+		}
+
 		// Check and consume quota if publishing directly
 		if s.quotaService != nil {
 			canPublish, quotaType, err := s.quotaService.CanPublishJob(companyID)
@@ -199,10 +220,16 @@ func (s *service) Create(ctx context.Context, companyID uint64, userID uint64, r
 		}
 	}
 
+	// Add screening questions
+	if len(req.ScreeningQuestions) > 0 {
+		if err := s.repo.ReplaceScreeningQuestions(ctx, job.ID, toScreeningQuestions(req.ScreeningQuestions)); err != nil {
+			return nil, apperrors.NewInternalError("Failed to add screening questions", err)
+		}
+	}
+
 	// Send email notification if job is published
 	if job.Status == JobStatusActive && s.emailService != nil {
-		// Use background context for goroutine since request context will be cancelled
-		go s.sendJobPostedNotification(context.Background(), job.ID, companyID, userID)
+		s.sendJobPostedNotification(ctx, job.ID, companyID, userID)
 	}
 
 	return s.GetByID(ctx, job.ID)
@@ -210,25 +237,23 @@ func (s *service) Create(ctx context.Context, companyID uint64, userID uint64, r
 
 // GetByID retrieves a job by ID with all related data
 func (s *service) GetByID(ctx context.Context, id uint64) (*JobResponse, error) {
-	log.Printf("[DEBUG] Service.GetByID: id=%d", id)
 	job, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		log.Printf("[ERROR] Service.GetByID: repo.GetByID failed: %v", err)
+		logger.FromContext(ctx).Error("failed to get job", "job_id", id, "error", err)
 		return nil, apperrors.NewInternalError("Failed to get job", err)
 	}
 	if job == nil {
-		log.Printf("[WARN] Service.GetByID: Job not found with id=%d", id)
 		return nil, apperrors.NewNotFoundError("Job")
 	}
 
-	log.Printf("[DEBUG] Service.GetByID: Loading relations for job ID=%d", id)
 	// Load related data
 	if err := s.loadJobRelations(ctx, job); err != nil {
-		log.Printf("[ERROR] Service.GetByID: loadJobRelations failed: %v", err)
+		return nil, err
+	}
+	if err := s.loadScreeningQuestions(ctx, job); err != nil {
 		return nil, err
 	}
 
-	log.Printf("[DEBUG] Service.GetByID: Converting to response")
 	return job.ToResponse(), nil
 }
 
@@ -246,36 +271,37 @@ func (s *service) GetBySlug(ctx context.Context, slug string) (*JobResponse, err
 	if err := s.loadJobRelations(ctx, job); err != nil {
 		return nil, err
 	}
+	if err := s.loadScreeningQuestions(ctx, job); err != nil {
+		return nil, err
+	}
 
 	return job.ToResponse(), nil
 }
 
 // Update updates a job posting
 func (s *service) Update(ctx context.Context, id uint64, companyID uint64, req *UpdateJobRequest) (*JobResponse, error) {
-	log.Printf("[DEBUG] Service.Update: id=%d, companyID=%d", id, companyID)
+	if errs := ValidateScreeningQuestions(req.ScreeningQuestions); errs != nil {
+		return nil, apperrors.NewValidationError("Invalid screening questions", errs)
+	}
 
 	// Get existing job
 	job, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		log.Printf("[ERROR] Service.Update: repo.GetByID failed: %v", err)
+		logger.FromContext(ctx).Error("failed to get job", "job_id", id, "error", err)
 		return nil, apperrors.NewInternalError("Failed to get job", err)
 	}
 	if job == nil {
-		log.Printf("[WARN] Service.Update: Job not found with id=%d", id)
 		return nil, apperrors.NewNotFoundError("Job")
 	}
 
-	log.Printf("[DEBUG] Service.Update: Found job - ID=%d, CompanyID=%d, Title=%s, EditCount=%d", job.ID, job.CompanyID, job.Title, job.EditCount)
-
 	// Check ownership
 	if job.CompanyID != companyID {
-		log.Printf("[WARN] Service.Update: Ownership mismatch - job.CompanyID=%d, requestCompanyID=%d", job.CompanyID, companyID)
+		logger.FromContext(ctx).Warn("job update denied: not the owner", "job_id", job.ID, "company_id", companyID)
 		return nil, apperrors.NewForbiddenError("You don't have permission to update this job")
 	}
 
 	// Check if job has already been edited (max 1 edit allowed)
 	if job.EditCount >= 1 {
-		log.Printf("[WARN] Service.Update: Job ID=%d has already been edited (edit_count=%d)", job.ID, job.EditCount)
 		return nil, apperrors.NewValidationError("Lowongan ini sudah pernah diedit. Setiap lowongan hanya dapat diedit 1 kali.", map[string]string{
 			"code":       "EDIT_LIMIT_REACHED",
 			"edit_count": fmt.Sprintf("%d", job.EditCount),
@@ -291,7 +317,7 @@ func (s *service) Update(ctx context.Context, id uint64, companyID uint64, req *
 			// Check if new slug exists (for another job)
 			existing, err := s.repo.GetBySlug(ctx, newSlug)
 			if err != nil {
-				log.Printf("[ERROR] Service.Update: Failed to check slug: %v", err)
+				logger.FromContext(ctx).Error("failed to check job slug", "job_id", job.ID, "error", err)
 				return nil, apperrors.NewInternalError("Failed to check slug", err)
 			}
 			// If slug exists and belongs to different job, append timestamp
@@ -344,7 +370,7 @@ func (s *service) Update(ctx context.Context, id uint64, companyID uint64, req *
 		job.IsSalaryFixed = *req.IsSalaryFixed
 	}
 	if req.ApplicationDeadline != nil {
-		deadline, err := time.Parse("2006-01-02", *req.ApplicationDeadline)
+		deadline, err := time.ParseInLocation(DeadlineLayout, *req.ApplicationDeadline, time.Local)
 		if err == nil {
 			job.ApplicationDeadline = sql.NullTime{Time: deadline, Valid: true}
 		}
@@ -352,36 +378,41 @@ func (s *service) Update(ctx context.Context, id uint64, companyID uint64, req *
 	if req.Status != nil {
 		// Handle status transition
 		if *req.Status == JobStatusActive && job.Status != JobStatusActive {
+			if err := s.ensureEmailVerified(ctx, companyID); err != nil {
+				return nil, err
+			}
 			job.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
 		}
 		job.Status = *req.Status
 	}
 
 	// Update job
-	log.Printf("[DEBUG] Service.Update: Calling repo.Update for job ID=%d", job.ID)
 	if err := s.repo.Update(ctx, job); err != nil {
-		log.Printf("[ERROR] Service.Update: repo.Update failed: %v", err)
+		logger.FromContext(ctx).Error("failed to update job", "job_id", job.ID, "error", err)
 		return nil, apperrors.NewInternalError("Failed to update job", err)
 	}
-	log.Printf("[DEBUG] Service.Update: repo.Update successful")
 
 	// Update skills if provided
 	if req.Skills != nil {
-		log.Printf("[DEBUG] Service.Update: Updating skills for job ID=%d, count=%d", job.ID, len(req.Skills))
 		if err := s.repo.DeleteSkills(ctx, job.ID); err != nil {
-			log.Printf("[ERROR] Service.Update: DeleteSkills failed: %v", err)
+			logger.FromContext(ctx).Error("failed to delete job skills", "job_id", job.ID, "error", err)
 			return nil, apperrors.NewInternalError("Failed to update skills", err)
 		}
 		if len(req.Skills) > 0 {
 			if err := s.repo.AddSkills(ctx, job.ID, req.Skills); err != nil {
-				log.Printf("[ERROR] Service.Update: AddSkills failed: %v", err)
+				logger.FromContext(ctx).Error("failed to add job skills", "job_id", job.ID, "error", err)
 				return nil, apperrors.NewInternalError("Failed to add skills", err)
 			}
 		}
-		log.Printf("[DEBUG] Service.Update: Skills updated successfully")
 	}
 
-	log.Printf("[DEBUG] Service.Update: Calling GetByID to return updated job")
+	// Replace screening questions if provided
+	if req.ScreeningQuestions != nil {
+		if err := s.repo.ReplaceScreeningQuestions(ctx, job.ID, toScreeningQuestions(req.ScreeningQuestions)); err != nil {
+			return nil, apperrors.NewInternalError("Failed to update screening questions", err)
+		}
+	}
+
 	return s.GetByID(ctx, job.ID)
 }
 
@@ -454,11 +485,6 @@ func (s *service) IncrementViewCount(ctx context.Context, id uint64) error {
 	return s.repo.IncrementViewCount(ctx, id)
 }
 
-// IncrementApplicationCount increments the application count for a job
-func (s *service) IncrementApplicationCount(ctx context.Context, id uint64) error {
-	return s.repo.IncrementApplicationCount(ctx, id)
-}
-
 // UpdateStatus updates the job status (publish, close, pause, reopen)
 func (s *service) UpdateStatus(ctx context.Context, id uint64, companyID uint64, userID uint64, newStatus string) (*JobResponse, error) {
 	// Get existing job
@@ -480,6 +506,12 @@ func (s *service) UpdateStatus(ctx context.Context, id uint64, companyID uint64,
 		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Cannot change status from '%s' to '%s'", job.Status, newStatus))
 	}
 
+	if newStatus == JobStatusActive {
+		if err := s.ensureEmailVerified(ctx, companyID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check and consume quota when publishing (draft -> active or first time active)
 	if newStatus == JobStatusActive && !job.PublishedAt.Valid {
 		if s.quotaService != nil {
@@ -500,6 +532,26 @@ func (s *service) UpdateStatus(ctx context.Context, id uint64, companyID uint64,
 		}
 	}
 
+	// Closing records who closed the job
+	if newStatus == JobStatusClosed {
+		closure := &JobClosure{
+			ClosedByType: JobClosedByCompany,
+			ClosedByID:   sql.NullInt64{Int64: int64(userID), Valid: true},
+			Reason:       JobCloseReasonManual,
+		}
+		ok, err := s.repo.CloseJob(ctx, id, job.Status, closure)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to update job status", err)
+		}
+		if !ok {
+			// Closed or changed by someone else (e.g. the sweeper) since it was read
+			return nil, apperrors.NewConflictError("Job status was changed by another update, please reload")
+		}
+		return s.GetByID(ctx, id)
+	}
+
+	wasClosed := job.Status == JobStatusClosed
+
 	// Update status
 	job.Status = newStatus
 
@@ -512,9 +564,61 @@ func (s *service) UpdateStatus(ctx context.Context, id uint64, companyID uint64,
 		return nil, apperrors.NewInternalError("Failed to update job status", err)
 	}
 
+	// Reopened jobs no longer carry closure info
+	if wasClosed {
+		if err := s.repo.ClearClosure(ctx, id); err != nil {
+			return nil, apperrors.NewInternalError("Failed to update job status", err)
+		}
+	}
+
 	return s.GetByID(ctx, id)
 }
 
+// CloseExpiredJobs closes open jobs whose application deadline has passed or that
+// reached their maximum number of applications. Used by the background sweeper.
+func (s *service) CloseExpiredJobs(ctx context.Context, limit int) (int, error) {
+	candidates, err := s.repo.ListAutoCloseCandidates(ctx, Today(s.now()), limit)
+	if err != nil {
+		return 0, apperrors.NewInternalError("Failed to list jobs to close", err)
+	}
+
+	closed := 0
+	for _, c := range candidates {
+		// Same rules as a manual status change
+		if !isValidStatusTransition(c.Status, JobStatusClosed) {
+			continue
+		}
+
+		closure := &JobClosure{
+			ClosedByType: JobClosedBySystem,
+			Reason:       c.Reason,
+		}
+		ok, err := s.repo.CloseJob(ctx, c.ID, c.Status, closure)
+		if err != nil {
+			logger.FromContext(ctx).Error("job sweeper failed to close job", "job_id", c.ID, "error", err)
+			continue
+		}
+		if ok {
+			closed++
+			logger.FromContext(ctx).Info("job sweeper closed job", "job_id", c.ID, "company_id", c.CompanyID, "reason", c.Reason)
+		}
+	}
+
+	return closed, nil
+}
+
+// ensureEmailVerified blocks publishing until the company account has confirmed its email
+func (s *service) ensureEmailVerified(ctx context.Context, companyID uint64) error {
+	verified, err := s.repo.IsCompanyEmailVerified(ctx, companyID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to check email verification", err)
+	}
+	if !verified {
+		return apperrors.NewEmailNotVerifiedError("Verifikasi email akun Anda sebelum mempublikasikan lowongan")
+	}
+	return nil
+}
+
 // isValidStatusTransition checks if the status transition is allowed
 func isValidStatusTransition(from, to string) bool {
 	validTransitions := map[string][]string{
@@ -539,21 +643,18 @@ func isValidStatusTransition(from, to string) bool {
 
 // loadJobRelations loads related data for a job
 func (s *service) loadJobRelations(ctx context.Context, job *Job) error {
-	log.Printf("[DEBUG] loadJobRelations: Loading relations for job ID=%d, CompanyID=%d", job.ID, job.CompanyID)
-
 	// Load company info
 	company, err := s.repo.GetCompanyInfo(ctx, job.CompanyID)
 	if err != nil {
-		log.Printf("[ERROR] loadJobRelations: GetCompanyInfo failed for companyID=%d: %v", job.CompanyID, err)
+		logger.FromContext(ctx).Error("failed to load company of job", "job_id", job.ID, "company_id", job.CompanyID, "error", err)
 		return apperrors.NewInternalError("Failed to load company info", err)
 	}
-	log.Printf("[DEBUG] loadJobRelations: Company loaded - isNil=%v", company == nil)
 	job.Company = company
 
 	// Load skills
 	skills, err := s.repo.GetSkills(ctx, job.ID)
 	if err != nil {
-		log.Printf("[ERROR] loadJobRelations: GetSkills failed for jobID=%d: %v", job.ID, err)
+		logger.FromContext(ctx).Error("failed to load job skills", "job_id", job.ID, "error", err)
 		return apperrors.NewInternalError("Failed to load skills", err)
 	}
 	job.Skills = skills
@@ -561,6 +662,16 @@ func (s *service) loadJobRelations(ctx context.Context, job *Job) error {
 	return nil
 }
 
+// loadScreeningQuestions loads the screening questions shown on a single job
+func (s *service) loadScreeningQuestions(ctx context.Context, job *Job) error {
+	questions, err := s.repo.GetScreeningQuestions(ctx, job.ID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to load screening questions", err)
+	}
+	job.ScreeningQuestions = questions
+	return nil
+}
+
 // generateSlug generates a URL-friendly slug from a title
 func generateSlug(title string) string {
 	// Convert to lowercase
@@ -588,14 +699,14 @@ func (s *service) sendJobPostedNotification(ctx context.Context, jobID uint64, c
 	// Get job details
 	job, err := s.GetByID(ctx, jobID)
 	if err != nil {
-		log.Printf("Failed to get job details for email notification: %v", err)
+		logger.FromContext(ctx).Error("failed to load job for job posted email", "job_id", jobID, "error", err)
 		return
 	}
 
 	// Get company details to get email
 	company, err := s.companyRepo.GetByID(ctx, companyID)
 	if err != nil {
-		log.Printf("Failed to get company details for email notification: %v", err)
+		logger.FromContext(ctx).Error("failed to load company for job posted email", "company_id", companyID, "error", err)
 		return
 	}
 
@@ -611,7 +722,7 @@ func (s *service) sendJobPostedNotification(ctx context.Context, jobID uint64, c
 	}
 
 	if companyEmail == "" {
-		log.Printf("Company email is empty for company #%d, skipping notification", companyID)
+		logger.FromContext(ctx).Debug("company has no email, job posted email skipped", "company_id", companyID)
 		return
 	}
 
@@ -620,149 +731,28 @@ func (s *service) sendJobPostedNotification(ctx context.Context, jobID uint64, c
 	if job.Location.Province != "" {
 		location = fmt.Sprintf("%s, %s", job.Location.City, job.Location.Province)
 	}
-	remoteText := ""
-	if job.Location.IsRemote {
-		remoteText = "(Remote)"
-	}
 
-	// Format job type
-	jobTypeMap := map[string]string{
-		"full-time":  "Full Time",
-		"part-time":  "Part Time",
-		"contract":   "Kontrak",
-		"internship": "Magang",
-		"freelance":  "Freelance",
-	}
-	jobTypeText := jobTypeMap[job.JobType]
-	if jobTypeText == "" {
-		jobTypeText = job.JobType
-	}
-
-	// Format experience level
-	levelMap := map[string]string{
-		"entry":    "Entry Level",
-		"junior":   "Junior",
-		"mid":      "Mid Level",
-		"senior":   "Senior",
-		"lead":     "Lead",
-		"manager":  "Manager",
-		"director": "Director",
-	}
-	levelText := levelMap[job.ExperienceLevel]
-	if levelText == "" {
-		levelText = job.ExperienceLevel
-	}
-
-	// Format published date
-	publishedDate := "Baru saja"
+	var publishedAt time.Time
 	if job.PublishedAt != "" {
-		publishedDate = job.PublishedAt
-	}
-
-	// Build email content
-	subject := fmt.Sprintf("✅ Lowongan '%s' Berhasil Dipublikasikan", job.Title)
-
-	body := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background: linear-gradient(135deg, #2980b9 0%%, #6dd5fa 100%%); color: white; padding: 30px; text-align: center; border-radius: 8px 8px 0 0; }
-        .content { background: #f9f9f9; padding: 30px; border: 1px solid #e0e0e0; }
-        .job-details { background: white; padding: 20px; margin: 20px 0; border-left: 4px solid #2980b9; border-radius: 4px; }
-        .job-details h3 { margin-top: 0; color: #2980b9; }
-        .detail-row { margin: 10px 0; }
-        .detail-label { font-weight: bold; color: #555; }
-        .button { display: inline-block; padding: 12px 30px; background: #2980b9; color: white; text-decoration: none; border-radius: 5px; margin: 20px 0; }
-        .footer { text-align: center; padding: 20px; color: #777; font-size: 12px; }
-        .alert { background: #d4edda; border: 1px solid #c3e6cb; color: #155724; padding: 15px; border-radius: 4px; margin: 15px 0; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>🎉 Lowongan Berhasil Dipublikasikan!</h1>
-        </div>
-        
-        <div class="content">
-            <p>Halo <strong>%s</strong>,</p>
-            
-            <p>Selamat! Lowongan pekerjaan Anda telah berhasil dipublikasikan di platform <strong>Karir Nusantara</strong> dan sekarang dapat dilihat oleh ribuan pencari kerja di seluruh Indonesia.</p>
-            
-            <div class="job-details">
-                <h3>📋 Detail Lowongan</h3>
-                <div class="detail-row">
-                    <span class="detail-label">Posisi:</span> %s
-                </div>
-                <div class="detail-row">
-                    <span class="detail-label">Lokasi:</span> %s %s
-                </div>
-                <div class="detail-row">
-                    <span class="detail-label">Tipe Pekerjaan:</span> %s
-                </div>
-                <div class="detail-row">
-                    <span class="detail-label">Level:</span> %s
-                </div>
-                <div class="detail-row">
-                    <span class="detail-label">Status:</span> <span style="color: #28a745; font-weight: bold;">AKTIF</span>
-                </div>
-                <div class="detail-row">
-                    <span class="detail-label">Tanggal Publish:</span> %s
-                </div>
-            </div>
-            
-            <div class="alert">
-                <strong>🔒 Keamanan & Privasi</strong><br>
-                Email ini dikirim untuk mengkonfirmasi aktivitas posting lowongan dari akun Anda. 
-                Jika Anda tidak melakukan posting ini, segera hubungi tim support kami melalui chat support di dashboard.
-            </div>
-            
-            <p><strong>Apa yang terjadi selanjutnya?</strong></p>
-            <ul>
-                <li>Lowongan Anda kini dapat dilihat oleh pencari kerja</li>
-                <li>Anda akan menerima notifikasi saat ada lamaran masuk</li>
-                <li>Anda dapat mengelola lowongan di menu Dashboard > Lowongan</li>
-                <li>Statistik viewing akan diupdate secara real-time</li>
-            </ul>
-            
-            <div style="text-align: center;">
-                <a href="http://localhost:5174/dashboard/jobs" class="button">Kelola Lowongan Saya</a>
-            </div>
-            
-            <p style="margin-top: 30px; padding-top: 20px; border-top: 1px solid #ddd;">
-                <strong>Butuh bantuan?</strong><br>
-                Hubungi kami melalui fitur Chat Support di dashboard atau email ke support@karirnusantara.com
-            </p>
-        </div>
-        
-        <div class="footer">
-            <p>Karir Nusantara - Platform Pencarian Kerja Terpercaya Indonesia</p>
-            <p>Email ini dikirim otomatis oleh sistem. Mohon tidak membalas email ini.</p>
-            <p>&copy; 2024 Karir Nusantara. All rights reserved.</p>
-        </div>
-    </div>
-</body>
-</html>
-	`,
-		companyName,
-		job.Title,
-		location,
-		remoteText,
-		jobTypeText,
-		levelText,
-		publishedDate,
-	)
+		publishedAt, _ = time.Parse(time.RFC3339, job.PublishedAt)
+	}
 
 	// Send email
-	log.Printf("[JOB NOTIFICATION] Attempting to send email to: %s for job #%d (%s)", companyEmail, jobID, job.Title)
-	err = s.emailService.SendEmail(companyEmail, subject, body)
+	err = s.emailService.SendJobPostedEmail(ctx, companyEmail, email.JobPostedData{
+		JobID:           jobID,
+		CompanyName:     companyName,
+		Title:           job.Title,
+		Location:        location,
+		IsRemote:        job.Location.IsRemote,
+		JobType:         job.JobType,
+		ExperienceLevel: job.ExperienceLevel,
+		PublishedAt:     publishedAt,
+	})
 	if err != nil {
-		log.Printf("[JOB NOTIFICATION ERROR] Failed to send job posted notification email to %s: %v", companyEmail, err)
+		logger.FromContext(ctx).Error("failed to send job posted email", "job_id", jobID, "to", companyEmail, "error", err)
 		// Don't fail the job creation if email fails
 	} else {
-		log.Printf("[JOB NOTIFICATION SUCCESS] Job posted notification email sent to %s for job #%d", companyEmail, jobID)
+		logger.FromContext(ctx).Info("job posted email sent", "job_id", jobID, "to", companyEmail)
 	}
 }
 
@@ -787,7 +777,7 @@ func (s *service) TrackView(ctx context.Context, jobID, userID uint64) (bool, er
 	// If this is a new view, increment the counter
 	if isNewView {
 		if err := s.repo.IncrementViewCount(ctx, jobID); err != nil {
-			log.Printf("[VIEW TRACKING] Failed to increment view count for job %d: %v", jobID, err)
+			logger.FromContext(ctx).Error("failed to increment job view count", "job_id", jobID, "error", err)
 			// Don't fail the request, just log the error
 		}
 	}
@@ -813,7 +803,7 @@ func (s *service) TrackShare(ctx context.Context, jobID uint64, userID *uint64,
 
 	// Increment share count
 	if err := s.repo.IncrementShareCount(ctx, jobID); err != nil {
-		log.Printf("[SHARE TRACKING] Failed to increment share count for job %d: %v", jobID, err)
+		logger.FromContext(ctx).Error("failed to increment job share count", "job_id", jobID, "error", err)
 		// Don't fail the request
 	}
 
@@ -846,3 +836,37 @@ func (s *service) GetJobStats(ctx context.Context, jobID, companyID uint64) (*Jo
 
 	return stats, nil
 }
+
+// GetScreeningQuestions retrieves a job's screening questions including knockout rules
+func (s *service) GetScreeningQuestions(ctx context.Context, jobID uint64) ([]ScreeningQuestion, error) {
+	questions, err := s.repo.GetScreeningQuestions(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get screening questions", err)
+	}
+	return questions, nil
+}
+
+// GetScreeningQuestionsForCompany retrieves a job's screening questions with knockout rules for its company
+func (s *service) GetScreeningQuestionsForCompany(ctx context.Context, jobID, companyID uint64) ([]*ScreeningQuestionResponse, error) {
+	job, err := s.repo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get job", err)
+	}
+	if job == nil {
+		return nil, apperrors.NewNotFoundError("Job")
+	}
+	if job.CompanyID != companyID {
+		return nil, apperrors.NewForbiddenError("Access denied")
+	}
+
+	questions, err := s.GetScreeningQuestions(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*ScreeningQuestionResponse, len(questions))
+	for i := range questions {
+		responses[i] = questions[i].ToResponse(true)
+	}
+	return responses, nil
+}
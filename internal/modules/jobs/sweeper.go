@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// sweepBatchSize limits how many jobs are closed per sweep
+const sweepBatchSize = 200
+
+// Sweeper periodically closes jobs whose application deadline has passed
+// or that reached their maximum number of applications
+type Sweeper struct {
+	service  Service
+	interval time.Duration
+}
+
+// NewSweeper creates a new job sweeper
+func NewSweeper(service Service, interval time.Duration) *Sweeper {
+	return &Sweeper{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is cancelled
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// sweep closes expired jobs in batches until none are left
+func (s *Sweeper) sweep(ctx context.Context) {
+	total := 0
+	for {
+		closed, err := s.service.CloseExpiredJobs(ctx, sweepBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("job sweep failed", "error", err)
+			return
+		}
+		total += closed
+		if closed < sweepBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.FromContext(ctx).Info("job sweep closed jobs", "count", total)
+	}
+}
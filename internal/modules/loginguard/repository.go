@@ -0,0 +1,176 @@
+package loginguard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the login guard repository interface
+type Repository interface {
+	Get(ctx context.Context, scope, keyType, keyValue string) (*Throttle, error)
+	// Increment counts one failure for a key and locks it when limit is reached, atomically.
+	// It returns the updated throttle and whether this failure locked the key.
+	Increment(ctx context.Context, scope, keyType, keyValue string, limit int, policy Policy, now time.Time) (*Throttle, bool, error)
+	Delete(ctx context.Context, scope, keyType, keyValue string) error
+
+	// Admin
+	ListLocked(ctx context.Context, now time.Time, search string) ([]*Throttle, error)
+	Unlock(ctx context.Context, id, adminID uint64) (bool, error)
+
+	// GetAccountName returns the full name of the user with email, or "" if there is none
+	GetAccountName(ctx context.Context, email string) (string, error)
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new login guard repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+// Get retrieves the throttle for a key
+func (r *mysqlRepository) Get(ctx context.Context, scope, keyType, keyValue string) (*Throttle, error) {
+	query := `
+		SELECT id, scope, key_type, key_value, failed_count, last_failed_at, locked_until,
+		       lockout_count, unlocked_by, unlocked_at, created_at, updated_at
+		FROM auth_throttles
+		WHERE scope = ? AND key_type = ? AND key_value = ?
+	`
+
+	var throttle Throttle
+	if err := r.db.GetContext(ctx, &throttle, query, scope, keyType, keyValue); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get auth throttle: %w", err)
+	}
+
+	return &throttle, nil
+}
+
+// Increment counts one failure in SQL, so concurrent failures are never lost, and reads the
+// count back under the row lock of the same transaction to decide on a lockout. Failures
+// older than the policy window and lockouts older than the longest lock are forgotten first.
+func (r *mysqlRepository) Increment(ctx context.Context, scope, keyType, keyValue string, limit int, policy Policy, now time.Time) (*Throttle, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// last_failed_at is assigned last: the conditions before it read the previous failure
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth_throttles (scope, key_type, key_value, failed_count, last_failed_at, lockout_count, created_at, updated_at)
+		VALUES (?, ?, ?, 1, ?, 0, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE
+			lockout_count = IF(last_failed_at IS NULL OR last_failed_at < ?, 0, lockout_count),
+			failed_count = IF(last_failed_at IS NULL OR last_failed_at < ?, 1, failed_count + 1),
+			last_failed_at = VALUES(last_failed_at),
+			updated_at = NOW()
+	`, scope, keyType, keyValue, now, now.Add(-policy.MaxLockDuration), now.Add(-policy.Window))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to count auth failure: %w", err)
+	}
+
+	var throttle Throttle
+	if err := tx.GetContext(ctx, &throttle, `
+		SELECT id, scope, key_type, key_value, failed_count, last_failed_at, locked_until,
+		       lockout_count, unlocked_by, unlocked_at, created_at, updated_at
+		FROM auth_throttles
+		WHERE scope = ? AND key_type = ? AND key_value = ?
+		FOR UPDATE
+	`, scope, keyType, keyValue); err != nil {
+		return nil, false, fmt.Errorf("failed to get auth throttle: %w", err)
+	}
+
+	locked := false
+	if limit > 0 && throttle.FailedCount >= limit {
+		throttle.LockedUntil = sql.NullTime{Time: now.Add(policy.lockDuration(throttle.LockoutCount)), Valid: true}
+		throttle.LockoutCount++
+		throttle.FailedCount = 0
+		locked = true
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE auth_throttles
+			SET locked_until = ?, lockout_count = ?, failed_count = 0, updated_at = NOW()
+			WHERE id = ?
+		`, throttle.LockedUntil, throttle.LockoutCount, throttle.ID); err != nil {
+			return nil, false, fmt.Errorf("failed to lock auth throttle: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &throttle, locked, nil
+}
+
+// Delete forgets the failures for a key
+func (r *mysqlRepository) Delete(ctx context.Context, scope, keyType, keyValue string) error {
+	query := `DELETE FROM auth_throttles WHERE scope = ? AND key_type = ? AND key_value = ?`
+	if _, err := r.db.ExecContext(ctx, query, scope, keyType, keyValue); err != nil {
+		return fmt.Errorf("failed to delete auth throttle: %w", err)
+	}
+	return nil
+}
+
+// ListLocked lists keys that are locked at now, optionally filtered by key value
+func (r *mysqlRepository) ListLocked(ctx context.Context, now time.Time, search string) ([]*Throttle, error) {
+	query := `
+		SELECT id, scope, key_type, key_value, failed_count, last_failed_at, locked_until,
+		       lockout_count, unlocked_by, unlocked_at, created_at, updated_at
+		FROM auth_throttles
+		WHERE locked_until > ?
+	`
+	args := []interface{}{now}
+	if search != "" {
+		query += " AND key_value LIKE ?"
+		args = append(args, "%"+search+"%")
+	}
+	query += " ORDER BY locked_until DESC LIMIT 200"
+
+	var throttles []*Throttle
+	if err := r.db.SelectContext(ctx, &throttles, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list lockouts: %w", err)
+	}
+	return throttles, nil
+}
+
+// Unlock lifts a lockout and clears its failures. Returns false if the throttle does not exist.
+func (r *mysqlRepository) Unlock(ctx context.Context, id, adminID uint64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE auth_throttles
+		SET failed_count = 0, locked_until = NULL, lockout_count = 0,
+			unlocked_by = ?, unlocked_at = NOW(), updated_at = NOW()
+		WHERE id = ?
+	`, adminID, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlock: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetAccountName returns the full name of the user with email
+func (r *mysqlRepository) GetAccountName(ctx context.Context, email string) (string, error) {
+	var fullName string
+	err := r.db.GetContext(ctx, &fullName, `SELECT full_name FROM users WHERE email = ? LIMIT 1`, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get account name: %w", err)
+	}
+	return fullName, nil
+}
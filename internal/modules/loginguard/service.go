@@ -0,0 +1,253 @@
+package loginguard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/clientip"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// EmailSender sends the lockout notification
+type EmailSender interface {
+	SendAccountLockedEmail(to, fullName string, lockedUntil time.Time) error
+}
+
+// Service defines the login guard service interface.
+// The client IP is read from the context (see clientip.Resolver).
+type Service interface {
+	// Check returns a 429 error while the account or IP must wait or is locked
+	Check(ctx context.Context, scope, account string) error
+	// RecordFailure counts a failed attempt for the account and IP
+	RecordFailure(ctx context.Context, scope, account string)
+	// RecordSuccess forgets the account's failures
+	RecordSuccess(ctx context.Context, scope, account string)
+	// Attempt checks and counts a request that is limited whether or not it succeeds
+	Attempt(ctx context.Context, scope, account string) error
+
+	// Admin
+	ListLockouts(ctx context.Context, search string) ([]*LockoutResponse, error)
+	Unlock(ctx context.Context, id, adminID uint64) error
+}
+
+// service implements Service
+type service struct {
+	repo        Repository
+	emailSender EmailSender
+	now         func() time.Time
+}
+
+// NewService creates a new login guard service
+func NewService(repo Repository, emailSender EmailSender) Service {
+	return &service{
+		repo:        repo,
+		emailSender: emailSender,
+		now:         time.Now,
+	}
+}
+
+// NewServiceWithClock creates a new login guard service with a custom clock (used by tests)
+func NewServiceWithClock(repo Repository, emailSender EmailSender, now func() time.Time) Service {
+	return &service{
+		repo:        repo,
+		emailSender: emailSender,
+		now:         now,
+	}
+}
+
+// Check returns a 429 error while the account or IP must wait or is locked
+func (s *service) Check(ctx context.Context, scope, account string) error {
+	policy := Policies[scope]
+	now := s.now()
+
+	if ip := clientip.FromContext(ctx); ip != "" {
+		throttle, err := s.repo.Get(ctx, scope, KeyIP, ip)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to check login attempts", err)
+		}
+		if throttle != nil && throttle.IsLocked(now) {
+			wait := throttle.LockedUntil.Time.Sub(now)
+			return tooManyAttempts(fmt.Sprintf("Terlalu banyak percobaan dari alamat IP Anda. Coba lagi dalam %s.", formatWait(wait)), wait)
+		}
+	}
+
+	if account = normalizeAccount(account); account == "" {
+		return nil
+	}
+	throttle, err := s.repo.Get(ctx, scope, KeyAccount, account)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to check login attempts", err)
+	}
+	if throttle == nil {
+		return nil
+	}
+
+	if throttle.IsLocked(now) {
+		wait := throttle.LockedUntil.Time.Sub(now)
+		return tooManyAttempts(fmt.Sprintf("Akun dikunci sementara karena terlalu banyak percobaan gagal. Coba lagi dalam %s atau hubungi admin.", formatWait(wait)), wait)
+	}
+	if wait := policy.delayRemaining(throttle, now); wait > 0 {
+		return tooManyAttempts(fmt.Sprintf("Terlalu banyak percobaan. Coba lagi dalam %s.", formatWait(wait)), wait)
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt for the account and IP. Errors are logged, not returned,
+// so the caller's own error (usually invalid credentials) is what the client sees.
+func (s *service) RecordFailure(ctx context.Context, scope, account string) {
+	policy := Policies[scope]
+	now := s.now()
+
+	if account = normalizeAccount(account); account != "" {
+		throttle, locked, err := s.repo.Increment(ctx, scope, KeyAccount, account, policy.AccountLimit, policy, now)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to record failed login", "account", account, "error", err)
+		} else if locked {
+			logger.FromContext(ctx).Warn("account locked", "account", account, "scope", scope, "locked_until", throttle.LockedUntil.Time)
+			if policy.NotifyLockout {
+				s.notifyLockout(ctx, account, throttle.LockedUntil.Time)
+			}
+		}
+	}
+
+	if ip := clientip.FromContext(ctx); ip != "" {
+		throttle, locked, err := s.repo.Increment(ctx, scope, KeyIP, ip, policy.IPLimit, policy, now)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to record failed login", "ip", ip, "error", err)
+		} else if locked {
+			logger.FromContext(ctx).Warn("IP locked", "ip", ip, "scope", scope, "locked_until", throttle.LockedUntil.Time)
+		}
+	}
+}
+
+// RecordSuccess forgets the account's failures. IP failures are kept, so one valid
+// account cannot be used to reset the counter while guessing others.
+func (s *service) RecordSuccess(ctx context.Context, scope, account string) {
+	if account = normalizeAccount(account); account == "" {
+		return
+	}
+	if err := s.repo.Delete(ctx, scope, KeyAccount, account); err != nil {
+		logger.FromContext(ctx).Error("failed to reset failed logins", "account", account, "error", err)
+	}
+}
+
+// Attempt checks and counts a request that is limited whether or not it succeeds
+func (s *service) Attempt(ctx context.Context, scope, account string) error {
+	if err := s.Check(ctx, scope, account); err != nil {
+		return err
+	}
+	s.RecordFailure(ctx, scope, account)
+	return nil
+}
+
+// ListLockouts lists active lockouts, optionally filtered by email or IP
+func (s *service) ListLockouts(ctx context.Context, search string) ([]*LockoutResponse, error) {
+	throttles, err := s.repo.ListLocked(ctx, s.now(), strings.TrimSpace(search))
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list lockouts", err)
+	}
+
+	lockouts := make([]*LockoutResponse, 0, len(throttles))
+	for _, throttle := range throttles {
+		lockouts = append(lockouts, throttle.ToResponse())
+	}
+	return lockouts, nil
+}
+
+// Unlock lifts a lockout
+func (s *service) Unlock(ctx context.Context, id, adminID uint64) error {
+	found, err := s.repo.Unlock(ctx, id, adminID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to unlock", err)
+	}
+	if !found {
+		return apperrors.NewNotFoundError("Lockout")
+	}
+
+	logger.FromContext(ctx).Info("lockout lifted", "lockout_id", id, "admin_id", adminID)
+	return nil
+}
+
+// notifyLockout emails the account owner, if the account exists
+func (s *service) notifyLockout(ctx context.Context, account string, lockedUntil time.Time) {
+	if s.emailSender == nil {
+		return
+	}
+
+	fullName, err := s.repo.GetAccountName(ctx, account)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to look up locked account", "account", account, "error", err)
+		return
+	}
+	if fullName == "" {
+		return
+	}
+
+	if err := s.emailSender.SendAccountLockedEmail(account, fullName, lockedUntil); err != nil {
+		logger.FromContext(ctx).Error("failed to send lockout email", "account", account, "error", err)
+	}
+}
+
+// delayRemaining returns how long the account must still wait before the next attempt
+func (p Policy) delayRemaining(throttle *Throttle, now time.Time) time.Duration {
+	if !throttle.LastFailedAt.Valid || throttle.FailedCount < p.FreeAttempts {
+		return 0
+	}
+	if now.Sub(throttle.LastFailedAt.Time) > p.Window {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < throttle.FailedCount && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return throttle.LastFailedAt.Time.Add(delay).Sub(now)
+}
+
+// lockDuration returns the lock duration after previous consecutive lockouts
+func (p Policy) lockDuration(previous int) time.Duration {
+	d := p.LockDuration
+	for i := 0; i < previous && d < p.MaxLockDuration; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockDuration {
+		d = p.MaxLockDuration
+	}
+	return d
+}
+
+// normalizeAccount lowercases and trims an email so variants share one counter
+func normalizeAccount(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
+
+// tooManyAttempts builds the 429 error, with the wait in seconds under details.retry_after
+func tooManyAttempts(message string, wait time.Duration) error {
+	seconds := int((wait + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	appErr := apperrors.NewTooManyRequestsError(message)
+	appErr.Details = map[string]string{"retry_after": strconv.Itoa(seconds)}
+	return appErr
+}
+
+// formatWait formats a wait for messages, in seconds below a minute and minutes above
+func formatWait(wait time.Duration) string {
+	if wait < time.Minute {
+		seconds := int((wait + time.Second - 1) / time.Second)
+		if seconds < 1 {
+			seconds = 1
+		}
+		return fmt.Sprintf("%d detik", seconds)
+	}
+	return fmt.Sprintf("%d menit", int((wait+time.Minute-1)/time.Minute))
+}
@@ -0,0 +1,135 @@
+package mfa
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/token"
+)
+
+// Portals that can issue MFA challenges. A challenge can only be completed on the portal that issued it.
+const (
+	PortalAuth    = token.PortalApp
+	PortalAdmin   = token.PortalAdmin
+	PortalPartner = token.PortalPartner
+)
+
+// Challenge purposes
+const (
+	// PurposeVerify asks an enrolled user for a code
+	PurposeVerify = "verify"
+	// PurposeEnroll lets a user who must use MFA enroll before the first login
+	PurposeEnroll = "enroll"
+)
+
+// Limits
+const (
+	ChallengeExpiry    = 5 * time.Minute
+	RecoveryCodeCount  = 10
+	MaxFailedAttempts  = 5
+	FailedAttemptReset = 15 * time.Minute
+
+	// allowedSkew is the number of 30 second steps accepted either side of now
+	allowedSkew = 1
+
+	// issuer is shown in authenticator apps
+	issuer = "Karir Nusantara"
+)
+
+// EncryptionKeys are the keys TOTP secrets are sealed with. They are kept apart from the
+// token signing secret so either can be rotated without touching the other.
+type EncryptionKeys struct {
+	// KeyID identifies Key and is stored next to every secret sealed with it
+	KeyID string
+	Key   string
+	// Previous are retired keys by ID. Secrets sealed with them can still be opened
+	// and are sealed again with Key the next time they are used.
+	Previous map[string]string
+}
+
+// Enrollment represents a user's TOTP enrollment
+type Enrollment struct {
+	UserID          uint64       `db:"user_id"`
+	SecretEncrypted string       `db:"secret_encrypted"`
+	SecretKeyID     string       `db:"secret_key_id"`
+	EnabledAt       sql.NullTime `db:"enabled_at"`
+	LastUsedStep    int64        `db:"last_used_step"`
+	FailedAttempts  int          `db:"failed_attempts"`
+	LastFailedAt    sql.NullTime `db:"last_failed_at"`
+	CreatedAt       time.Time    `db:"created_at"`
+	UpdatedAt       time.Time    `db:"updated_at"`
+}
+
+// IsEnabled reports whether enrollment was confirmed
+func (e *Enrollment) IsEnabled() bool {
+	return e != nil && e.EnabledAt.Valid
+}
+
+// IsLocked reports whether too many wrong codes were entered recently
+func (e *Enrollment) IsLocked(now time.Time) bool {
+	return e.FailedAttempts >= MaxFailedAttempts &&
+		e.LastFailedAt.Valid && now.Sub(e.LastFailedAt.Time) < FailedAttemptReset
+}
+
+// RecoveryCode represents a hashed single-use recovery code
+type RecoveryCode struct {
+	ID        uint64       `db:"id"`
+	UserID    uint64       `db:"user_id"`
+	CodeHash  string       `db:"code_hash"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+// ChallengeClaims are the claims of a short-lived MFA challenge token
+type ChallengeClaims struct {
+	UserID  uint64
+	Portal  token.Portal
+	Purpose string
+}
+
+// Request DTOs
+
+// CodeRequest carries an authenticator or recovery code
+type CodeRequest struct {
+	Code string `json:"code" validate:"required,min=6,max=20"`
+}
+
+// ChallengeRequest completes an MFA challenge issued at login
+type ChallengeRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,min=6,max=20"`
+}
+
+// ChallengeSetupRequest starts enrollment with an enrollment challenge
+type ChallengeSetupRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+// Response DTOs
+
+// StatusResponse describes a user's MFA state
+type StatusResponse struct {
+	Enabled                bool    `json:"enabled"`
+	EnabledAt              *string `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int     `json:"recovery_codes_remaining"`
+}
+
+// SetupResponse contains what an authenticator app needs to enroll
+type SetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	Issuer          string `json:"issuer"`
+	Account         string `json:"account"`
+}
+
+// RecoveryCodesResponse contains freshly generated recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Challenge is returned by a login that needs a second step
+type Challenge struct {
+	Token     string `json:"mfa_token"`
+	Purpose   string `json:"purpose"`
+	ExpiresIn int64  `json:"expires_in"`
+}
@@ -0,0 +1,161 @@
+package mfa
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles MFA self-service HTTP requests
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new MFA handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetStatus handles getting the current user's MFA status
+// GET .../mfa
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.GetStatus(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "MFA status retrieved", status)
+}
+
+// Setup handles starting enrollment
+// POST .../mfa/setup
+func (h *Handler) Setup(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	email, _ := r.Context().Value("user_email").(string)
+	setup, err := h.service.Setup(r.Context(), userID, email)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Pindai kode QR dengan aplikasi autentikator, lalu masukkan kode untuk mengaktifkan", setup)
+}
+
+// Enable handles confirming enrollment
+// POST .../mfa/enable
+func (h *Handler) Enable(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CodeRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	codes, err := h.service.Enable(r.Context(), userID, req.Code)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Autentikasi dua faktor diaktifkan. Simpan kode pemulihan di tempat yang aman.", codes)
+}
+
+// Disable handles turning MFA off
+// POST .../mfa/disable
+func (h *Handler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CodeRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	if err := h.service.Disable(r.Context(), userID, req.Code); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Autentikasi dua faktor dinonaktifkan", nil)
+}
+
+// RegenerateRecoveryCodes handles replacing recovery codes
+// POST .../mfa/recovery-codes
+func (h *Handler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CodeRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Kode pemulihan baru dibuat. Kode lama tidak berlaku lagi.", codes)
+}
+
+// userID extracts the authenticated user ID, writing an error response if missing
+func (h *Handler) userID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	userID, ok := r.Context().Value("user_id").(uint64)
+	if !ok || userID == 0 {
+		response.Unauthorized(w, "Unauthorized")
+		return 0, false
+	}
+	return userID, true
+}
+
+// decode reads and validates a JSON body, writing an error response on failure
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return false
+	}
+	if errors := h.validator.Validate(req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return false
+	}
+	return true
+}
+
+// handleError handles errors and sends appropriate response
+func handleError(w http.ResponseWriter, err error) {
+	appErr := apperrors.GetAppError(err)
+	if appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An unexpected error occurred")
+}
@@ -0,0 +1,244 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the MFA repository interface
+type Repository interface {
+	GetEnrollment(ctx context.Context, userID uint64) (*Enrollment, error)
+	SaveEnrollmentSecret(ctx context.Context, userID uint64, secretEncrypted, keyID string) error
+	// RekeySecret replaces a secret sealed with oldKeyID by the same secret sealed with keyID
+	RekeySecret(ctx context.Context, userID uint64, oldKeyID, secretEncrypted, keyID string) error
+	EnableEnrollment(ctx context.Context, userID uint64, step int64, codeHashes []string) error
+	DeleteEnrollment(ctx context.Context, userID uint64) error
+
+	// UseStep records an accepted TOTP step. Returns false if the step (or a later one) was already used.
+	UseStep(ctx context.Context, userID uint64, step int64) (bool, error)
+	RecordFailedAttempt(ctx context.Context, userID uint64) error
+
+	// Recovery codes
+	ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uint64) (int, error)
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new MFA repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+// GetEnrollment retrieves a user's enrollment, confirmed or not
+func (r *mysqlRepository) GetEnrollment(ctx context.Context, userID uint64) (*Enrollment, error) {
+	query := `
+		SELECT user_id, secret_encrypted, secret_key_id, enabled_at, last_used_step, failed_attempts,
+		       last_failed_at, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = ?
+	`
+
+	var enrollment Enrollment
+	if err := r.db.GetContext(ctx, &enrollment, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get mfa enrollment: %w", err)
+	}
+
+	return &enrollment, nil
+}
+
+// SaveEnrollmentSecret starts (or restarts) an unconfirmed enrollment
+func (r *mysqlRepository) SaveEnrollmentSecret(ctx context.Context, userID uint64, secretEncrypted, keyID string) error {
+	query := `
+		INSERT INTO user_mfa (user_id, secret_encrypted, secret_key_id, enabled_at, last_used_step, failed_attempts, created_at, updated_at)
+		VALUES (?, ?, ?, NULL, 0, 0, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE
+			secret_encrypted = VALUES(secret_encrypted),
+			secret_key_id = VALUES(secret_key_id),
+			enabled_at = NULL,
+			last_used_step = 0,
+			failed_attempts = 0,
+			last_failed_at = NULL,
+			updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, userID, secretEncrypted, keyID); err != nil {
+		return fmt.Errorf("failed to save mfa secret: %w", err)
+	}
+	return nil
+}
+
+// RekeySecret stores a secret sealed with a new key. Nothing changes if the secret was
+// replaced or rekeyed concurrently.
+func (r *mysqlRepository) RekeySecret(ctx context.Context, userID uint64, oldKeyID, secretEncrypted, keyID string) error {
+	query := `
+		UPDATE user_mfa
+		SET secret_encrypted = ?, secret_key_id = ?, updated_at = NOW()
+		WHERE user_id = ? AND secret_key_id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, secretEncrypted, keyID, userID, oldKeyID); err != nil {
+		return fmt.Errorf("failed to rekey mfa secret: %w", err)
+	}
+	return nil
+}
+
+// EnableEnrollment confirms an enrollment and stores its first recovery codes
+func (r *mysqlRepository) EnableEnrollment(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_mfa
+		SET enabled_at = NOW(), last_used_step = ?, failed_attempts = 0, last_failed_at = NULL, updated_at = NOW()
+		WHERE user_id = ?
+	`, step, userID); err != nil {
+		return fmt.Errorf("failed to enable mfa: %w", err)
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteEnrollment removes a user's enrollment and recovery codes
+func (r *mysqlRepository) DeleteEnrollment(ctx context.Context, userID uint64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete mfa enrollment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UseStep records an accepted TOTP step and resets failed attempts
+func (r *mysqlRepository) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE user_mfa
+		SET last_used_step = ?, failed_attempts = 0, last_failed_at = NULL, updated_at = NOW()
+		WHERE user_id = ? AND last_used_step < ?
+	`, step, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to record mfa step: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// RecordFailedAttempt counts a wrong code. The counter restarts once the previous failures are old.
+func (r *mysqlRepository) RecordFailedAttempt(ctx context.Context, userID uint64) error {
+	query := `
+		UPDATE user_mfa
+		SET failed_attempts = IF(last_failed_at IS NULL OR last_failed_at < NOW() - INTERVAL ? SECOND, 1, failed_attempts + 1),
+			last_failed_at = NOW(),
+			updated_at = NOW()
+		WHERE user_id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, int(FailedAttemptReset.Seconds()), userID); err != nil {
+		return fmt.Errorf("failed to record failed mfa attempt: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes invalidates all recovery codes of a user and stores new ones
+func (r *mysqlRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, codeHashes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes an unused recovery code. Returns false if no such code exists.
+func (r *mysqlRepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE user_mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+		LIMIT 1
+	`, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if _, err := r.db.ExecContext(ctx, `
+		UPDATE user_mfa SET failed_attempts = 0, last_failed_at = NULL, updated_at = NOW() WHERE user_id = ?
+	`, userID); err != nil {
+		return false, fmt.Errorf("failed to reset failed mfa attempts: %w", err)
+	}
+	return true, nil
+}
+
+// CountUnusedRecoveryCodes counts the recovery codes a user can still use
+func (r *mysqlRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uint64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM user_mfa_recovery_codes WHERE user_id = ? AND used_at IS NULL`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// replaceRecoveryCodes deletes a user's recovery codes and inserts new ones inside tx
+func replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, userID uint64, codeHashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_mfa_recovery_codes (user_id, code_hash, created_at) VALUES (?, ?, NOW())
+		`, userID, hash); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+	return nil
+}
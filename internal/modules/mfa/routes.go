@@ -0,0 +1,15 @@
+package mfa
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+// Routes registers the MFA self-service routes on an authenticated router.
+// Each portal mounts them behind its own authentication middleware.
+func (h *Handler) Routes(r chi.Router) {
+	r.Get("/", h.GetStatus)
+	r.Post("/setup", h.Setup)
+	r.Post("/enable", h.Enable)
+	r.Post("/disable", h.Disable)
+	r.Post("/recovery-codes", h.RegenerateRecoveryCodes)
+}
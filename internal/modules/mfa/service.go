@@ -0,0 +1,443 @@
+package mfa
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/token"
+	"github.com/karirnusantara/api/internal/shared/totp"
+)
+
+// Service defines the MFA service interface
+type Service interface {
+	// Self-service enrollment for a signed-in user
+	GetStatus(ctx context.Context, userID uint64) (*StatusResponse, error)
+	Setup(ctx context.Context, userID uint64, account string) (*SetupResponse, error)
+	Enable(ctx context.Context, userID uint64, code string) (*RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uint64, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint64, code string) (*RecoveryCodesResponse, error)
+
+	// VerifyCode checks an authenticator code or consumes a recovery code
+	VerifyCode(ctx context.Context, userID uint64, code string) error
+
+	// Login challenges
+	BeginLogin(ctx context.Context, userID uint64, account string, portal token.Portal, required bool) (*Challenge, error)
+	SetupChallenge(ctx context.Context, challengeToken string, portal token.Portal) (*SetupResponse, error)
+	CompleteChallenge(ctx context.Context, req *ChallengeRequest, portal token.Portal) (uint64, []string, error)
+}
+
+// service implements Service
+type service struct {
+	repo   Repository
+	tokens *token.Manager
+	keys   EncryptionKeys
+	now    func() time.Time
+}
+
+// NewService creates a new MFA service. tokens signs challenge tokens; keys encrypt TOTP secrets.
+func NewService(repo Repository, tokens *token.Manager, keys EncryptionKeys) Service {
+	return &service{
+		repo:   repo,
+		tokens: tokens,
+		keys:   keys,
+		now:    time.Now,
+	}
+}
+
+// NewServiceWithClock creates a new MFA service with a custom clock (used by tests)
+func NewServiceWithClock(repo Repository, tokens *token.Manager, keys EncryptionKeys, now func() time.Time) Service {
+	return &service{
+		repo:   repo,
+		tokens: tokens,
+		keys:   keys,
+		now:    now,
+	}
+}
+
+// GetStatus returns whether MFA is enabled for a user
+func (s *service) GetStatus(ctx context.Context, userID uint64) (*StatusResponse, error) {
+	enrollment, err := s.repo.GetEnrollment(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get MFA status", err)
+	}
+
+	resp := &StatusResponse{Enabled: enrollment.IsEnabled()}
+	if !resp.Enabled {
+		return resp, nil
+	}
+
+	enabledAt := enrollment.EnabledAt.Time.Format(time.RFC3339)
+	resp.EnabledAt = &enabledAt
+	resp.RecoveryCodesRemaining, err = s.repo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to count recovery codes", err)
+	}
+	return resp, nil
+}
+
+// Setup generates a new secret for a user who has not enabled MFA yet
+func (s *service) Setup(ctx context.Context, userID uint64, account string) (*SetupResponse, error) {
+	enrollment, err := s.repo.GetEnrollment(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get MFA status", err)
+	}
+	if enrollment.IsEnabled() {
+		return nil, apperrors.NewConflictError("Autentikasi dua faktor sudah aktif")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to generate MFA secret", err)
+	}
+	encrypted, err := s.encrypt(secret)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to encrypt MFA secret", err)
+	}
+	if err := s.repo.SaveEnrollmentSecret(ctx, userID, encrypted, s.keys.KeyID); err != nil {
+		return nil, apperrors.NewInternalError("Failed to save MFA secret", err)
+	}
+
+	return &SetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(issuer, account, secret),
+		Issuer:          issuer,
+		Account:         account,
+	}, nil
+}
+
+// Enable confirms enrollment with a first code and returns the recovery codes
+func (s *service) Enable(ctx context.Context, userID uint64, code string) (*RecoveryCodesResponse, error) {
+	enrollment, err := s.repo.GetEnrollment(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get MFA status", err)
+	}
+	if enrollment == nil {
+		return nil, apperrors.NewBadRequestError("Mulai pengaturan autentikasi dua faktor terlebih dahulu")
+	}
+	if enrollment.IsEnabled() {
+		return nil, apperrors.NewConflictError("Autentikasi dua faktor sudah aktif")
+	}
+	if enrollment.IsLocked(s.now()) {
+		return nil, lockedError()
+	}
+
+	secret, err := s.openSecret(ctx, enrollment)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to read MFA secret", err)
+	}
+	step, ok := totp.Validate(secret, code, s.now(), allowedSkew)
+	if !ok {
+		s.recordFailure(ctx, userID)
+		return nil, invalidCodeError()
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to generate recovery codes", err)
+	}
+	if err := s.repo.EnableEnrollment(ctx, userID, step, hashes); err != nil {
+		return nil, apperrors.NewInternalError("Failed to enable MFA", err)
+	}
+
+	logger.FromContext(ctx).Info("two-factor authentication enabled", "user_id", userID)
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns MFA off after checking a current code
+func (s *service) Disable(ctx context.Context, userID uint64, code string) error {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteEnrollment(ctx, userID); err != nil {
+		return apperrors.NewInternalError("Failed to disable MFA", err)
+	}
+
+	logger.FromContext(ctx).Info("two-factor authentication disabled", "user_id", userID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes after checking a current code
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID uint64, code string) (*RecoveryCodesResponse, error) {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to generate recovery codes", err)
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, apperrors.NewInternalError("Failed to save recovery codes", err)
+	}
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyCode checks an authenticator code (each time step is accepted once) or consumes a recovery code
+func (s *service) VerifyCode(ctx context.Context, userID uint64, code string) error {
+	enrollment, err := s.repo.GetEnrollment(ctx, userID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get MFA status", err)
+	}
+	if !enrollment.IsEnabled() {
+		return apperrors.NewBadRequestError("Autentikasi dua faktor belum aktif")
+	}
+	if enrollment.IsLocked(s.now()) {
+		return lockedError()
+	}
+
+	secret, err := s.openSecret(ctx, enrollment)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to read MFA secret", err)
+	}
+
+	if step, ok := totp.Validate(secret, code, s.now(), allowedSkew); ok {
+		fresh, err := s.repo.UseStep(ctx, userID, step)
+		if err != nil {
+			return apperrors.NewInternalError("Failed to verify code", err)
+		}
+		if fresh {
+			return nil
+		}
+		// A code that was already used counts as a failure
+	} else if normalized := normalizeRecoveryCode(code); len(normalized) == recoveryCodeLength {
+		used, err := s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(normalized))
+		if err != nil {
+			return apperrors.NewInternalError("Failed to verify recovery code", err)
+		}
+		if used {
+			logger.FromContext(ctx).Info("two-factor recovery code used", "user_id", userID)
+			return nil
+		}
+	}
+
+	s.recordFailure(ctx, userID)
+	return invalidCodeError()
+}
+
+// BeginLogin returns the challenge a login must complete before tokens are issued,
+// or nil when the user has no second step. When required is set, users without MFA
+// get an enrollment challenge instead of tokens.
+func (s *service) BeginLogin(ctx context.Context, userID uint64, account string, portal token.Portal, required bool) (*Challenge, error) {
+	enrollment, err := s.repo.GetEnrollment(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get MFA status", err)
+	}
+
+	purpose := PurposeVerify
+	if !enrollment.IsEnabled() {
+		if !required {
+			return nil, nil
+		}
+		purpose = PurposeEnroll
+	}
+
+	challengeToken, err := s.tokens.IssueChallenge(portal, token.Claims{
+		UserID:  userID,
+		Email:   account,
+		Purpose: purpose,
+	}, ChallengeExpiry)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to create MFA challenge", err)
+	}
+	return &Challenge{
+		Token:     challengeToken,
+		Purpose:   purpose,
+		ExpiresIn: int64(ChallengeExpiry.Seconds()),
+	}, nil
+}
+
+// SetupChallenge starts enrollment for a user holding an enrollment challenge
+func (s *service) SetupChallenge(ctx context.Context, challengeToken string, portal token.Portal) (*SetupResponse, error) {
+	claims, account, err := s.parseChallenge(challengeToken, portal)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != PurposeEnroll {
+		return nil, apperrors.NewBadRequestError("Autentikasi dua faktor sudah aktif")
+	}
+	return s.Setup(ctx, claims.UserID, account)
+}
+
+// CompleteChallenge checks the code for a login challenge and returns the user ID to issue tokens for.
+// Enrollment challenges also enable MFA and return the new recovery codes.
+func (s *service) CompleteChallenge(ctx context.Context, req *ChallengeRequest, portal token.Portal) (uint64, []string, error) {
+	claims, _, err := s.parseChallenge(req.MFAToken, portal)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if claims.Purpose == PurposeEnroll {
+		codes, err := s.Enable(ctx, claims.UserID, req.Code)
+		if err != nil {
+			return 0, nil, err
+		}
+		return claims.UserID, codes.RecoveryCodes, nil
+	}
+
+	if err := s.VerifyCode(ctx, claims.UserID, req.Code); err != nil {
+		return 0, nil, err
+	}
+	return claims.UserID, nil, nil
+}
+
+// parseChallenge validates a challenge token issued for portal
+func (s *service) parseChallenge(challengeToken string, portal token.Portal) (*ChallengeClaims, string, error) {
+	claims, err := s.tokens.ParseChallenge(portal, challengeToken)
+	if err != nil {
+		return nil, "", apperrors.NewUnauthorizedError("Sesi verifikasi tidak valid atau sudah kedaluwarsa. Silakan login kembali.")
+	}
+
+	return &ChallengeClaims{
+		UserID:  claims.UserID,
+		Portal:  portal,
+		Purpose: claims.Purpose,
+	}, claims.Email, nil
+}
+
+// recordFailure counts a wrong code, logging instead of failing on error
+func (s *service) recordFailure(ctx context.Context, userID uint64) {
+	if err := s.repo.RecordFailedAttempt(ctx, userID); err != nil {
+		logger.FromContext(ctx).Error("failed to record failed two-factor attempt", "user_id", userID, "error", err)
+	}
+}
+
+// openSecret decrypts an enrollment's TOTP secret. A secret sealed with a previous key
+// is sealed again with the current one, so retired keys can be dropped after a while.
+func (s *service) openSecret(ctx context.Context, enrollment *Enrollment) (string, error) {
+	secret, err := s.decrypt(enrollment.SecretEncrypted, enrollment.SecretKeyID)
+	if err != nil || enrollment.SecretKeyID == s.keys.KeyID {
+		return secret, err
+	}
+
+	encrypted, err := s.encrypt(secret)
+	if err == nil {
+		err = s.repo.RekeySecret(ctx, enrollment.UserID, enrollment.SecretKeyID, encrypted, s.keys.KeyID)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to rekey two-factor secret", "user_id", enrollment.UserID, "key_id", enrollment.SecretKeyID, "error", err)
+	}
+	return secret, nil
+}
+
+// encryptionKey derives the AES-256 key for TOTP secrets sealed with keyID
+func (s *service) encryptionKey(keyID string) ([]byte, error) {
+	material := s.keys.Key
+	if keyID != s.keys.KeyID {
+		material = s.keys.Previous[keyID]
+	}
+	if material == "" {
+		return nil, fmt.Errorf("unknown mfa encryption key %q", keyID)
+	}
+
+	key := sha256.Sum256([]byte("mfa-secret:" + material))
+	return key[:], nil
+}
+
+// encrypt seals a TOTP secret with AES-GCM under the current key, returning base64(nonce || ciphertext)
+func (s *service) encrypt(plaintext string) (string, error) {
+	key, err := s.encryptionKey(s.keys.KeyID)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt opens a TOTP secret sealed by encrypt with keyID
+func (s *service) decrypt(encoded, keyID string) (string, error) {
+	key, err := s.encryptionKey(keyID)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// recoveryCodeLength is the number of characters in a recovery code, without the separator
+const recoveryCodeLength = 10
+
+// recoveryAlphabet avoids characters that are easy to confuse (0/o, 1/l/i)
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// generateRecoveryCodes returns new codes formatted as "xxxxx-xxxxx" and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = recoveryAlphabet[int(b[j])%len(recoveryAlphabet)]
+		}
+
+		raw := string(b)
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode lowercases a code and strips separators
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// hashRecoveryCode creates a SHA256 hash of a normalized recovery code
+func hashRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
+
+func invalidCodeError() error {
+	return apperrors.NewUnauthorizedError("Kode verifikasi tidak valid")
+}
+
+func lockedError() error {
+	return apperrors.NewTooManyRequestsError("Terlalu banyak kode yang salah. Silakan coba lagi dalam 15 menit.")
+}
@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/hashid"
+)
+
+// Notification types
+const (
+	TypeApplicationStatus = "application_status"
+	TypePaymentConfirmed  = "payment_confirmed"
+	TypePaymentRejected   = "payment_rejected"
+	TypePaymentReversed   = "payment_reversed"
+	TypeCompanyVerified   = "company_verified"
+	TypeCompanyRejected   = "company_rejected"
+	TypeTicketCreated     = "ticket_created"
+	TypeTicketReply       = "ticket_reply"
+	TypeChatReply         = "chat_reply"
+	TypeInterviewInvite   = "interview_invite"
+	TypeInterviewUpdate   = "interview_update"
+	TypeInterviewCancel   = "interview_cancel"
+	TypeInterviewResponse = "interview_response"
+	TypeTalentInvitation  = "talent_invitation"
+	TypeTalentResponse    = "talent_invitation_response"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        uint64         `json:"id" db:"id"`
+	UserID    uint64         `json:"user_id" db:"user_id"`
+	Type      string         `json:"type" db:"type"`
+	Title     string         `json:"title" db:"title"`
+	Message   string         `json:"message" db:"message"`
+	Data      sql.NullString `json:"data,omitempty" db:"data"`
+	IsRead    bool           `json:"is_read" db:"is_read"`
+	ReadAt    sql.NullTime   `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// ListParams represents notification list parameters
+type ListParams struct {
+	Page       int  `json:"page"`
+	PerPage    int  `json:"per_page"`
+	UnreadOnly bool `json:"unread_only"`
+}
+
+// NotificationResponse represents the notification API response
+type NotificationResponse struct {
+	ID        uint64          `json:"id"`
+	HashID    string          `json:"hash_id"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	IsRead    bool            `json:"is_read"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// UnreadCountResponse represents the unread notification counter
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// ToResponse converts Notification to NotificationResponse
+func (n *Notification) ToResponse() *NotificationResponse {
+	resp := &NotificationResponse{
+		ID:        n.ID,
+		HashID:    hashid.Encode(n.ID),
+		Type:      n.Type,
+		Title:     n.Title,
+		Message:   n.Message,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+	}
+
+	if n.Data.Valid && n.Data.String != "" {
+		resp.Data = json.RawMessage(n.Data.String)
+	}
+	if n.ReadAt.Valid {
+		resp.ReadAt = &n.ReadAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,145 @@
+package notifications
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/response"
+)
+
+// Handler handles HTTP requests for notifications
+type Handler struct {
+	service Service
+}
+
+// NewHandler creates a new notifications handler
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// parseID parses an ID which can be either a numeric ID or a hash_id
+func parseID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	if strings.HasPrefix(idStr, "kn_") {
+		return hashid.Decode(idStr)
+	}
+
+	return 0, err
+}
+
+// List handles listing notifications of the current user
+// GET /notifications?unread=true&page=1&per_page=20
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	params := ListParams{
+		Page:    1,
+		PerPage: 20,
+	}
+
+	query := r.URL.Query()
+	if p := query.Get("page"); p != "" {
+		if page, err := strconv.Atoi(p); err == nil && page > 0 {
+			params.Page = page
+		}
+	}
+	if pp := query.Get("per_page"); pp != "" {
+		if perPage, err := strconv.Atoi(pp); err == nil && perPage > 0 && perPage <= 100 {
+			params.PerPage = perPage
+		}
+	}
+	if unread := query.Get("unread"); unread != "" {
+		params.UnreadOnly, _ = strconv.ParseBool(unread)
+	}
+
+	items, total, err := h.service.List(r.Context(), userID, params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       params.Page,
+		PerPage:    params.PerPage,
+		TotalItems: total,
+		TotalPages: int((total + int64(params.PerPage) - 1) / int64(params.PerPage)),
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Notifications retrieved", items, meta)
+}
+
+// UnreadCount handles getting the unread notification counter
+// GET /notifications/unread-count
+func (h *Handler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	count, err := h.service.GetUnreadCount(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Unread count retrieved", count)
+}
+
+// MarkAsRead handles marking a single notification as read
+// PATCH /notifications/{id}/read
+func (h *Handler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid notification ID")
+		return
+	}
+
+	if err := h.service.MarkAsRead(r.Context(), id, userID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Notification marked as read", nil)
+}
+
+// MarkAllAsRead handles marking all notifications as read
+// PATCH /notifications/read-all
+func (h *Handler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	updated, err := h.service.MarkAllAsRead(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "All notifications marked as read", map[string]int64{"updated": updated})
+}
+
+// handleError handles service errors and returns appropriate HTTP response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		switch appErr.Code {
+		case apperrors.ErrCodeBadRequest:
+			response.BadRequest(w, appErr.Message)
+		case apperrors.ErrCodeValidation:
+			response.UnprocessableEntity(w, appErr.Message, appErr.Details)
+		case apperrors.ErrCodeNotFound:
+			response.NotFound(w, appErr.Message)
+		case apperrors.ErrCodeForbidden:
+			response.Forbidden(w, appErr.Message)
+		default:
+			response.InternalServerError(w, "An error occurred")
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
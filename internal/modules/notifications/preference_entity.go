@@ -0,0 +1,43 @@
+package notifications
+
+import "time"
+
+// Preference is a user's choice to receive email of one category.
+// Users without a stored preference receive every category.
+type Preference struct {
+	UserID       uint64    `db:"user_id"`
+	Category     string    `db:"category"`
+	EmailEnabled bool      `db:"email_enabled"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// PreferenceResponse represents one email category in the preferences API
+type PreferenceResponse struct {
+	Category     string `json:"category"`
+	EmailEnabled bool   `json:"email_enabled"`
+	// Locked categories are always sent and cannot be turned off
+	Locked bool `json:"locked"`
+}
+
+// PreferencesResponse represents the notification preferences of a user
+type PreferencesResponse struct {
+	Preferences []PreferenceResponse `json:"preferences"`
+}
+
+// PreferenceUpdate turns email of one category on or off
+type PreferenceUpdate struct {
+	Category     string `json:"category" validate:"required"`
+	EmailEnabled *bool  `json:"email_enabled" validate:"required"`
+}
+
+// UpdatePreferencesRequest represents the request to change notification preferences.
+// Categories that are not listed keep their current setting.
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceUpdate `json:"preferences" validate:"required,min=1,dive"`
+}
+
+// UnsubscribeResponse describes the email category an unsubscribe link turns off
+type UnsubscribeResponse struct {
+	Category     string `json:"category"`
+	EmailEnabled bool   `json:"email_enabled"`
+}
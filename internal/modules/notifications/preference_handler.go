@@ -0,0 +1,84 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// PreferenceHandler handles HTTP requests for notification preferences and unsubscribe links
+type PreferenceHandler struct {
+	service   PreferenceService
+	validator *validator.Validator
+}
+
+// NewPreferenceHandler creates a new notification preferences handler
+func NewPreferenceHandler(service PreferenceService, validator *validator.Validator) *PreferenceHandler {
+	return &PreferenceHandler{service: service, validator: validator}
+}
+
+// GetPreferences handles getting the email preferences of the current user
+// GET /me/notification-preferences
+func (h *PreferenceHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	prefs, err := h.service.GetPreferences(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Notification preferences retrieved", prefs)
+}
+
+// UpdatePreferences handles changing the email preferences of the current user
+// PUT /me/notification-preferences
+func (h *PreferenceHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	prefs, err := h.service.UpdatePreferences(r.Context(), userID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Notification preferences updated", prefs)
+}
+
+// CheckUnsubscribe handles validating an unsubscribe link for the confirmation page
+// GET /notifications/unsubscribe?token=...
+func (h *PreferenceHandler) CheckUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.CheckUnsubscribe(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Unsubscribe link is valid", result)
+}
+
+// Unsubscribe handles one-click unsubscribes from mail clients (RFC 8058) and the portal page.
+// The token is read from the query string or a form body.
+// POST /notifications/unsubscribe?token=...
+func (h *PreferenceHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Unsubscribe(r.Context(), r.FormValue("token"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Unsubscribed", result)
+}
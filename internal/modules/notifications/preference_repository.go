@@ -0,0 +1,63 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PreferenceRepository defines the notification preferences repository interface
+type PreferenceRepository interface {
+	ListByUser(ctx context.Context, userID uint64) ([]*Preference, error)
+	// SetEmailEnabled stores the given categories of a user at once; other categories are untouched
+	SetEmailEnabled(ctx context.Context, userID uint64, enabled map[string]bool) error
+}
+
+type preferenceRepository struct {
+	db *sqlx.DB
+}
+
+// NewPreferenceRepository creates a new notification preferences repository
+func NewPreferenceRepository(db *sqlx.DB) PreferenceRepository {
+	return &preferenceRepository{db: db}
+}
+
+// ListByUser returns the stored preferences of a user
+func (r *preferenceRepository) ListByUser(ctx context.Context, userID uint64) ([]*Preference, error) {
+	query := `
+		SELECT user_id, category, email_enabled, updated_at
+		FROM notification_preferences
+		WHERE user_id = ?
+	`
+
+	var prefs []*Preference
+	if err := r.db.SelectContext(ctx, &prefs, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SetEmailEnabled upserts the preferences of a user in one transaction.
+// Nothing is stored for users that no longer exist.
+func (r *preferenceRepository) SetEmailEnabled(ctx context.Context, userID uint64, enabled map[string]bool) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO notification_preferences (user_id, category, email_enabled, created_at, updated_at)
+		SELECT id, ?, ?, NOW(), NOW() FROM users WHERE id = ?
+		ON DUPLICATE KEY UPDATE email_enabled = VALUES(email_enabled), updated_at = NOW()
+	`
+	for category, on := range enabled {
+		if _, err := tx.ExecContext(ctx, query, category, on, userID); err != nil {
+			return fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,119 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/karirnusantara/api/internal/shared/email"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// PreferenceService defines the notification preferences service interface
+type PreferenceService interface {
+	GetPreferences(ctx context.Context, userID uint64) (*PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID uint64, req *UpdatePreferencesRequest) (*PreferencesResponse, error)
+	// CheckUnsubscribe validates an unsubscribe token without using it
+	CheckUnsubscribe(ctx context.Context, token string) (*UnsubscribeResponse, error)
+	// Unsubscribe turns off the email category an unsubscribe token was issued for
+	Unsubscribe(ctx context.Context, token string) (*UnsubscribeResponse, error)
+}
+
+type preferenceService struct {
+	repo   PreferenceRepository
+	secret string
+}
+
+// NewPreferenceService creates a new notification preferences service.
+// secret verifies unsubscribe tokens and must match the email service's.
+func NewPreferenceService(repo PreferenceRepository, secret string) PreferenceService {
+	return &preferenceService{repo: repo, secret: secret}
+}
+
+// GetPreferences lists every email category with the user's choice
+func (s *preferenceService) GetPreferences(ctx context.Context, userID uint64) (*PreferencesResponse, error) {
+	prefs, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(prefs))
+	for _, p := range prefs {
+		enabled[p.Category] = p.EmailEnabled
+	}
+
+	resp := &PreferencesResponse{Preferences: make([]PreferenceResponse, 0, len(email.Categories))}
+	for _, category := range email.Categories {
+		pref := PreferenceResponse{Category: category, EmailEnabled: true, Locked: !email.IsOptional(category)}
+		if on, ok := enabled[category]; ok && !pref.Locked {
+			pref.EmailEnabled = on
+		}
+		resp.Preferences = append(resp.Preferences, pref)
+	}
+
+	return resp, nil
+}
+
+// UpdatePreferences turns categories on or off. Transactional email cannot be turned off.
+func (s *preferenceService) UpdatePreferences(ctx context.Context, userID uint64, req *UpdatePreferencesRequest) (*PreferencesResponse, error) {
+	enabled := make(map[string]bool, len(req.Preferences))
+	for i, p := range req.Preferences {
+		field := fmt.Sprintf("preferences[%d].category", i)
+		switch {
+		case !email.IsCategory(p.Category):
+			return nil, apperrors.NewValidationError("Invalid notification preferences", map[string]string{
+				field: "Unknown notification category",
+			})
+		case !email.IsOptional(p.Category):
+			if !*p.EmailEnabled {
+				return nil, apperrors.NewValidationError("Invalid notification preferences", map[string]string{
+					field: "Transactional email cannot be turned off",
+				})
+			}
+			continue
+		}
+		enabled[p.Category] = *p.EmailEnabled
+	}
+
+	if len(enabled) > 0 {
+		if err := s.repo.SetEmailEnabled(ctx, userID, enabled); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
+
+// CheckUnsubscribe validates an unsubscribe token and reports whether its category is still on
+func (s *preferenceService) CheckUnsubscribe(ctx context.Context, token string) (*UnsubscribeResponse, error) {
+	userID, category, err := email.ParseUnsubscribeToken(s.secret, token)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid or expired unsubscribe link")
+	}
+
+	prefs, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &UnsubscribeResponse{Category: category, EmailEnabled: true}
+	for _, p := range prefs {
+		if p.Category == category {
+			resp.EmailEnabled = p.EmailEnabled
+		}
+	}
+	return resp, nil
+}
+
+// Unsubscribe turns off the email category an unsubscribe token was issued for
+func (s *preferenceService) Unsubscribe(ctx context.Context, token string) (*UnsubscribeResponse, error) {
+	userID, category, err := email.ParseUnsubscribeToken(s.secret, token)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid or expired unsubscribe link")
+	}
+
+	if err := s.repo.SetEmailEnabled(ctx, userID, map[string]bool{category: false}); err != nil {
+		return nil, err
+	}
+
+	return &UnsubscribeResponse{Category: category, EmailEnabled: false}, nil
+}
@@ -0,0 +1,157 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the notifications repository interface
+type Repository interface {
+	Create(ctx context.Context, n *Notification) error
+	GetByID(ctx context.Context, id uint64) (*Notification, error)
+	ListByUser(ctx context.Context, userID uint64, params ListParams) ([]*Notification, int64, error)
+	MarkAsRead(ctx context.Context, id, userID uint64) error
+	MarkAllAsRead(ctx context.Context, userID uint64) (int64, error)
+	CountUnread(ctx context.Context, userID uint64) (int64, error)
+	ListAdminUserIDs(ctx context.Context) ([]uint64, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new notifications repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create inserts a new notification
+func (r *repository) Create(ctx context.Context, n *Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, data, is_read, created_at)
+		VALUES (?, ?, ?, ?, ?, 0, NOW())
+	`
+
+	result, err := r.db.ExecContext(ctx, query, n.UserID, n.Type, n.Title, n.Message, n.Data)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get notification ID: %w", err)
+	}
+	n.ID = uint64(id)
+
+	return nil
+}
+
+// GetByID retrieves a notification by ID
+func (r *repository) GetByID(ctx context.Context, id uint64) (*Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, message, data, is_read, read_at, created_at
+		FROM notifications
+		WHERE id = ?
+	`
+
+	var n Notification
+	if err := r.db.GetContext(ctx, &n, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	return &n, nil
+}
+
+// ListByUser lists notifications for a user, newest first
+func (r *repository) ListByUser(ctx context.Context, userID uint64, params ListParams) ([]*Notification, int64, error) {
+	where := "WHERE user_id = ?"
+	if params.UnreadOnly {
+		where += " AND is_read = 0"
+	}
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM notifications " + where
+	if err := r.db.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	if total == 0 {
+		return []*Notification{}, 0, nil
+	}
+
+	query := `
+		SELECT id, user_id, type, title, message, data, is_read, read_at, created_at
+		FROM notifications
+		` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	offset := (params.Page - 1) * params.PerPage
+
+	var items []*Notification
+	if err := r.db.SelectContext(ctx, &items, query, userID, params.PerPage, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// MarkAsRead marks a single notification as read
+func (r *repository) MarkAsRead(ctx context.Context, id, userID uint64) error {
+	query := `
+		UPDATE notifications
+		SET is_read = 1, read_at = NOW()
+		WHERE id = ? AND user_id = ? AND is_read = 0
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, userID); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks all unread notifications of a user as read
+func (r *repository) MarkAllAsRead(ctx context.Context, userID uint64) (int64, error) {
+	query := `
+		UPDATE notifications
+		SET is_read = 1, read_at = NOW()
+		WHERE user_id = ? AND is_read = 0
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// CountUnread counts unread notifications of a user
+func (r *repository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = ? AND is_read = 0`
+	if err := r.db.GetContext(ctx, &count, query, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListAdminUserIDs returns IDs of all active admin users
+func (r *repository) ListAdminUserIDs(ctx context.Context) ([]uint64, error) {
+	var ids []uint64
+	query := `SELECT id FROM users WHERE role = 'admin' AND is_active = 1`
+	if err := r.db.SelectContext(ctx, &ids, query); err != nil {
+		return nil, fmt.Errorf("failed to list admin users: %w", err)
+	}
+
+	return ids, nil
+}
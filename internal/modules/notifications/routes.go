@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the notification and notification preference routes
+func RegisterRoutes(r chi.Router, h *Handler, ph *PreferenceHandler, authenticate MiddlewareFunc) {
+	r.Route("/notifications", func(r chi.Router) {
+		// Unsubscribe links in emails are signed and work without logging in
+		r.Get("/unsubscribe", ph.CheckUnsubscribe)
+		r.Post("/unsubscribe", ph.Unsubscribe)
+
+		// Available to every authenticated role
+		r.Group(func(r chi.Router) {
+			r.Use(authenticate)
+
+			r.Get("/", h.List)
+			r.Get("/unread-count", h.UnreadCount)
+			r.Patch("/read-all", h.MarkAllAsRead)
+			r.Patch("/{id}/read", h.MarkAsRead)
+		})
+	})
+
+	r.Route("/me", func(r chi.Router) {
+		r.Use(authenticate)
+
+		r.Get("/notification-preferences", ph.GetPreferences)
+		r.Put("/notification-preferences", ph.UpdatePreferences)
+	})
+}
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Service defines the notifications service interface
+type Service interface {
+	// Notify creates a notification for a single user
+	Notify(ctx context.Context, userID uint64, notifType, title, message string, data map[string]interface{}) error
+	// NotifyAdmins creates the same notification for every active admin
+	NotifyAdmins(ctx context.Context, notifType, title, message string, data map[string]interface{}) error
+
+	List(ctx context.Context, userID uint64, params ListParams) ([]*NotificationResponse, int64, error)
+	MarkAsRead(ctx context.Context, id, userID uint64) error
+	MarkAllAsRead(ctx context.Context, userID uint64) (int64, error)
+	GetUnreadCount(ctx context.Context, userID uint64) (*UnreadCountResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new notifications service
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// Notify creates a notification for a single user
+func (s *service) Notify(ctx context.Context, userID uint64, notifType, title, message string, data map[string]interface{}) error {
+	n := &Notification{
+		UserID:  userID,
+		Type:    notifType,
+		Title:   title,
+		Message: message,
+	}
+
+	if len(data) > 0 {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode notification data: %w", err)
+		}
+		n.Data = sql.NullString{String: string(raw), Valid: true}
+	}
+
+	return s.repo.Create(ctx, n)
+}
+
+// NotifyAdmins creates the same notification for every active admin
+func (s *service) NotifyAdmins(ctx context.Context, notifType, title, message string, data map[string]interface{}) error {
+	adminIDs, err := s.repo.ListAdminUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, adminID := range adminIDs {
+		if err := s.Notify(ctx, adminID, notifType, title, message, data); err != nil {
+			logger.FromContext(ctx).Error("failed to notify admin", "admin_id", adminID, "type", notifType, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// List retrieves notifications for a user
+func (s *service) List(ctx context.Context, userID uint64, params ListParams) ([]*NotificationResponse, int64, error) {
+	items, total, err := s.repo.ListByUser(ctx, userID, params)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("Failed to list notifications", err)
+	}
+
+	responses := make([]*NotificationResponse, len(items))
+	for i, item := range items {
+		responses[i] = item.ToResponse()
+	}
+
+	return responses, total, nil
+}
+
+// MarkAsRead marks a notification as read
+func (s *service) MarkAsRead(ctx context.Context, id, userID uint64) error {
+	n, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to get notification", err)
+	}
+	if n == nil || n.UserID != userID {
+		return apperrors.NewNotFoundError("Notification")
+	}
+
+	if n.IsRead {
+		return nil
+	}
+
+	if err := s.repo.MarkAsRead(ctx, id, userID); err != nil {
+		return apperrors.NewInternalError("Failed to mark notification as read", err)
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks all notifications of a user as read
+func (s *service) MarkAllAsRead(ctx context.Context, userID uint64) (int64, error) {
+	updated, err := s.repo.MarkAllAsRead(ctx, userID)
+	if err != nil {
+		return 0, apperrors.NewInternalError("Failed to mark notifications as read", err)
+	}
+
+	return updated, nil
+}
+
+// GetUnreadCount returns the number of unread notifications
+func (s *service) GetUnreadCount(ctx context.Context, userID uint64) (*UnreadCountResponse, error) {
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to count notifications", err)
+	}
+
+	return &UnreadCountResponse{UnreadCount: count}, nil
+}
@@ -3,6 +3,8 @@ package partner
 import (
 	"database/sql"
 	"time"
+
+	"github.com/karirnusantara/api/internal/modules/mfa"
 )
 
 // Partner status constants
@@ -167,12 +169,16 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required,min=6"`
 }
 
-// LoginResponse for partner login
+// LoginResponse for partner login.
+// When MFARequired is set, no tokens are issued until the MFA challenge is completed.
 type LoginResponse struct {
-	User         *PartnerUserResponse `json:"user"`
-	AccessToken  string               `json:"access_token"`
-	RefreshToken string               `json:"refresh_token"`
-	ExpiresIn    int64                `json:"expires_in"`
+	User          *PartnerUserResponse `json:"user"`
+	AccessToken   string               `json:"access_token"`
+	RefreshToken  string               `json:"refresh_token"`
+	ExpiresIn     int64                `json:"expires_in"`
+	MFARequired   bool                 `json:"mfa_required,omitempty"`
+	MFA           *mfa.Challenge       `json:"mfa,omitempty"`
+	RecoveryCodes []string             `json:"recovery_codes,omitempty"` // Only after enrolling during login
 }
 
 // PartnerUserResponse is the safe user response for partners
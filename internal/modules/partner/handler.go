@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
 	"github.com/karirnusantara/api/internal/shared/response"
 	"github.com/karirnusantara/api/internal/shared/validator"
 )
@@ -71,6 +73,34 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if authResp.MFARequired {
+		response.OK(w, "Two-factor authentication required", authResp)
+		return
+	}
+
+	response.OK(w, "Login successful", authResp)
+}
+
+// LoginMFA completes a login with an authenticator or recovery code
+// POST /api/v1/partner/auth/login/mfa
+func (h *Handler) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req mfa.ChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	authResp, err := h.service.LoginMFA(r.Context(), &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
 	response.OK(w, "Login successful", authResp)
 }
 
@@ -460,6 +490,13 @@ func getIntQuery(r *http.Request, key string, defaultVal int) int {
 }
 
 func handleError(w http.ResponseWriter, err error) {
-	// Simple error handling - in production, use proper error types
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
 	response.InternalServerError(w, err.Error())
 }
@@ -186,7 +186,7 @@ func (r *repository) GetDashboardStats(ctx context.Context, partnerID uint64) (*
 			rp.total_commission,
 			rp.available_balance,
 			rp.paid_amount,
-			(SELECT COUNT(*) FROM partner_commissions WHERE partner_id = rp.id) as total_transactions
+			(SELECT COUNT(*) FROM partner_commissions WHERE partner_id = rp.id AND status != 'cancelled') as total_transactions
 		FROM referral_partners rp
 		WHERE rp.id = ?
 	`
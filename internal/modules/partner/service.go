@@ -11,7 +11,11 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/modules/mfa"
 	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/token"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -20,9 +24,9 @@ type Service interface {
 	// Authentication
 	Register(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error)
 	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
+	LoginMFA(ctx context.Context, req *mfa.ChallengeRequest) (*LoginResponse, error)
 	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
-	ValidateAccessToken(tokenString string) (*TokenClaims, error)
 
 	// Profile
 	GetProfile(ctx context.Context, userID uint64) (*PartnerUserResponse, error)
@@ -55,22 +59,15 @@ type EmailSender interface {
 	SendPartnerPasswordResetEmail(to, name, resetLink string) error
 }
 
-// TokenClaims represents JWT claims for partner tokens
-type TokenClaims struct {
-	UserID       uint64 `json:"user_id"`
-	PartnerID    uint64 `json:"partner_id"`
-	Email        string `json:"email"`
-	Role         string `json:"role"`
-	ReferralCode string `json:"referral_code"`
-	jwt.RegisteredClaims
-}
-
 // service implements Service
 type service struct {
 	repo        Repository
 	config      *config.JWTConfig
 	baseURL     string
 	emailSender EmailSender
+	mfaService  mfa.Service
+	guard       loginguard.Service
+	tokens      *token.Manager
 }
 
 // NewService creates a new partner service
@@ -79,6 +76,7 @@ func NewService(repo Repository, cfg *config.JWTConfig, baseURL string) Service
 		repo:    repo,
 		config:  cfg,
 		baseURL: baseURL,
+		tokens:  token.NewManager(cfg),
 	}
 }
 
@@ -89,6 +87,32 @@ func NewServiceWithEmail(repo Repository, cfg *config.JWTConfig, baseURL string,
 		config:      cfg,
 		baseURL:     baseURL,
 		emailSender: emailSender,
+		tokens:      token.NewManager(cfg),
+	}
+}
+
+// NewServiceWithMFA creates a new partner service with email and two-factor authentication support
+func NewServiceWithMFA(repo Repository, cfg *config.JWTConfig, baseURL string, emailSender EmailSender, mfaSvc mfa.Service) Service {
+	return &service{
+		repo:        repo,
+		config:      cfg,
+		baseURL:     baseURL,
+		emailSender: emailSender,
+		mfaService:  mfaSvc,
+		tokens:      token.NewManager(cfg),
+	}
+}
+
+// NewServiceWithSecurity creates a new partner service with email, two-factor authentication and brute-force protection
+func NewServiceWithSecurity(repo Repository, cfg *config.JWTConfig, baseURL string, emailSender EmailSender, mfaSvc mfa.Service, guard loginguard.Service) Service {
+	return &service{
+		repo:        repo,
+		config:      cfg,
+		baseURL:     baseURL,
+		emailSender: emailSender,
+		mfaService:  mfaSvc,
+		guard:       guard,
+		tokens:      token.NewManager(cfg),
 	}
 }
 
@@ -131,11 +155,11 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*Register
 		Message: "Registration successful! Your account is pending approval. You will receive an email once your account is activated.",
 	}
 
-	// Send welcome email (async, don't fail if email fails)
+	// Queue welcome email (don't fail if email fails)
 	if s.emailSender != nil {
-		go func() {
-			_ = s.emailSender.SendPartnerWelcomeEmail(req.Email, req.FullName, referralCode)
-		}()
+		if err := s.emailSender.SendPartnerWelcomeEmail(req.Email, req.FullName, referralCode); err != nil {
+			logger.FromContext(ctx).Error("failed to send partner welcome email", "user_id", userID, "to", req.Email, "error", err)
+		}
 	}
 
 	_ = partnerID // Used for future reference
@@ -145,6 +169,13 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*Register
 
 // ForgotPassword sends password reset email
 func (s *service) ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error {
+	// Limit requests per email and IP, whether or not the email is registered
+	if s.guard != nil {
+		if err := s.guard.Attempt(ctx, loginguard.ScopePasswordReset, req.Email); err != nil {
+			return err
+		}
+	}
+
 	// Check if user exists
 	partnerUser, err := s.repo.GetPartnerUserByEmail(ctx, req.Email)
 	if err != nil {
@@ -164,12 +195,12 @@ func (s *service) ForgotPassword(ctx context.Context, req *ForgotPasswordRequest
 		return apperrors.NewInternalError("Failed to create reset token", err)
 	}
 
-	// Send reset email
+	// Queue reset email
 	if s.emailSender != nil {
 		resetLink := fmt.Sprintf("%s/reset-password?token=%s", s.baseURL, token)
-		go func() {
-			_ = s.emailSender.SendPartnerPasswordResetEmail(req.Email, partnerUser.FullName, resetLink)
-		}()
+		if err := s.emailSender.SendPartnerPasswordResetEmail(req.Email, partnerUser.FullName, resetLink); err != nil {
+			logger.FromContext(ctx).Error("failed to send partner password reset email", "user_id", partnerUser.ID, "error", err)
+		}
 	}
 
 	return nil
@@ -233,27 +264,25 @@ func (s *service) generateResetToken() string {
 
 // Login authenticates a partner
 func (s *service) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	// Reject while the account or IP is throttled
+	if s.guard != nil {
+		if err := s.guard.Check(ctx, loginguard.ScopeLogin, req.Email); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get partner user by email
 	partnerUser, err := s.repo.GetPartnerUserByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to get user", err)
 	}
 	if partnerUser == nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, apperrors.NewInvalidCredentialsError()
 	}
 
-	// Check if user is active
-	if !partnerUser.IsActive {
-		return nil, apperrors.NewForbiddenError("Account is deactivated")
-	}
-
-	// Check partner status
-	if partnerUser.PartnerStatus == StatusSuspended {
-		return nil, apperrors.NewForbiddenError("Partner account is suspended")
-	}
-
-	if partnerUser.PartnerStatus == StatusPending {
-		return nil, apperrors.NewForbiddenError("Partner account is pending approval")
+	if err := checkPartnerAccess(partnerUser); err != nil {
+		return nil, err
 	}
 
 	// Get password hash and verify
@@ -263,10 +292,89 @@ func (s *service) Login(ctx context.Context, req *LoginRequest) (*LoginResponse,
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password)); err != nil {
+		s.recordLoginFailure(ctx, req.Email)
 		return nil, apperrors.NewInvalidCredentialsError()
 	}
+	if s.guard != nil {
+		s.guard.RecordSuccess(ctx, loginguard.ScopeLogin, req.Email)
+	}
+
+	// Ask for the second factor before issuing tokens
+	if s.mfaService != nil {
+		challenge, err := s.mfaService.BeginLogin(ctx, partnerUser.ID, partnerUser.Email, mfa.PortalPartner, false)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			return &LoginResponse{MFARequired: true, MFA: challenge}, nil
+		}
+	}
+
+	return s.issueLoginResponse(partnerUser)
+}
+
+// recordLoginFailure counts a failed password attempt when brute-force protection is enabled
+func (s *service) recordLoginFailure(ctx context.Context, email string) {
+	if s.guard != nil {
+		s.guard.RecordFailure(ctx, loginguard.ScopeLogin, email)
+	}
+}
+
+// LoginMFA finishes a login that returned an MFA challenge
+func (s *service) LoginMFA(ctx context.Context, req *mfa.ChallengeRequest) (*LoginResponse, error) {
+	if s.mfaService == nil {
+		return nil, apperrors.NewBadRequestError("Two-factor authentication is not available")
+	}
 
-	// Generate tokens
+	userID, recoveryCodes, err := s.mfaService.CompleteChallenge(ctx, req, mfa.PortalPartner)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := s.repo.GetUserEmailByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get user", err)
+	}
+	partnerUser, err := s.repo.GetPartnerUserByEmail(ctx, email)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get user", err)
+	}
+	if partnerUser == nil {
+		return nil, apperrors.NewUnauthorizedError("User not found or inactive")
+	}
+	if err := checkPartnerAccess(partnerUser); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.issueLoginResponse(partnerUser)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// checkPartnerAccess rejects deactivated, suspended and pending partners
+func checkPartnerAccess(partnerUser *PartnerUser) error {
+	// Check if user is active
+	if !partnerUser.IsActive {
+		return apperrors.NewForbiddenError("Account is deactivated")
+	}
+
+	// Check partner status
+	if partnerUser.PartnerStatus == StatusSuspended {
+		return apperrors.NewForbiddenError("Partner account is suspended")
+	}
+
+	if partnerUser.PartnerStatus == StatusPending {
+		return apperrors.NewForbiddenError("Partner account is pending approval")
+	}
+
+	return nil
+}
+
+// issueLoginResponse generates the partner tokens
+func (s *service) issueLoginResponse(partnerUser *PartnerUser) (*LoginResponse, error) {
 	accessToken, err := s.generateAccessToken(partnerUser)
 	if err != nil {
 		return nil, apperrors.NewInternalError("Failed to generate access token", err)
@@ -282,26 +390,6 @@ func (s *service) Login(ctx context.Context, req *LoginRequest) (*LoginResponse,
 	}, nil
 }
 
-// ValidateAccessToken validates JWT token and returns claims
-func (s *service) ValidateAccessToken(tokenString string) (*TokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*TokenClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, fmt.Errorf("invalid token")
-}
-
 // GetProfile retrieves partner profile
 func (s *service) GetProfile(ctx context.Context, userID uint64) (*PartnerUserResponse, error) {
 	partnerUser, err := s.repo.GetPartnerUserByEmail(ctx, "")
@@ -522,21 +610,16 @@ func (s *service) GetPayoutHistory(ctx context.Context, partnerID uint64, page,
 // Private helper methods
 
 func (s *service) generateAccessToken(user *PartnerUser) (string, error) {
-	claims := TokenClaims{
+	return s.tokens.Issue(token.PortalPartner, token.Claims{
 		UserID:       user.ID,
 		PartnerID:    user.PartnerID,
 		Email:        user.Email,
 		Role:         "partner",
 		ReferralCode: user.ReferralCode,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.AccessExpiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   fmt.Sprintf("partner_%d", user.ID),
+			Subject: fmt.Sprintf("partner_%d", user.ID),
 		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.Secret))
+	}, s.config.AccessExpiry)
 }
 
 func (s *service) generateRefreshToken() string {
@@ -2,9 +2,10 @@ package passwordreset
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
 	"github.com/karirnusantara/api/internal/shared/response"
 )
 
@@ -35,8 +36,12 @@ func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Request password reset
-	if err := h.service.RequestPasswordReset(req.Email); err != nil {
-		log.Printf("[PASSWORD RESET ERROR] Failed to send reset email for %s: %v", req.Email, err)
+	if err := h.service.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		if appErr := apperrors.GetAppError(err); appErr != nil && appErr.HTTPStatus == http.StatusTooManyRequests {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+			return
+		}
+		logger.FromContext(r.Context()).Error("failed to send password reset email", "email", req.Email, "error", err)
 		response.InternalServerError(w, "Gagal mengirim email reset password")
 		return
 	}
@@ -1,10 +1,12 @@
 package passwordreset
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/karirnusantara/api/internal/modules/loginguard"
 	"github.com/karirnusantara/api/internal/shared/email"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -13,6 +15,7 @@ import (
 type Service struct {
 	repo         *Repository
 	emailService *email.Service
+	guard        loginguard.Service
 }
 
 // NewService creates a new password reset service
@@ -23,8 +26,25 @@ func NewService(repo *Repository, emailService *email.Service) *Service {
 	}
 }
 
-// RequestPasswordReset initiates password reset process
-func (s *Service) RequestPasswordReset(emailAddr string) error {
+// NewServiceWithGuard creates a new password reset service that limits reset requests per email and IP
+func NewServiceWithGuard(repo *Repository, emailService *email.Service, guard loginguard.Service) *Service {
+	return &Service{
+		repo:         repo,
+		emailService: emailService,
+		guard:        guard,
+	}
+}
+
+// RequestPasswordReset initiates password reset process.
+// Requests are limited per email and IP whether or not the email is registered,
+// so the limit cannot be used to discover accounts.
+func (s *Service) RequestPasswordReset(ctx context.Context, emailAddr string) error {
+	if s.guard != nil {
+		if err := s.guard.Attempt(ctx, loginguard.ScopePasswordReset, emailAddr); err != nil {
+			return err
+		}
+	}
+
 	// Check if user exists
 	_, fullName, err := s.repo.GetUserByEmail(emailAddr)
 	if err != nil {
@@ -49,7 +69,7 @@ func (s *Service) RequestPasswordReset(emailAddr string) error {
 	}
 
 	// Send email
-	if err := s.emailService.SendPasswordResetEmail(emailAddr, token, fullName); err != nil {
+	if err := s.emailService.SendPasswordResetEmail(emailAddr, token, fullName, "job_seeker"); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
@@ -120,7 +140,7 @@ func (s *Service) ResetPassword(token, newPassword string) error {
 	_, fullName, err := s.repo.GetUserByEmail(resetToken.Email)
 	if err == nil {
 		// Send confirmation email (don't fail if this errors)
-		_ = s.emailService.SendPasswordChangeConfirmationEmail(resetToken.Email, fullName)
+		_ = s.emailService.SendPasswordChangeConfirmationEmail(resetToken.Email, fullName, "job_seeker")
 	}
 
 	return nil
@@ -0,0 +1,328 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/hashid"
+)
+
+// System stage keys every pipeline must contain.
+// Applications always start in StageSubmitted; applicants can always withdraw.
+const (
+	StageSubmitted = "submitted"
+	StageRejected  = "rejected"
+	StageWithdrawn = "withdrawn"
+)
+
+// Stage categories used by dashboard counters and notifications
+const (
+	CategoryNew        = "new"
+	CategoryReview     = "review"
+	CategoryInterview  = "interview"
+	CategoryAssessment = "assessment"
+	CategoryOffer      = "offer"
+	CategoryAccepted   = "accepted"
+	CategoryRejected   = "rejected"
+	CategoryWithdrawn  = "withdrawn"
+)
+
+// maxStagesPerPipeline limits the size of a pipeline template
+const maxStagesPerPipeline = 20
+
+var stageKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// Stage is a single step of a hiring pipeline
+type Stage struct {
+	Key                string   `json:"key"`
+	Label              string   `json:"label"`
+	Category           string   `json:"category"`
+	IsTerminal         bool     `json:"is_terminal"`
+	SchedulesInterview bool     `json:"schedules_interview"`
+	Transitions        []string `json:"transitions"`
+}
+
+// Pipeline is a hiring pipeline template. ID 0 is the built-in default pipeline.
+type Pipeline struct {
+	ID          uint64
+	CompanyID   uint64
+	Name        string
+	Description string
+	Stages      []Stage
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// pipelineRow represents a row of the hiring_pipelines table
+type pipelineRow struct {
+	ID          uint64    `db:"id"`
+	CompanyID   uint64    `db:"company_id"`
+	Name        string    `db:"name"`
+	Description *string   `db:"description"`
+	Stages      string    `db:"stages"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// toPipeline decodes the stored stage definitions
+func (r *pipelineRow) toPipeline() (*Pipeline, error) {
+	p := &Pipeline{
+		ID:        r.ID,
+		CompanyID: r.CompanyID,
+		Name:      r.Name,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.Description != nil {
+		p.Description = *r.Description
+	}
+	if err := json.Unmarshal([]byte(r.Stages), &p.Stages); err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline stages: %w", err)
+	}
+	return p, nil
+}
+
+// DefaultPipeline returns the built-in pipeline used by jobs without a custom one
+func DefaultPipeline() *Pipeline {
+	return &Pipeline{
+		Name:        "Default",
+		Description: "Alur rekrutmen standar Karir Nusantara",
+		Stages: []Stage{
+			{Key: StageSubmitted, Label: "Lamaran Terkirim", Category: CategoryNew,
+				Transitions: []string{"viewed", "shortlisted", "interview_scheduled", StageRejected}},
+			{Key: "viewed", Label: "Sedang Ditinjau", Category: CategoryReview,
+				Transitions: []string{"shortlisted", "interview_scheduled", StageRejected}},
+			{Key: "shortlisted", Label: "Masuk Shortlist", Category: CategoryReview,
+				Transitions: []string{"interview_scheduled", StageRejected}},
+			{Key: "interview_scheduled", Label: "Interview Dijadwalkan", Category: CategoryInterview, SchedulesInterview: true,
+				Transitions: []string{"interview_completed", StageRejected}},
+			{Key: "interview_completed", Label: "Interview Selesai", Category: CategoryInterview,
+				Transitions: []string{"assessment", "offer_sent", "hired", StageRejected}},
+			{Key: "assessment", Label: "Tahap Assessment", Category: CategoryAssessment,
+				Transitions: []string{"offer_sent", "hired", StageRejected}},
+			{Key: "offer_sent", Label: "Penawaran Dikirim", Category: CategoryOffer,
+				Transitions: []string{"offer_accepted", StageRejected}},
+			{Key: "offer_accepted", Label: "Penawaran Diterima", Category: CategoryAccepted,
+				Transitions: []string{"hired"}},
+			{Key: "hired", Label: "Diterima", Category: CategoryAccepted, IsTerminal: true},
+			{Key: StageRejected, Label: "Tidak Lolos", Category: CategoryRejected, IsTerminal: true},
+			{Key: StageWithdrawn, Label: "Dibatalkan", Category: CategoryWithdrawn, IsTerminal: true},
+		},
+	}
+}
+
+// IsDefault reports whether this is the built-in default pipeline
+func (p *Pipeline) IsDefault() bool {
+	return p.ID == 0
+}
+
+// Stage returns the stage with the given key, or nil
+func (p *Pipeline) Stage(key string) *Stage {
+	for i := range p.Stages {
+		if p.Stages[i].Key == key {
+			return &p.Stages[i]
+		}
+	}
+	return nil
+}
+
+// HasStage checks if the pipeline contains a stage
+func (p *Pipeline) HasStage(key string) bool {
+	return p.Stage(key) != nil
+}
+
+// Label returns the human-readable label of a stage, falling back to the key
+func (p *Pipeline) Label(key string) string {
+	if stage := p.Stage(key); stage != nil {
+		return stage.Label
+	}
+	return key
+}
+
+// Category returns the category of a stage, or an empty string for unknown stages
+func (p *Pipeline) Category(key string) string {
+	if stage := p.Stage(key); stage != nil {
+		return stage.Category
+	}
+	return ""
+}
+
+// IsTerminal checks if a stage is terminal (no further updates)
+func (p *Pipeline) IsTerminal(key string) bool {
+	stage := p.Stage(key)
+	return stage != nil && stage.IsTerminal
+}
+
+// CanTransition checks if moving from one stage to another is allowed
+func (p *Pipeline) CanTransition(from, to string) bool {
+	stage := p.Stage(from)
+	if stage == nil || stage.IsTerminal || !p.HasStage(to) {
+		return false
+	}
+	for _, next := range stage.Transitions {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the stage graph is well-formed.
+// Returns field errors keyed like the validator package does.
+func (p *Pipeline) Validate() map[string]string {
+	errs := map[string]string{}
+
+	if len(p.Stages) > maxStagesPerPipeline {
+		errs["stages"] = fmt.Sprintf("a pipeline can have at most %d stages", maxStagesPerPipeline)
+		return errs
+	}
+
+	seen := map[string]bool{}
+	for i, stage := range p.Stages {
+		field := fmt.Sprintf("stages[%d]", i)
+		if !stageKeyPattern.MatchString(stage.Key) {
+			errs[field+".key"] = "key must be 2-50 characters of lowercase letters, digits or underscores"
+		}
+		if seen[stage.Key] {
+			errs[field+".key"] = "duplicate stage key " + stage.Key
+		}
+		seen[stage.Key] = true
+
+		if !isValidCategory(stage.Category) {
+			errs[field+".category"] = "category must be one of new review interview assessment offer accepted rejected withdrawn"
+		}
+		if stage.IsTerminal && len(stage.Transitions) > 0 {
+			errs[field+".transitions"] = "terminal stages cannot have transitions"
+		}
+		if !stage.IsTerminal && len(stage.Transitions) == 0 {
+			errs[field+".transitions"] = "non-terminal stages need at least one transition"
+		}
+	}
+
+	for i, stage := range p.Stages {
+		for _, next := range stage.Transitions {
+			if !seen[next] {
+				errs[fmt.Sprintf("stages[%d].transitions", i)] = "unknown stage " + next
+			} else if next == StageSubmitted || next == stage.Key {
+				errs[fmt.Sprintf("stages[%d].transitions", i)] = "cannot transition to " + next
+			}
+		}
+	}
+
+	if stage := p.Stage(StageSubmitted); stage == nil {
+		errs["stages"] = "pipeline must contain the submitted stage"
+	} else if stage.IsTerminal {
+		errs["stages"] = "the submitted stage cannot be terminal"
+	}
+	for _, key := range []string{StageRejected, StageWithdrawn} {
+		if stage := p.Stage(key); stage != nil && !stage.IsTerminal {
+			errs["stages"] = "the " + key + " stage must be terminal"
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ensureSystemStages appends the rejected and withdrawn stages when a template omits them
+func (p *Pipeline) ensureSystemStages() {
+	defaults := DefaultPipeline()
+	for _, key := range []string{StageRejected, StageWithdrawn} {
+		if !p.HasStage(key) {
+			p.Stages = append(p.Stages, *defaults.Stage(key))
+		}
+	}
+}
+
+func isValidCategory(category string) bool {
+	switch category {
+	case CategoryNew, CategoryReview, CategoryInterview, CategoryAssessment,
+		CategoryOffer, CategoryAccepted, CategoryRejected, CategoryWithdrawn:
+		return true
+	}
+	return false
+}
+
+// Request DTOs
+
+// StageRequest represents a stage in a pipeline create/update request
+type StageRequest struct {
+	Key                string   `json:"key" validate:"required,max=50"`
+	Label              string   `json:"label" validate:"required,max=100"`
+	Category           string   `json:"category" validate:"required"`
+	IsTerminal         bool     `json:"is_terminal"`
+	SchedulesInterview bool     `json:"schedules_interview"`
+	Transitions        []string `json:"transitions"`
+}
+
+// PipelineRequest represents the create/update request for a pipeline template
+type PipelineRequest struct {
+	Name        string         `json:"name" validate:"required,min=2,max=100"`
+	Description string         `json:"description,omitempty" validate:"omitempty,max=500"`
+	Stages      []StageRequest `json:"stages" validate:"required,min=1,dive"`
+}
+
+// AssignPipelineRequest attaches a pipeline to a job. A zero or empty pipeline_id restores the default.
+type AssignPipelineRequest struct {
+	PipelineID string `json:"pipeline_id"`
+}
+
+// toPipeline builds a pipeline from the request, adding missing system stages
+func (r *PipelineRequest) toPipeline() *Pipeline {
+	p := &Pipeline{
+		Name:        r.Name,
+		Description: r.Description,
+		Stages:      make([]Stage, len(r.Stages)),
+	}
+	for i, s := range r.Stages {
+		transitions := s.Transitions
+		if transitions == nil {
+			transitions = []string{}
+		}
+		p.Stages[i] = Stage{
+			Key:                s.Key,
+			Label:              s.Label,
+			Category:           s.Category,
+			IsTerminal:         s.IsTerminal,
+			SchedulesInterview: s.SchedulesInterview,
+			Transitions:        transitions,
+		}
+	}
+	p.ensureSystemStages()
+	return p
+}
+
+// Response DTOs
+
+// PipelineResponse represents the pipeline response
+type PipelineResponse struct {
+	ID          uint64  `json:"id"`
+	HashID      string  `json:"hash_id,omitempty"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	IsDefault   bool    `json:"is_default"`
+	Stages      []Stage `json:"stages"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+	UpdatedAt   string  `json:"updated_at,omitempty"`
+}
+
+// ToResponse converts Pipeline to PipelineResponse
+func (p *Pipeline) ToResponse() *PipelineResponse {
+	resp := &PipelineResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		IsDefault:   p.IsDefault(),
+		Stages:      p.Stages,
+	}
+	if !p.IsDefault() {
+		resp.HashID = hashid.Encode(p.ID)
+		resp.CreatedAt = p.CreatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = p.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
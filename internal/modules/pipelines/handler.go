@@ -0,0 +1,253 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for hiring pipelines
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new hiring pipeline handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// parseID parses an ID which can be either a numeric ID or a hash_id
+func parseID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	if strings.HasPrefix(idStr, "kn_") {
+		return hashid.Decode(idStr)
+	}
+
+	return 0, err
+}
+
+// List handles listing the company's pipelines, starting with the default
+// GET /company/pipelines
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	pipelines, err := h.service.List(r.Context(), companyID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Pipelines retrieved", pipelines)
+}
+
+// Create handles creating a pipeline template
+// POST /company/pipelines
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req PipelineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	pipeline, err := h.service.Create(r.Context(), companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Created(w, "Pipeline created", pipeline)
+}
+
+// Get handles getting a pipeline (0 is the default pipeline)
+// GET /company/pipelines/{id}
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid pipeline ID")
+		return
+	}
+
+	pipeline, err := h.service.Get(r.Context(), id, companyID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Pipeline retrieved", pipeline)
+}
+
+// Update handles replacing a pipeline template
+// PUT /company/pipelines/{id}
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid pipeline ID")
+		return
+	}
+
+	var req PipelineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	pipeline, err := h.service.Update(r.Context(), id, companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Pipeline updated", pipeline)
+}
+
+// Delete handles deleting a pipeline template
+// DELETE /company/pipelines/{id}
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid pipeline ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, companyID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Pipeline deleted", nil)
+}
+
+// GetJobPipeline handles getting the pipeline a job follows
+// GET /jobs/{jobId}/pipeline
+func (h *Handler) GetJobPipeline(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	jobID, err := parseID(chi.URLParam(r, "jobId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid job ID")
+		return
+	}
+
+	pipeline, err := h.service.GetJobPipeline(r.Context(), jobID, companyID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Job pipeline retrieved", pipeline)
+}
+
+// AssignJobPipeline handles attaching a pipeline to a job
+// PUT /jobs/{jobId}/pipeline
+func (h *Handler) AssignJobPipeline(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	jobID, err := parseID(chi.URLParam(r, "jobId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid job ID")
+		return
+	}
+
+	var req AssignPipelineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	var pipelineID uint64
+	if req.PipelineID != "" {
+		pipelineID, err = parseID(req.PipelineID)
+		if err != nil {
+			response.BadRequest(w, "Invalid pipeline ID")
+			return
+		}
+	}
+
+	pipeline, err := h.service.AssignToJob(r.Context(), jobID, companyID, pipelineID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Job pipeline updated", pipeline)
+}
+
+// companyID resolves the authenticated user's company, writing an error response on failure
+func (h *Handler) companyID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	userID := middleware.GetUserID(r.Context())
+
+	companyID, err := h.service.GetCompanyIDByUserID(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return 0, false
+	}
+	return companyID, true
+}
+
+// handleError handles service errors and returns appropriate HTTP response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
@@ -0,0 +1,245 @@
+package pipelines
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the hiring pipeline repository interface
+type Repository interface {
+	Create(ctx context.Context, pipeline *Pipeline) error
+	GetByID(ctx context.Context, id uint64) (*Pipeline, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]*Pipeline, error)
+	ListByCompany(ctx context.Context, companyID uint64) ([]*Pipeline, error)
+	Update(ctx context.Context, pipeline *Pipeline) error
+	Delete(ctx context.Context, id uint64) error
+
+	// Usage
+	CountJobs(ctx context.Context, pipelineID uint64) (int64, error)
+	ListStagesInUse(ctx context.Context, pipelineID uint64) ([]string, error)
+
+	// Jobs
+	GetJobPipelineRef(ctx context.Context, jobID uint64) (*JobPipelineRef, error)
+	ListJobStagesInUse(ctx context.Context, jobID uint64) ([]string, error)
+	SetJobPipeline(ctx context.Context, jobID uint64, pipelineID *uint64) error
+
+	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
+}
+
+// JobPipelineRef is the owner and pipeline of a job
+type JobPipelineRef struct {
+	JobID      uint64        `db:"id"`
+	CompanyID  uint64        `db:"company_id"`
+	PipelineID sql.NullInt64 `db:"pipeline_id"`
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new hiring pipeline repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+const pipelineColumns = `id, company_id, name, description, stages, created_at, updated_at`
+
+// Create inserts a new pipeline template
+func (r *mysqlRepository) Create(ctx context.Context, pipeline *Pipeline) error {
+	stages, err := json.Marshal(pipeline.Stages)
+	if err != nil {
+		return fmt.Errorf("failed to encode pipeline stages: %w", err)
+	}
+
+	query := `
+		INSERT INTO hiring_pipelines (company_id, name, description, stages, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
+	`
+
+	result, err := r.db.ExecContext(ctx, query, pipeline.CompanyID, pipeline.Name, nullString(pipeline.Description), string(stages))
+	if err != nil {
+		return fmt.Errorf("failed to create pipeline: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline ID: %w", err)
+	}
+	pipeline.ID = uint64(id)
+
+	return nil
+}
+
+// GetByID retrieves a pipeline template by ID
+func (r *mysqlRepository) GetByID(ctx context.Context, id uint64) (*Pipeline, error) {
+	query := `SELECT ` + pipelineColumns + ` FROM hiring_pipelines WHERE id = ?`
+
+	var row pipelineRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pipeline: %w", err)
+	}
+
+	return row.toPipeline()
+}
+
+// GetByIDs retrieves the pipeline templates with the given IDs in one query
+func (r *mysqlRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*Pipeline, error) {
+	if len(ids) == 0 {
+		return []*Pipeline{}, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT `+pipelineColumns+` FROM hiring_pipelines WHERE id IN (?)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pipelines query: %w", err)
+	}
+
+	var rows []pipelineRow
+	if err := r.db.SelectContext(ctx, &rows, r.db.Rebind(query), args...); err != nil {
+		return nil, fmt.Errorf("failed to get pipelines: %w", err)
+	}
+
+	pipelines := make([]*Pipeline, 0, len(rows))
+	for i := range rows {
+		p, err := rows[i].toPipeline()
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	return pipelines, nil
+}
+
+// ListByCompany lists the pipeline templates of a company
+func (r *mysqlRepository) ListByCompany(ctx context.Context, companyID uint64) ([]*Pipeline, error) {
+	query := `SELECT ` + pipelineColumns + ` FROM hiring_pipelines WHERE company_id = ? ORDER BY name ASC`
+
+	var rows []pipelineRow
+	if err := r.db.SelectContext(ctx, &rows, query, companyID); err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	pipelines := make([]*Pipeline, 0, len(rows))
+	for i := range rows {
+		p, err := rows[i].toPipeline()
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	return pipelines, nil
+}
+
+// Update updates a pipeline template
+func (r *mysqlRepository) Update(ctx context.Context, pipeline *Pipeline) error {
+	stages, err := json.Marshal(pipeline.Stages)
+	if err != nil {
+		return fmt.Errorf("failed to encode pipeline stages: %w", err)
+	}
+
+	query := `
+		UPDATE hiring_pipelines
+		SET name = ?, description = ?, stages = ?, updated_at = NOW()
+		WHERE id = ?
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, pipeline.Name, nullString(pipeline.Description), string(stages), pipeline.ID); err != nil {
+		return fmt.Errorf("failed to update pipeline: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a pipeline template
+func (r *mysqlRepository) Delete(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM hiring_pipelines WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete pipeline: %w", err)
+	}
+	return nil
+}
+
+// CountJobs counts the (non-deleted) jobs using a pipeline
+func (r *mysqlRepository) CountJobs(ctx context.Context, pipelineID uint64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM jobs WHERE pipeline_id = ? AND deleted_at IS NULL`
+	if err := r.db.GetContext(ctx, &count, query, pipelineID); err != nil {
+		return 0, fmt.Errorf("failed to count pipeline jobs: %w", err)
+	}
+	return count, nil
+}
+
+// ListStagesInUse lists the distinct stages applications are currently in across jobs using a pipeline
+func (r *mysqlRepository) ListStagesInUse(ctx context.Context, pipelineID uint64) ([]string, error) {
+	query := `
+		SELECT DISTINCT a.current_status
+		FROM applications a
+		JOIN jobs j ON a.job_id = j.id
+		WHERE j.pipeline_id = ? AND j.deleted_at IS NULL
+	`
+
+	stages := []string{}
+	if err := r.db.SelectContext(ctx, &stages, query, pipelineID); err != nil {
+		return nil, fmt.Errorf("failed to list stages in use: %w", err)
+	}
+	return stages, nil
+}
+
+// GetJobPipelineRef retrieves the owner and pipeline of a job
+func (r *mysqlRepository) GetJobPipelineRef(ctx context.Context, jobID uint64) (*JobPipelineRef, error) {
+	query := `SELECT id, company_id, pipeline_id FROM jobs WHERE id = ? AND deleted_at IS NULL`
+
+	var ref JobPipelineRef
+	if err := r.db.GetContext(ctx, &ref, query, jobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get job pipeline: %w", err)
+	}
+
+	return &ref, nil
+}
+
+// ListJobStagesInUse lists the distinct stages applications of a job are currently in
+func (r *mysqlRepository) ListJobStagesInUse(ctx context.Context, jobID uint64) ([]string, error) {
+	stages := []string{}
+	query := `SELECT DISTINCT current_status FROM applications WHERE job_id = ?`
+	if err := r.db.SelectContext(ctx, &stages, query, jobID); err != nil {
+		return nil, fmt.Errorf("failed to list job stages in use: %w", err)
+	}
+	return stages, nil
+}
+
+// SetJobPipeline attaches a pipeline to a job (nil restores the default pipeline)
+func (r *mysqlRepository) SetJobPipeline(ctx context.Context, jobID uint64, pipelineID *uint64) error {
+	query := `UPDATE jobs SET pipeline_id = ?, updated_at = NOW() WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, pipelineID, jobID); err != nil {
+		return fmt.Errorf("failed to set job pipeline: %w", err)
+	}
+	return nil
+}
+
+// GetCompanyIDByUserID retrieves company ID for a given user ID
+func (r *mysqlRepository) GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error) {
+	var companyID uint64
+	query := `SELECT id FROM companies WHERE user_id = ? AND deleted_at IS NULL`
+	if err := r.db.GetContext(ctx, &companyID, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get company ID: %w", err)
+	}
+	return companyID, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
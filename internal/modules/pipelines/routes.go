@@ -0,0 +1,33 @@
+package pipelines
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the hiring pipeline routes
+func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireCompany MiddlewareFunc) {
+	r.Route("/company/pipelines", func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(requireCompany)
+
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+	})
+
+	// Company route for the pipeline a job follows
+	r.Route("/jobs/{jobId}/pipeline", func(r chi.Router) {
+		r.Use(authenticate)
+		r.Use(requireCompany)
+
+		r.Get("/", h.GetJobPipeline)
+		r.Put("/", h.AssignJobPipeline)
+	})
+}
@@ -0,0 +1,291 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// maxPipelinesPerCompany limits how many templates a company can keep
+const maxPipelinesPerCompany = 20
+
+// Service defines the hiring pipeline service interface
+type Service interface {
+	List(ctx context.Context, companyID uint64) ([]*PipelineResponse, error)
+	Get(ctx context.Context, id, companyID uint64) (*PipelineResponse, error)
+	Create(ctx context.Context, companyID uint64, req *PipelineRequest) (*PipelineResponse, error)
+	Update(ctx context.Context, id, companyID uint64, req *PipelineRequest) (*PipelineResponse, error)
+	Delete(ctx context.Context, id, companyID uint64) error
+
+	// Jobs
+	GetJobPipeline(ctx context.Context, jobID, companyID uint64) (*PipelineResponse, error)
+	AssignToJob(ctx context.Context, jobID, companyID, pipelineID uint64) (*PipelineResponse, error)
+
+	// Resolve returns the pipeline with the given ID, or the default pipeline for 0
+	Resolve(ctx context.Context, pipelineID uint64) (*Pipeline, error)
+	// ResolveAll resolves several pipelines in one query, keyed by the requested IDs
+	ResolveAll(ctx context.Context, pipelineIDs []uint64) (map[uint64]*Pipeline, error)
+
+	GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new hiring pipeline service
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// List lists the default pipeline followed by the company's templates
+func (s *service) List(ctx context.Context, companyID uint64) ([]*PipelineResponse, error) {
+	pipelines, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list pipelines", err)
+	}
+
+	responses := make([]*PipelineResponse, 0, len(pipelines)+1)
+	responses = append(responses, DefaultPipeline().ToResponse())
+	for _, p := range pipelines {
+		responses = append(responses, p.ToResponse())
+	}
+
+	return responses, nil
+}
+
+// Get retrieves a pipeline owned by the company (0 is the default pipeline)
+func (s *service) Get(ctx context.Context, id, companyID uint64) (*PipelineResponse, error) {
+	if id == 0 {
+		return DefaultPipeline().ToResponse(), nil
+	}
+
+	pipeline, err := s.getOwned(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.ToResponse(), nil
+}
+
+// Create saves a new pipeline template
+func (s *service) Create(ctx context.Context, companyID uint64, req *PipelineRequest) (*PipelineResponse, error) {
+	pipeline := req.toPipeline()
+	if errs := pipeline.Validate(); errs != nil {
+		return nil, apperrors.NewValidationError("Invalid pipeline", errs)
+	}
+
+	existing, err := s.repo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list pipelines", err)
+	}
+	if len(existing) >= maxPipelinesPerCompany {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("A company can have at most %d pipelines", maxPipelinesPerCompany))
+	}
+
+	pipeline.CompanyID = companyID
+	if err := s.repo.Create(ctx, pipeline); err != nil {
+		return nil, apperrors.NewInternalError("Failed to create pipeline", err)
+	}
+
+	return s.Get(ctx, pipeline.ID, companyID)
+}
+
+// Update replaces a pipeline template. Stages that applications are currently in cannot be removed.
+func (s *service) Update(ctx context.Context, id, companyID uint64, req *PipelineRequest) (*PipelineResponse, error) {
+	if id == 0 {
+		return nil, apperrors.NewForbiddenError("The default pipeline cannot be modified")
+	}
+
+	existing, err := s.getOwned(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := req.toPipeline()
+	if errs := pipeline.Validate(); errs != nil {
+		return nil, apperrors.NewValidationError("Invalid pipeline", errs)
+	}
+
+	inUse, err := s.repo.ListStagesInUse(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to check pipeline usage", err)
+	}
+	if missing := missingStages(pipeline, inUse); len(missing) > 0 {
+		return nil, apperrors.NewConflictError("Applications are still in stages removed from this pipeline: " + strings.Join(missing, ", "))
+	}
+
+	pipeline.ID = existing.ID
+	pipeline.CompanyID = existing.CompanyID
+	if err := s.repo.Update(ctx, pipeline); err != nil {
+		return nil, apperrors.NewInternalError("Failed to update pipeline", err)
+	}
+
+	return s.Get(ctx, id, companyID)
+}
+
+// Delete removes a pipeline template that no job uses
+func (s *service) Delete(ctx context.Context, id, companyID uint64) error {
+	if id == 0 {
+		return apperrors.NewForbiddenError("The default pipeline cannot be deleted")
+	}
+
+	if _, err := s.getOwned(ctx, id, companyID); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountJobs(ctx, id)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to check pipeline usage", err)
+	}
+	if count > 0 {
+		return apperrors.NewConflictError(fmt.Sprintf("Pipeline is used by %d job(s)", count))
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return apperrors.NewInternalError("Failed to delete pipeline", err)
+	}
+
+	return nil
+}
+
+// GetJobPipeline retrieves the pipeline a company's job follows
+func (s *service) GetJobPipeline(ctx context.Context, jobID, companyID uint64) (*PipelineResponse, error) {
+	ref, err := s.getOwnedJob(ctx, jobID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := s.Resolve(ctx, uint64(ref.PipelineID.Int64))
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.ToResponse(), nil
+}
+
+// AssignToJob attaches a pipeline to a job (0 restores the default pipeline).
+// Every stage the job's applications are currently in must exist in the new pipeline.
+func (s *service) AssignToJob(ctx context.Context, jobID, companyID, pipelineID uint64) (*PipelineResponse, error) {
+	if _, err := s.getOwnedJob(ctx, jobID, companyID); err != nil {
+		return nil, err
+	}
+
+	pipeline := DefaultPipeline()
+	if pipelineID != 0 {
+		owned, err := s.getOwned(ctx, pipelineID, companyID)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = owned
+	}
+
+	inUse, err := s.repo.ListJobStagesInUse(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to check job applications", err)
+	}
+	if missing := missingStages(pipeline, inUse); len(missing) > 0 {
+		return nil, apperrors.NewConflictError("Applications for this job are in stages the pipeline does not have: " + strings.Join(missing, ", "))
+	}
+
+	var ref *uint64
+	if !pipeline.IsDefault() {
+		ref = &pipeline.ID
+	}
+	if err := s.repo.SetJobPipeline(ctx, jobID, ref); err != nil {
+		return nil, apperrors.NewInternalError("Failed to assign pipeline", err)
+	}
+
+	return pipeline.ToResponse(), nil
+}
+
+// Resolve returns the pipeline with the given ID, or the default pipeline for 0
+func (s *service) Resolve(ctx context.Context, pipelineID uint64) (*Pipeline, error) {
+	if pipelineID == 0 {
+		return DefaultPipeline(), nil
+	}
+
+	pipeline, err := s.repo.GetByID(ctx, pipelineID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get pipeline", err)
+	}
+	if pipeline == nil {
+		return DefaultPipeline(), nil
+	}
+	return pipeline, nil
+}
+
+// ResolveAll resolves several pipelines in one query. Every requested ID is in the
+// result; 0 and pipelines that no longer exist map to the default pipeline.
+func (s *service) ResolveAll(ctx context.Context, pipelineIDs []uint64) (map[uint64]*Pipeline, error) {
+	resolved := make(map[uint64]*Pipeline, len(pipelineIDs))
+	ids := make([]uint64, 0, len(pipelineIDs))
+	for _, id := range pipelineIDs {
+		if _, seen := resolved[id]; seen {
+			continue
+		}
+		resolved[id] = DefaultPipeline()
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get pipelines", err)
+	}
+	for _, p := range found {
+		resolved[p.ID] = p
+	}
+	return resolved, nil
+}
+
+// GetCompanyIDByUserID retrieves company ID for a given user ID
+func (s *service) GetCompanyIDByUserID(ctx context.Context, userID uint64) (uint64, error) {
+	companyID, err := s.repo.GetCompanyIDByUserID(ctx, userID)
+	if err != nil {
+		return 0, apperrors.NewInternalError("Failed to get company", err)
+	}
+	if companyID == 0 {
+		return 0, apperrors.NewNotFoundError("Company")
+	}
+	return companyID, nil
+}
+
+// getOwned loads a pipeline template and verifies it belongs to the company
+func (s *service) getOwned(ctx context.Context, id, companyID uint64) (*Pipeline, error) {
+	pipeline, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get pipeline", err)
+	}
+	if pipeline == nil || pipeline.CompanyID != companyID {
+		return nil, apperrors.NewNotFoundError("Pipeline")
+	}
+	return pipeline, nil
+}
+
+// getOwnedJob loads a job's pipeline reference and verifies the job belongs to the company
+func (s *service) getOwnedJob(ctx context.Context, jobID, companyID uint64) (*JobPipelineRef, error) {
+	ref, err := s.repo.GetJobPipelineRef(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get job", err)
+	}
+	if ref == nil {
+		return nil, apperrors.NewNotFoundError("Job")
+	}
+	if ref.CompanyID != companyID {
+		return nil, apperrors.NewForbiddenError("You don't have permission to manage this job")
+	}
+	return ref, nil
+}
+
+// missingStages returns the stage keys in use that the pipeline does not define
+func missingStages(pipeline *Pipeline, inUse []string) []string {
+	var missing []string
+	for _, key := range inUse {
+		if !pipeline.HasStage(key) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
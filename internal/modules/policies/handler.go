@@ -2,12 +2,14 @@ package policies
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
 // Handler handles policies HTTP requests
@@ -15,7 +17,7 @@ type Handler struct{}
 
 // GeneratePrivacyPolicyPDF generates privacy policy PDF
 func (h *Handler) GeneratePrivacyPolicyPDF(w http.ResponseWriter, r *http.Request) {
-	pdfContent := h.generatePrivacyPolicy()
+	pdfContent := h.generatePrivacyPolicy(r.Context())
 	
 	filename := fmt.Sprintf("kebijakan_privasi_%s.pdf", time.Now().Format("20060102"))
 	w.Header().Set("Content-Type", "application/pdf")
@@ -26,7 +28,7 @@ func (h *Handler) GeneratePrivacyPolicyPDF(w http.ResponseWriter, r *http.Reques
 
 // GenerateTermsOfServicePDF generates terms of service PDF
 func (h *Handler) GenerateTermsOfServicePDF(w http.ResponseWriter, r *http.Request) {
-	pdfContent := h.generateTermsOfService()
+	pdfContent := h.generateTermsOfService(r.Context())
 	
 	filename := fmt.Sprintf("terms_of_service_%s.pdf", time.Now().Format("20060102"))
 	w.Header().Set("Content-Type", "application/pdf")
@@ -35,7 +37,7 @@ func (h *Handler) GenerateTermsOfServicePDF(w http.ResponseWriter, r *http.Reque
 	w.Write(pdfContent)
 }
 
-func (h *Handler) generatePrivacyPolicy() []byte {
+func (h *Handler) generatePrivacyPolicy(ctx context.Context) []byte {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 15)
 	pdf.AddPage()
@@ -133,14 +135,14 @@ func (h *Handler) generatePrivacyPolicy() []byte {
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
 	if err != nil {
-		log.Printf("Error generating privacy policy PDF: %v", err)
+		logger.FromContext(ctx).Error("failed to generate privacy policy PDF", "error", err)
 		return []byte{}
 	}
 	
 	return buf.Bytes()
 }
 
-func (h *Handler) generateTermsOfService() []byte {
+func (h *Handler) generateTermsOfService(ctx context.Context) []byte {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetAutoPageBreak(true, 15)
 	pdf.AddPage()
@@ -242,7 +244,7 @@ func (h *Handler) generateTermsOfService() []byte {
 	var buf bytes.Buffer
 	err := pdf.Output(&buf)
 	if err != nil {
-		log.Printf("Error generating terms of service PDF: %v", err)
+		logger.FromContext(ctx).Error("failed to generate terms of service PDF", "error", err)
 		return []byte{}
 	}
 	
@@ -80,6 +80,9 @@ type ApplicantProfile struct {
 	AvailableFrom      sql.NullTime   `db:"available_from" json:"-"`
 	WillingToRelocate  bool           `db:"willing_to_relocate" json:"willing_to_relocate"`
 
+	// Talent search: companies can only find job seekers who opt in
+	IsDiscoverable bool `db:"is_discoverable" json:"is_discoverable"`
+
 	// Profile Completeness
 	ProfileCompleteness int `db:"profile_completeness" json:"profile_completeness"`
 
@@ -146,6 +149,9 @@ type ProfileResponse struct {
 	AvailableFrom      *string  `json:"available_from,omitempty"`
 	WillingToRelocate  bool     `json:"willing_to_relocate"`
 
+	// Talent search
+	IsDiscoverable bool `json:"is_discoverable"`
+
 	// Profile Completeness
 	ProfileCompleteness int `json:"profile_completeness"`
 
@@ -175,6 +181,7 @@ func (p *ApplicantProfile) ToResponse() *ProfileResponse {
 		HashID:              hashid.Encode(p.ID),
 		UserID:              p.UserID,
 		WillingToRelocate:   p.WillingToRelocate,
+		IsDiscoverable:      p.IsDiscoverable,
 		ProfileCompleteness: p.ProfileCompleteness,
 		CreatedAt:           p.CreatedAt,
 		UpdatedAt:           p.UpdatedAt,
@@ -333,6 +340,9 @@ type UpdateProfileRequest struct {
 	PreferredLocations []string `json:"preferred_locations" validate:"omitempty,max=10,dive,max=100"`
 	AvailableFrom      *string  `json:"available_from" validate:"omitempty,datetime=2006-01-02"`
 	WillingToRelocate  *bool    `json:"willing_to_relocate"`
+
+	// Talent search opt-in
+	IsDiscoverable *bool `json:"is_discoverable"`
 }
 
 // UploadDocumentRequest is the request for document upload (multipart form)
@@ -53,7 +53,7 @@ func (r *mysqlRepository) CreateProfile(ctx context.Context, profile *ApplicantP
 			linkedin_url, github_url, portfolio_url, personal_website,
 			professional_summary, headline,
 			expected_salary_min, expected_salary_max, preferred_job_types, preferred_locations,
-			available_from, willing_to_relocate, profile_completeness,
+			available_from, willing_to_relocate, is_discoverable, profile_completeness,
 			created_at, updated_at
 		) VALUES (
 			?, ?, ?, ?, ?,
@@ -61,7 +61,7 @@ func (r *mysqlRepository) CreateProfile(ctx context.Context, profile *ApplicantP
 			?, ?, ?, ?,
 			?, ?,
 			?, ?, ?, ?,
-			?, ?, ?,
+			?, ?, ?, ?,
 			NOW(), NOW()
 		)
 	`
@@ -72,7 +72,7 @@ func (r *mysqlRepository) CreateProfile(ctx context.Context, profile *ApplicantP
 		profile.LinkedInURL, profile.GithubURL, profile.PortfolioURL, profile.PersonalWebsite,
 		profile.ProfessionalSummary, profile.Headline,
 		profile.ExpectedSalaryMin, profile.ExpectedSalaryMax, profile.PreferredJobTypes, profile.PreferredLocations,
-		profile.AvailableFrom, profile.WillingToRelocate, profile.ProfileCompleteness,
+		profile.AvailableFrom, profile.WillingToRelocate, profile.IsDiscoverable, profile.ProfileCompleteness,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create profile: %w", err)
@@ -95,7 +95,7 @@ func (r *mysqlRepository) GetProfileByUserID(ctx context.Context, userID uint64)
 			   linkedin_url, github_url, portfolio_url, personal_website,
 			   professional_summary, headline,
 			   expected_salary_min, expected_salary_max, preferred_job_types, preferred_locations,
-			   available_from, willing_to_relocate, profile_completeness,
+			   available_from, willing_to_relocate, is_discoverable, profile_completeness,
 			   created_at, updated_at
 		FROM applicant_profiles
 		WHERE user_id = ?
@@ -120,7 +120,7 @@ func (r *mysqlRepository) GetProfileByID(ctx context.Context, id uint64) (*Appli
 			   linkedin_url, github_url, portfolio_url, personal_website,
 			   professional_summary, headline,
 			   expected_salary_min, expected_salary_max, preferred_job_types, preferred_locations,
-			   available_from, willing_to_relocate, profile_completeness,
+			   available_from, willing_to_relocate, is_discoverable, profile_completeness,
 			   created_at, updated_at
 		FROM applicant_profiles
 		WHERE id = ?
@@ -146,7 +146,7 @@ func (r *mysqlRepository) UpdateProfile(ctx context.Context, profile *ApplicantP
 			linkedin_url = ?, github_url = ?, portfolio_url = ?, personal_website = ?,
 			professional_summary = ?, headline = ?,
 			expected_salary_min = ?, expected_salary_max = ?, preferred_job_types = ?, preferred_locations = ?,
-			available_from = ?, willing_to_relocate = ?, profile_completeness = ?,
+			available_from = ?, willing_to_relocate = ?, is_discoverable = ?, profile_completeness = ?,
 			updated_at = NOW()
 		WHERE id = ?
 	`
@@ -157,7 +157,7 @@ func (r *mysqlRepository) UpdateProfile(ctx context.Context, profile *ApplicantP
 		profile.LinkedInURL, profile.GithubURL, profile.PortfolioURL, profile.PersonalWebsite,
 		profile.ProfessionalSummary, profile.Headline,
 		profile.ExpectedSalaryMin, profile.ExpectedSalaryMax, profile.PreferredJobTypes, profile.PreferredLocations,
-		profile.AvailableFrom, profile.WillingToRelocate, profile.ProfileCompleteness,
+		profile.AvailableFrom, profile.WillingToRelocate, profile.IsDiscoverable, profile.ProfileCompleteness,
 		profile.ID,
 	)
 	if err != nil {
@@ -214,6 +214,9 @@ func (s *service) applyRequestToProfile(profile *ApplicantProfile, req *UpdatePr
 	if req.WillingToRelocate != nil {
 		profile.WillingToRelocate = *req.WillingToRelocate
 	}
+	if req.IsDiscoverable != nil {
+		profile.IsDiscoverable = *req.IsDiscoverable
+	}
 }
 
 // calculateCompleteness calculates the profile completeness percentage
@@ -2,6 +2,7 @@ package quota
 
 import (
 	"database/sql"
+	"math"
 	"time"
 )
 
@@ -10,8 +11,20 @@ const (
 	PaymentStatusPending   = "pending"
 	PaymentStatusConfirmed = "confirmed"
 	PaymentStatusRejected  = "rejected"
+	PaymentStatusReversed  = "reversed" // confirmed, then taken back by an admin
 )
 
+// Partner commission statuses (partner_commissions.status)
+const (
+	CommissionStatusPending   = "pending"
+	CommissionStatusApproved  = "approved"
+	CommissionStatusPaid      = "paid"
+	CommissionStatusCancelled = "cancelled"
+)
+
+// Partner status that earns commission
+const PartnerStatusActive = "active"
+
 // Constants
 const (
 	FreeQuotaLimit = 10         // Free job postings per company
@@ -119,6 +132,36 @@ type Payment struct {
 	UpdatedAt     time.Time      `db:"updated_at" json:"updated_at"`
 }
 
+// PartnerReferral is the referral a company registered under, with the referring partner's current terms
+type PartnerReferral struct {
+	ID             uint64  `db:"id"`
+	PartnerID      uint64  `db:"partner_id"`
+	CompanyID      uint64  `db:"company_id"`
+	CommissionRate float64 `db:"commission_rate"`
+	PartnerStatus  string  `db:"partner_status"`
+}
+
+// Commission represents a partner commission earned from a confirmed payment
+type Commission struct {
+	ID                uint64  `db:"id" json:"id"`
+	PartnerID         uint64  `db:"partner_id" json:"partner_id"`
+	ReferralID        uint64  `db:"referral_id" json:"referral_id"`
+	PaymentID         uint64  `db:"payment_id" json:"payment_id"`
+	CompanyID         uint64  `db:"company_id" json:"company_id"`
+	TransactionAmount int64   `db:"transaction_amount" json:"transaction_amount"`
+	CommissionRate    float64 `db:"commission_rate" json:"commission_rate"`
+	CommissionAmount  int64   `db:"commission_amount" json:"commission_amount"`
+	JobQuota          int     `db:"job_quota" json:"job_quota"`
+	Status            string  `db:"status" json:"status"`
+}
+
+// CalculateCommission returns the commission for an amount at a percentage rate (e.g. 40.00),
+// rounded down to whole rupiah
+func CalculateCommission(amount int64, rate float64) int64 {
+	basisPoints := int64(math.Round(rate * 100))
+	return amount * basisPoints / 10000
+}
+
 // QuotaResponse represents the quota response for API
 type QuotaResponse struct {
 	FreeQuota          int   `json:"free_quota"`
@@ -154,6 +197,8 @@ func GetStatusLabel(status string) string {
 		return "Dikonfirmasi"
 	case PaymentStatusRejected:
 		return "Ditolak"
+	case PaymentStatusReversed:
+		return "Dibatalkan"
 	default:
 		return status
 	}
@@ -173,3 +173,199 @@ func (r *Repository) GetPendingPaymentsCount(companyID uint64) (int, error) {
 	`, companyID, PaymentStatusPending)
 	return count, err
 }
+
+// ConfirmPayment marks a pending payment as confirmed, adds its quota to the company and,
+// when the company was referred by an active partner, records a pending partner commission.
+// Everything runs in a single transaction. Returns the created commission, if any.
+func (r *Repository) ConfirmPayment(payment *Payment, quotaToAdd int, confirmedByID uint64, note string) (*Commission, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := transitionPayment(tx, payment.ID, PaymentStatusPending, PaymentStatusConfirmed, confirmedByID, note); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO company_quotas (company_id, free_quota_used, paid_quota, created_at, updated_at)
+		VALUES (?, 0, ?, NOW(), NOW())
+		ON DUPLICATE KEY UPDATE paid_quota = paid_quota + VALUES(paid_quota), updated_at = NOW()
+	`, payment.CompanyID, quotaToAdd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add paid quota: %w", err)
+	}
+
+	commission, err := createCommission(tx, payment, quotaToAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return commission, nil
+}
+
+// RejectPayment marks a pending payment as rejected and cancels any commission recorded for it
+func (r *Repository) RejectPayment(paymentID uint64, confirmedByID uint64, note string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := transitionPayment(tx, paymentID, PaymentStatusPending, PaymentStatusRejected, confirmedByID, note); err != nil {
+		return err
+	}
+
+	if _, err := cancelCommission(tx, paymentID, note); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ReversePayment moves a confirmed payment to reversed, takes back the quota it granted
+// (never below zero) and cancels its partner commission. Returns whether a commission was cancelled.
+func (r *Repository) ReversePayment(payment *Payment, quotaToRemove int, confirmedByID uint64, note string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := transitionPayment(tx, payment.ID, PaymentStatusConfirmed, PaymentStatusReversed, confirmedByID, note); err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE company_quotas
+		SET paid_quota = GREATEST(paid_quota - ?, 0), updated_at = NOW()
+		WHERE company_id = ?
+	`, quotaToRemove, payment.CompanyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove paid quota: %w", err)
+	}
+
+	cancelled, err := cancelCommission(tx, payment.ID, note)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return cancelled, nil
+}
+
+// transitionPayment moves a payment between statuses, failing if it is no longer in the expected one
+func transitionPayment(tx *sqlx.Tx, paymentID uint64, from, to string, confirmedByID uint64, note string) error {
+	result, err := tx.Exec(`
+		UPDATE payments
+		SET status = ?, note = ?, confirmed_by_id = ?, confirmed_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND status = ?
+	`, to, note, confirmedByID, paymentID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if rows == 0 {
+		if from == PaymentStatusConfirmed {
+			return ErrPaymentNotConfirmed
+		}
+		return ErrPaymentAlreadyProcessed
+	}
+	return nil
+}
+
+// createCommission records a pending commission for the partner who referred the paying company.
+// Partner balances are maintained by the after_commission_insert trigger.
+func createCommission(tx *sqlx.Tx, payment *Payment, jobQuota int) (*Commission, error) {
+	var referral PartnerReferral
+	err := tx.Get(&referral, `
+		SELECT pr.id, pr.partner_id, pr.company_id, rp.commission_rate, rp.status AS partner_status
+		FROM partner_referrals pr
+		JOIN referral_partners rp ON rp.id = pr.partner_id
+		WHERE pr.company_id = ?
+		FOR UPDATE
+	`, payment.CompanyID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partner referral: %w", err)
+	}
+
+	if referral.PartnerStatus != PartnerStatusActive {
+		return nil, nil
+	}
+
+	commission := &Commission{
+		PartnerID:         referral.PartnerID,
+		ReferralID:        referral.ID,
+		PaymentID:         payment.ID,
+		CompanyID:         payment.CompanyID,
+		TransactionAmount: payment.Amount,
+		CommissionRate:    referral.CommissionRate,
+		CommissionAmount:  CalculateCommission(payment.Amount, referral.CommissionRate),
+		JobQuota:          jobQuota,
+		Status:            CommissionStatusPending,
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO partner_commissions (
+			partner_id, referral_id, payment_id, company_id, transaction_amount,
+			commission_rate, commission_amount, job_quota, status, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())
+	`, commission.PartnerID, commission.ReferralID, commission.PaymentID, commission.CompanyID,
+		commission.TransactionAmount, commission.CommissionRate, commission.CommissionAmount,
+		commission.JobQuota, commission.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partner commission: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commission ID: %w", err)
+	}
+	commission.ID = uint64(id)
+
+	_, err = tx.Exec(`
+		UPDATE partner_referrals SET first_payment_at = NOW()
+		WHERE id = ? AND first_payment_at IS NULL
+	`, referral.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set first payment date: %w", err)
+	}
+
+	return commission, nil
+}
+
+// cancelCommission cancels an unpaid commission for a payment. Commissions already paid out
+// are left untouched. Partner balances are maintained by the after_commission_update trigger.
+func cancelCommission(tx *sqlx.Tx, paymentID uint64, note string) (bool, error) {
+	result, err := tx.Exec(`
+		UPDATE partner_commissions
+		SET status = ?, notes = ?, updated_at = NOW()
+		WHERE payment_id = ? AND status IN (?, ?)
+	`, CommissionStatusCancelled, note, paymentID, CommissionStatusPending, CommissionStatusApproved)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel partner commission: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel partner commission: %w", err)
+	}
+	return rows > 0, nil
+}
@@ -1,16 +1,38 @@
 package quota
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 )
 
+// Payment processing errors
+var (
+	ErrPaymentAlreadyProcessed = errors.New("payment already processed")
+	ErrPaymentNotConfirmed     = errors.New("payment is not confirmed")
+)
+
+// Store is the quota and payment storage used by the service, implemented by Repository
+type Store interface {
+	GetOrCreateCompanyQuota(companyID uint64) (*CompanyQuota, error)
+	IncrementFreeQuotaUsed(companyID uint64) error
+	DecrementPaidQuota(companyID uint64) error
+	CreatePayment(payment *Payment) error
+	GetPaymentByID(id uint64) (*Payment, error)
+	ListPayments(params PaymentListParams) ([]Payment, int, error)
+	GetPendingPaymentsCount(companyID uint64) (int, error)
+	ConfirmPayment(payment *Payment, quotaToAdd int, confirmedByID uint64, note string) (*Commission, error)
+	RejectPayment(paymentID uint64, confirmedByID uint64, note string) error
+	ReversePayment(payment *Payment, quotaToRemove int, confirmedByID uint64, note string) (bool, error)
+}
+
 // Service handles business logic for quota
 type Service struct {
-	repo *Repository
+	repo Store
 }
 
 // NewService creates a new quota service
-func NewService(repo *Repository) *Service {
+func NewService(repo Store) *Service {
 	return &Service{repo: repo}
 }
 
@@ -119,7 +141,8 @@ func (s *Service) SubmitPaymentProof(companyID uint64, jobID *uint64, packageID
 	return payment, nil
 }
 
-// ConfirmPayment confirms a payment (admin only)
+// ConfirmPayment confirms a payment (admin only).
+// Adds the purchased quota and, for referred companies, creates a pending partner commission.
 func (s *Service) ConfirmPayment(paymentID uint64, adminID uint64, note string) error {
 	payment, err := s.repo.GetPaymentByID(paymentID)
 	if err != nil {
@@ -127,21 +150,20 @@ func (s *Service) ConfirmPayment(paymentID uint64, adminID uint64, note string)
 	}
 	
 	if payment.Status != PaymentStatusPending {
-		return fmt.Errorf("payment already processed")
+		return ErrPaymentAlreadyProcessed
 	}
 	
-	// Update payment status
-	err = s.repo.UpdatePaymentStatus(paymentID, PaymentStatusConfirmed, &adminID, note)
+	commission, err := s.repo.ConfirmPayment(payment, paymentQuota(payment), adminID, note)
 	if err != nil {
 		return err
 	}
 	
-	// Add paid quota to company (use QuotaAmount from payment which includes bonus)
-	quotaToAdd := payment.QuotaAmount
-	if quotaToAdd == 0 {
-		quotaToAdd = 1 // Fallback for old payments
+	if commission != nil {
+		slog.Info("partner commission created",
+			"commission_id", commission.ID, "amount", commission.CommissionAmount, "rate", commission.CommissionRate,
+			"partner_id", commission.PartnerID, "payment_id", payment.ID)
 	}
-	return s.repo.AddPaidQuota(payment.CompanyID, quotaToAdd)
+	return nil
 }
 
 // RejectPayment rejects a payment (admin only)
@@ -152,10 +174,33 @@ func (s *Service) RejectPayment(paymentID uint64, adminID uint64, note string) e
 	}
 	
 	if payment.Status != PaymentStatusPending {
-		return fmt.Errorf("payment already processed")
+		return ErrPaymentAlreadyProcessed
 	}
 	
-	return s.repo.UpdatePaymentStatus(paymentID, PaymentStatusRejected, &adminID, note)
+	return s.repo.RejectPayment(paymentID, adminID, note)
+}
+
+// ReversePayment reverses a confirmed payment (admin only).
+// Removes the quota it granted and cancels the partner commission unless it was already paid out.
+func (s *Service) ReversePayment(paymentID uint64, adminID uint64, note string) error {
+	payment, err := s.repo.GetPaymentByID(paymentID)
+	if err != nil {
+		return err
+	}
+	
+	if payment.Status != PaymentStatusConfirmed {
+		return ErrPaymentNotConfirmed
+	}
+	
+	cancelled, err := s.repo.ReversePayment(payment, paymentQuota(payment), adminID, note)
+	if err != nil {
+		return err
+	}
+	
+	if cancelled {
+		slog.Info("partner commission cancelled for reversed payment", "payment_id", payment.ID)
+	}
+	return nil
 }
 
 // GetPayments gets the payment history for a company
@@ -182,3 +227,11 @@ func (s *Service) GetPendingPaymentsCount(companyID uint64) (int, error) {
 func (s *Service) GetPaymentByID(paymentID uint64) (*Payment, error) {
 	return s.repo.GetPaymentByID(paymentID)
 }
+
+// paymentQuota returns the quota granted by a payment (QuotaAmount includes bonus quota)
+func paymentQuota(payment *Payment) int {
+	if payment.QuotaAmount == 0 {
+		return 1 // Fallback for old payments
+	}
+	return payment.QuotaAmount
+}
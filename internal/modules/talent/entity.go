@@ -0,0 +1,390 @@
+package talent
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/karirnusantara/api/internal/modules/cvs"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+)
+
+// Invitation statuses
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+const (
+	// MaxSkillFilters limits how many skills a search can require
+	MaxSkillFilters = 10
+	// MaxPoolsPerCompany limits how many talent pools a company can keep
+	MaxPoolsPerCompany = 50
+)
+
+// CompanyRef is the company using talent search
+type CompanyRef struct {
+	ID     uint64 `db:"id"`
+	Name   string `db:"company_name"`
+	Status string `db:"company_status"`
+}
+
+// IsVerified reports whether the company may use talent search
+func (c *CompanyRef) IsVerified() bool {
+	return c.Status == "verified"
+}
+
+// Candidate is a job seeker as seen by a company in talent search
+type Candidate struct {
+	UserID             uint64         `db:"user_id"`
+	FullName           string         `db:"full_name"`
+	Email              string         `db:"email"`
+	Phone              sql.NullString `db:"phone"`
+	AvatarURL          sql.NullString `db:"avatar_url"`
+	Headline           sql.NullString `db:"headline"`
+	Summary            sql.NullString `db:"professional_summary"`
+	City               sql.NullString `db:"city"`
+	Province           sql.NullString `db:"province"`
+	LinkedInURL        sql.NullString `db:"linkedin_url"`
+	GithubURL          sql.NullString `db:"github_url"`
+	PortfolioURL       sql.NullString `db:"portfolio_url"`
+	ExpectedSalaryMin  sql.NullInt64  `db:"expected_salary_min"`
+	ExpectedSalaryMax  sql.NullInt64  `db:"expected_salary_max"`
+	PreferredJobTypes  sql.NullString `db:"preferred_job_types"`
+	PreferredLocations sql.NullString `db:"preferred_locations"`
+	WillingToRelocate  bool           `db:"willing_to_relocate"`
+	ExperienceMonths   int            `db:"experience_months"`
+	SkillsRaw          sql.NullString `db:"skills"`
+	InvitationStatus   sql.NullString `db:"invitation_status"` // Latest invitation from the viewing company
+
+	// Talent pool membership (pool member lists only)
+	PoolNote sql.NullString `db:"note"`
+	AddedAt  sql.NullTime   `db:"added_at"`
+}
+
+// ContactVisible reports whether the viewing company may see the candidate's contact details.
+// Contact details are shared once the candidate accepts one of the company's invitations.
+func (c *Candidate) ContactVisible() bool {
+	return c.InvitationStatus.Valid && c.InvitationStatus.String == InvitationAccepted
+}
+
+// Skills returns the skill names from the candidate's CV
+func (c *Candidate) Skills() []string {
+	skills := []string{}
+	if !c.SkillsRaw.Valid || c.SkillsRaw.String == "" {
+		return skills
+	}
+
+	var parsed []cvs.Skill
+	if err := json.Unmarshal([]byte(c.SkillsRaw.String), &parsed); err != nil {
+		return skills
+	}
+	for _, skill := range parsed {
+		if skill.Name != "" {
+			skills = append(skills, skill.Name)
+		}
+	}
+	return skills
+}
+
+// JobRef is the job a candidate is invited to
+type JobRef struct {
+	ID          uint64 `db:"id"`
+	CompanyID   uint64 `db:"company_id"`
+	CompanyName string `db:"company_name"`
+	Title       string `db:"title"`
+	Slug        string `db:"slug"`
+	Status      string `db:"status"`
+}
+
+// Invitation is a company's invitation for a job seeker to apply for a job
+type Invitation struct {
+	ID          uint64         `db:"id"`
+	CompanyID   uint64         `db:"company_id"`
+	UserID      uint64         `db:"user_id"`
+	JobID       uint64         `db:"job_id"`
+	Message     sql.NullString `db:"message"`
+	Status      string         `db:"status"`
+	RespondedAt sql.NullTime   `db:"responded_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+
+	// Joined fields
+	CompanyName    string         `db:"company_name"`
+	CompanyLogoURL sql.NullString `db:"company_logo_url"`
+	CompanyUserID  uint64         `db:"company_user_id"`
+	JobTitle       string         `db:"job_title"`
+	JobSlug        string         `db:"job_slug"`
+	CandidateName  string         `db:"candidate_name"`
+	CandidateEmail string         `db:"candidate_email"`
+}
+
+// Pool is a company's saved candidate shortlist
+type Pool struct {
+	ID          uint64         `db:"id"`
+	CompanyID   uint64         `db:"company_id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	MemberCount int            `db:"member_count"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+// SearchParams represents talent search filters
+type SearchParams struct {
+	Query             string   // Matches headline and summary
+	Skills            []string // Candidates must have every skill
+	MinExperience     *int     // Years
+	MaxExperience     *int     // Years
+	Province          string
+	City              string
+	SalaryMin         *int64 // Expected salary range overlaps [SalaryMin, SalaryMax]
+	SalaryMax         *int64
+	WillingToRelocate *bool
+	Page              int
+	PerPage           int
+}
+
+// DefaultSearchParams returns default search parameters
+func DefaultSearchParams() SearchParams {
+	return SearchParams{
+		Page:    1,
+		PerPage: 20,
+	}
+}
+
+// ListParams represents list pagination and status filter
+type ListParams struct {
+	Status  string
+	Page    int
+	PerPage int
+}
+
+// DefaultListParams returns default list parameters
+func DefaultListParams() ListParams {
+	return ListParams{
+		Page:    1,
+		PerPage: 20,
+	}
+}
+
+// ========================================
+// Request DTOs
+// ========================================
+
+// InviteRequest represents a request to invite a candidate to apply for a job
+type InviteRequest struct {
+	JobID   string `json:"job_id" validate:"required"`
+	Message string `json:"message" validate:"omitempty,max=2000"`
+}
+
+// PoolRequest represents a request to create or update a talent pool
+type PoolRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=500"`
+}
+
+// AddPoolMemberRequest represents a request to add a candidate to a talent pool
+type AddPoolMemberRequest struct {
+	CandidateID string `json:"candidate_id" validate:"required"`
+	Note        string `json:"note" validate:"omitempty,max=500"`
+}
+
+// ========================================
+// Response DTOs
+// ========================================
+
+// ContactDetails holds a candidate's contact details
+type ContactDetails struct {
+	Email        string  `json:"email"`
+	Phone        *string `json:"phone,omitempty"`
+	LinkedInURL  *string `json:"linkedin_url,omitempty"`
+	GithubURL    *string `json:"github_url,omitempty"`
+	PortfolioURL *string `json:"portfolio_url,omitempty"`
+}
+
+// CandidateResponse represents a candidate in talent search
+type CandidateResponse struct {
+	ID                 uint64   `json:"id"`
+	HashID             string   `json:"hash_id"`
+	FullName           string   `json:"full_name"`
+	AvatarURL          *string  `json:"avatar_url,omitempty"`
+	Headline           *string  `json:"headline,omitempty"`
+	Summary            *string  `json:"summary,omitempty"`
+	City               *string  `json:"city,omitempty"`
+	Province           *string  `json:"province,omitempty"`
+	Skills             []string `json:"skills"`
+	ExperienceMonths   int      `json:"experience_months"`
+	ExperienceYears    int      `json:"experience_years"`
+	ExpectedSalaryMin  *int64   `json:"expected_salary_min,omitempty"`
+	ExpectedSalaryMax  *int64   `json:"expected_salary_max,omitempty"`
+	PreferredJobTypes  []string `json:"preferred_job_types,omitempty"`
+	PreferredLocations []string `json:"preferred_locations,omitempty"`
+	WillingToRelocate  bool     `json:"willing_to_relocate"`
+	InvitationStatus   *string  `json:"invitation_status,omitempty"`
+
+	// Only after the candidate accepts an invitation
+	Contact *ContactDetails `json:"contact,omitempty"`
+
+	// Talent pool membership
+	PoolNote *string    `json:"pool_note,omitempty"`
+	AddedAt  *time.Time `json:"added_at,omitempty"`
+
+	// CV details (candidate detail only)
+	Education      []cvs.Education     `json:"education,omitempty"`
+	Experience     []cvs.Experience    `json:"experience,omitempty"`
+	Certifications []cvs.Certification `json:"certifications,omitempty"`
+	Languages      []cvs.Language      `json:"languages,omitempty"`
+	Projects       []cvs.Project       `json:"projects,omitempty"`
+}
+
+// ToResponse converts Candidate to CandidateResponse
+func (c *Candidate) ToResponse() *CandidateResponse {
+	resp := &CandidateResponse{
+		ID:                c.UserID,
+		HashID:            hashid.Encode(c.UserID),
+		FullName:          c.FullName,
+		Skills:            c.Skills(),
+		ExperienceMonths:  c.ExperienceMonths,
+		ExperienceYears:   c.ExperienceMonths / 12,
+		WillingToRelocate: c.WillingToRelocate,
+	}
+
+	if c.AvatarURL.Valid {
+		resp.AvatarURL = &c.AvatarURL.String
+	}
+	if c.Headline.Valid {
+		resp.Headline = &c.Headline.String
+	}
+	if c.Summary.Valid {
+		resp.Summary = &c.Summary.String
+	}
+	if c.City.Valid {
+		resp.City = &c.City.String
+	}
+	if c.Province.Valid {
+		resp.Province = &c.Province.String
+	}
+	if c.ExpectedSalaryMin.Valid {
+		resp.ExpectedSalaryMin = &c.ExpectedSalaryMin.Int64
+	}
+	if c.ExpectedSalaryMax.Valid {
+		resp.ExpectedSalaryMax = &c.ExpectedSalaryMax.Int64
+	}
+	if c.PreferredJobTypes.Valid {
+		var jobTypes []string
+		if err := json.Unmarshal([]byte(c.PreferredJobTypes.String), &jobTypes); err == nil {
+			resp.PreferredJobTypes = jobTypes
+		}
+	}
+	if c.PreferredLocations.Valid {
+		var locations []string
+		if err := json.Unmarshal([]byte(c.PreferredLocations.String), &locations); err == nil {
+			resp.PreferredLocations = locations
+		}
+	}
+	if c.InvitationStatus.Valid {
+		resp.InvitationStatus = &c.InvitationStatus.String
+	}
+	if c.PoolNote.Valid {
+		resp.PoolNote = &c.PoolNote.String
+	}
+	if c.AddedAt.Valid {
+		resp.AddedAt = &c.AddedAt.Time
+	}
+
+	if c.ContactVisible() {
+		resp.Contact = &ContactDetails{Email: c.Email}
+		if c.Phone.Valid {
+			resp.Contact.Phone = &c.Phone.String
+		}
+		if c.LinkedInURL.Valid {
+			resp.Contact.LinkedInURL = &c.LinkedInURL.String
+		}
+		if c.GithubURL.Valid {
+			resp.Contact.GithubURL = &c.GithubURL.String
+		}
+		if c.PortfolioURL.Valid {
+			resp.Contact.PortfolioURL = &c.PortfolioURL.String
+		}
+	}
+
+	return resp
+}
+
+// InvitationResponse represents an invitation to apply
+type InvitationResponse struct {
+	ID             uint64     `json:"id"`
+	HashID         string     `json:"hash_id"`
+	Status         string     `json:"status"`
+	Message        *string    `json:"message,omitempty"`
+	JobID          uint64     `json:"job_id"`
+	JobTitle       string     `json:"job_title"`
+	JobSlug        string     `json:"job_slug"`
+	CompanyID      uint64     `json:"company_id"`
+	CompanyName    string     `json:"company_name"`
+	CompanyLogoURL *string    `json:"company_logo_url,omitempty"`
+	CandidateID    uint64     `json:"candidate_id"`
+	CandidateName  string     `json:"candidate_name"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ToResponse converts Invitation to InvitationResponse
+func (i *Invitation) ToResponse() *InvitationResponse {
+	resp := &InvitationResponse{
+		ID:            i.ID,
+		HashID:        hashid.Encode(i.ID),
+		Status:        i.Status,
+		JobID:         i.JobID,
+		JobTitle:      i.JobTitle,
+		JobSlug:       i.JobSlug,
+		CompanyID:     i.CompanyID,
+		CompanyName:   i.CompanyName,
+		CandidateID:   i.UserID,
+		CandidateName: i.CandidateName,
+		CreatedAt:     i.CreatedAt,
+	}
+
+	if i.Message.Valid {
+		resp.Message = &i.Message.String
+	}
+	if i.CompanyLogoURL.Valid {
+		resp.CompanyLogoURL = &i.CompanyLogoURL.String
+	}
+	if i.RespondedAt.Valid {
+		resp.RespondedAt = &i.RespondedAt.Time
+	}
+
+	return resp
+}
+
+// PoolResponse represents a talent pool
+type PoolResponse struct {
+	ID          uint64    `json:"id"`
+	HashID      string    `json:"hash_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToResponse converts Pool to PoolResponse
+func (p *Pool) ToResponse() *PoolResponse {
+	resp := &PoolResponse{
+		ID:          p.ID,
+		HashID:      hashid.Encode(p.ID),
+		Name:        p.Name,
+		MemberCount: p.MemberCount,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+
+	if p.Description.Valid {
+		resp.Description = &p.Description.String
+	}
+
+	return resp
+}
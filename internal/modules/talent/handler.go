@@ -0,0 +1,499 @@
+package talent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/karirnusantara/api/internal/middleware"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/response"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for talent search
+type Handler struct {
+	service   Service
+	validator *validator.Validator
+}
+
+// NewHandler creates a new talent handler
+func NewHandler(service Service, validator *validator.Validator) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// parseID parses an ID which can be either a numeric ID or a hash_id
+func parseID(idStr string) (uint64, error) {
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	if strings.HasPrefix(idStr, "kn_") {
+		return hashid.Decode(idStr)
+	}
+
+	return 0, err
+}
+
+// Search handles searching discoverable candidates
+// GET /talent/candidates
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	params := DefaultSearchParams()
+	query := r.URL.Query()
+
+	params.Page, params.PerPage = parsePagination(query, params.Page, params.PerPage)
+	params.Query = strings.TrimSpace(query.Get("q"))
+	params.Province = strings.TrimSpace(query.Get("province"))
+	params.City = strings.TrimSpace(query.Get("city"))
+
+	// Skills can be repeated (?skills=Go&skills=SQL) or comma separated
+	for _, value := range query["skills"] {
+		for _, skill := range strings.Split(value, ",") {
+			if skill = strings.TrimSpace(skill); skill != "" {
+				params.Skills = append(params.Skills, skill)
+			}
+		}
+	}
+
+	if minExp := query.Get("min_experience"); minExp != "" {
+		if years, err := strconv.Atoi(minExp); err == nil && years >= 0 {
+			params.MinExperience = &years
+		}
+	}
+	if maxExp := query.Get("max_experience"); maxExp != "" {
+		if years, err := strconv.Atoi(maxExp); err == nil && years >= 0 {
+			params.MaxExperience = &years
+		}
+	}
+	if salaryMin := query.Get("salary_min"); salaryMin != "" {
+		if min, err := strconv.ParseInt(salaryMin, 10, 64); err == nil {
+			params.SalaryMin = &min
+		}
+	}
+	if salaryMax := query.Get("salary_max"); salaryMax != "" {
+		if max, err := strconv.ParseInt(salaryMax, 10, 64); err == nil {
+			params.SalaryMax = &max
+		}
+	}
+	if relocate := query.Get("willing_to_relocate"); relocate != "" {
+		willing := relocate == "true"
+		params.WillingToRelocate = &willing
+	}
+
+	candidates, total, err := h.service.Search(r.Context(), companyID, params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Candidates retrieved", candidates, newMeta(params.Page, params.PerPage, total))
+}
+
+// GetCandidate handles getting a candidate's profile and CV
+// GET /talent/candidates/{id}
+func (h *Handler) GetCandidate(w http.ResponseWriter, r *http.Request) {
+	candidateID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid candidate ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	candidate, err := h.service.GetCandidate(r.Context(), companyID, candidateID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Candidate retrieved", candidate)
+}
+
+// Invite handles inviting a candidate to apply for a job
+// POST /talent/candidates/{id}/invitations
+func (h *Handler) Invite(w http.ResponseWriter, r *http.Request) {
+	candidateID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid candidate ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req InviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	jobID, err := parseID(req.JobID)
+	if err != nil {
+		response.BadRequest(w, "Invalid job ID")
+		return
+	}
+
+	invitation, err := h.service.Invite(r.Context(), companyID, candidateID, jobID, req.Message)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Created(w, "Invitation sent", invitation)
+}
+
+// ListSentInvitations handles listing the invitations a company has sent
+// GET /talent/invitations/sent
+func (h *Handler) ListSentInvitations(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	params := parseListParams(r.URL.Query())
+
+	invitations, total, err := h.service.ListCompanyInvitations(r.Context(), companyID, params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Invitations retrieved", invitations, newMeta(params.Page, params.PerPage, total))
+}
+
+// ListMyInvitations handles listing the invitations a job seeker has received
+// GET /talent/invitations
+func (h *Handler) ListMyInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	params := parseListParams(r.URL.Query())
+
+	invitations, total, err := h.service.ListMyInvitations(r.Context(), userID, params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Invitations retrieved", invitations, newMeta(params.Page, params.PerPage, total))
+}
+
+// AcceptInvitation handles a job seeker accepting an invitation
+// POST /talent/invitations/{id}/accept
+func (h *Handler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, true)
+}
+
+// DeclineInvitation handles a job seeker declining an invitation
+// POST /talent/invitations/{id}/decline
+func (h *Handler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, false)
+}
+
+func (h *Handler) respond(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid invitation ID")
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+
+	if accept {
+		invitation, err := h.service.AcceptInvitation(r.Context(), id, userID)
+		if err != nil {
+			handleError(w, err)
+			return
+		}
+		response.OK(w, "Invitation accepted", invitation)
+		return
+	}
+
+	invitation, err := h.service.DeclineInvitation(r.Context(), id, userID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	response.OK(w, "Invitation declined", invitation)
+}
+
+// ListPools handles listing the company's talent pools
+// GET /talent/pools
+func (h *Handler) ListPools(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	pools, err := h.service.ListPools(r.Context(), companyID)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Talent pools retrieved", pools)
+}
+
+// CreatePool handles creating a talent pool
+// POST /talent/pools
+func (h *Handler) CreatePool(w http.ResponseWriter, r *http.Request) {
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req PoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	pool, err := h.service.CreatePool(r.Context(), companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.Created(w, "Talent pool created", pool)
+}
+
+// UpdatePool handles renaming a talent pool
+// PUT /talent/pools/{id}
+func (h *Handler) UpdatePool(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid talent pool ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req PoolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	pool, err := h.service.UpdatePool(r.Context(), id, companyID, &req)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Talent pool updated", pool)
+}
+
+// DeletePool handles deleting a talent pool
+// DELETE /talent/pools/{id}
+func (h *Handler) DeletePool(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid talent pool ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePool(r.Context(), id, companyID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Talent pool deleted", nil)
+}
+
+// ListPoolMembers handles listing the candidates in a talent pool
+// GET /talent/pools/{id}/candidates
+func (h *Handler) ListPoolMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid talent pool ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	params := parseListParams(r.URL.Query())
+
+	candidates, total, err := h.service.ListPoolMembers(r.Context(), id, companyID, params)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.SuccessWithMeta(w, http.StatusOK, "Talent pool candidates retrieved", candidates, newMeta(params.Page, params.PerPage, total))
+}
+
+// AddPoolMember handles adding a candidate to a talent pool
+// POST /talent/pools/{id}/candidates
+func (h *Handler) AddPoolMember(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid talent pool ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AddPoolMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errors := h.validator.Validate(&req); errors != nil {
+		response.UnprocessableEntity(w, "Validation failed", errors)
+		return
+	}
+
+	candidateID, err := parseID(req.CandidateID)
+	if err != nil {
+		response.BadRequest(w, "Invalid candidate ID")
+		return
+	}
+
+	pool, err := h.service.AddPoolMember(r.Context(), id, companyID, candidateID, req.Note)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Candidate added to talent pool", pool)
+}
+
+// RemovePoolMember handles removing a candidate from a talent pool
+// DELETE /talent/pools/{id}/candidates/{candidateId}
+func (h *Handler) RemovePoolMember(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequest(w, "Invalid talent pool ID")
+		return
+	}
+
+	candidateID, err := parseID(chi.URLParam(r, "candidateId"))
+	if err != nil {
+		response.BadRequest(w, "Invalid candidate ID")
+		return
+	}
+
+	companyID, ok := h.companyID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemovePoolMember(r.Context(), id, companyID, candidateID); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	response.OK(w, "Candidate removed from talent pool", nil)
+}
+
+// companyID resolves the authenticated user's verified company, writing an error response on failure
+func (h *Handler) companyID(w http.ResponseWriter, r *http.Request) (uint64, bool) {
+	userID := middleware.GetUserID(r.Context())
+
+	company, err := h.service.GetVerifiedCompany(r.Context(), userID)
+	if err != nil {
+		handleError(w, err)
+		return 0, false
+	}
+	return company.ID, true
+}
+
+// parsePagination reads page and per_page, keeping the defaults for invalid values
+func parsePagination(query url.Values, page, perPage int) (int, int) {
+	if value := query.Get("page"); value != "" {
+		if p, err := strconv.Atoi(value); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if value := query.Get("per_page"); value != "" {
+		if pp, err := strconv.Atoi(value); err == nil && pp > 0 && pp <= 100 {
+			perPage = pp
+		}
+	}
+	return page, perPage
+}
+
+// parseListParams reads the invitation and pool member list parameters
+func parseListParams(query url.Values) ListParams {
+	params := DefaultListParams()
+	params.Page, params.PerPage = parsePagination(query, params.Page, params.PerPage)
+
+	switch status := query.Get("status"); status {
+	case InvitationPending, InvitationAccepted, InvitationDeclined:
+		params.Status = status
+	}
+	return params
+}
+
+func newMeta(page, perPage int, total int64) *response.Meta {
+	totalPages := int(total) / perPage
+	if int(total)%perPage > 0 {
+		totalPages++
+	}
+
+	return &response.Meta{
+		Page:       page,
+		PerPage:    perPage,
+		TotalItems: total,
+		TotalPages: totalPages,
+	}
+}
+
+// handleError handles service errors and returns appropriate HTTP response
+func handleError(w http.ResponseWriter, err error) {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		if appErr.Details != nil {
+			response.ErrorWithDetails(w, appErr.HTTPStatus, appErr.Code, appErr.Message, appErr.Details)
+		} else {
+			response.Error(w, appErr.HTTPStatus, appErr.Code, appErr.Message)
+		}
+		return
+	}
+	response.InternalServerError(w, "An error occurred")
+}
@@ -0,0 +1,496 @@
+package talent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the talent repository interface
+type Repository interface {
+	GetCompanyByUserID(ctx context.Context, userID uint64) (*CompanyRef, error)
+
+	// Candidates
+	Search(ctx context.Context, companyID uint64, params SearchParams) ([]*Candidate, int64, error)
+	GetCandidate(ctx context.Context, companyID, userID uint64) (*Candidate, error)
+
+	// Invitations
+	GetJobRef(ctx context.Context, jobID uint64) (*JobRef, error)
+	HasApplied(ctx context.Context, jobID, userID uint64) (bool, error)
+	CreateInvitation(ctx context.Context, invitation *Invitation) error
+	GetInvitation(ctx context.Context, id uint64) (*Invitation, error)
+	GetInvitationByJobAndUser(ctx context.Context, jobID, userID uint64) (*Invitation, error)
+	ListInvitationsByCompany(ctx context.Context, companyID uint64, params ListParams) ([]*Invitation, int64, error)
+	ListInvitationsByUser(ctx context.Context, userID uint64, params ListParams) ([]*Invitation, int64, error)
+	RespondInvitation(ctx context.Context, id uint64, status string) (bool, error)
+
+	// Pools
+	CreatePool(ctx context.Context, pool *Pool) error
+	GetPool(ctx context.Context, id uint64) (*Pool, error)
+	ListPools(ctx context.Context, companyID uint64) ([]*Pool, error)
+	CountPools(ctx context.Context, companyID uint64) (int, error)
+	PoolNameExists(ctx context.Context, companyID uint64, name string, excludeID uint64) (bool, error)
+	UpdatePool(ctx context.Context, pool *Pool) error
+	DeletePool(ctx context.Context, id uint64) error
+	AddPoolMember(ctx context.Context, poolID, userID uint64, note string) error
+	RemovePoolMember(ctx context.Context, poolID, userID uint64) (bool, error)
+	ListPoolMembers(ctx context.Context, poolID, companyID uint64, params ListParams) ([]*Candidate, int64, error)
+}
+
+type mysqlRepository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new talent repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &mysqlRepository{db: db}
+}
+
+// experienceMonthsExpr is the candidate's total experience in months: finished periods
+// plus the months of the ongoing job (see cvs.SummarizeExperience)
+const experienceMonthsExpr = `(COALESCE(cv.experience_months, 0) + IF(cv.experience_current_since IS NULL, 0,
+	PERIOD_DIFF(DATE_FORMAT(CURDATE(), '%Y%m'), DATE_FORMAT(cv.experience_current_since, '%Y%m'))))`
+
+// candidateColumns selects a Candidate; the first placeholder is the viewing company ID
+const candidateColumns = `
+	u.id AS user_id, u.full_name, u.email, u.phone, u.avatar_url,
+	ap.headline, ap.professional_summary, ap.city, ap.province,
+	ap.linkedin_url, ap.github_url, ap.portfolio_url,
+	ap.expected_salary_min, ap.expected_salary_max, ap.preferred_job_types, ap.preferred_locations,
+	COALESCE(ap.willing_to_relocate, 0) AS willing_to_relocate,
+	` + experienceMonthsExpr + ` AS experience_months,
+	cv.skills,
+	(
+		SELECT ti.status FROM talent_invitations ti
+		WHERE ti.company_id = ? AND ti.user_id = u.id
+		ORDER BY ti.status = 'accepted' DESC, ti.created_at DESC
+		LIMIT 1
+	) AS invitation_status`
+
+// candidateFrom joins job seekers with their profile and CV
+const candidateFrom = `
+	FROM users u
+	JOIN applicant_profiles ap ON ap.user_id = u.id
+	LEFT JOIN cvs cv ON cv.user_id = u.id`
+
+// candidateBase limits results to active job seekers
+const candidateBase = `u.role = 'job_seeker' AND u.is_active = 1 AND u.deleted_at IS NULL`
+
+// candidateVisible limits results to candidates the company may see: those who are
+// discoverable, or who accepted one of the company's invitations. Takes the company ID.
+const candidateVisible = `(ap.is_discoverable = 1 OR EXISTS (
+	SELECT 1 FROM talent_invitations ta
+	WHERE ta.company_id = ? AND ta.user_id = u.id AND ta.status = 'accepted'
+))`
+
+// GetCompanyByUserID gets the company owned by a user
+func (r *mysqlRepository) GetCompanyByUserID(ctx context.Context, userID uint64) (*CompanyRef, error) {
+	var company CompanyRef
+	query := `
+		SELECT id, COALESCE(company_name, '') AS company_name, COALESCE(company_status, 'pending') AS company_status
+		FROM companies
+		WHERE user_id = ? AND deleted_at IS NULL
+	`
+	if err := r.db.GetContext(ctx, &company, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	return &company, nil
+}
+
+// Search finds discoverable candidates matching the filters
+func (r *mysqlRepository) Search(ctx context.Context, companyID uint64, params SearchParams) ([]*Candidate, int64, error) {
+	conditions := []string{candidateBase, "ap.is_discoverable = 1"}
+	var args []interface{}
+
+	if params.Query != "" {
+		conditions = append(conditions, "(ap.headline LIKE ? OR ap.professional_summary LIKE ?)")
+		pattern := "%" + escapeLike(params.Query) + "%"
+		args = append(args, pattern, pattern)
+	}
+
+	for _, skill := range params.Skills {
+		conditions = append(conditions, "JSON_SEARCH(LOWER(cv.skills), 'one', ?, NULL, '$[*].name') IS NOT NULL")
+		args = append(args, strings.ToLower(escapeLike(skill)))
+	}
+
+	if params.MinExperience != nil {
+		conditions = append(conditions, experienceMonthsExpr+" >= ?")
+		args = append(args, *params.MinExperience*12)
+	}
+
+	if params.MaxExperience != nil {
+		// Up to and including the last month of the max year
+		conditions = append(conditions, experienceMonthsExpr+" < ?")
+		args = append(args, (*params.MaxExperience+1)*12)
+	}
+
+	if params.Province != "" {
+		conditions = append(conditions, "ap.province = ?")
+		args = append(args, params.Province)
+	}
+
+	if params.City != "" {
+		conditions = append(conditions, "ap.city = ?")
+		args = append(args, params.City)
+	}
+
+	if params.SalaryMin != nil {
+		conditions = append(conditions, "(ap.expected_salary_max IS NULL OR ap.expected_salary_max >= ?)")
+		args = append(args, *params.SalaryMin)
+	}
+
+	if params.SalaryMax != nil {
+		conditions = append(conditions, "(ap.expected_salary_min IS NULL OR ap.expected_salary_min <= ?)")
+		args = append(args, *params.SalaryMax)
+	}
+
+	if params.WillingToRelocate != nil {
+		conditions = append(conditions, "COALESCE(ap.willing_to_relocate, 0) = ?")
+		args = append(args, *params.WillingToRelocate)
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+
+	// Count total
+	var total int64
+	countQuery := "SELECT COUNT(*)" + candidateFrom + " WHERE " + whereClause
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count candidates: %w", err)
+	}
+
+	if total == 0 {
+		return []*Candidate{}, 0, nil
+	}
+
+	// Most complete profiles first
+	query := "SELECT" + candidateColumns + candidateFrom + " WHERE " + whereClause + `
+		ORDER BY COALESCE(cv.completeness_score, 0) DESC, ap.profile_completeness DESC, u.id DESC
+		LIMIT ? OFFSET ?`
+
+	offset := (params.Page - 1) * params.PerPage
+	queryArgs := append([]interface{}{companyID}, args...)
+	queryArgs = append(queryArgs, params.PerPage, offset)
+
+	var candidates []*Candidate
+	if err := r.db.SelectContext(ctx, &candidates, query, queryArgs...); err != nil {
+		return nil, 0, fmt.Errorf("failed to search candidates: %w", err)
+	}
+
+	return candidates, total, nil
+}
+
+// GetCandidate gets a candidate visible to the company
+func (r *mysqlRepository) GetCandidate(ctx context.Context, companyID, userID uint64) (*Candidate, error) {
+	query := "SELECT" + candidateColumns + candidateFrom + `
+		WHERE u.id = ? AND ` + candidateBase + ` AND ` + candidateVisible
+
+	var candidate Candidate
+	if err := r.db.GetContext(ctx, &candidate, query, companyID, userID, companyID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get candidate: %w", err)
+	}
+	return &candidate, nil
+}
+
+// GetJobRef gets the job a candidate is invited to
+func (r *mysqlRepository) GetJobRef(ctx context.Context, jobID uint64) (*JobRef, error) {
+	var job JobRef
+	query := `
+		SELECT j.id, j.company_id, COALESCE(c.company_name, '') AS company_name, j.title, j.slug, j.status
+		FROM jobs j
+		JOIN companies c ON c.id = j.company_id
+		WHERE j.id = ? AND j.deleted_at IS NULL
+	`
+	if err := r.db.GetContext(ctx, &job, query, jobID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return &job, nil
+}
+
+// HasApplied checks whether the candidate already applied for the job
+func (r *mysqlRepository) HasApplied(ctx context.Context, jobID, userID uint64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM applications WHERE job_id = ? AND user_id = ?)`
+	if err := r.db.GetContext(ctx, &exists, query, jobID, userID); err != nil {
+		return false, fmt.Errorf("failed to check application: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateInvitation creates an invitation to apply
+func (r *mysqlRepository) CreateInvitation(ctx context.Context, invitation *Invitation) error {
+	query := `
+		INSERT INTO talent_invitations (company_id, user_id, job_id, message, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, NOW(), NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		invitation.CompanyID, invitation.UserID, invitation.JobID, invitation.Message, invitation.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	invitation.ID = uint64(id)
+	return nil
+}
+
+const invitationSelect = `
+	SELECT ti.id, ti.company_id, ti.user_id, ti.job_id, ti.message, ti.status,
+		ti.responded_at, ti.created_at, ti.updated_at,
+		COALESCE(c.company_name, '') AS company_name, c.company_logo_url, c.user_id AS company_user_id,
+		j.title AS job_title, j.slug AS job_slug,
+		u.full_name AS candidate_name, u.email AS candidate_email
+	FROM talent_invitations ti
+	JOIN companies c ON c.id = ti.company_id
+	JOIN jobs j ON j.id = ti.job_id
+	JOIN users u ON u.id = ti.user_id`
+
+// GetInvitation gets an invitation by ID
+func (r *mysqlRepository) GetInvitation(ctx context.Context, id uint64) (*Invitation, error) {
+	var invitation Invitation
+	if err := r.db.GetContext(ctx, &invitation, invitationSelect+" WHERE ti.id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// GetInvitationByJobAndUser gets the invitation for a job and candidate
+func (r *mysqlRepository) GetInvitationByJobAndUser(ctx context.Context, jobID, userID uint64) (*Invitation, error) {
+	var invitation Invitation
+	if err := r.db.GetContext(ctx, &invitation, invitationSelect+" WHERE ti.job_id = ? AND ti.user_id = ?", jobID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// ListInvitationsByCompany lists the invitations a company has sent
+func (r *mysqlRepository) ListInvitationsByCompany(ctx context.Context, companyID uint64, params ListParams) ([]*Invitation, int64, error) {
+	return r.listInvitations(ctx, "ti.company_id = ?", companyID, params)
+}
+
+// ListInvitationsByUser lists the invitations a job seeker has received
+func (r *mysqlRepository) ListInvitationsByUser(ctx context.Context, userID uint64, params ListParams) ([]*Invitation, int64, error) {
+	return r.listInvitations(ctx, "ti.user_id = ?", userID, params)
+}
+
+func (r *mysqlRepository) listInvitations(ctx context.Context, ownerCondition string, ownerID uint64, params ListParams) ([]*Invitation, int64, error) {
+	conditions := []string{ownerCondition}
+	args := []interface{}{ownerID}
+
+	if params.Status != "" {
+		conditions = append(conditions, "ti.status = ?")
+		args = append(args, params.Status)
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM talent_invitations ti WHERE " + whereClause
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count invitations: %w", err)
+	}
+
+	if total == 0 {
+		return []*Invitation{}, 0, nil
+	}
+
+	query := invitationSelect + " WHERE " + whereClause + " ORDER BY ti.created_at DESC LIMIT ? OFFSET ?"
+	offset := (params.Page - 1) * params.PerPage
+	args = append(args, params.PerPage, offset)
+
+	var invitations []*Invitation
+	if err := r.db.SelectContext(ctx, &invitations, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	return invitations, total, nil
+}
+
+// RespondInvitation records the candidate's answer to a pending invitation.
+// It returns false when the invitation was no longer pending.
+func (r *mysqlRepository) RespondInvitation(ctx context.Context, id uint64, status string) (bool, error) {
+	query := `
+		UPDATE talent_invitations
+		SET status = ?, responded_at = NOW(), updated_at = NOW()
+		WHERE id = ? AND status = 'pending'
+	`
+	result, err := r.db.ExecContext(ctx, query, status, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to respond to invitation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// CreatePool creates a talent pool
+func (r *mysqlRepository) CreatePool(ctx context.Context, pool *Pool) error {
+	query := `
+		INSERT INTO talent_pools (company_id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, NOW(), NOW())
+	`
+	result, err := r.db.ExecContext(ctx, query, pool.CompanyID, pool.Name, pool.Description)
+	if err != nil {
+		return fmt.Errorf("failed to create talent pool: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	pool.ID = uint64(id)
+	return nil
+}
+
+const poolSelect = `
+	SELECT p.id, p.company_id, p.name, p.description, p.created_at, p.updated_at,
+		(SELECT COUNT(*) FROM talent_pool_members m WHERE m.pool_id = p.id) AS member_count
+	FROM talent_pools p`
+
+// GetPool gets a talent pool by ID
+func (r *mysqlRepository) GetPool(ctx context.Context, id uint64) (*Pool, error) {
+	var pool Pool
+	if err := r.db.GetContext(ctx, &pool, poolSelect+" WHERE p.id = ?", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get talent pool: %w", err)
+	}
+	return &pool, nil
+}
+
+// ListPools lists a company's talent pools
+func (r *mysqlRepository) ListPools(ctx context.Context, companyID uint64) ([]*Pool, error) {
+	var pools []*Pool
+	if err := r.db.SelectContext(ctx, &pools, poolSelect+" WHERE p.company_id = ? ORDER BY p.name", companyID); err != nil {
+		return nil, fmt.Errorf("failed to list talent pools: %w", err)
+	}
+	return pools, nil
+}
+
+// CountPools counts a company's talent pools
+func (r *mysqlRepository) CountPools(ctx context.Context, companyID uint64) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM talent_pools WHERE company_id = ?`, companyID); err != nil {
+		return 0, fmt.Errorf("failed to count talent pools: %w", err)
+	}
+	return count, nil
+}
+
+// PoolNameExists checks whether the company already has a pool with the name
+func (r *mysqlRepository) PoolNameExists(ctx context.Context, companyID uint64, name string, excludeID uint64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM talent_pools WHERE company_id = ? AND name = ? AND id != ?)`
+	if err := r.db.GetContext(ctx, &exists, query, companyID, name, excludeID); err != nil {
+		return false, fmt.Errorf("failed to check talent pool name: %w", err)
+	}
+	return exists, nil
+}
+
+// UpdatePool updates a talent pool's name and description
+func (r *mysqlRepository) UpdatePool(ctx context.Context, pool *Pool) error {
+	query := `UPDATE talent_pools SET name = ?, description = ?, updated_at = NOW() WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, pool.Name, pool.Description, pool.ID); err != nil {
+		return fmt.Errorf("failed to update talent pool: %w", err)
+	}
+	return nil
+}
+
+// DeletePool deletes a talent pool and its members
+func (r *mysqlRepository) DeletePool(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM talent_pools WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete talent pool: %w", err)
+	}
+	return nil
+}
+
+// AddPoolMember adds a candidate to a talent pool, updating the note if already added
+func (r *mysqlRepository) AddPoolMember(ctx context.Context, poolID, userID uint64, note string) error {
+	query := `
+		INSERT INTO talent_pool_members (pool_id, user_id, note, added_at)
+		VALUES (?, ?, ?, NOW())
+		ON DUPLICATE KEY UPDATE note = VALUES(note)
+	`
+	noteValue := sql.NullString{String: note, Valid: note != ""}
+	if _, err := r.db.ExecContext(ctx, query, poolID, userID, noteValue); err != nil {
+		return fmt.Errorf("failed to add talent pool member: %w", err)
+	}
+
+	_, err := r.db.ExecContext(ctx, `UPDATE talent_pools SET updated_at = NOW() WHERE id = ?`, poolID)
+	if err != nil {
+		return fmt.Errorf("failed to update talent pool: %w", err)
+	}
+	return nil
+}
+
+// RemovePoolMember removes a candidate from a talent pool
+func (r *mysqlRepository) RemovePoolMember(ctx context.Context, poolID, userID uint64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM talent_pool_members WHERE pool_id = ? AND user_id = ?`, poolID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove talent pool member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListPoolMembers lists the candidates in a talent pool that are still visible to the company
+func (r *mysqlRepository) ListPoolMembers(ctx context.Context, poolID, companyID uint64, params ListParams) ([]*Candidate, int64, error) {
+	from := candidateFrom + `
+		JOIN talent_pool_members m ON m.user_id = u.id
+		WHERE m.pool_id = ? AND ` + candidateBase + ` AND ` + candidateVisible
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*)"+from, poolID, companyID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count talent pool members: %w", err)
+	}
+
+	if total == 0 {
+		return []*Candidate{}, 0, nil
+	}
+
+	query := "SELECT" + candidateColumns + ", m.note, m.added_at" + from + " ORDER BY m.added_at DESC LIMIT ? OFFSET ?"
+	offset := (params.Page - 1) * params.PerPage
+
+	var candidates []*Candidate
+	if err := r.db.SelectContext(ctx, &candidates, query, companyID, poolID, companyID, params.PerPage, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list talent pool members: %w", err)
+	}
+
+	return candidates, total, nil
+}
+
+// escapeLike escapes LIKE and JSON_SEARCH wildcards
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
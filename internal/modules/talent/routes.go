@@ -0,0 +1,48 @@
+package talent
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MiddlewareFunc defines the middleware function type
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// RegisterRoutes registers the talent search routes
+func RegisterRoutes(r chi.Router, h *Handler, authenticate, requireJobSeeker, requireCompany MiddlewareFunc) {
+	r.Route("/talent", func(r chi.Router) {
+		r.Use(authenticate)
+
+		// Company only routes (the company must be verified)
+		r.Group(func(r chi.Router) {
+			r.Use(requireCompany)
+
+			// Candidate search
+			r.Get("/candidates", h.Search)
+			r.Get("/candidates/{id}", h.GetCandidate)
+			r.Post("/candidates/{id}/invitations", h.Invite)
+
+			// Sent invitations
+			r.Get("/invitations/sent", h.ListSentInvitations)
+
+			// Talent pools
+			r.Get("/pools", h.ListPools)
+			r.Post("/pools", h.CreatePool)
+			r.Put("/pools/{id}", h.UpdatePool)
+			r.Delete("/pools/{id}", h.DeletePool)
+			r.Get("/pools/{id}/candidates", h.ListPoolMembers)
+			r.Post("/pools/{id}/candidates", h.AddPoolMember)
+			r.Delete("/pools/{id}/candidates/{candidateId}", h.RemovePoolMember)
+		})
+
+		// Job seeker only routes
+		r.Group(func(r chi.Router) {
+			r.Use(requireJobSeeker)
+
+			r.Get("/invitations", h.ListMyInvitations)
+			r.Post("/invitations/{id}/accept", h.AcceptInvitation)
+			r.Post("/invitations/{id}/decline", h.DeclineInvitation)
+		})
+	})
+}
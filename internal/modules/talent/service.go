@@ -0,0 +1,475 @@
+package talent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/karirnusantara/api/internal/modules/cvs"
+	"github.com/karirnusantara/api/internal/modules/jobs"
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/shared/email"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Service defines the talent service interface
+type Service interface {
+	// GetVerifiedCompany returns the user's company, which must be verified to use talent search
+	GetVerifiedCompany(ctx context.Context, userID uint64) (*CompanyRef, error)
+
+	// Company
+	Search(ctx context.Context, companyID uint64, params SearchParams) ([]*CandidateResponse, int64, error)
+	GetCandidate(ctx context.Context, companyID, candidateID uint64) (*CandidateResponse, error)
+	Invite(ctx context.Context, companyID, candidateID, jobID uint64, message string) (*InvitationResponse, error)
+	ListCompanyInvitations(ctx context.Context, companyID uint64, params ListParams) ([]*InvitationResponse, int64, error)
+
+	ListPools(ctx context.Context, companyID uint64) ([]*PoolResponse, error)
+	CreatePool(ctx context.Context, companyID uint64, req *PoolRequest) (*PoolResponse, error)
+	UpdatePool(ctx context.Context, id, companyID uint64, req *PoolRequest) (*PoolResponse, error)
+	DeletePool(ctx context.Context, id, companyID uint64) error
+	ListPoolMembers(ctx context.Context, id, companyID uint64, params ListParams) ([]*CandidateResponse, int64, error)
+	AddPoolMember(ctx context.Context, id, companyID, candidateID uint64, note string) (*PoolResponse, error)
+	RemovePoolMember(ctx context.Context, id, companyID, candidateID uint64) error
+
+	// Job seeker
+	ListMyInvitations(ctx context.Context, userID uint64, params ListParams) ([]*InvitationResponse, int64, error)
+	AcceptInvitation(ctx context.Context, id, userID uint64) (*InvitationResponse, error)
+	DeclineInvitation(ctx context.Context, id, userID uint64) (*InvitationResponse, error)
+}
+
+type service struct {
+	repo                Repository
+	cvService           cvs.Service
+	emailService        *email.Service
+	notificationService notifications.Service
+}
+
+// NewService creates a new talent service
+func NewService(repo Repository, cvService cvs.Service, emailService *email.Service, notificationService notifications.Service) Service {
+	return &service{
+		repo:                repo,
+		cvService:           cvService,
+		emailService:        emailService,
+		notificationService: notificationService,
+	}
+}
+
+// GetVerifiedCompany returns the user's company if it is verified
+func (s *service) GetVerifiedCompany(ctx context.Context, userID uint64) (*CompanyRef, error) {
+	company, err := s.repo.GetCompanyByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get company", err)
+	}
+	if company == nil {
+		return nil, apperrors.NewNotFoundError("Company")
+	}
+	if !company.IsVerified() {
+		return nil, apperrors.NewForbiddenError("Talent search is only available to verified companies")
+	}
+	return company, nil
+}
+
+// Search finds discoverable candidates
+func (s *service) Search(ctx context.Context, companyID uint64, params SearchParams) ([]*CandidateResponse, int64, error) {
+	if len(params.Skills) > MaxSkillFilters {
+		return nil, 0, apperrors.NewBadRequestError(fmt.Sprintf("At most %d skills can be searched at once", MaxSkillFilters))
+	}
+	if params.MinExperience != nil && params.MaxExperience != nil && *params.MinExperience > *params.MaxExperience {
+		return nil, 0, apperrors.NewBadRequestError("min_experience must not be greater than max_experience")
+	}
+	if params.SalaryMin != nil && params.SalaryMax != nil && *params.SalaryMin > *params.SalaryMax {
+		return nil, 0, apperrors.NewBadRequestError("salary_min must not be greater than salary_max")
+	}
+
+	candidates, total, err := s.repo.Search(ctx, companyID, params)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("Failed to search candidates", err)
+	}
+
+	responses := make([]*CandidateResponse, len(candidates))
+	for i, candidate := range candidates {
+		responses[i] = candidate.ToResponse()
+	}
+	return responses, total, nil
+}
+
+// GetCandidate returns a candidate with their CV. Contact details are only included
+// once the candidate has accepted one of the company's invitations.
+func (s *service) GetCandidate(ctx context.Context, companyID, candidateID uint64) (*CandidateResponse, error) {
+	candidate, err := s.getCandidate(ctx, companyID, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := candidate.ToResponse()
+
+	cv, err := s.cvService.GetByUserID(ctx, candidateID)
+	if err != nil {
+		if appErr := apperrors.GetAppError(err); appErr == nil || appErr.Code != apperrors.ErrCodeNotFound {
+			return nil, err
+		}
+	}
+	if cv != nil {
+		// Personal info is left out: it holds the contact details
+		resp.Education = cv.Education
+		resp.Experience = cv.Experience
+		resp.Certifications = cv.Certifications
+		resp.Languages = cv.Languages
+		resp.Projects = cv.Projects
+	}
+
+	return resp, nil
+}
+
+// Invite invites a candidate to apply for one of the company's active jobs
+func (s *service) Invite(ctx context.Context, companyID, candidateID, jobID uint64, message string) (*InvitationResponse, error) {
+	job, err := s.repo.GetJobRef(ctx, jobID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get job", err)
+	}
+	if job == nil || job.CompanyID != companyID {
+		return nil, apperrors.NewNotFoundError("Job")
+	}
+	if job.Status != jobs.JobStatusActive {
+		return nil, apperrors.NewBadRequestError("Candidates can only be invited to active jobs")
+	}
+
+	if _, err := s.getCandidate(ctx, companyID, candidateID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetInvitationByJobAndUser(ctx, jobID, candidateID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to check invitation", err)
+	}
+	if existing != nil {
+		return nil, apperrors.NewConflictError("Candidate has already been invited to this job")
+	}
+
+	applied, err := s.repo.HasApplied(ctx, jobID, candidateID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to check application", err)
+	}
+	if applied {
+		return nil, apperrors.NewConflictError("Candidate has already applied for this job")
+	}
+
+	invitation := &Invitation{
+		CompanyID: companyID,
+		UserID:    candidateID,
+		JobID:     jobID,
+		Status:    InvitationPending,
+	}
+	if message = strings.TrimSpace(message); message != "" {
+		invitation.Message = sql.NullString{String: message, Valid: true}
+	}
+
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, apperrors.NewInternalError("Failed to create invitation", err)
+	}
+
+	invitation, err = s.repo.GetInvitation(ctx, invitation.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get invitation", err)
+	}
+
+	logger.FromContext(ctx).Info("talent invitation sent", "company_id", companyID, "candidate_id", candidateID, "job_id", jobID)
+	s.notifyCandidate(ctx, invitation)
+
+	return invitation.ToResponse(), nil
+}
+
+// ListCompanyInvitations lists the invitations a company has sent
+func (s *service) ListCompanyInvitations(ctx context.Context, companyID uint64, params ListParams) ([]*InvitationResponse, int64, error) {
+	invitations, total, err := s.repo.ListInvitationsByCompany(ctx, companyID, params)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("Failed to list invitations", err)
+	}
+	return toInvitationResponses(invitations), total, nil
+}
+
+// ListMyInvitations lists the invitations a job seeker has received
+func (s *service) ListMyInvitations(ctx context.Context, userID uint64, params ListParams) ([]*InvitationResponse, int64, error) {
+	invitations, total, err := s.repo.ListInvitationsByUser(ctx, userID, params)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("Failed to list invitations", err)
+	}
+	return toInvitationResponses(invitations), total, nil
+}
+
+// AcceptInvitation accepts an invitation, sharing the job seeker's contact details with the company
+func (s *service) AcceptInvitation(ctx context.Context, id, userID uint64) (*InvitationResponse, error) {
+	return s.respond(ctx, id, userID, InvitationAccepted)
+}
+
+// DeclineInvitation declines an invitation
+func (s *service) DeclineInvitation(ctx context.Context, id, userID uint64) (*InvitationResponse, error) {
+	return s.respond(ctx, id, userID, InvitationDeclined)
+}
+
+func (s *service) respond(ctx context.Context, id, userID uint64, status string) (*InvitationResponse, error) {
+	invitation, err := s.repo.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get invitation", err)
+	}
+	if invitation == nil || invitation.UserID != userID {
+		return nil, apperrors.NewNotFoundError("Invitation")
+	}
+
+	updated, err := s.repo.RespondInvitation(ctx, id, status)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to respond to invitation", err)
+	}
+	if !updated {
+		return nil, apperrors.NewBadRequestError("Invitation has already been answered")
+	}
+
+	invitation, err = s.repo.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get invitation", err)
+	}
+
+	logger.FromContext(ctx).Info("talent invitation answered", "invitation_id", id, "status", status, "company_id", invitation.CompanyID)
+	s.notifyCompany(ctx, invitation)
+
+	return invitation.ToResponse(), nil
+}
+
+// ListPools lists a company's talent pools
+func (s *service) ListPools(ctx context.Context, companyID uint64) ([]*PoolResponse, error) {
+	pools, err := s.repo.ListPools(ctx, companyID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to list talent pools", err)
+	}
+
+	responses := make([]*PoolResponse, len(pools))
+	for i, pool := range pools {
+		responses[i] = pool.ToResponse()
+	}
+	return responses, nil
+}
+
+// CreatePool creates a talent pool
+func (s *service) CreatePool(ctx context.Context, companyID uint64, req *PoolRequest) (*PoolResponse, error) {
+	count, err := s.repo.CountPools(ctx, companyID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to count talent pools", err)
+	}
+	if count >= MaxPoolsPerCompany {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("A company can have at most %d talent pools", MaxPoolsPerCompany))
+	}
+
+	pool := &Pool{CompanyID: companyID}
+	if err := s.applyPoolRequest(ctx, pool, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreatePool(ctx, pool); err != nil {
+		return nil, apperrors.NewInternalError("Failed to create talent pool", err)
+	}
+
+	return s.getPoolResponse(ctx, pool.ID)
+}
+
+// UpdatePool renames a talent pool or changes its description
+func (s *service) UpdatePool(ctx context.Context, id, companyID uint64, req *PoolRequest) (*PoolResponse, error) {
+	pool, err := s.getPool(ctx, id, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyPoolRequest(ctx, pool, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePool(ctx, pool); err != nil {
+		return nil, apperrors.NewInternalError("Failed to update talent pool", err)
+	}
+
+	return s.getPoolResponse(ctx, pool.ID)
+}
+
+// DeletePool deletes a talent pool
+func (s *service) DeletePool(ctx context.Context, id, companyID uint64) error {
+	if _, err := s.getPool(ctx, id, companyID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePool(ctx, id); err != nil {
+		return apperrors.NewInternalError("Failed to delete talent pool", err)
+	}
+	return nil
+}
+
+// ListPoolMembers lists the candidates in a talent pool. Candidates who are no longer
+// discoverable are left out unless they accepted one of the company's invitations.
+func (s *service) ListPoolMembers(ctx context.Context, id, companyID uint64, params ListParams) ([]*CandidateResponse, int64, error) {
+	if _, err := s.getPool(ctx, id, companyID); err != nil {
+		return nil, 0, err
+	}
+
+	candidates, total, err := s.repo.ListPoolMembers(ctx, id, companyID, params)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("Failed to list talent pool members", err)
+	}
+
+	responses := make([]*CandidateResponse, len(candidates))
+	for i, candidate := range candidates {
+		responses[i] = candidate.ToResponse()
+	}
+	return responses, total, nil
+}
+
+// AddPoolMember adds a candidate to a talent pool
+func (s *service) AddPoolMember(ctx context.Context, id, companyID, candidateID uint64, note string) (*PoolResponse, error) {
+	if _, err := s.getPool(ctx, id, companyID); err != nil {
+		return nil, err
+	}
+	if _, err := s.getCandidate(ctx, companyID, candidateID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddPoolMember(ctx, id, candidateID, strings.TrimSpace(note)); err != nil {
+		return nil, apperrors.NewInternalError("Failed to add candidate to talent pool", err)
+	}
+
+	return s.getPoolResponse(ctx, id)
+}
+
+// RemovePoolMember removes a candidate from a talent pool
+func (s *service) RemovePoolMember(ctx context.Context, id, companyID, candidateID uint64) error {
+	if _, err := s.getPool(ctx, id, companyID); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemovePoolMember(ctx, id, candidateID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to remove candidate from talent pool", err)
+	}
+	if !removed {
+		return apperrors.NewNotFoundError("Candidate")
+	}
+	return nil
+}
+
+// getCandidate loads a candidate the company may see
+func (s *service) getCandidate(ctx context.Context, companyID, candidateID uint64) (*Candidate, error) {
+	candidate, err := s.repo.GetCandidate(ctx, companyID, candidateID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get candidate", err)
+	}
+	if candidate == nil {
+		return nil, apperrors.NewNotFoundError("Candidate")
+	}
+	return candidate, nil
+}
+
+// getPool loads a talent pool owned by the company
+func (s *service) getPool(ctx context.Context, id, companyID uint64) (*Pool, error) {
+	pool, err := s.repo.GetPool(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get talent pool", err)
+	}
+	if pool == nil || pool.CompanyID != companyID {
+		return nil, apperrors.NewNotFoundError("Talent pool")
+	}
+	return pool, nil
+}
+
+func (s *service) getPoolResponse(ctx context.Context, id uint64) (*PoolResponse, error) {
+	pool, err := s.repo.GetPool(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to get talent pool", err)
+	}
+	if pool == nil {
+		return nil, apperrors.NewNotFoundError("Talent pool")
+	}
+	return pool.ToResponse(), nil
+}
+
+// applyPoolRequest sets the pool name and description, keeping names unique per company
+func (s *service) applyPoolRequest(ctx context.Context, pool *Pool, req *PoolRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apperrors.NewValidationError("Validation failed", map[string]string{"name": "name is required"})
+	}
+
+	exists, err := s.repo.PoolNameExists(ctx, pool.CompanyID, name, pool.ID)
+	if err != nil {
+		return apperrors.NewInternalError("Failed to check talent pool name", err)
+	}
+	if exists {
+		return apperrors.NewConflictError("A talent pool with this name already exists")
+	}
+
+	pool.Name = name
+	description := strings.TrimSpace(req.Description)
+	pool.Description = sql.NullString{String: description, Valid: description != ""}
+	return nil
+}
+
+// notifyCandidate tells the job seeker about a new invitation
+func (s *service) notifyCandidate(ctx context.Context, invitation *Invitation) {
+	if s.notificationService != nil {
+		title := "Undangan melamar pekerjaan"
+		message := fmt.Sprintf("%s mengundang Anda untuk melamar posisi %s.", invitation.CompanyName, invitation.JobTitle)
+		data := map[string]interface{}{
+			"invitation_id": invitation.ID,
+			"job_id":        invitation.JobID,
+			"job_slug":      invitation.JobSlug,
+		}
+		if err := s.notificationService.Notify(ctx, invitation.UserID, notifications.TypeTalentInvitation, title, message, data); err != nil {
+			logger.FromContext(ctx).Error("failed to notify candidate of talent invitation", "candidate_id", invitation.UserID, "invitation_id", invitation.ID, "error", err)
+		}
+	}
+
+	if s.emailService == nil || invitation.CandidateEmail == "" {
+		return
+	}
+
+	data := email.TalentInvitationData{
+		FullName:    invitation.CandidateName,
+		CompanyName: invitation.CompanyName,
+		JobTitle:    invitation.JobTitle,
+		JobSlug:     invitation.JobSlug,
+		Message:     invitation.Message.String,
+	}
+	// Queue email (don't fail if email fails)
+	if err := s.emailService.SendTalentInvitationEmail(invitation.CandidateEmail, data); err != nil {
+		logger.FromContext(ctx).Error("failed to send talent invitation email", "invitation_id", invitation.ID, "to", invitation.CandidateEmail, "error", err)
+	}
+}
+
+// notifyCompany tells the company how the job seeker answered an invitation
+func (s *service) notifyCompany(ctx context.Context, invitation *Invitation) {
+	if s.notificationService == nil {
+		return
+	}
+
+	title := "Undangan diterima"
+	message := fmt.Sprintf("%s menerima undangan Anda untuk posisi %s. Kontak kandidat kini dapat dilihat.", invitation.CandidateName, invitation.JobTitle)
+	if invitation.Status == InvitationDeclined {
+		title = "Undangan ditolak"
+		message = fmt.Sprintf("%s menolak undangan Anda untuk posisi %s.", invitation.CandidateName, invitation.JobTitle)
+	}
+
+	data := map[string]interface{}{
+		"invitation_id": invitation.ID,
+		"candidate_id":  invitation.UserID,
+		"job_id":        invitation.JobID,
+		"status":        invitation.Status,
+	}
+	if err := s.notificationService.Notify(ctx, invitation.CompanyUserID, notifications.TypeTalentResponse, title, message, data); err != nil {
+		logger.FromContext(ctx).Error("failed to notify company of talent invitation response", "company_id", invitation.CompanyID, "invitation_id", invitation.ID, "error", err)
+	}
+}
+
+func toInvitationResponses(invitations []*Invitation) []*InvitationResponse {
+	responses := make([]*InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = invitation.ToResponse()
+	}
+	return responses
+}
@@ -1,12 +1,15 @@
 package tickets
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/token"
 )
 
-// RegisterRoutes registers ticket routes
-func RegisterRoutes(r chi.Router, h *Handler, authMiddleware *middleware.AuthMiddleware) {
+// RegisterRoutes registers ticket routes. canRead and canReply check the admin's support permissions.
+func RegisterRoutes(r chi.Router, h *Handler, authMiddleware *middleware.AuthMiddleware, canRead, canReply func(http.Handler) http.Handler) {
 	// Job Seeker routes
 	r.Route("/tickets", func(r chi.Router) {
 		r.Use(authMiddleware.Authenticate)
@@ -20,15 +23,16 @@ func RegisterRoutes(r chi.Router, h *Handler, authMiddleware *middleware.AuthMid
 	})
 
 	// Admin routes
+	adminAuth := authMiddleware.ForPortal(token.PortalAdmin)
 	r.Route("/admin/tickets", func(r chi.Router) {
-		r.Use(authMiddleware.Authenticate)
-		r.Use(authMiddleware.RequireAdmin)
+		r.Use(adminAuth.Authenticate)
+		r.Use(adminAuth.RequireAdmin)
 
-		r.Get("/", h.AdminGetAllTickets)
-		r.Get("/{id}", h.AdminGetTicket)
-		r.Post("/{id}/responses", h.AdminAddResponse)
-		r.Patch("/{id}/status", h.AdminUpdateTicketStatus)
-		r.Post("/{id}/close", h.AdminCloseTicket)
-		r.Post("/{id}/resolve", h.AdminResolveTicket)
+		r.With(canRead).Get("/", h.AdminGetAllTickets)
+		r.With(canRead).Get("/{id}", h.AdminGetTicket)
+		r.With(canReply).Post("/{id}/responses", h.AdminAddResponse)
+		r.With(canReply).Patch("/{id}/status", h.AdminUpdateTicketStatus)
+		r.With(canReply).Post("/{id}/close", h.AdminCloseTicket)
+		r.With(canReply).Post("/{id}/resolve", h.AdminResolveTicket)
 	})
 }
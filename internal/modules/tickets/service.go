@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/shared/logger"
 )
 
 // Service defines ticket business logic
@@ -23,7 +26,8 @@ type Service interface {
 }
 
 type service struct {
-	repo Repository
+	repo                Repository
+	notificationService notifications.Service
 }
 
 // NewService creates a new ticket service
@@ -31,6 +35,14 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
+// NewServiceWithNotifications creates a new ticket service with in-app notifications
+func NewServiceWithNotifications(repo Repository, notificationService notifications.Service) Service {
+	return &service{
+		repo:                repo,
+		notificationService: notificationService,
+	}
+}
+
 // CreateTicket creates a new support ticket
 func (s *service) CreateTicket(ctx context.Context, userID uint64, req *CreateTicketRequest) (*TicketWithDetails, error) {
 	// Check cooldown
@@ -77,12 +89,21 @@ func (s *service) CreateTicket(ctx context.Context, userID uint64, req *CreateTi
 	err = s.repo.CreateResponse(ctx, initialResp)
 	if err != nil {
 		// Log but don't fail
-		fmt.Printf("Warning: failed to create initial response: %v\n", err)
+		logger.FromContext(ctx).Error("failed to create initial ticket response", "ticket_id", ticket.ID, "error", err)
 	}
 
 	// Reset status to open after initial response
 	_ = s.repo.UpdateTicketStatus(ctx, ticket.ID, TicketStatusOpen)
 
+	// Notify admins about the new ticket
+	if s.notificationService != nil {
+		data := map[string]interface{}{"ticket_id": ticket.ID}
+		message := fmt.Sprintf("Ticket baru: %s", ticket.Title)
+		if err := s.notificationService.NotifyAdmins(ctx, notifications.TypeTicketCreated, "Ticket support baru", message, data); err != nil {
+			logger.FromContext(ctx).Error("failed to notify admins of new ticket", "ticket_id", ticket.ID, "error", err)
+		}
+	}
+
 	// Get ticket with details
 	return s.repo.GetTicketByID(ctx, ticket.ID)
 }
@@ -174,6 +195,8 @@ func (s *service) AddResponse(ctx context.Context, ticketID uint64, userID uint6
 		return nil, err
 	}
 
+	s.notifyTicketReply(ctx, ticket, senderType)
+
 	// Return response with sender info
 	return &TicketResponseWithSender{
 		TicketResponse: *resp,
@@ -182,6 +205,27 @@ func (s *service) AddResponse(ctx context.Context, ticketID uint64, userID uint6
 	}, nil
 }
 
+// notifyTicketReply notifies the other party of a ticket about a new response
+func (s *service) notifyTicketReply(ctx context.Context, ticket *TicketWithDetails, senderType string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	data := map[string]interface{}{"ticket_id": ticket.ID}
+	title := "Balasan ticket baru"
+	message := fmt.Sprintf("Ada balasan baru pada ticket: %s", ticket.Title)
+
+	var err error
+	if senderType == "admin" {
+		err = s.notificationService.Notify(ctx, ticket.UserID, notifications.TypeTicketReply, title, message, data)
+	} else {
+		err = s.notificationService.NotifyAdmins(ctx, notifications.TypeTicketReply, title, message, data)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to notify ticket reply", "ticket_id", ticket.ID, "error", err)
+	}
+}
+
 // CheckCooldown checks if user can create a new ticket
 func (s *service) CheckCooldown(ctx context.Context, userID uint64) (bool, time.Duration, error) {
 	return s.repo.CanCreateTicket(ctx, userID)
@@ -0,0 +1,143 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Calendar methods (RFC 5546)
+const (
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+)
+
+// productID identifies the application that produced the calendar
+const productID = "-//Karir Nusantara//Interview Scheduler//ID"
+
+// maxLineOctets is the maximum content line length before folding (RFC 5545 section 3.1)
+const maxLineOctets = 75
+
+// Person is an organizer or attendee of an event
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Event is a single calendar event
+type Event struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   Person
+	Attendees   []Person
+}
+
+// Build renders the event as an iCalendar (RFC 5545) object for the given method.
+// A CANCEL keeps the UID and must carry a sequence higher than the last invite.
+func Build(method string, event Event, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(content string) {
+		writeFolded(&buf, content)
+	}
+
+	status := "CONFIRMED"
+	if method == MethodCancel {
+		status = "CANCELLED"
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("PRODID:" + productID)
+	line("VERSION:2.0")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + method)
+	line("BEGIN:VEVENT")
+	line("UID:" + event.UID)
+	line(fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+	line("DTSTAMP:" + formatTime(now))
+	line("DTSTART:" + formatTime(event.Start))
+	line("DTEND:" + formatTime(event.End))
+	line("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.Location != "" {
+		line("LOCATION:" + escapeText(event.Location))
+	}
+	if event.URL != "" {
+		line("URL:" + event.URL)
+	}
+	if event.Organizer.Email != "" {
+		line(fmt.Sprintf("ORGANIZER;CN=%s:mailto:%s", quoteParam(event.Organizer.Name), event.Organizer.Email))
+	}
+	for _, attendee := range event.Attendees {
+		line(fmt.Sprintf("ATTENDEE;CN=%s;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=FALSE:mailto:%s", quoteParam(attendee.Name), attendee.Email))
+	}
+	line("STATUS:" + status)
+	line("TRANSP:OPAQUE")
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// ContentType returns the MIME type of a calendar object for the given method
+func ContentType(method string) string {
+	return fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", method)
+}
+
+// formatTime formats a time as a UTC DATE-TIME value
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// quoteParam quotes a parameter value, dropping characters that are not allowed inside quotes
+func quoteParam(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '"' || r < 0x20 {
+			return -1
+		}
+		return r
+	}, s)
+	return `"` + s + `"`
+}
+
+// writeFolded writes a content line, folding it at 75 octets without splitting UTF-8 characters
+func writeFolded(buf *bytes.Buffer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
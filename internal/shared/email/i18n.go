@@ -0,0 +1,212 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Supported email languages
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+// Locales lists the supported email languages
+var Locales = []string{LocaleID, LocaleEN}
+
+// wib is the time zone dates in emails are shown in
+var wib = time.FixedZone("WIB", 7*60*60)
+
+// URLs are the base URLs of the web portals that emails link to
+type URLs struct {
+	JobSeeker string
+	Company   string
+	Admin     string
+	Partner   string
+}
+
+// Portal returns the base URL of the portal used by role
+func (u URLs) Portal(role string) string {
+	switch role {
+	case "company":
+		return u.Company
+	case "admin":
+		return u.Admin
+	case "partner":
+		return u.Partner
+	default:
+		return u.JobSeeker
+	}
+}
+
+// Recipient is what the email service knows about the user an address belongs to
+type Recipient struct {
+	UserID uint64
+	Role   string
+	Locale string
+	// OptedOut holds the email categories the user turned off
+	OptedOut map[string]bool
+}
+
+// Recipients looks up what the email service needs to know about a recipient
+type Recipients interface {
+	// Recipient returns the user with address, or nil when the address belongs to no user
+	Recipient(ctx context.Context, address string) (*Recipient, error)
+}
+
+type recipientStore struct {
+	db *sqlx.DB
+}
+
+// NewRecipientStore creates a recipient lookup backed by the users and notification_preferences tables
+func NewRecipientStore(db *sqlx.DB) Recipients {
+	return &recipientStore{db: db}
+}
+
+// Recipient finds the user by login email, or by the contact email of their company
+func (r *recipientStore) Recipient(ctx context.Context, address string) (*Recipient, error) {
+	var user struct {
+		ID     uint64 `db:"id"`
+		Role   string `db:"role"`
+		Locale string `db:"locale"`
+	}
+	err := r.db.GetContext(ctx, &user, `
+		SELECT id, role, locale FROM users WHERE email = ? AND deleted_at IS NULL LIMIT 1
+	`, address)
+	if err == sql.ErrNoRows {
+		err = r.db.GetContext(ctx, &user, `
+			SELECT u.id, u.role, u.locale
+			FROM companies c
+			JOIN users u ON u.id = c.user_id
+			WHERE c.company_email = ? AND c.deleted_at IS NULL AND u.deleted_at IS NULL
+			LIMIT 1
+		`, address)
+	}
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	var optedOut []string
+	if err := r.db.SelectContext(ctx, &optedOut, `
+		SELECT category FROM notification_preferences WHERE user_id = ? AND email_enabled = 0
+	`, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	recipient := &Recipient{UserID: user.ID, Role: user.Role, Locale: user.Locale, OptedOut: map[string]bool{}}
+	for _, category := range optedOut {
+		recipient.OptedOut[category] = true
+	}
+	return recipient, nil
+}
+
+// NormalizeLocale returns locale if it is supported, or fallback otherwise
+func NormalizeLocale(locale, fallback string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	for _, supported := range Locales {
+		if locale == supported {
+			return locale
+		}
+	}
+	return fallback
+}
+
+var monthNames = map[string][]string{
+	LocaleID: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	LocaleEN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// formatDate formats t as a long date in WIB, e.g. "5 Maret 2026"
+func formatDate(locale string, t time.Time) string {
+	t = t.In(wib)
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[locale][t.Month()-1], t.Year())
+}
+
+// formatDateTime formats t as a long date and time in WIB, e.g. "5 Maret 2026 pukul 14:00 WIB"
+func formatDateTime(locale string, t time.Time) string {
+	at := "pukul"
+	if locale == LocaleEN {
+		at = "at"
+	}
+	return fmt.Sprintf("%s %s %s WIB", formatDate(locale, t), at, t.In(wib).Format("15:04"))
+}
+
+// labels translates codes stored in the database, such as job types, for display in emails
+var labels = map[string]map[string]string{
+	LocaleID: {
+		"category.application_updates": "notifikasi lamaran",
+		"category.job_alerts":          "notifikasi lowongan",
+		"category.marketing":           "email info dan promosi",
+		"job_type.full-time":           "Full Time",
+		"job_type.part-time":           "Part Time",
+		"job_type.contract":            "Kontrak",
+		"job_type.internship":          "Magang",
+		"job_type.freelance":           "Freelance",
+		"level.entry":                  "Entry Level",
+		"level.junior":                 "Junior",
+		"level.mid":                    "Mid Level",
+		"level.senior":                 "Senior",
+		"level.lead":                   "Lead",
+		"level.manager":                "Manager",
+		"level.director":               "Director",
+		"frequency.daily":              "harian",
+		"frequency.weekly":             "mingguan",
+	},
+	LocaleEN: {
+		"category.application_updates": "application updates",
+		"category.job_alerts":          "job alerts",
+		"category.marketing":           "news and announcements",
+		"job_type.full-time":           "Full-time",
+		"job_type.part-time":           "Part-time",
+		"job_type.contract":            "Contract",
+		"job_type.internship":          "Internship",
+		"job_type.freelance":           "Freelance",
+		"level.entry":                  "Entry level",
+		"level.junior":                 "Junior",
+		"level.mid":                    "Mid level",
+		"level.senior":                 "Senior",
+		"level.lead":                   "Lead",
+		"level.manager":                "Manager",
+		"level.director":               "Director",
+		"frequency.daily":              "daily",
+		"frequency.weekly":             "weekly",
+	},
+}
+
+// label returns the translation of group.code, or code itself when there is none
+func label(locale, group, code string) string {
+	if text, ok := labels[locale][group+"."+code]; ok {
+		return text
+	}
+	return code
+}
+
+// formatRupiah formats an amount in Indonesian Rupiah, e.g. "Rp 1.500.000"
+func formatRupiah(amount int64) string {
+	digits := fmt.Sprintf("%d", amount)
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	var result strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			result.WriteByte('.')
+		}
+		result.WriteRune(digit)
+	}
+	if negative {
+		return "-Rp " + result.String()
+	}
+	return "Rp " + result.String()
+}
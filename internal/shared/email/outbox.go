@@ -0,0 +1,322 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Outbox statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead"
+)
+
+const (
+	// DefaultMaxAttempts is how many delivery attempts a message gets before it is dead-lettered
+	DefaultMaxAttempts = 8
+	// outboxBaseBackoff is the delay after the first failed attempt; it doubles after each failure
+	outboxBaseBackoff = time.Minute
+	// outboxMaxBackoff caps the delay between attempts
+	outboxMaxBackoff = 6 * time.Hour
+	// outboxLease is how long a claimed message is reserved for one worker. Messages
+	// left in sending after a crash are picked up again once it runs out.
+	outboxLease = 5 * time.Minute
+	// outboxPurgeBatchSize limits how many finished messages are deleted per statement
+	outboxPurgeBatchSize = 500
+)
+
+// ErrOutboxMessageNotFound is returned when retrying a message that does not exist or is not dead
+var ErrOutboxMessageNotFound = errors.New("email outbox message not found")
+
+// OutboxMessage is an email waiting in, or delivered from, the outbox
+type OutboxMessage struct {
+	ID             uint64         `db:"id"`
+	IdempotencyKey sql.NullString `db:"idempotency_key"`
+	Recipient      string         `db:"recipient"`
+	Subject        string         `db:"subject"`
+	Body           []byte         `db:"body"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	MaxAttempts    int            `db:"max_attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastError      sql.NullString `db:"last_error"`
+	SentAt         sql.NullTime   `db:"sent_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// OutboxMessageResponse represents an outbox message in API responses. The body is left
+// out because it can contain password reset and verification links.
+type OutboxMessageResponse struct {
+	ID             uint64 `json:"id"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	Recipient      string `json:"recipient"`
+	Subject        string `json:"subject"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	MaxAttempts    int    `json:"max_attempts"`
+	NextAttemptAt  string `json:"next_attempt_at"`
+	LastError      string `json:"last_error,omitempty"`
+	SentAt         string `json:"sent_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// ToResponse converts OutboxMessage to OutboxMessageResponse
+func (m *OutboxMessage) ToResponse() *OutboxMessageResponse {
+	resp := &OutboxMessageResponse{
+		ID:             m.ID,
+		IdempotencyKey: m.IdempotencyKey.String,
+		Recipient:      m.Recipient,
+		Subject:        m.Subject,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		MaxAttempts:    m.MaxAttempts,
+		NextAttemptAt:  m.NextAttemptAt.Format(time.RFC3339),
+		LastError:      m.LastError.String,
+		CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+	}
+	if m.SentAt.Valid {
+		resp.SentAt = m.SentAt.Time.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// OutboxFilter selects outbox messages for the admin list, newest first
+type OutboxFilter struct {
+	Status string
+	Cursor uint64 // only messages with a smaller id
+	Limit  int
+}
+
+// OutboxStore persists outgoing email
+type OutboxStore interface {
+	// Enqueue stores msg. It returns false without error when a message with the same
+	// idempotency key was already enqueued.
+	Enqueue(ctx context.Context, msg *OutboxMessage) (bool, error)
+
+	// Claim reserves up to limit messages that are due at now for one delivery attempt each,
+	// counting the attempt.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error)
+
+	MarkSent(ctx context.Context, id uint64) error
+	MarkFailed(ctx context.Context, id uint64, lastError string, nextAttemptAt time.Time, dead bool) error
+
+	// List returns messages without their bodies
+	List(ctx context.Context, filter OutboxFilter) ([]*OutboxMessage, error)
+
+	// Retry moves a dead message back to pending with a fresh set of attempts.
+	// It returns false when no dead message has that id.
+	Retry(ctx context.Context, id uint64, now time.Time) (bool, error)
+	Get(ctx context.Context, id uint64) (*OutboxMessage, error)
+
+	// DeleteFinished deletes up to limit sent and dead messages last updated before cutoff
+	// and returns how many were deleted
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+type outboxStore struct {
+	db *sqlx.DB
+}
+
+// NewOutboxStore creates a MySQL backed outbox store
+func NewOutboxStore(db *sqlx.DB) OutboxStore {
+	return &outboxStore{db: db}
+}
+
+const outboxColumns = `
+	id, idempotency_key, recipient, subject, body, status, attempts, max_attempts,
+	next_attempt_at, last_error, sent_at, created_at, updated_at
+`
+
+// outboxListColumns leaves out the body, which can hold password reset and verification links
+const outboxListColumns = `
+	id, idempotency_key, recipient, subject, status, attempts, max_attempts,
+	next_attempt_at, last_error, sent_at, created_at, updated_at
+`
+
+// Enqueue inserts a pending message
+func (s *outboxStore) Enqueue(ctx context.Context, msg *OutboxMessage) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO email_outbox (idempotency_key, recipient, subject, body, status, attempts, max_attempts, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'pending', 0, ?, ?, NOW(), NOW())
+	`, msg.IdempotencyKey, msg.Recipient, msg.Subject, msg.Body, msg.MaxAttempts, msg.NextAttemptAt)
+	if err != nil {
+		// A duplicate idempotency key means the message is already queued (MySQL error 1062)
+		if strings.Contains(err.Error(), "Duplicate entry") || strings.Contains(err.Error(), "1062") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to enqueue email: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("failed to get outbox message id: %w", err)
+	}
+	msg.ID = uint64(id)
+	msg.Status = OutboxStatusPending
+	return true, nil
+}
+
+// Claim marks due messages with a random claim token and reads them back, so concurrent
+// workers, including ones in other API instances, never deliver the same message
+func (s *outboxStore) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxMessage, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate claim token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE email_outbox
+		SET status = 'sending', claim_token = ?, locked_until = ?, attempts = attempts + 1, updated_at = NOW()
+		WHERE (status = 'pending' AND next_attempt_at <= ?)
+		   OR (status = 'sending' AND locked_until <= ?)
+		ORDER BY next_attempt_at, id
+		LIMIT ?
+	`, token, now.Add(lease), now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim emails: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, nil
+	}
+
+	var messages []*OutboxMessage
+	if err := s.db.SelectContext(ctx, &messages,
+		`SELECT `+outboxColumns+` FROM email_outbox WHERE claim_token = ? ORDER BY id`, token,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get claimed emails: %w", err)
+	}
+	return messages, nil
+}
+
+// MarkSent records a successful delivery
+func (s *outboxStore) MarkSent(ctx context.Context, id uint64) error {
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE email_outbox
+		SET status = 'sent', sent_at = NOW(), last_error = NULL, claim_token = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = ?
+	`, id); err != nil {
+		return fmt.Errorf("failed to mark email sent: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed schedules the next attempt, or dead-letters the message
+func (s *outboxStore) MarkFailed(ctx context.Context, id uint64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	status := OutboxStatusPending
+	if dead {
+		status = OutboxStatusDead
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE email_outbox
+		SET status = ?, last_error = ?, next_attempt_at = ?, claim_token = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = ?
+	`, status, lastError, nextAttemptAt, id); err != nil {
+		return fmt.Errorf("failed to mark email failed: %w", err)
+	}
+	return nil
+}
+
+// List returns messages matching filter, newest first
+func (s *outboxStore) List(ctx context.Context, filter OutboxFilter) ([]*OutboxMessage, error) {
+	query := `SELECT ` + outboxListColumns + ` FROM email_outbox WHERE 1=1`
+	args := []interface{}{}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+	if filter.Cursor > 0 {
+		query += ` AND id < ?`
+		args = append(args, filter.Cursor)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	var messages []*OutboxMessage
+	if err := s.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+	return messages, nil
+}
+
+// Retry moves a dead message back to the queue
+func (s *outboxStore) Retry(ctx context.Context, id uint64, now time.Time) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE email_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = ?, updated_at = NOW()
+		WHERE id = ? AND status = 'dead'
+	`, now, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to retry email: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	return affected > 0, nil
+}
+
+// Get returns one message
+func (s *outboxStore) Get(ctx context.Context, id uint64) (*OutboxMessage, error) {
+	var msg OutboxMessage
+	if err := s.db.GetContext(ctx, &msg, `SELECT `+outboxColumns+` FROM email_outbox WHERE id = ?`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email: %w", err)
+	}
+	return &msg, nil
+}
+
+// DeleteFinished deletes old sent and dead messages
+func (s *outboxStore) DeleteFinished(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM email_outbox
+		WHERE status IN ('sent', 'dead') AND updated_at < ?
+		ORDER BY id
+		LIMIT ?
+	`, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished emails: %w", err)
+	}
+	deleted, _ := result.RowsAffected()
+	return int(deleted), nil
+}
+
+// outboxBackoff returns the delay before the attempt following the given number of failed attempts
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return delay
+}
+
+// isPermanentFailure reports whether retrying err cannot succeed, such as a rejected recipient
+func isPermanentFailure(err error) bool {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code >= 500
+	}
+	return false
+}
+
+// truncateError keeps stored error messages within the column size
+func truncateError(err error) string {
+	msg := strings.TrimSpace(err.Error())
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	return msg
+}
@@ -0,0 +1,213 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// ErrOutboxDisabled is returned by outbox operations on a service without an outbox
+var ErrOutboxDisabled = errors.New("email outbox is not configured")
+
+// OutboxWorker delivers queued email with a pool of concurrent senders
+type OutboxWorker struct {
+	service  *Service
+	workers  int
+	interval time.Duration
+}
+
+// NewOutboxWorker creates a new outbox worker with the given number of concurrent senders
+func NewOutboxWorker(service *Service, workers int, interval time.Duration) *OutboxWorker {
+	if workers < 1 {
+		workers = 1
+	}
+	return &OutboxWorker{
+		service:  service,
+		workers:  workers,
+		interval: interval,
+	}
+}
+
+// Run delivers due messages immediately and then on every interval until ctx is cancelled
+func (w *OutboxWorker) Run(ctx context.Context) {
+	if w.service.outbox == nil {
+		logger.FromContext(ctx).Warn("email outbox worker not started", "error", ErrOutboxDisabled)
+		return
+	}
+
+	queue := make(chan *OutboxMessage)
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range queue {
+				// Finish the attempt even during shutdown so its outcome is recorded
+				_ = w.service.deliverQueued(context.Background(), msg)
+			}
+		}()
+	}
+	defer func() {
+		close(queue)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.drain(ctx, queue)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx, queue)
+		}
+	}
+}
+
+// drain claims due messages in batches and hands them to the senders until none are left
+func (w *OutboxWorker) drain(ctx context.Context, queue chan<- *OutboxMessage) {
+	batchSize := w.workers * 4
+	for ctx.Err() == nil {
+		messages, err := w.service.outbox.Claim(ctx, time.Now(), outboxLease, batchSize)
+		if err != nil {
+			logger.FromContext(ctx).Error("email outbox claim failed", "error", err)
+			return
+		}
+		for _, msg := range messages {
+			select {
+			case queue <- msg:
+			case <-ctx.Done():
+				// Unsent claims are picked up again when their lease runs out
+				return
+			}
+		}
+		if len(messages) < batchSize {
+			return
+		}
+	}
+}
+
+// deliverQueued makes one delivery attempt for a claimed message and records the outcome.
+// Failed messages are retried with exponential backoff and dead-lettered after their last
+// attempt or when the server rejects them permanently.
+func (s *Service) deliverQueued(ctx context.Context, msg *OutboxMessage) error {
+	err := s.transport.Send(ctx, s.config.FromEmail, msg.Recipient, msg.Body)
+	if err == nil {
+		if markErr := s.outbox.MarkSent(ctx, msg.ID); markErr != nil {
+			logger.FromContext(ctx).Error("email sent but could not be marked", "email_id", msg.ID, "error", markErr)
+		}
+		return nil
+	}
+
+	dead := msg.Attempts >= msg.MaxAttempts || isPermanentFailure(err)
+	nextAttemptAt := time.Now().Add(outboxBackoff(msg.Attempts))
+	if markErr := s.outbox.MarkFailed(ctx, msg.ID, truncateError(err), nextAttemptAt, dead); markErr != nil {
+		logger.FromContext(ctx).Error("failed to record email delivery failure", "email_id", msg.ID, "error", markErr)
+	}
+	if dead {
+		logger.FromContext(ctx).Error("email dead-lettered", "email_id", msg.ID, "to", msg.Recipient, "attempts", msg.Attempts, "error", err)
+	} else {
+		logger.FromContext(ctx).Warn("email delivery failed, will retry",
+			"email_id", msg.ID, "to", msg.Recipient, "attempts", msg.Attempts, "max_attempts", msg.MaxAttempts,
+			"next_attempt_at", nextAttemptAt, "error", err)
+	}
+	return err
+}
+
+// ListOutbox returns outbox messages matching filter, newest first
+func (s *Service) ListOutbox(ctx context.Context, filter OutboxFilter) ([]*OutboxMessage, error) {
+	if s.outbox == nil {
+		return nil, ErrOutboxDisabled
+	}
+	return s.outbox.List(ctx, filter)
+}
+
+// RetryOutboxMessage queues a dead-lettered message for delivery again
+func (s *Service) RetryOutboxMessage(ctx context.Context, id uint64) (*OutboxMessage, error) {
+	if s.outbox == nil {
+		return nil, ErrOutboxDisabled
+	}
+	retried, err := s.outbox.Retry(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !retried {
+		return nil, ErrOutboxMessageNotFound
+	}
+	return s.outbox.Get(ctx, id)
+}
+
+// PurgeOutbox deletes sent and dead messages last updated before cutoff, in batches.
+// Bodies hold password reset, verification and invitation links, so finished messages
+// are not kept longer than needed.
+func (s *Service) PurgeOutbox(ctx context.Context, before time.Time) (int, error) {
+	if s.outbox == nil {
+		return 0, ErrOutboxDisabled
+	}
+	total := 0
+	for {
+		deleted, err := s.outbox.DeleteFinished(ctx, before, outboxPurgeBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if deleted < outboxPurgeBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// OutboxSweeper periodically deletes sent and dead email older than the retention period
+type OutboxSweeper struct {
+	service   *Service
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewOutboxSweeper creates a new outbox sweeper
+func NewOutboxSweeper(service *Service, retention, interval time.Duration) *OutboxSweeper {
+	return &OutboxSweeper{
+		service:   service,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is cancelled
+func (w *OutboxSweeper) Run(ctx context.Context) {
+	if w.service.outbox == nil {
+		logger.FromContext(ctx).Warn("email outbox sweeper not started", "error", ErrOutboxDisabled)
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.sweep(ctx)
+		}
+	}
+}
+
+// sweep deletes the messages that finished before the retention period
+func (w *OutboxSweeper) sweep(ctx context.Context) {
+	deleted, err := w.service.PurgeOutbox(ctx, time.Now().Add(-w.retention))
+	if err != nil {
+		logger.FromContext(ctx).Error("email outbox sweep failed", "error", err)
+	}
+	if deleted > 0 {
+		logger.FromContext(ctx).Info("old emails deleted from outbox", "count", deleted)
+	}
+}
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Email categories. Users can turn off every category except transactional email,
+// which covers account security, billing and verification messages.
+const (
+	CategoryTransactional      = "transactional"
+	CategoryApplicationUpdates = "application_updates"
+	CategoryJobAlerts          = "job_alerts"
+	CategoryMarketing          = "marketing"
+)
+
+// Categories lists every email category
+var Categories = []string{CategoryTransactional, CategoryApplicationUpdates, CategoryJobAlerts, CategoryMarketing}
+
+// ErrInvalidUnsubscribeToken is returned for unsubscribe tokens that are malformed or not signed by us
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// IsCategory reports whether category is a known email category
+func IsCategory(category string) bool {
+	for _, known := range Categories {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOptional reports whether users may turn off email of category
+func IsOptional(category string) bool {
+	return IsCategory(category) && category != CategoryTransactional
+}
+
+// UnsubscribeToken signs a one-click unsubscribe of userID from category.
+// The token is "<user id>.<category>.<signature>" and does not expire.
+func UnsubscribeToken(secret string, userID uint64, category string) string {
+	payload := strconv.FormatUint(userID, 10) + "." + category
+	return payload + "." + unsubscribeSignature(secret, payload)
+}
+
+// ParseUnsubscribeToken verifies token and returns the user and category it unsubscribes
+func ParseUnsubscribeToken(secret, token string) (uint64, string, error) {
+	parts := strings.Split(token, ".")
+	if secret == "" || len(parts) != 3 {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(unsubscribeSignature(secret, payload))) {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+
+	userID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || userID == 0 || !IsOptional(parts[1]) {
+		return 0, "", ErrInvalidUnsubscribeToken
+	}
+	return userID, parts[1], nil
+}
+
+func unsubscribeSignature(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "unsubscribe:%s", payload)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// unsubscribeLinks are the links that let a recipient stop email of one category
+type unsubscribeLinks struct {
+	// OneClick is the List-Unsubscribe endpoint mail clients POST to (RFC 8058)
+	OneClick string
+	// Page is the portal page linked from the email footer
+	Page string
+}
+
+// unsubscribeLinks returns the links for email of category to recipient, or nil when
+// the email cannot be unsubscribed from
+func (s *Service) unsubscribeLinks(recipient *Recipient, category string) *unsubscribeLinks {
+	if recipient == nil || recipient.UserID == 0 || !IsOptional(category) ||
+		s.config.UnsubscribeSecret == "" || s.config.UnsubscribeURL == "" {
+		return nil
+	}
+
+	token := url.QueryEscape(UnsubscribeToken(s.config.UnsubscribeSecret, recipient.UserID, category))
+	return &unsubscribeLinks{
+		OneClick: s.config.UnsubscribeURL + "?token=" + token,
+		Page:     s.config.URLs.Portal(recipient.Role) + "/unsubscribe?token=" + token,
+	}
+}
@@ -0,0 +1,238 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// SMTP TLS modes
+const (
+	// SMTPTLSStartTLS upgrades a plain connection with STARTTLS (port 587)
+	SMTPTLSStartTLS = "starttls"
+	// SMTPTLSImplicit connects over TLS from the start (port 465)
+	SMTPTLSImplicit = "implicit"
+)
+
+const (
+	// smtpMaxIdle is how many open connections are kept for reuse
+	smtpMaxIdle = 4
+	// smtpIdleTimeout closes kept connections before servers drop them on their own
+	smtpIdleTimeout = 30 * time.Second
+	// smtpTimeout bounds connecting and each message exchange
+	smtpTimeout = 30 * time.Second
+)
+
+// SMTPTransport delivers messages to an SMTP server over a verified TLS connection.
+// Connections are kept open and reused for following messages.
+type SMTPTransport struct {
+	host        string
+	addr        string
+	username    string
+	password    string
+	implicitTLS bool
+	tlsConfig   *tls.Config
+	idle        chan *smtpConn
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+// NewSMTPTransport creates an SMTP transport. The server certificate is verified against the
+// system roots, plus config.SMTPCAFile when set for servers with a private CA.
+func NewSMTPTransport(config *Config) (*SMTPTransport, error) {
+	if config.SMTPHost == "" {
+		return nil, errors.New("SMTP_HOST is required for the smtp email driver")
+	}
+	port := config.SMTPPort
+	if port == "" {
+		port = "587"
+	}
+
+	mode := config.SMTPTLS
+	if mode == "" {
+		mode = SMTPTLSStartTLS
+		if port == "465" {
+			mode = SMTPTLSImplicit
+		}
+	}
+	if mode != SMTPTLSStartTLS && mode != SMTPTLSImplicit {
+		return nil, fmt.Errorf("unknown SMTP_TLS mode %q (use starttls or implicit)", mode)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: config.SMTPHost,
+		MinVersion: tls.VersionTLS12,
+	}
+	if config.SMTPCAFile != "" {
+		pem, err := os.ReadFile(config.SMTPCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SMTP CA file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("SMTP CA file %s contains no certificates", config.SMTPCAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	return &SMTPTransport{
+		host:        config.SMTPHost,
+		addr:        net.JoinHostPort(config.SMTPHost, port),
+		username:    config.SMTPUser,
+		password:    config.SMTPPassword,
+		implicitTLS: mode == SMTPTLSImplicit,
+		tlsConfig:   tlsConfig,
+		idle:        make(chan *smtpConn, smtpMaxIdle),
+	}, nil
+}
+
+// Send delivers message on a reused or new connection
+func (t *SMTPTransport) Send(ctx context.Context, from, to string, message []byte) error {
+	c, err := t.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.send(from, to, message); err != nil {
+		// A rejected sender or recipient leaves the connection usable after RSET
+		if c.client.Reset() == nil {
+			t.put(c)
+		} else {
+			c.client.Close()
+		}
+		return err
+	}
+
+	t.put(c)
+	logger.FromContext(ctx).Info("email sent", "to", to)
+	return nil
+}
+
+// Close closes the kept connections
+func (t *SMTPTransport) Close() error {
+	for {
+		select {
+		case c := <-t.idle:
+			c.quit()
+		default:
+			return nil
+		}
+	}
+}
+
+// get returns a kept connection that is still alive, or opens a new one
+func (t *SMTPTransport) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		select {
+		case c := <-t.idle:
+			c.conn.SetDeadline(time.Now().Add(smtpTimeout))
+			if time.Since(c.lastUsed) > smtpIdleTimeout || c.client.Noop() != nil {
+				c.quit()
+				continue
+			}
+			return c, nil
+		default:
+			return t.dial(ctx)
+		}
+	}
+}
+
+// put keeps c for reuse, or closes it when enough connections are kept
+func (t *SMTPTransport) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+	select {
+	case t.idle <- c:
+	default:
+		c.quit()
+	}
+}
+
+// dial connects, secures and authenticates a new connection
+func (t *SMTPTransport) dial(ctx context.Context) (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	var conn net.Conn
+	var err error
+	if t.implicitTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: t.tlsConfig}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to connect to SMTP server", "addr", t.addr, "error", err)
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+
+	if !t.implicitTLS {
+		// Never send credentials or mail over an unencrypted connection
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.New("SMTP server does not support STARTTLS")
+		}
+		if err := client.StartTLS(t.tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+// send runs one mail transaction
+func (c *smtpConn) send(from, to string, message []byte) error {
+	c.conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	if err := c.client.Mail(from); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := c.client.Rcpt(to); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to get data writer: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close data writer: %w", err)
+	}
+	return nil
+}
+
+// quit ends the session politely and closes the connection
+func (c *smtpConn) quit() {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+}
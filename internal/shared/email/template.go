@@ -0,0 +1,203 @@
+package email
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// templates holds every email template by locale and name
+var templates = mustLoadTemplates(templateFiles)
+
+// templateData is what every email template is executed with. Message specific values are in Data.
+type templateData struct {
+	Locale string
+	Year   int
+	URLs   URLs
+	// Category and Unsubscribe are set for email the recipient can turn off
+	Category    string
+	Unsubscribe *unsubscribeLinks
+	Data        interface{}
+}
+
+// emailTemplate is one message in one language
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// renderedEmail is a message rendered for one recipient
+type renderedEmail struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// templateSet maps locale and message name to its template
+type templateSet map[string]map[string]*emailTemplate
+
+func mustLoadTemplates(fsys fs.FS) templateSet {
+	set, err := loadTemplates(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// loadTemplates parses templates/<locale>/<name>.tmpl together with the shared layout, once as
+// HTML and once as plain text. Every message must exist in every supported language.
+func loadTemplates(fsys fs.FS) (templateSet, error) {
+	layout, err := fs.ReadFile(fsys, "templates/layout.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email layout: %w", err)
+	}
+
+	set := templateSet{}
+	for _, locale := range Locales {
+		files, err := fs.Glob(fsys, "templates/"+locale+"/*.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		funcs := templateFuncs(locale)
+		set[locale] = map[string]*emailTemplate{}
+
+		for _, file := range files {
+			content, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read email template %s: %w", file, err)
+			}
+			name := strings.TrimSuffix(path.Base(file), ".tmpl")
+
+			html, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).Parse(string(layout))
+			if err == nil {
+				html, err = html.Parse(string(content))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", file, err)
+			}
+			text, err := texttemplate.New(name).Funcs(funcs).Parse(string(layout))
+			if err == nil {
+				text, err = text.Parse(string(content))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s: %w", file, err)
+			}
+
+			for _, required := range []string{"subject", "heading", "html", "text"} {
+				if text.Lookup(required) == nil {
+					return nil, fmt.Errorf("email template %s does not define %q", file, required)
+				}
+			}
+			set[locale][name] = &emailTemplate{html: html, text: text}
+		}
+	}
+
+	for name := range set[LocaleID] {
+		for _, locale := range Locales {
+			if _, ok := set[locale][name]; !ok {
+				return nil, fmt.Errorf("email template %s has no %s translation", name, locale)
+			}
+		}
+	}
+	return set, nil
+}
+
+// templateFuncs are the helpers available to templates of locale
+func templateFuncs(locale string) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"date":     func(t time.Time) string { return formatDate(locale, t) },
+		"datetime": func(t time.Time) string { return formatDateTime(locale, t) },
+		"label":    func(group, code string) string { return label(locale, group, code) },
+		"rupiah":   formatRupiah,
+	}
+}
+
+// render executes a template in locale
+func (set templateSet) render(locale, name string, page templateData) (*renderedEmail, error) {
+	tmpl, ok := set[locale][name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+	page.Locale = locale
+
+	var subject, html, text strings.Builder
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout.html", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "layout.text", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+
+	return &renderedEmail{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    html.String(),
+		Text:    tidyText(text.String()),
+	}, nil
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidyText removes the indentation and extra blank lines left by template actions
+func tidyText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")) + "\n"
+}
+
+// lookupRecipient returns the user that to belongs to. Addresses without a user, and
+// failed lookups, give an empty recipient so email is still sent.
+func (s *Service) lookupRecipient(ctx context.Context, to string) *Recipient {
+	if s.recipients == nil {
+		return &Recipient{}
+	}
+	recipient, err := s.recipients.Recipient(ctx, to)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to look up email recipient", "to", to, "error", err)
+		return &Recipient{}
+	}
+	if recipient == nil {
+		return &Recipient{}
+	}
+	return recipient
+}
+
+// sendTemplate renders the named template in the recipient's language and sends it.
+// msg may carry a category, an attachment and an idempotency key; its address and content are filled in.
+func (s *Service) sendTemplate(ctx context.Context, to, name string, data interface{}, msg Message) error {
+	msg.To = to
+	msg.recipient = s.lookupRecipient(ctx, to)
+	category := msg.category()
+
+	rendered, err := templates.render(NormalizeLocale(msg.recipient.Locale, NormalizeLocale(s.config.DefaultLocale, LocaleID)), name, templateData{
+		Year:        time.Now().In(wib).Year(),
+		URLs:        s.config.URLs,
+		Category:    category,
+		Unsubscribe: s.unsubscribeLinks(msg.recipient, category),
+		Data:        data,
+	})
+	if err != nil {
+		return err
+	}
+
+	msg.Subject = rendered.Subject
+	msg.HTMLBody = rendered.HTML
+	msg.TextBody = rendered.Text
+	return s.Send(ctx, msg)
+}
@@ -0,0 +1,190 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/karirnusantara/api/internal/shared/logger"
+)
+
+// Email drivers
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+	DriverLog    = "log"
+)
+
+// Transport delivers rendered messages
+type Transport interface {
+	// Send delivers one MIME message to a single recipient
+	Send(ctx context.Context, from, to string, message []byte) error
+	// Close releases connections held by the transport
+	Close() error
+}
+
+// NewTransport creates the transport selected by config.Driver
+func NewTransport(config *Config) (Transport, error) {
+	switch config.Driver {
+	case DriverSMTP:
+		return NewSMTPTransport(config)
+	case DriverFile:
+		return NewFileTransport(config.FileDir)
+	case DriverMemory:
+		return NewMemoryTransport(), nil
+	case DriverLog, "":
+		return NewLogTransport(), nil
+	default:
+		return nil, fmt.Errorf("unknown email driver %q (use smtp, file, memory or log)", config.Driver)
+	}
+}
+
+// FileTransport writes every message to a directory as an .eml file, for local development
+type FileTransport struct {
+	dir string
+}
+
+// NewFileTransport creates a file transport, creating dir if needed
+func NewFileTransport(dir string) (*FileTransport, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("EMAIL_FILE_DIR is required for the file email driver")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create email directory: %w", err)
+	}
+	return &FileTransport{dir: dir}, nil
+}
+
+// Send writes message to a new .eml file. The file appears complete or not at all.
+func (t *FileTransport) Send(ctx context.Context, from, to string, message []byte) error {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to name email file: %w", err)
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+
+	tmp, err := os.CreateTemp(t.dir, ".email-*")
+	if err != nil {
+		return fmt.Errorf("failed to write email file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = fmt.Fprintf(tmp, "X-Envelope-From: %s\r\nX-Envelope-To: %s\r\n", from, to)
+	if err == nil {
+		_, err = tmp.Write(message)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write email file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(t.dir, name)); err != nil {
+		return fmt.Errorf("failed to write email file: %w", err)
+	}
+	logger.FromContext(ctx).Info("email written to file", "to", to, "file", name)
+	return nil
+}
+
+// Close does nothing
+func (t *FileTransport) Close() error {
+	return nil
+}
+
+// SentMessage is a message captured by MemoryTransport
+type SentMessage struct {
+	From    string
+	To      string
+	Message []byte
+	SentAt  time.Time
+}
+
+// Subject returns the decoded Subject header of the message
+func (m SentMessage) Subject() string {
+	return messageSubject(m.Message)
+}
+
+// MemoryTransport keeps sent messages in memory, for tests
+type MemoryTransport struct {
+	mu       sync.Mutex
+	messages []SentMessage
+}
+
+// NewMemoryTransport creates an empty memory transport
+func NewMemoryTransport() *MemoryTransport {
+	return &MemoryTransport{}
+}
+
+// Send records message
+func (t *MemoryTransport) Send(ctx context.Context, from, to string, message []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, SentMessage{
+		From:    from,
+		To:      to,
+		Message: append([]byte(nil), message...),
+		SentAt:  time.Now(),
+	})
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first
+func (t *MemoryTransport) Messages() []SentMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SentMessage(nil), t.messages...)
+}
+
+// Reset forgets all sent messages
+func (t *MemoryTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = nil
+}
+
+// Close does nothing
+func (t *MemoryTransport) Close() error {
+	return nil
+}
+
+// LogTransport only logs the recipient and subject of each message. It is the default
+// when no mail server is configured.
+type LogTransport struct{}
+
+// NewLogTransport creates a log transport
+func NewLogTransport() *LogTransport {
+	return &LogTransport{}
+}
+
+// Send logs message instead of delivering it
+func (t *LogTransport) Send(ctx context.Context, from, to string, message []byte) error {
+	logger.FromContext(ctx).Info("email not sent (log driver)", "to", to, "subject", messageSubject(message))
+	return nil
+}
+
+// Close does nothing
+func (t *LogTransport) Close() error {
+	return nil
+}
+
+// messageSubject reads the decoded Subject header of a MIME message
+func messageSubject(message []byte) string {
+	parsed, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		return ""
+	}
+	subject := parsed.Header.Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+		return decoded
+	}
+	return subject
+}
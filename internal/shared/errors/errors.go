@@ -39,6 +39,12 @@ const (
 	ErrCodeTokenExpired      = "TOKEN_EXPIRED"
 	ErrCodeTokenInvalid      = "TOKEN_INVALID"
 	ErrCodeDuplicateEntry    = "DUPLICATE_ENTRY"
+	ErrCodeTooManyRequests   = "TOO_MANY_REQUESTS"
+	ErrCodeEmailNotVerified  = "EMAIL_NOT_VERIFIED"
+
+	// Application errors
+	ErrCodeApplicationDeadlinePassed = "APPLICATION_DEADLINE_PASSED"
+	ErrCodeApplicationLimitReached   = "APPLICATION_LIMIT_REACHED"
 )
 
 // Error constructors
@@ -144,6 +150,42 @@ func NewDuplicateEntryError(field string) *AppError {
 	}
 }
 
+// NewTooManyRequestsError creates a rate limit error
+func NewTooManyRequestsError(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodeTooManyRequests,
+		Message:    message,
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+}
+
+// NewEmailNotVerifiedError creates an error for actions that need a verified email address
+func NewEmailNotVerifiedError(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodeEmailNotVerified,
+		Message:    message,
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+// NewApplicationDeadlinePassedError creates an error for applying after a job's deadline
+func NewApplicationDeadlinePassedError() *AppError {
+	return &AppError{
+		Code:       ErrCodeApplicationDeadlinePassed,
+		Message:    "The application deadline for this job has passed",
+		HTTPStatus: http.StatusBadRequest,
+	}
+}
+
+// NewApplicationLimitReachedError creates an error for applying to a job that reached its cap
+func NewApplicationLimitReachedError() *AppError {
+	return &AppError{
+		Code:       ErrCodeApplicationLimitReached,
+		Message:    "This job has reached its maximum number of applications",
+		HTTPStatus: http.StatusConflict,
+	}
+}
+
 // IsAppError checks if an error is an AppError
 func IsAppError(err error) bool {
 	var appErr *AppError
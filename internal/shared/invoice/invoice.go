@@ -2,6 +2,7 @@ package invoice
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"time"
@@ -32,7 +33,7 @@ type Service struct {
 func NewService(invoiceDir string) *Service {
 	// Ensure invoice directory exists
 	if err := os.MkdirAll(invoiceDir, 0755); err != nil {
-		fmt.Printf("Warning: Failed to create invoice directory: %v\n", err)
+		slog.Warn("failed to create invoice directory", "dir", invoiceDir, "error", err)
 	}
 	
 	return &Service{
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/karirnusantara/api/internal/config"
+)
+
+type contextKey struct{}
+
+// Redacted replaces the value of sensitive attributes
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute key fragments whose values are never written.
+// Keys are matched case-insensitively, so password_hash and resetToken are covered.
+var sensitiveKeys = []string{"password", "secret", "token", "authorization", "cookie", "otp", "recovery_code"}
+
+// New creates the JSON logger of the API, writing to stdout at the level of cfg
+func New(cfg *config.AppConfig) *slog.Logger {
+	return NewWithWriter(os.Stdout, Level(cfg))
+}
+
+// NewWithWriter creates a JSON logger writing to w that redacts sensitive attributes
+func NewWithWriter(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}))
+}
+
+// Level returns debug when debugging is on outside production, and info otherwise
+func Level(cfg *config.AppConfig) slog.Level {
+	if cfg.Debug && cfg.Env != "production" {
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// IsSensitive reports whether values logged under key must be redacted
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	// NIK (national identity number) as a whole word, e.g. nik or ktp_nik but not unique
+	for _, word := range strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if word == "nik" {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
+
+// WithContext returns a copy of ctx carrying l
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger of ctx, or the default logger if there is none
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger adds args to every record
+func With(ctx context.Context, args ...interface{}) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
@@ -0,0 +1,177 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/karirnusantara/api/internal/config"
+)
+
+// Portal is a client application with its own login. Tokens issued for one portal
+// carry that portal's issuer and audience and are rejected by the others.
+type Portal string
+
+// Portals
+const (
+	// PortalApp is the job seeker and company app (/auth)
+	PortalApp     Portal = "app"
+	PortalAdmin   Portal = "admin"
+	PortalPartner Portal = "partner"
+)
+
+// Issuer returns the iss claim of the portal's tokens
+func (p Portal) Issuer() string {
+	return "karirnusantara-api/" + string(p)
+}
+
+// Audience returns the aud claim of the portal's tokens
+func (p Portal) Audience() string {
+	return "karirnusantara-" + string(p)
+}
+
+// Token types
+const (
+	// TypeAccess is the token_type of access tokens
+	TypeAccess = "access"
+	// TypeMFAChallenge is the token_type of the short-lived tokens that carry a login through its MFA step
+	TypeMFAChallenge = "mfa_challenge"
+)
+
+var (
+	// ErrInvalid is returned for malformed tokens, bad signatures, unknown keys and tokens of another portal
+	ErrInvalid = errors.New("invalid token")
+
+	// ErrExpired is returned for tokens past their expiry
+	ErrExpired = errors.New("token expired")
+)
+
+// Claims are the claims of an access token. Portal-specific fields are empty when not used.
+type Claims struct {
+	UserID    uint64 `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	// SessionID is the refresh token family of app tokens
+	SessionID string `json:"sid,omitempty"`
+	// PartnerID and ReferralCode are set on partner tokens
+	PartnerID    uint64 `json:"partner_id,omitempty"`
+	ReferralCode string `json:"referral_code,omitempty"`
+	// Purpose is set on MFA challenge tokens
+	Purpose string `json:"purpose,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Key is an HMAC signing key and its ID
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Manager issues and verifies access and MFA challenge tokens. Tokens are signed with the current key
+// and verified with whichever key their kid header names.
+type Manager struct {
+	current Key
+	keys    map[string][]byte
+	now     func() time.Time
+}
+
+// NewManager creates a manager from the JWT configuration
+func NewManager(cfg *config.JWTConfig) *Manager {
+	previous := make([]Key, 0, len(cfg.PreviousKeys))
+	for id, secret := range cfg.PreviousKeys {
+		previous = append(previous, Key{ID: id, Secret: []byte(secret)})
+	}
+
+	keyID := cfg.KeyID
+	if keyID == "" {
+		keyID = "default"
+	}
+	return NewManagerWithKeys(Key{ID: keyID, Secret: []byte(cfg.Secret)}, previous, time.Now)
+}
+
+// NewManagerWithKeys creates a manager that signs with current and also accepts
+// tokens signed with the previous keys
+func NewManagerWithKeys(current Key, previous []Key, now func() time.Time) *Manager {
+	keys := make(map[string][]byte, len(previous)+1)
+	for _, key := range previous {
+		keys[key.ID] = key.Secret
+	}
+	keys[current.ID] = current.Secret
+
+	return &Manager{
+		current: current,
+		keys:    keys,
+		now:     now,
+	}
+}
+
+// Issue signs an access token for portal, setting the registered claims and the kid header
+func (m *Manager) Issue(portal Portal, claims Claims, expiry time.Duration) (string, error) {
+	return m.issue(portal, TypeAccess, claims, expiry)
+}
+
+// Parse verifies an access token issued for portal and returns its claims
+func (m *Manager) Parse(portal Portal, tokenString string) (*Claims, error) {
+	return m.parse(portal, TypeAccess, tokenString)
+}
+
+// IssueChallenge signs an MFA challenge token for portal. It cannot be used as an access token.
+func (m *Manager) IssueChallenge(portal Portal, claims Claims, expiry time.Duration) (string, error) {
+	return m.issue(portal, TypeMFAChallenge, claims, expiry)
+}
+
+// ParseChallenge verifies an MFA challenge token issued for portal and returns its claims
+func (m *Manager) ParseChallenge(portal Portal, tokenString string) (*Claims, error) {
+	return m.parse(portal, TypeMFAChallenge, tokenString)
+}
+
+// issue signs a token of tokenType for portal, setting the registered claims and the kid header
+func (m *Manager) issue(portal Portal, tokenType string, claims Claims, expiry time.Duration) (string, error) {
+	now := m.now()
+	claims.TokenType = tokenType
+	claims.Issuer = portal.Issuer()
+	claims.Audience = jwt.ClaimStrings{portal.Audience()}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(expiry))
+	if claims.Subject == "" {
+		claims.Subject = fmt.Sprintf("%d", claims.UserID)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = m.current.ID
+	return token.SignedString(m.current.Secret)
+}
+
+// parse verifies a token of tokenType issued for portal and returns its claims
+func (m *Manager) parse(portal Portal, tokenType string, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, m.keyFor,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(portal.Issuer()),
+		jwt.WithAudience(portal.Audience()),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpired
+		}
+		return nil, ErrInvalid
+	}
+	if !token.Valid || claims.TokenType != tokenType || claims.UserID == 0 {
+		return nil, ErrInvalid
+	}
+	return claims, nil
+}
+
+// keyFor returns the verification key named by the token's kid header
+func (m *Manager) keyFor(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	secret, ok := m.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return secret, nil
+}
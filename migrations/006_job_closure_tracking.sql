@@ -0,0 +1,16 @@
+-- =============================================
+-- Migration: Job closure tracking
+-- Version: 006
+-- Date: 2026-10-17
+-- Description: Record who or what closed a job (company, admin or the
+--              auto-close sweeper) and why
+-- =============================================
+
+ALTER TABLE `jobs`
+ADD COLUMN `closed_at` TIMESTAMP NULL DEFAULT NULL AFTER `published_at`,
+ADD COLUMN `closed_by_type` ENUM('company','admin','system') NULL DEFAULT NULL AFTER `closed_at`,
+ADD COLUMN `closed_by_id` BIGINT(20) UNSIGNED NULL DEFAULT NULL COMMENT 'User ID when closed by company/admin' AFTER `closed_by_type`,
+ADD COLUMN `closed_reason` VARCHAR(50) NULL DEFAULT NULL COMMENT 'manual, deadline_passed, max_applications_reached' AFTER `closed_by_id`;
+
+-- Speeds up the auto-close sweeper scan
+CREATE INDEX IF NOT EXISTS `idx_jobs_status_deadline` ON `jobs` (`status`, `application_deadline`);
@@ -0,0 +1,31 @@
+-- =============================================
+-- Migration: Saved job searches
+-- Version: 007
+-- Date: 2026-10-17
+-- Description: Named job searches for job seekers with daily/weekly
+--              email alerts for newly published matching jobs
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `saved_searches` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `search` varchar(255) DEFAULT NULL,
+  `city` varchar(100) DEFAULT NULL,
+  `province` varchar(100) DEFAULT NULL,
+  `job_type` varchar(50) DEFAULT NULL,
+  `experience_level` varchar(50) DEFAULT NULL,
+  `is_remote` tinyint(1) DEFAULT NULL,
+  `salary_min` bigint(20) DEFAULT NULL,
+  `salary_max` bigint(20) DEFAULT NULL,
+  `frequency` enum('daily','weekly') NOT NULL DEFAULT 'daily',
+  `is_active` tinyint(1) NOT NULL DEFAULT 1,
+  `last_run_at` timestamp NULL DEFAULT NULL,
+  `last_sent_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_saved_searches_user_id` (`user_id`),
+  KEY `idx_saved_searches_due` (`is_active`, `frequency`, `last_run_at`),
+  CONSTRAINT `saved_searches_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,34 @@
+-- =============================================
+-- Migration: Configurable hiring pipelines
+-- Version: 008
+-- Date: 2026-10-17
+-- Description: Per-company pipeline templates (stages, labels, allowed
+--              transitions) that can be attached to jobs. Jobs without a
+--              pipeline keep using the built-in default status graph.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `hiring_pipelines` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `company_id` bigint(20) UNSIGNED NOT NULL COMMENT 'companies.id',
+  `name` varchar(100) NOT NULL,
+  `description` varchar(500) DEFAULT NULL,
+  `stages` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL CHECK (json_valid(`stages`)) COMMENT 'Ordered stage definitions with allowed transitions',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_hiring_pipelines_company_id` (`company_id`),
+  CONSTRAINT `hiring_pipelines_ibfk_1` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Attach a pipeline to a job (NULL = default pipeline)
+ALTER TABLE `jobs`
+  ADD COLUMN `pipeline_id` bigint(20) UNSIGNED DEFAULT NULL COMMENT 'hiring_pipelines.id' AFTER `company_id`,
+  ADD KEY `idx_jobs_pipeline_id` (`pipeline_id`),
+  ADD CONSTRAINT `jobs_pipeline_fk` FOREIGN KEY (`pipeline_id`) REFERENCES `hiring_pipelines` (`id`) ON DELETE SET NULL;
+
+-- Custom stages are not limited to the default status enum
+ALTER TABLE `applications`
+  MODIFY `current_status` varchar(50) NOT NULL DEFAULT 'submitted';
+
+ALTER TABLE `application_timelines`
+  MODIFY `status` varchar(50) NOT NULL;
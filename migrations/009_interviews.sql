@@ -0,0 +1,42 @@
+-- =============================================
+-- Migration: Interviews
+-- Version: 009
+-- Date: 2026-10-17
+-- Description: Interviews as their own records. An application can have
+--              several interview rounds, each with interviewers, a schedule
+--              that can be rescheduled or cancelled by the company, and a
+--              confirm/decline response from the candidate. `uid` and
+--              `sequence` identify the calendar (.ics) event sent by email.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `interviews` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `application_id` bigint(20) UNSIGNED NOT NULL,
+  `round` int(10) UNSIGNED NOT NULL DEFAULT 1,
+  `title` varchar(150) NOT NULL,
+  `status` enum('scheduled','confirmed','declined','cancelled') NOT NULL DEFAULT 'scheduled',
+  `scheduled_at` datetime NOT NULL,
+  `duration_minutes` int(10) UNSIGNED NOT NULL DEFAULT 60,
+  `interview_type` enum('online','offline','phone') NOT NULL DEFAULT 'online',
+  `meeting_platform` varchar(50) DEFAULT NULL,
+  `meeting_link` varchar(500) DEFAULT NULL,
+  `location` varchar(500) DEFAULT NULL,
+  `interviewers` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL CHECK (json_valid(`interviewers`)) COMMENT 'Interviewer names',
+  `contact_person` varchar(100) DEFAULT NULL,
+  `contact_phone` varchar(30) DEFAULT NULL,
+  `notes` text DEFAULT NULL,
+  `uid` varchar(100) NOT NULL COMMENT 'Calendar event UID',
+  `sequence` int(10) UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Calendar event revision',
+  `candidate_note` varchar(500) DEFAULT NULL,
+  `responded_at` timestamp NULL DEFAULT NULL,
+  `cancel_reason` varchar(500) DEFAULT NULL,
+  `cancelled_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) UNSIGNED NOT NULL COMMENT 'companies.id',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_interviews_uid` (`uid`),
+  KEY `idx_interviews_application_id` (`application_id`),
+  KEY `idx_interviews_scheduled_at` (`scheduled_at`),
+  CONSTRAINT `interviews_ibfk_1` FOREIGN KEY (`application_id`) REFERENCES `applications` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
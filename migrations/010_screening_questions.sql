@@ -0,0 +1,44 @@
+-- =============================================
+-- Migration: Screening questions
+-- Version: 010
+-- Date: 2026-10-17
+-- Description: Questions a company asks candidates when they apply
+--              (yes/no, multiple choice, numeric, free text), with optional
+--              knockout rules that auto-reject applications, and the answers
+--              stored with each application.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `job_screening_questions` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `job_id` bigint(20) UNSIGNED NOT NULL,
+  `position` int(10) UNSIGNED NOT NULL DEFAULT 0,
+  `question` varchar(500) NOT NULL,
+  `question_type` enum('yes_no','multiple_choice','numeric','text') NOT NULL,
+  `options` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL CHECK (json_valid(`options`)) COMMENT 'Choices for multiple_choice',
+  `is_required` tinyint(1) NOT NULL DEFAULT 1,
+  `is_knockout` tinyint(1) NOT NULL DEFAULT 0,
+  `accepted_answers` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL CHECK (json_valid(`accepted_answers`)) COMMENT 'Passing answers for yes_no and multiple_choice knockouts',
+  `min_value` decimal(15,2) DEFAULT NULL COMMENT 'Numeric knockout lower bound',
+  `max_value` decimal(15,2) DEFAULT NULL COMMENT 'Numeric knockout upper bound',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_job_screening_questions_job_id` (`job_id`, `position`),
+  CONSTRAINT `job_screening_questions_ibfk_1` FOREIGN KEY (`job_id`) REFERENCES `jobs` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Answers keep a copy of the question so editing a job does not rewrite history
+CREATE TABLE IF NOT EXISTS `application_screening_answers` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `application_id` bigint(20) UNSIGNED NOT NULL,
+  `question_id` bigint(20) UNSIGNED DEFAULT NULL,
+  `question` varchar(500) NOT NULL,
+  `question_type` enum('yes_no','multiple_choice','numeric','text') NOT NULL,
+  `answer` text NOT NULL,
+  `is_knockout` tinyint(1) NOT NULL DEFAULT 0,
+  `passed` tinyint(1) NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_application_screening_answers_application_id` (`application_id`),
+  CONSTRAINT `application_screening_answers_ibfk_1` FOREIGN KEY (`application_id`) REFERENCES `applications` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `application_screening_answers_ibfk_2` FOREIGN KEY (`question_id`) REFERENCES `job_screening_questions` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,66 @@
+-- =============================================
+-- Migration: Talent search and talent pools
+-- Version: 011
+-- Date: 2026-10-17
+-- Description: Lets verified companies search job seekers who opt in to
+--              being discoverable, invite them to apply for a job, and keep
+--              saved candidate shortlists (talent pools). Contact details
+--              are only shared once the job seeker accepts an invitation.
+--              Run cmd/backfill-cv-experience once afterwards to fill the
+--              experience totals of existing CVs.
+-- =============================================
+
+-- Job seekers opt in to talent search
+ALTER TABLE `applicant_profiles`
+  ADD COLUMN `is_discoverable` tinyint(1) NOT NULL DEFAULT 0 AFTER `willing_to_relocate`,
+  ADD KEY `idx_applicant_profiles_discoverable` (`is_discoverable`);
+
+-- Work experience totals, kept up to date by the CV service.
+-- Total months = experience_months + months since experience_current_since.
+ALTER TABLE `cvs`
+  ADD COLUMN `experience_months` int(10) UNSIGNED NOT NULL DEFAULT 0 AFTER `completeness_score`,
+  ADD COLUMN `experience_current_since` date DEFAULT NULL AFTER `experience_months`;
+
+-- Invitations to apply sent from talent search
+CREATE TABLE IF NOT EXISTS `talent_invitations` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `company_id` bigint(20) UNSIGNED NOT NULL,
+  `user_id` bigint(20) UNSIGNED NOT NULL COMMENT 'Invited job seeker',
+  `job_id` bigint(20) UNSIGNED NOT NULL,
+  `message` text DEFAULT NULL,
+  `status` enum('pending','accepted','declined') NOT NULL DEFAULT 'pending',
+  `responded_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_talent_invitations_job_user` (`job_id`, `user_id`),
+  KEY `idx_talent_invitations_company` (`company_id`, `status`),
+  KEY `idx_talent_invitations_user` (`user_id`, `status`),
+  CONSTRAINT `talent_invitations_ibfk_1` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `talent_invitations_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `talent_invitations_ibfk_3` FOREIGN KEY (`job_id`) REFERENCES `jobs` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Saved candidate shortlists per company
+CREATE TABLE IF NOT EXISTS `talent_pools` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `company_id` bigint(20) UNSIGNED NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `description` varchar(500) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_talent_pools_company_name` (`company_id`, `name`),
+  CONSTRAINT `talent_pools_ibfk_1` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `talent_pool_members` (
+  `pool_id` bigint(20) UNSIGNED NOT NULL,
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `note` varchar(500) DEFAULT NULL,
+  `added_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`pool_id`, `user_id`),
+  KEY `idx_talent_pool_members_user` (`user_id`),
+  CONSTRAINT `talent_pool_members_ibfk_1` FOREIGN KEY (`pool_id`) REFERENCES `talent_pools` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `talent_pool_members_ibfk_2` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
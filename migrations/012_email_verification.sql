@@ -0,0 +1,32 @@
+-- =============================================
+-- Migration: Email verification
+-- Version: 012
+-- Date: 2026-10-17
+-- Description: Stores single-use email verification tokens sent after
+--              registration. Only a SHA-256 hash of each token is kept.
+--              Job seekers must verify their email before applying and
+--              companies before publishing jobs, so existing accounts are
+--              marked as verified to keep them working.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `email_verification_tokens` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `email` varchar(255) NOT NULL COMMENT 'Address the token was sent to',
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` timestamp NOT NULL,
+  `used_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_email_verification_token_hash` (`token_hash`),
+  KEY `idx_email_verification_user_created` (`user_id`, `created_at`),
+  KEY `idx_email_verification_expires` (`expires_at`),
+  CONSTRAINT `email_verification_tokens_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Accounts created before verification existed keep access
+UPDATE `users`
+SET `is_verified` = 1,
+    `email_verified_at` = COALESCE(`email_verified_at`, `created_at`),
+    `updated_at` = `updated_at`
+WHERE `is_verified` = 0 AND `deleted_at` IS NULL;
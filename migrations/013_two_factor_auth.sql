@@ -0,0 +1,45 @@
+-- =============================================
+-- Migration: Two-factor authentication (TOTP)
+-- Version: 013
+-- Date: 2026-10-17
+-- Description: Optional authenticator app (RFC 6238) second factor for
+--              admin, company and partner logins, with single-use recovery
+--              codes. The TOTP secret is stored encrypted and recovery codes
+--              only as SHA-256 hashes. `system_settings` holds platform-wide
+--              switches such as requiring MFA for every admin.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `user_mfa` (
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `secret_encrypted` varchar(255) NOT NULL,
+  `enabled_at` timestamp NULL DEFAULT NULL COMMENT 'NULL while enrollment is not confirmed',
+  `last_used_step` bigint(20) NOT NULL DEFAULT 0 COMMENT 'Last accepted TOTP time step, blocks code reuse',
+  `failed_attempts` int(10) UNSIGNED NOT NULL DEFAULT 0,
+  `last_failed_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`user_id`),
+  CONSTRAINT `user_mfa_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `user_mfa_recovery_codes` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_mfa_recovery_codes_user` (`user_id`, `used_at`),
+  CONSTRAINT `user_mfa_recovery_codes_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `system_settings` (
+  `setting_key` varchar(100) NOT NULL,
+  `setting_value` varchar(255) NOT NULL,
+  `updated_by` bigint(20) UNSIGNED DEFAULT NULL COMMENT 'Admin users.id',
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`setting_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `system_settings` (`setting_key`, `setting_value`) VALUES
+('require_admin_mfa', 'false');
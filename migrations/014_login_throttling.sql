@@ -0,0 +1,29 @@
+-- =============================================
+-- Migration: Login brute-force protection
+-- Version: 014
+-- Date: 2026-10-17
+-- Description: Failed login and password reset attempts counted per account
+--              (normalized email) and per client IP. Rows drive progressive
+--              delays and temporary lockouts; admins can lift a lockout.
+--              Accounts are keyed by the email that was typed, whether or not
+--              it exists, so lockouts do not reveal registered emails.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `auth_throttles` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `scope` varchar(30) NOT NULL COMMENT 'login or password_reset',
+  `key_type` enum('account','ip') NOT NULL,
+  `key_value` varchar(255) NOT NULL COMMENT 'Lowercased email or IP address',
+  `failed_count` int(10) UNSIGNED NOT NULL DEFAULT 0,
+  `last_failed_at` timestamp NULL DEFAULT NULL,
+  `locked_until` timestamp NULL DEFAULT NULL,
+  `lockout_count` int(10) UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Consecutive lockouts, doubles the next lock duration',
+  `unlocked_by` bigint(20) UNSIGNED DEFAULT NULL,
+  `unlocked_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_auth_throttles_key` (`scope`, `key_type`, `key_value`),
+  KEY `idx_auth_throttles_locked_until` (`locked_until`),
+  CONSTRAINT `auth_throttles_ibfk_1` FOREIGN KEY (`unlocked_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,19 @@
+-- =============================================
+-- Migration: Session management for refresh tokens
+-- Version: 015
+-- Date: 2026-10-17
+-- Description: Each login starts a token family (one session per device).
+--              Refreshing rotates the token within its family; presenting a
+--              token that was already rotated revokes the whole family.
+--              `device_info` holds the user agent and `ip_address` the client
+--              IP of the latest refresh. Existing tokens become one session each.
+-- =============================================
+
+ALTER TABLE `refresh_tokens`
+  ADD COLUMN `family_id` varchar(36) NOT NULL DEFAULT '' AFTER `token_hash`,
+  ADD COLUMN `last_used_at` timestamp NULL DEFAULT NULL AFTER `ip_address`,
+  ADD COLUMN `revoked_reason` varchar(30) DEFAULT NULL COMMENT 'rotated, logout, revoked, reuse_detected, password_changed' AFTER `revoked_at`,
+  ADD KEY `idx_refresh_tokens_family` (`family_id`),
+  ADD KEY `idx_refresh_tokens_user_active` (`user_id`, `revoked_at`, `expires_at`);
+
+UPDATE `refresh_tokens` SET `family_id` = UUID() WHERE `family_id` = '';
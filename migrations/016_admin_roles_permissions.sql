@@ -0,0 +1,93 @@
+-- =============================================
+-- Migration: Admin roles and permissions
+-- Version: 016
+-- Date: 2026-10-17
+-- Description: Admin access is granted through roles, each holding a set of
+--              permissions (e.g. payments.approve, jobs.moderate). Admins may
+--              hold several roles. Every existing admin receives the built-in
+--              super_admin role so nobody loses access.
+--              audit_logs gains the `details` column already written by the
+--              admin service, and the permission used for the action.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `admin_roles` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `description` varchar(255) DEFAULT NULL,
+  `is_system` tinyint(1) NOT NULL DEFAULT 0 COMMENT 'Built-in roles cannot be edited or deleted',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_admin_roles_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `admin_role_permissions` (
+  `role_id` bigint(20) UNSIGNED NOT NULL,
+  `permission` varchar(60) NOT NULL,
+  PRIMARY KEY (`role_id`, `permission`),
+  CONSTRAINT `admin_role_permissions_ibfk_1` FOREIGN KEY (`role_id`) REFERENCES `admin_roles` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `admin_user_roles` (
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `role_id` bigint(20) UNSIGNED NOT NULL,
+  `assigned_by` bigint(20) UNSIGNED DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`user_id`, `role_id`),
+  KEY `idx_admin_user_roles_role` (`role_id`),
+  CONSTRAINT `admin_user_roles_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `admin_user_roles_ibfk_2` FOREIGN KEY (`role_id`) REFERENCES `admin_roles` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `admin_user_roles_ibfk_3` FOREIGN KEY (`assigned_by`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Built-in roles
+INSERT INTO `admin_roles` (`name`, `description`, `is_system`) VALUES
+  ('super_admin', 'Akses penuh ke seluruh panel admin', 1),
+  ('finance', 'Pembayaran dan pencairan komisi mitra', 0),
+  ('moderator', 'Verifikasi perusahaan dan moderasi lowongan', 0),
+  ('content', 'Pengumuman, banner dan informasi', 0)
+ON DUPLICATE KEY UPDATE `name` = `name`;
+
+INSERT IGNORE INTO `admin_role_permissions` (`role_id`, `permission`)
+SELECT r.id, p.permission
+FROM `admin_roles` r
+JOIN (
+  SELECT 'companies.view' AS permission UNION ALL SELECT 'companies.verify'
+  UNION ALL SELECT 'jobs.view' UNION ALL SELECT 'jobs.moderate'
+  UNION ALL SELECT 'payments.view' UNION ALL SELECT 'payments.approve'
+  UNION ALL SELECT 'job_seekers.view' UNION ALL SELECT 'job_seekers.manage'
+  UNION ALL SELECT 'partners.view' UNION ALL SELECT 'partners.manage' UNION ALL SELECT 'partners.payout'
+  UNION ALL SELECT 'announcements.manage'
+  UNION ALL SELECT 'security.manage' UNION ALL SELECT 'admins.manage'
+) p
+WHERE r.name = 'super_admin';
+
+INSERT IGNORE INTO `admin_role_permissions` (`role_id`, `permission`)
+SELECT r.id, p.permission
+FROM `admin_roles` r
+JOIN (
+  SELECT 'finance' AS role_name, 'payments.view' AS permission
+  UNION ALL SELECT 'finance', 'payments.approve'
+  UNION ALL SELECT 'finance', 'partners.view'
+  UNION ALL SELECT 'finance', 'partners.payout'
+  UNION ALL SELECT 'finance', 'companies.view'
+  UNION ALL SELECT 'moderator', 'companies.view'
+  UNION ALL SELECT 'moderator', 'companies.verify'
+  UNION ALL SELECT 'moderator', 'jobs.view'
+  UNION ALL SELECT 'moderator', 'jobs.moderate'
+  UNION ALL SELECT 'moderator', 'job_seekers.view'
+  UNION ALL SELECT 'content', 'announcements.manage'
+) p ON p.role_name = r.name;
+
+-- Existing admins keep full access
+INSERT IGNORE INTO `admin_user_roles` (`user_id`, `role_id`)
+SELECT u.id, r.id
+FROM `users` u
+JOIN `admin_roles` r ON r.name = 'super_admin'
+WHERE u.role = 'admin';
+
+-- Audit log: details were written but the column never existed
+ALTER TABLE `audit_logs`
+  ADD COLUMN `details` text DEFAULT NULL AFTER `entity_id`,
+  ADD COLUMN `permission` varchar(60) DEFAULT NULL COMMENT 'Admin permission used for the action' AFTER `details`,
+  ADD KEY `idx_audit_logs_permission` (`permission`);
@@ -0,0 +1,17 @@
+-- =============================================
+-- Migration: Audit log access
+-- Version: 017
+-- Date: 2026-10-17
+-- Description: Admins holding audit_logs.view can search and export the
+--              audit log. Admin actions now store before/after snapshots of
+--              the changed entity in old_values/new_values and the client
+--              user agent. Cursor pagination walks the log newest first by id.
+-- =============================================
+
+INSERT IGNORE INTO `admin_role_permissions` (`role_id`, `permission`)
+SELECT r.id, 'audit_logs.view'
+FROM `admin_roles` r
+WHERE r.name = 'super_admin';
+
+ALTER TABLE `audit_logs`
+  ADD KEY `idx_audit_logs_user_created` (`user_id`, `created_at`);
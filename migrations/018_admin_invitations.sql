@@ -0,0 +1,37 @@
+-- =============================================
+-- Migration: Admin invitations
+-- Version: 018
+-- Date: 2026-10-17
+-- Description: Admin accounts are created by invitation instead of inserting
+--              users rows by hand. An admin holding admins.manage invites an
+--              email address with a set of roles; the invitee follows the
+--              emailed link (valid 72 hours, stored as a SHA-256 hash) and
+--              sets a password, which creates the admin account.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `admin_invitations` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `email` varchar(255) NOT NULL,
+  `full_name` varchar(255) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `invited_by` bigint(20) UNSIGNED DEFAULT NULL,
+  `expires_at` timestamp NOT NULL,
+  `accepted_at` timestamp NULL DEFAULT NULL,
+  `accepted_user_id` bigint(20) UNSIGNED DEFAULT NULL,
+  `revoked_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_admin_invitations_token` (`token_hash`),
+  KEY `idx_admin_invitations_email` (`email`),
+  CONSTRAINT `admin_invitations_ibfk_1` FOREIGN KEY (`invited_by`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `admin_invitations_ibfk_2` FOREIGN KEY (`accepted_user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `admin_invitation_roles` (
+  `invitation_id` bigint(20) UNSIGNED NOT NULL,
+  `role_id` bigint(20) UNSIGNED NOT NULL,
+  PRIMARY KEY (`invitation_id`, `role_id`),
+  CONSTRAINT `admin_invitation_roles_ibfk_1` FOREIGN KEY (`invitation_id`) REFERENCES `admin_invitations` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `admin_invitation_roles_ibfk_2` FOREIGN KEY (`role_id`) REFERENCES `admin_roles` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,23 @@
+-- =============================================
+-- Migration: Account deletion requests
+-- Version: 019
+-- Date: 2026-10-17
+-- Description: Job seekers can ask for their account to be deleted (UU PDP,
+--              Law 27/2022). The request is kept for a grace period during
+--              which it can be cancelled; afterwards the purge worker
+--              anonymizes the data companies still hold and erases the rest.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `account_deletions` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `reason` text DEFAULT NULL,
+  `requested_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `purge_after` timestamp NOT NULL,
+  `cancelled_at` timestamp NULL DEFAULT NULL,
+  `completed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_account_deletions_user` (`user_id`, `cancelled_at`, `completed_at`),
+  KEY `idx_account_deletions_due` (`purge_after`, `cancelled_at`, `completed_at`),
+  CONSTRAINT `account_deletions_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,38 @@
+-- =============================================
+-- Migration: Email outbox
+-- Version: 020
+-- Date: 2026-10-17
+-- Description: Outgoing email is stored in `email_outbox` in the request and
+--              delivered by a background worker pool. Failed deliveries are
+--              retried with exponential backoff and dead-lettered after the
+--              last attempt. An optional idempotency key stops the same email
+--              from being queued twice. Admins holding emails.manage can list
+--              failed emails and queue them again.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `email_outbox` (
+  `id` bigint(20) UNSIGNED NOT NULL AUTO_INCREMENT,
+  `idempotency_key` varchar(191) DEFAULT NULL,
+  `recipient` varchar(255) NOT NULL,
+  `subject` varchar(500) NOT NULL,
+  `body` longblob NOT NULL COMMENT 'Complete MIME message',
+  `status` enum('pending','sending','sent','dead') NOT NULL DEFAULT 'pending',
+  `attempts` int(10) UNSIGNED NOT NULL DEFAULT 0,
+  `max_attempts` int(10) UNSIGNED NOT NULL DEFAULT 8,
+  `next_attempt_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `claim_token` char(32) DEFAULT NULL,
+  `locked_until` timestamp NULL DEFAULT NULL,
+  `last_error` text DEFAULT NULL,
+  `sent_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_email_outbox_idempotency_key` (`idempotency_key`),
+  KEY `idx_email_outbox_due` (`status`, `next_attempt_at`),
+  KEY `idx_email_outbox_claim` (`claim_token`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT IGNORE INTO `admin_role_permissions` (`role_id`, `permission`)
+SELECT r.id, 'emails.manage'
+FROM `admin_roles` r
+WHERE r.name = 'super_admin';
@@ -0,0 +1,11 @@
+-- =============================================
+-- Migration: User email language
+-- Version: 021
+-- Date: 2026-10-17
+-- Description: Emails are rendered from templates in Indonesian or English.
+--              `users.locale` stores the language each user receives email
+--              in. Existing users keep Indonesian.
+-- =============================================
+
+ALTER TABLE `users`
+  ADD COLUMN `locale` varchar(5) NOT NULL DEFAULT 'id' AFTER `avatar_url`;
@@ -0,0 +1,20 @@
+-- =============================================
+-- Migration: Notification preferences
+-- Version: 022
+-- Date: 2026-10-17
+-- Description: Users choose which categories of email they receive.
+--              A row with email_enabled = 0 turns a category off; users
+--              without a row receive everything. Transactional email
+--              (security, billing, verification) cannot be turned off.
+--              Optional email carries a signed one-click unsubscribe link.
+-- =============================================
+
+CREATE TABLE IF NOT EXISTS `notification_preferences` (
+  `user_id` bigint(20) UNSIGNED NOT NULL,
+  `category` varchar(32) NOT NULL COMMENT 'application_updates, job_alerts or marketing',
+  `email_enabled` tinyint(1) NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (`user_id`, `category`),
+  CONSTRAINT `fk_notification_preferences_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
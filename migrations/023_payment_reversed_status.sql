@@ -0,0 +1,13 @@
+-- =============================================
+-- Migration: Reversed payment status
+-- Version: 023
+-- Date: 2026-10-17
+-- Description: Reversing a confirmed payment (quota taken back, partner
+--              commission cancelled) gets its own 'reversed' status so
+--              reports and the company portal can tell it apart from a
+--              payment that was rejected before it was ever confirmed.
+-- =============================================
+
+ALTER TABLE `payments`
+MODIFY `status` enum('pending','confirmed','rejected','reversed') NOT NULL DEFAULT 'pending';
+
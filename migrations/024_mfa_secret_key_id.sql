@@ -0,0 +1,13 @@
+-- =============================================
+-- Migration: MFA secret key ID
+-- Version: 024
+-- Date: 2026-10-17
+-- Description: TOTP secrets are encrypted with MFA_ENCRYPTION_KEY instead of a
+--              key derived from JWT_SECRET. Each secret records the ID of the
+--              key it was encrypted with so the key can be rotated. Existing
+--              secrets were encrypted with the JWT secret and are marked
+--              'legacy' (see MFA_PREVIOUS_KEYS in .env.example).
+-- =============================================
+
+ALTER TABLE `user_mfa`
+ADD COLUMN `secret_key_id` varchar(64) NOT NULL DEFAULT 'legacy' COMMENT 'ID of the key secret_encrypted was encrypted with' AFTER `secret_encrypted`;
@@ -0,0 +1,29 @@
+-- =============================================
+-- Migration: Support and dashboard permissions
+-- Version: 025
+-- Date: 2026-10-17
+-- Description: Admin chat, support tickets and the admin dashboard now require
+--              a permission (support.read, support.reply, dashboard.read)
+--              instead of being open to every admin. Every built-in role keeps
+--              the dashboard, super_admin keeps support, and a new support
+--              role is added for customer service staff.
+-- =============================================
+
+INSERT INTO `admin_roles` (`name`, `description`, `is_system`) VALUES
+  ('support', 'Chat dan tiket dukungan pengguna', 0)
+ON DUPLICATE KEY UPDATE `name` = `name`;
+
+INSERT IGNORE INTO `admin_role_permissions` (`role_id`, `permission`)
+SELECT r.id, p.permission
+FROM `admin_roles` r
+JOIN (
+  SELECT 'super_admin' AS role_name, 'dashboard.read' AS permission
+  UNION ALL SELECT 'super_admin', 'support.read'
+  UNION ALL SELECT 'super_admin', 'support.reply'
+  UNION ALL SELECT 'finance', 'dashboard.read'
+  UNION ALL SELECT 'moderator', 'dashboard.read'
+  UNION ALL SELECT 'content', 'dashboard.read'
+  UNION ALL SELECT 'support', 'dashboard.read'
+  UNION ALL SELECT 'support', 'support.read'
+  UNION ALL SELECT 'support', 'support.reply'
+) p ON p.role_name = r.name;
@@ -0,0 +1,252 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/karirnusantara/api/internal/modules/account"
+)
+
+// ============================================
+// Account Data Export & Deletion Tests (in-process, no server needed)
+// ============================================
+
+// accountDataRepo is an in-memory account repository for a single job seeker
+type accountDataRepo struct {
+	account.Repository
+	data      *account.ExportData
+	user      *account.AccountUser
+	documents []string
+	deletions []*account.DeletionRequest
+	purged    []uint64
+}
+
+func newAccountDataRepo(t *testing.T) *accountDataRepo {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("Rahasia123"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &accountDataRepo{
+		data: &account.ExportData{
+			User:    account.Record{"id": 7, "email": "budi@example.com", "full_name": "Budi", "avatar_url": "/docs/avatars/avatar_7.png"},
+			Profile: account.Record{"user_id": 7, "nik": "3171234567890001"},
+			CV:      account.Record{"id": 3, "personal_info": json.RawMessage(`{"full_name":"Budi"}`)},
+			CVSnapshots: []account.Record{
+				{"id": 11, "cv_id": 3},
+			},
+			Applications: []account.Record{
+				{"id": 21, "job_title": "Backend Developer", "timeline": []account.Record{{"status": "submitted"}},
+					"screening_answers": []account.Record{{"question": "Ceritakan diri Anda", "answer": "Saya suka Go"}},
+					"interviews":        []account.Record{{"round": 1, "candidate_note": "Bisa hadir"}}},
+			},
+			SavedJobs: []account.Record{},
+			Tickets:   []account.Record{},
+			Documents: []account.Record{
+				{"id": 5, "document_url": "/docs/applicants/7/cv_budi.pdf"},
+				{"id": 6, "document_url": "/docs/applicants/7/missing.pdf"},
+			},
+			Emails: []account.Record{
+				{"id": 40, "recipient": "budi@example.com", "subject": "Lamaran Anda diterima", "status": "sent"},
+			},
+			NotificationPreferences: []account.Record{{"category": "marketing", "email_enabled": 0}},
+			LoginThrottles:          []account.Record{{"scope": "login", "key_value": "budi@example.com", "failed_count": 2}},
+		},
+		user: &account.AccountUser{
+			ID: 7, Email: "budi@example.com", PasswordHash: string(hash), Role: "job_seeker", FullName: "Budi",
+			AvatarURL: sql.NullString{String: "/docs/avatars/avatar_7.png", Valid: true},
+		},
+		documents: []string{"/docs/applicants/7/cv_budi.pdf", "/docs/applicants/7/missing.pdf"},
+	}
+}
+
+func (r *accountDataRepo) GetExportData(ctx context.Context, userID uint64) (*account.ExportData, error) {
+	if userID != r.user.ID {
+		return nil, nil
+	}
+	return r.data, nil
+}
+
+func (r *accountDataRepo) GetUser(ctx context.Context, userID uint64) (*account.AccountUser, error) {
+	if userID != r.user.ID {
+		return nil, nil
+	}
+	return r.user, nil
+}
+
+func (r *accountDataRepo) GetOpenDeletion(ctx context.Context, userID uint64) (*account.DeletionRequest, error) {
+	for _, d := range r.deletions {
+		if d.UserID == userID && !d.CancelledAt.Valid && !d.CompletedAt.Valid {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *accountDataRepo) CreateDeletion(ctx context.Context, deletion *account.DeletionRequest) error {
+	deletion.ID = uint64(len(r.deletions) + 1)
+	r.deletions = append(r.deletions, deletion)
+	return nil
+}
+
+func (r *accountDataRepo) CancelDeletion(ctx context.Context, id uint64) error {
+	r.deletions[id-1].CancelledAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (r *accountDataRepo) GetDueDeletions(ctx context.Context, now time.Time, limit int) ([]*account.DeletionRequest, error) {
+	var due []*account.DeletionRequest
+	for _, d := range r.deletions {
+		if !d.PurgeAfter.After(now) && !d.CancelledAt.Valid && !d.CompletedAt.Valid {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (r *accountDataRepo) GetDocumentURLs(ctx context.Context, userID uint64) ([]string, error) {
+	return r.documents, nil
+}
+
+func (r *accountDataRepo) PurgeUser(ctx context.Context, userID uint64) error {
+	r.purged = append(r.purged, userID)
+	r.user.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (r *accountDataRepo) CompleteDeletion(ctx context.Context, id uint64) error {
+	r.deletions[id-1].CompletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+// writeDocsFile creates a file under the temporary docs directory
+func writeDocsFile(t *testing.T, docsPath, relative, content string) string {
+	t.Helper()
+	full := filepath.Join(docsPath, filepath.FromSlash(relative))
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+	return full
+}
+
+func TestAccountData_ExportZip(t *testing.T) {
+	docsPath := t.TempDir()
+	writeDocsFile(t, docsPath, "applicants/7/cv_budi.pdf", "%PDF cv")
+	writeDocsFile(t, docsPath, "avatars/avatar_7.png", "png")
+
+	repo := newAccountDataRepo(t)
+	svc := account.NewService(repo, docsPath)
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.ExportData(context.Background(), 7, &buf))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"user.json", "profile.json", "cv.json", "cv_snapshots.json", "applications.json", "saved_jobs.json", "tickets.json", "documents.json",
+		"emails.json", "notification_preferences.json", "login_throttles.json", "manifest.json"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["emails.json"], "Lamaran Anda diterima")
+	assert.Contains(t, files["notification_preferences.json"], `"marketing"`)
+	assert.Contains(t, files["login_throttles.json"], `"failed_count": 2`)
+	assert.Equal(t, "%PDF cv", files["documents/5_cv_budi.pdf"])
+	assert.Equal(t, "png", files["avatar/avatar_7.png"])
+	assert.Contains(t, files["applications.json"], `"timeline"`)
+	assert.Contains(t, files["applications.json"], `"screening_answers"`)
+	assert.Contains(t, files["applications.json"], `"candidate_note"`)
+	assert.Contains(t, files["cv.json"], `"full_name": "Budi"`, "JSON columns are exported as JSON, not strings")
+
+	var manifest struct {
+		MissingFiles []string `json:"missing_files"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+	assert.Equal(t, []string{"/docs/applicants/7/missing.pdf"}, manifest.MissingFiles)
+
+	// Unknown users get an error before anything is written
+	buf.Reset()
+	assert.Error(t, svc.ExportData(context.Background(), 99, &buf))
+	assert.Zero(t, buf.Len())
+}
+
+func TestAccountData_DeletionGracePeriod(t *testing.T) {
+	repo := newAccountDataRepo(t)
+	svc := account.NewService(repo, t.TempDir())
+	ctx := context.Background()
+
+	_, err := svc.RequestDeletion(ctx, 7, &account.DeleteAccountRequest{Password: "salah"})
+	assert.Error(t, err)
+	assert.Empty(t, repo.deletions)
+
+	scheduled, err := svc.RequestDeletion(ctx, 7, &account.DeleteAccountRequest{Password: "Rahasia123", Reason: "Sudah diterima kerja"})
+	require.NoError(t, err)
+	assert.Equal(t, account.DeletionStatusScheduled, scheduled.Status)
+	assert.WithinDuration(t, time.Now().Add(account.DeletionGracePeriod), repo.deletions[0].PurgeAfter, time.Minute)
+
+	_, err = svc.RequestDeletion(ctx, 7, &account.DeleteAccountRequest{Password: "Rahasia123"})
+	assert.Error(t, err, "only one deletion can be scheduled at a time")
+
+	// Nothing happens before the grace period ends
+	purged, err := svc.PurgeDueDeletions(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	require.NoError(t, svc.CancelDeletion(ctx, 7))
+	status, err := svc.GetDeletion(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, account.DeletionStatusNone, status.Status)
+
+	purged, err = svc.PurgeDueDeletions(ctx, time.Now().Add(account.DeletionGracePeriod+time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, purged, "cancelled deletions are never purged")
+	assert.Empty(t, repo.purged)
+}
+
+func TestAccountData_PurgeRemovesFiles(t *testing.T) {
+	docsPath := t.TempDir()
+	document := writeDocsFile(t, docsPath, "applicants/7/cv_budi.pdf", "%PDF cv")
+	avatar := writeDocsFile(t, docsPath, "avatars/avatar_7.png", "png")
+	other := writeDocsFile(t, docsPath, "avatars/avatar_8.png", "png")
+
+	repo := newAccountDataRepo(t)
+	svc := account.NewService(repo, docsPath)
+	ctx := context.Background()
+
+	_, err := svc.RequestDeletion(ctx, 7, &account.DeleteAccountRequest{Password: "Rahasia123"})
+	require.NoError(t, err)
+
+	purged, err := svc.PurgeDueDeletions(ctx, time.Now().Add(account.DeletionGracePeriod+time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, []uint64{7}, repo.purged)
+	assert.True(t, repo.deletions[0].CompletedAt.Valid)
+
+	assert.NoFileExists(t, document)
+	assert.NoDirExists(t, filepath.Join(docsPath, "applicants", "7"))
+	assert.NoFileExists(t, avatar)
+	assert.FileExists(t, other, "other users' files are untouched")
+
+	// Completed deletions are not purged twice
+	purged, err = svc.PurgeDueDeletions(ctx, time.Now().Add(account.DeletionGracePeriod+time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
@@ -0,0 +1,306 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/admin"
+	"github.com/karirnusantara/api/internal/modules/auth"
+	"github.com/karirnusantara/api/internal/shared/token"
+)
+
+// ============================================
+// Admin Account Tests (in-process, no server needed)
+// ============================================
+
+// accountRepo is an in-memory admin account repository backed by the role and audit fakes
+type accountRepo struct {
+	invitations map[uint64]*admin.AdminInvitation
+	roles       *roleRepo
+	audit       *auditRepo
+	passwords   map[uint64]string
+}
+
+func newAccountRepo(roles *roleRepo, audit *auditRepo) *accountRepo {
+	return &accountRepo{invitations: map[uint64]*admin.AdminInvitation{}, roles: roles, audit: audit, passwords: map[uint64]string{}}
+}
+
+func (r *accountRepo) CreateInvitation(ctx context.Context, invitation *admin.AdminInvitation) error {
+	invitation.ID = uint64(len(r.invitations) + 1)
+	invitation.CreatedAt = time.Now()
+	copied := *invitation
+	r.invitations[copied.ID] = &copied
+	return nil
+}
+
+func (r *accountRepo) GetInvitationByID(ctx context.Context, id uint64) (*admin.AdminInvitation, error) {
+	if invitation, ok := r.invitations[id]; ok {
+		copied := *invitation
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *accountRepo) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*admin.AdminInvitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.TokenHash == tokenHash {
+			copied := *invitation
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *accountRepo) GetOpenInvitations(ctx context.Context) ([]*admin.AdminInvitation, error) {
+	var open []*admin.AdminInvitation
+	for _, invitation := range r.invitations {
+		if !invitation.AcceptedAt.Valid && !invitation.RevokedAt.Valid {
+			open = append(open, invitation)
+		}
+	}
+	return open, nil
+}
+
+func (r *accountRepo) UpdateInvitationToken(ctx context.Context, id uint64, tokenHash string, expiresAt time.Time) error {
+	r.invitations[id].TokenHash = tokenHash
+	r.invitations[id].ExpiresAt = expiresAt
+	return nil
+}
+
+func (r *accountRepo) RevokeInvitation(ctx context.Context, id uint64) error {
+	r.invitations[id].RevokedAt.Time, r.invitations[id].RevokedAt.Valid = time.Now(), true
+	return nil
+}
+
+func (r *accountRepo) RevokeOpenInvitations(ctx context.Context, email string) error {
+	for _, invitation := range r.invitations {
+		if invitation.Email == email && !invitation.AcceptedAt.Valid && !invitation.RevokedAt.Valid {
+			invitation.RevokedAt.Time, invitation.RevokedAt.Valid = time.Now(), true
+		}
+	}
+	return nil
+}
+
+func (r *accountRepo) AcceptInvitation(ctx context.Context, invitation *admin.AdminInvitation, passwordHash string) (uint64, error) {
+	stored := r.invitations[invitation.ID]
+	if stored.AcceptedAt.Valid || stored.RevokedAt.Valid {
+		return 0, nil
+	}
+	stored.AcceptedAt.Time, stored.AcceptedAt.Valid = time.Now(), true
+
+	userID := uint64(len(r.audit.admins) + 1)
+	r.audit.admins[userID] = &admin.AdminUser{ID: userID, Email: stored.Email, FullName: stored.FullName, Role: "admin", IsActive: true}
+	r.roles.assignments[userID] = stored.RoleIDs
+	r.passwords[userID] = passwordHash
+	return userID, nil
+}
+
+func (r *accountRepo) EmailRegistered(ctx context.Context, email string) (bool, error) {
+	for _, a := range r.audit.admins {
+		if a.Email == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *accountRepo) SetAdminActive(ctx context.Context, id uint64, isActive bool) error {
+	r.audit.admins[id].IsActive = isActive
+	return nil
+}
+
+func (r *accountRepo) CountActiveAdminsWithRole(ctx context.Context, roleName string, excludeID uint64) (int, error) {
+	count := 0
+	for id, a := range r.audit.admins {
+		if id == excludeID || !a.IsActive {
+			continue
+		}
+		roles, _ := r.roles.GetAdminRoles(ctx, id)
+		for _, role := range roles {
+			if role.Name == roleName {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+// invitationMailer captures invitation tokens
+type invitationMailer struct {
+	tokens chan string
+}
+
+func (m *invitationMailer) SendAdminInvitationEmail(to, fullName, inviterName, token string, expiresAt time.Time) error {
+	m.tokens <- token
+	return nil
+}
+
+// receivedToken waits for an invitation email and returns its token
+func (m *invitationMailer) receivedToken(t *testing.T) string {
+	select {
+	case token := <-m.tokens:
+		return token
+	case <-time.After(time.Second):
+		t.Fatal("invitation email was not sent")
+		return ""
+	}
+}
+
+// passwordChanger records password changes
+type passwordChanger struct {
+	changed map[uint64]string
+}
+
+func (p *passwordChanger) ChangePassword(ctx context.Context, userID uint64, req *auth.ChangePasswordRequest) error {
+	p.changed[userID] = req.NewPassword
+	return nil
+}
+
+func newAccountService(t *testing.T) (admin.AccountService, *accountRepo, *invitationMailer, *passwordChanger) {
+	t.Helper()
+	roles := newRoleRepo()
+	roles.assignments[1] = []uint64{1} // super_admin
+	roles.assignments[2] = []uint64{2} // finance
+	audit := newAuditRepo()
+	repo := newAccountRepo(roles, audit)
+	mailer := &invitationMailer{tokens: make(chan string, 4)}
+	passwords := &passwordChanger{changed: map[uint64]string{}}
+	return admin.NewAccountService(repo, roles, audit, mailer, passwords), repo, mailer, passwords
+}
+
+func TestAdminAccounts_InviteAndAccept(t *testing.T) {
+	svc, repo, mailer, _ := newAccountService(t)
+	ctx := context.Background()
+
+	invitation, err := svc.InviteAdmin(ctx, &admin.InviteAdminRequest{Email: " Moderator@KarirNusantara.com ", FullName: "Moderator Baru", RoleIDs: []uint64{2, 2}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "moderator@karirnusantara.com", invitation.Email)
+	assert.Equal(t, admin.InvitationStatusPending, invitation.Status)
+	assert.Equal(t, []uint64{2}, invitation.RoleIDs)
+
+	token := mailer.receivedToken(t)
+	require.NotEmpty(t, token)
+	assert.NotEqual(t, token, repo.invitations[invitation.ID].TokenHash, "only the token hash is stored")
+
+	preview, err := svc.PreviewInvitation(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "Moderator Baru", preview.FullName)
+
+	created, err := svc.AcceptInvitation(ctx, &admin.AcceptInvitationRequest{Token: token, Password: "Rahasia123"})
+	require.NoError(t, err)
+	assert.Equal(t, "moderator@karirnusantara.com", created.Email)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(repo.passwords[created.ID]), []byte("Rahasia123")))
+	assert.Equal(t, []uint64{2}, repo.roles.assignments[created.ID])
+
+	// Links work once
+	_, err = svc.AcceptInvitation(ctx, &admin.AcceptInvitationRequest{Token: token, Password: "Rahasia123"})
+	assert.ErrorIs(t, err, admin.ErrInvitationInvalid)
+
+	_, err = svc.InviteAdmin(ctx, &admin.InviteAdminRequest{Email: "moderator@karirnusantara.com", FullName: "Lagi"}, 1)
+	assert.ErrorIs(t, err, admin.ErrEmailRegistered)
+	_, err = svc.InviteAdmin(ctx, &admin.InviteAdminRequest{Email: "baru@karirnusantara.com", FullName: "Baru", RoleIDs: []uint64{99}}, 1)
+	assert.ErrorIs(t, err, admin.ErrRoleNotFound)
+}
+
+func TestAdminAccounts_ExpiredAndResentInvitations(t *testing.T) {
+	svc, repo, mailer, _ := newAccountService(t)
+	ctx := context.Background()
+
+	invitation, err := svc.InviteAdmin(ctx, &admin.InviteAdminRequest{Email: "ops@karirnusantara.com", FullName: "Ops"}, 1)
+	require.NoError(t, err)
+	oldToken := mailer.receivedToken(t)
+
+	repo.invitations[invitation.ID].ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = svc.PreviewInvitation(ctx, oldToken)
+	assert.ErrorIs(t, err, admin.ErrInvitationInvalid)
+
+	resent, err := svc.ResendInvitation(ctx, invitation.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, admin.InvitationStatusPending, resent.Status)
+	newToken := mailer.receivedToken(t)
+	assert.NotEqual(t, oldToken, newToken)
+
+	_, err = svc.AcceptInvitation(ctx, &admin.AcceptInvitationRequest{Token: oldToken, Password: "Rahasia123"})
+	assert.ErrorIs(t, err, admin.ErrInvitationInvalid)
+
+	require.NoError(t, svc.RevokeInvitation(ctx, invitation.ID, 1))
+	_, err = svc.AcceptInvitation(ctx, &admin.AcceptInvitationRequest{Token: newToken, Password: "Rahasia123"})
+	assert.ErrorIs(t, err, admin.ErrInvitationInvalid)
+}
+
+func TestAdminAccounts_DeactivateAndPassword(t *testing.T) {
+	svc, repo, _, passwords := newAccountService(t)
+	ctx := context.Background()
+
+	_, err := svc.DeactivateAdmin(ctx, 1, 1)
+	assert.ErrorIs(t, err, admin.ErrOwnAccount)
+
+	// The only super admin cannot be deactivated by anyone
+	_, err = svc.DeactivateAdmin(ctx, 1, 2)
+	assert.ErrorIs(t, err, admin.ErrLastSuperAdmin)
+
+	deactivated, err := svc.DeactivateAdmin(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.False(t, deactivated.IsActive)
+	assert.False(t, repo.audit.admins[2].IsActive)
+
+	entry := repo.audit.logs[len(repo.audit.logs)-1].ToResponse()
+	assert.Equal(t, "deactivate_admin", entry.Action)
+	assert.JSONEq(t, `{"is_active":true}`, string(entry.Before))
+	assert.JSONEq(t, `{"is_active":false}`, string(entry.After))
+
+	reactivated, err := svc.ReactivateAdmin(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.True(t, reactivated.IsActive)
+
+	require.NoError(t, svc.ChangePassword(ctx, 2, &auth.ChangePasswordRequest{OldPassword: "Lama1234", NewPassword: "Baru12345"}))
+	assert.Equal(t, "Baru12345", passwords.changed[2])
+
+	unavailable := admin.NewAccountService(repo, repo.roles, repo.audit, nil, nil)
+	err = unavailable.ChangePassword(ctx, 2, &auth.ChangePasswordRequest{OldPassword: "Lama1234", NewPassword: "Baru12345"})
+	assert.ErrorIs(t, err, admin.ErrPasswordChangeUnavailable)
+}
+
+func TestAdminAccounts_DeactivatedAdminTokensStopWorking(t *testing.T) {
+	svc, repo, _, _ := newAccountService(t)
+	ctx := context.Background()
+
+	clock := &mfaClock{t: time.Now()}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("admin-secret")})
+	authMiddleware := middleware.NewAuthMiddleware(tokens)
+	adminAuth := authMiddleware.ForPortal(token.PortalAdmin)
+	authMiddleware.CheckActive(token.PortalAdmin, admin.ActiveAdmins(repo.audit))
+
+	// A route without a permission check, like the admin's own account
+	ownAccount := adminAuth.Authenticate(adminAuth.RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	access, err := tokens.Issue(token.PortalAdmin, token.Claims{UserID: 2, Email: "finance@karirnusantara.com", Role: "admin"}, 24*time.Hour)
+	require.NoError(t, err)
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/admin/account", nil)
+		req.Header.Set("Authorization", "Bearer "+access)
+		rec := httptest.NewRecorder()
+		ownAccount.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve())
+
+	_, err = svc.DeactivateAdmin(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, serve(), "the token is rejected long before it expires")
+
+	_, err = svc.ReactivateAdmin(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, serve())
+}
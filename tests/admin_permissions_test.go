@@ -0,0 +1,282 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/admin"
+	"github.com/karirnusantara/api/internal/modules/tickets"
+	"github.com/karirnusantara/api/internal/shared/token"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// ============================================
+// Admin Permission Tests (in-process, no server needed)
+// ============================================
+
+// roleRepo is an in-memory admin role repository
+type roleRepo struct {
+	roles       map[uint64]*admin.AdminRole
+	assignments map[uint64][]uint64
+	nextID      uint64
+}
+
+func newRoleRepo() *roleRepo {
+	repo := &roleRepo{roles: map[uint64]*admin.AdminRole{}, assignments: map[uint64][]uint64{}}
+	all := make([]string, 0, len(admin.Permissions))
+	for _, p := range admin.Permissions {
+		all = append(all, p.Key)
+	}
+	repo.add(&admin.AdminRole{Name: admin.RoleSuperAdmin, IsSystem: true, Permissions: all})
+	repo.add(&admin.AdminRole{Name: "finance", Permissions: []string{admin.PermPaymentsView, admin.PermPaymentsApprove, admin.PermPartnersPayout}})
+	return repo
+}
+
+func (r *roleRepo) add(role *admin.AdminRole) {
+	r.nextID++
+	role.ID = r.nextID
+	r.roles[role.ID] = role
+}
+
+func (r *roleRepo) GetRoles(ctx context.Context) ([]*admin.AdminRole, error) {
+	var roles []*admin.AdminRole
+	for _, role := range r.roles {
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (r *roleRepo) GetRoleByID(ctx context.Context, id uint64) (*admin.AdminRole, error) {
+	if role, ok := r.roles[id]; ok {
+		copied := *role
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *roleRepo) GetRoleByName(ctx context.Context, name string) (*admin.AdminRole, error) {
+	for _, role := range r.roles {
+		if role.Name == name {
+			return role, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *roleRepo) CreateRole(ctx context.Context, role *admin.AdminRole) error {
+	copied := *role
+	r.add(&copied)
+	role.ID = copied.ID
+	return nil
+}
+
+func (r *roleRepo) UpdateRole(ctx context.Context, role *admin.AdminRole) error {
+	copied := *role
+	r.roles[role.ID] = &copied
+	return nil
+}
+
+func (r *roleRepo) DeleteRole(ctx context.Context, id uint64) error {
+	delete(r.roles, id)
+	return nil
+}
+
+func (r *roleRepo) GetAdmins(ctx context.Context) ([]*admin.AdminUser, error) {
+	return nil, nil
+}
+
+func (r *roleRepo) GetAdminRoles(ctx context.Context, adminID uint64) ([]*admin.AdminRole, error) {
+	var roles []*admin.AdminRole
+	for _, id := range r.assignments[adminID] {
+		if role, ok := r.roles[id]; ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func (r *roleRepo) SetAdminRoles(ctx context.Context, adminID uint64, roleIDs []uint64, assignedBy uint64) error {
+	r.assignments[adminID] = roleIDs
+	return nil
+}
+
+func (r *roleRepo) GetAdminPermissions(ctx context.Context, adminID uint64) ([]string, error) {
+	seen := map[string]bool{}
+	var permissions []string
+	roles, _ := r.GetAdminRoles(ctx, adminID)
+	for _, role := range roles {
+		for _, p := range role.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// auditRepo is the part of the admin repository used by the role service
+type auditRepo struct {
+	admin.Repository
+	admins map[uint64]*admin.AdminUser
+	logs   []*admin.AdminActionLog
+}
+
+func newAuditRepo() *auditRepo {
+	return &auditRepo{admins: map[uint64]*admin.AdminUser{
+		1: {ID: 1, Email: "root@karirnusantara.com", FullName: "Super Admin", Role: "admin", IsActive: true},
+		2: {ID: 2, Email: "finance@karirnusantara.com", FullName: "Staf Keuangan", Role: "admin", IsActive: true},
+	}}
+}
+
+func (r *auditRepo) GetAdminByID(ctx context.Context, id uint64) (*admin.AdminUser, error) {
+	return r.admins[id], nil
+}
+
+func (r *auditRepo) LogAdminAction(ctx context.Context, log *admin.AdminActionLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+// asAdmin sends a request through handler as the given admin
+func asAdmin(handler http.Handler, adminID uint64, method string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/", nil)
+	if adminID != 0 {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, adminID))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAdminPermissions_MiddlewareChecksRoles(t *testing.T) {
+	roles := newRoleRepo()
+	roles.assignments[2] = []uint64{2} // finance
+	svc := admin.NewRoleService(roles, newAuditRepo())
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	approvePayments := admin.RequirePermission(svc, admin.PermPaymentsApprove)(ok)
+	moderateJobs := admin.RequirePermission(svc, admin.PermJobsModerate)(ok)
+
+	assert.Equal(t, http.StatusOK, asAdmin(approvePayments, 2, http.MethodPost).Code)
+
+	rec := asAdmin(moderateJobs, 2, http.MethodPost)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	var body struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "PERMISSION_DENIED", body.Error.Code)
+	assert.Equal(t, admin.PermJobsModerate, body.Error.Details["permission"])
+
+	// Admins without roles have no permissions
+	assert.Equal(t, http.StatusForbidden, asAdmin(approvePayments, 3, http.MethodPost).Code)
+	assert.Equal(t, http.StatusUnauthorized, asAdmin(approvePayments, 0, http.MethodPost).Code)
+}
+
+// supportTickets serves the admin ticket list without a database
+type supportTickets struct {
+	tickets.Service
+}
+
+func (s supportTickets) GetAllTickets(ctx context.Context, status, priority string) ([]*tickets.TicketWithDetails, error) {
+	return []*tickets.TicketWithDetails{}, nil
+}
+
+func TestAdminPermissions_SupportRoutesRequireSupportPermissions(t *testing.T) {
+	roles := newRoleRepo()
+	roles.assignments[1] = []uint64{1} // super_admin
+	roles.assignments[2] = []uint64{2} // finance
+	svc := admin.NewRoleService(roles, newAuditRepo())
+
+	clock := &mfaClock{t: time.Now()}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("support-secret")})
+	r := chi.NewRouter()
+	tickets.RegisterRoutes(r, tickets.NewHandler(supportTickets{}, validator.New()), middleware.NewAuthMiddleware(tokens),
+		admin.RequirePermission(svc, admin.PermSupportRead), admin.RequirePermission(svc, admin.PermSupportReply))
+
+	serve := func(adminID uint64, method, target string) int {
+		access, err := tokens.Issue(token.PortalAdmin, token.Claims{UserID: adminID, Email: "admin@karirnusantara.com", Role: "admin"}, time.Hour)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+access)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(1, http.MethodGet, "/admin/tickets"))
+	assert.Equal(t, http.StatusForbidden, serve(2, http.MethodGet, "/admin/tickets"), "being an admin is not enough to read support tickets")
+	assert.Equal(t, http.StatusForbidden, serve(2, http.MethodPost, "/admin/tickets/1/close"))
+	assert.Equal(t, http.StatusForbidden, serve(3, http.MethodPost, "/admin/tickets/1/responses"), "admins without roles cannot reply")
+}
+
+func TestAdminPermissions_AuditLogRecordsPermission(t *testing.T) {
+	roles := newRoleRepo()
+	roles.assignments[1] = []uint64{1} // super_admin
+	audit := newAuditRepo()
+	svc := admin.NewRoleService(roles, audit)
+
+	assign := admin.RequirePermission(svc, admin.PermAdminsManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := svc.AssignRoles(r.Context(), 2, &admin.AssignAdminRolesRequest{RoleIDs: []uint64{2}}, 1)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.Equal(t, http.StatusOK, asAdmin(assign, 1, http.MethodPut).Code)
+
+	require.Len(t, audit.logs, 1)
+	assert.Equal(t, "assign_roles", audit.logs[0].Action)
+	assert.Equal(t, uint64(2), audit.logs[0].EntityID)
+	assert.Equal(t, "finance", audit.logs[0].Details.String)
+	assert.Equal(t, admin.PermAdminsManage, audit.logs[0].Permission.String)
+
+	has, err := svc.HasPermission(context.Background(), 2, admin.PermPartnersPayout)
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestAdminPermissions_RoleRules(t *testing.T) {
+	roles := newRoleRepo()
+	svc := admin.NewRoleService(roles, newAuditRepo())
+	ctx := context.Background()
+
+	role, err := svc.CreateRole(ctx, &admin.AdminRoleRequest{
+		Name: " Support ", Permissions: []string{admin.PermJobSeekersView, admin.PermCompaniesView, admin.PermJobSeekersView},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "support", role.Name)
+	assert.Equal(t, []string{admin.PermCompaniesView, admin.PermJobSeekersView}, role.Permissions)
+
+	_, err = svc.CreateRole(ctx, &admin.AdminRoleRequest{Name: "support"}, 1)
+	assert.True(t, errors.Is(err, admin.ErrRoleNameTaken))
+
+	_, err = svc.CreateRole(ctx, &admin.AdminRoleRequest{Name: "ops", Permissions: []string{"jobs.delete_everything"}}, 1)
+	assert.True(t, errors.Is(err, admin.ErrUnknownPermission))
+
+	// The built-in role cannot be changed
+	_, err = svc.UpdateRole(ctx, 1, &admin.AdminRoleRequest{Name: admin.RoleSuperAdmin}, 1)
+	assert.True(t, errors.Is(err, admin.ErrSystemRole))
+	assert.True(t, errors.Is(svc.DeleteRole(ctx, 1, 1), admin.ErrSystemRole))
+
+	// Admins cannot change their own roles
+	_, err = svc.AssignRoles(ctx, 1, &admin.AssignAdminRolesRequest{RoleIDs: []uint64{1}}, 1)
+	assert.True(t, errors.Is(err, admin.ErrOwnRoles))
+
+	_, err = svc.AssignRoles(ctx, 2, &admin.AssignAdminRolesRequest{RoleIDs: []uint64{99}}, 1)
+	assert.True(t, errors.Is(err, admin.ErrRoleNotFound))
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/admin"
+)
+
+// ============================================
+// Admin Audit Log Tests (in-process, no server needed)
+// ============================================
+
+// auditLogRepo is an in-memory audit log with the company operations used by the admin service
+type auditLogRepo struct {
+	admin.Repository
+	logs      []*admin.AdminActionLog
+	companies map[uint64]*admin.CompanyAdmin
+	queries   int
+}
+
+func (r *auditLogRepo) LogAdminAction(ctx context.Context, log *admin.AdminActionLog) error {
+	copied := *log
+	copied.ID = uint64(len(r.logs) + 1)
+	copied.CreatedAt = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC).Add(time.Duration(copied.ID) * time.Hour)
+	r.logs = append(r.logs, &copied)
+	return nil
+}
+
+func (r *auditLogRepo) GetAuditLogs(ctx context.Context, filter admin.AuditLogFilter) ([]*admin.AdminActionLog, error) {
+	r.queries++
+	var result []*admin.AdminActionLog
+	for i := len(r.logs) - 1; i >= 0 && len(result) < filter.Limit; i-- {
+		l := r.logs[i]
+		if filter.Cursor > 0 && l.ID >= filter.Cursor {
+			continue
+		}
+		if filter.AdminID > 0 && l.AdminID != filter.AdminID {
+			continue
+		}
+		if filter.Action != "" && l.Action != filter.Action {
+			continue
+		}
+		if filter.EntityType != "" && l.EntityType != filter.EntityType {
+			continue
+		}
+		if filter.EntityID != nil && l.EntityID != *filter.EntityID {
+			continue
+		}
+		result = append(result, l)
+	}
+	return result, nil
+}
+
+func (r *auditLogRepo) GetCompanyByID(ctx context.Context, id uint64) (*admin.CompanyAdmin, error) {
+	if c, ok := r.companies[id]; ok {
+		copied := *c
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *auditLogRepo) UpdateCompanyActive(ctx context.Context, id uint64, isActive bool) error {
+	r.companies[id].IsActive = isActive
+	return nil
+}
+
+func (r *auditLogRepo) UpdateCompanyStatus(ctx context.Context, id uint64, status string) error {
+	r.companies[id].CompanyStatus = sql.NullString{String: status, Valid: true}
+	return nil
+}
+
+// seed adds n log entries alternating between two admins
+func (r *auditLogRepo) seed(n int) {
+	for i := 0; i < n; i++ {
+		adminID := uint64(1 + i%2)
+		_ = r.LogAdminAction(context.Background(), &admin.AdminActionLog{
+			AdminID: adminID, Action: "job_approved", EntityType: "job", EntityID: uint64(100 + i),
+		})
+	}
+}
+
+func TestAuditLog_CursorPagination(t *testing.T) {
+	repo := &auditLogRepo{}
+	repo.seed(5)
+	svc := admin.NewAuditService(repo)
+	ctx := context.Background()
+
+	first, err := svc.GetAuditLogs(ctx, admin.AuditLogFilter{Limit: 2}, "")
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, uint64(5), first.Items[0].ID, "newest entries come first")
+	assert.True(t, first.HasMore)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := svc.GetAuditLogs(ctx, admin.AuditLogFilter{Limit: 2}, first.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 2}, []uint64{second.Items[0].ID, second.Items[1].ID})
+
+	last, err := svc.GetAuditLogs(ctx, admin.AuditLogFilter{Limit: 2}, second.NextCursor)
+	require.NoError(t, err)
+	require.Len(t, last.Items, 1)
+	assert.False(t, last.HasMore)
+	assert.Empty(t, last.NextCursor)
+
+	// Filters apply across pages
+	own, err := svc.GetAuditLogs(ctx, admin.AuditLogFilter{AdminID: 2}, "")
+	require.NoError(t, err)
+	assert.Len(t, own.Items, 2)
+
+	_, err = svc.GetAuditLogs(ctx, admin.AuditLogFilter{}, "not-a-cursor")
+	assert.ErrorIs(t, err, admin.ErrInvalidAuditCursor)
+	_, err = svc.GetAuditLogs(ctx, admin.AuditLogFilter{DateFrom: "2026-10-10", DateTo: "2026-10-01"}, "")
+	assert.ErrorIs(t, err, admin.ErrInvalidAuditDateRange)
+}
+
+func TestAuditLog_ExportCSVAndJSON(t *testing.T) {
+	repo := &auditLogRepo{}
+	repo.seed(3)
+	svc := admin.NewAuditService(repo)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	count, err := svc.ExportAuditLogs(ctx, admin.AuditLogFilter{}, admin.AuditExportCSV, &out, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4, "header plus one row per entry")
+	assert.Equal(t, "id", records[0][0])
+	assert.Equal(t, "3", records[1][0])
+
+	// The export itself is audited
+	exported := repo.logs[len(repo.logs)-1]
+	assert.Equal(t, "export_audit_logs", exported.Action)
+	assert.Contains(t, exported.Details.String, "rows=3")
+
+	out.Reset()
+	count, err = svc.ExportAuditLogs(ctx, admin.AuditLogFilter{Action: "job_approved"}, admin.AuditExportJSON, &out, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	var entries []admin.AuditLogResponse
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	assert.Len(t, entries, 3)
+
+	_, err = svc.ExportAuditLogs(ctx, admin.AuditLogFilter{}, "xml", &out, 1)
+	assert.ErrorIs(t, err, admin.ErrInvalidExportFormat)
+}
+
+func TestAuditLog_ExportCSVEscapesFormulas(t *testing.T) {
+	repo := &auditLogRepo{}
+	_ = repo.LogAdminAction(context.Background(), &admin.AdminActionLog{
+		AdminID: 1, AdminName: "=HYPERLINK(\"http://evil.example\")", Action: "company_suspended", EntityType: "company", EntityID: 7,
+		Details:   sql.NullString{String: "+62 812 3456", Valid: true},
+		OldValues: sql.NullString{String: "-1+1", Valid: true},
+		NewValues: sql.NullString{String: `{"status":"suspended"}`, Valid: true},
+		UserAgent: sql.NullString{String: "@SUM(A1:A2)", Valid: true},
+	})
+	svc := admin.NewAuditService(repo)
+
+	var out bytes.Buffer
+	_, err := svc.ExportAuditLogs(context.Background(), admin.AuditLogFilter{}, admin.AuditExportCSV, &out, 1)
+	require.NoError(t, err)
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	row := map[string]string{}
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	assert.Equal(t, `'=HYPERLINK("http://evil.example")`, row["admin_name"])
+	assert.Equal(t, "'+62 812 3456", row["details"])
+	assert.Equal(t, "'-1+1", row["before"])
+	assert.Equal(t, "'@SUM(A1:A2)", row["user_agent"])
+	assert.Equal(t, `{"status":"suspended"}`, row["after"], "other cells are left as they are")
+	assert.Equal(t, "company_suspended", row["action"])
+}
+
+func TestAuditLog_RecordsBeforeAndAfterSnapshots(t *testing.T) {
+	repo := &auditLogRepo{companies: map[uint64]*admin.CompanyAdmin{
+		7: {ID: 7, CompanyStatus: sql.NullString{String: admin.CompanyStatusVerified, Valid: true}, IsActive: true},
+	}}
+	svc := admin.NewService(repo, &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}})
+
+	err := svc.UpdateCompanyStatus(context.Background(), 7, &admin.CompanyStatusRequest{Action: "suspend", Reason: "Laporan penipuan"}, 1)
+	require.NoError(t, err)
+
+	require.Len(t, repo.logs, 1)
+	entry := repo.logs[0].ToResponse()
+	assert.Equal(t, "company_suspended", entry.Action)
+	assert.Equal(t, "Laporan penipuan", entry.Details)
+	assert.JSONEq(t, `{"company_status":"verified","is_active":true}`, string(entry.Before))
+	assert.JSONEq(t, `{"company_status":"suspended","is_active":false}`, string(entry.After))
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/applications"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// ============================================
+// Bulk Status Update Tests (in-process, no server needed)
+// ============================================
+
+// bulkStatusRepo is an in-memory applications repository covering the bulk status path
+type bulkStatusRepo struct {
+	applications.Repository
+
+	job    *applications.JobInfo
+	apps   map[uint64]*applications.Application
+	events []*applications.TimelineEvent
+	// raced lists applications another update changes between validation and the transaction
+	raced map[uint64]bool
+}
+
+func (r *bulkStatusRepo) GetJobInfo(ctx context.Context, jobID uint64) (*applications.JobInfo, error) {
+	if r.job.ID != jobID {
+		return nil, nil
+	}
+	return r.job, nil
+}
+
+func (r *bulkStatusRepo) ListByJobAndIDs(ctx context.Context, jobID uint64, ids []uint64) ([]*applications.Application, error) {
+	var result []*applications.Application
+	for _, id := range ids {
+		if app, ok := r.apps[id]; ok && app.JobID == jobID {
+			copied := *app
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *bulkStatusRepo) ApplyStatusChanges(ctx context.Context, changes []*applications.StatusChange) error {
+	for _, change := range changes {
+		stored := r.apps[change.Application.ID]
+		if r.raced[stored.ID] || stored.CurrentStatus != change.FromStatus {
+			continue
+		}
+		stored.CurrentStatus = change.Application.CurrentStatus
+		r.events = append(r.events, change.Event)
+		change.Applied = true
+	}
+	return nil
+}
+
+func newBulkStatusRepo() *bulkStatusRepo {
+	return &bulkStatusRepo{
+		job: &applications.JobInfo{ID: 10, Title: "Backend Engineer", Company: applications.CompanyInfo{ID: 7}},
+		apps: map[uint64]*applications.Application{
+			1: {ID: 1, JobID: 10, CurrentStatus: "submitted"},
+			2: {ID: 2, JobID: 10, CurrentStatus: "viewed"},
+			3: {ID: 3, JobID: 10, CurrentStatus: "hired"},
+			4: {ID: 4, JobID: 99, CurrentStatus: "submitted"},
+			5: {ID: 5, JobID: 10, CurrentStatus: "viewed"},
+		},
+		raced: map[uint64]bool{5: true},
+	}
+}
+
+func TestBulkUpdateStatus_PerItemResults(t *testing.T) {
+	repo := newBulkStatusRepo()
+	svc := applications.NewService(repo, nil, nil, nil)
+
+	req := &applications.UpdateStatusRequest{Status: "rejected", Note: "Posisi sudah terisi"}
+	ids := []string{"1", "2", "2", "3", "4", "abc", "5"}
+
+	result, err := svc.BulkUpdateStatus(context.Background(), 10, 7, ids, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(10), result.JobID)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 5, result.Failed)
+	require.Len(t, result.Results, len(ids))
+
+	// Results keep the request order
+	for i, id := range ids {
+		assert.Equal(t, id, result.Results[i].ApplicationID)
+	}
+
+	assert.True(t, result.Results[0].Success)
+	assert.Equal(t, "rejected", result.Results[0].Status)
+	assert.True(t, result.Results[1].Success)
+	assert.Equal(t, "Duplicate application ID", result.Results[2].Error)
+	assert.Equal(t, "Cannot update a terminal status", result.Results[3].Error)
+	assert.Equal(t, "Application not found for this job", result.Results[4].Error)
+	assert.Equal(t, "Invalid application ID", result.Results[5].Error)
+	assert.Equal(t, "Application status was changed by another update", result.Results[6].Error)
+
+	assert.Equal(t, "rejected", repo.apps[1].CurrentStatus)
+	assert.Equal(t, "rejected", repo.apps[2].CurrentStatus)
+	assert.Equal(t, "submitted", repo.apps[4].CurrentStatus)
+
+	// One company timeline event per applied change, same as the single-item update
+	require.Len(t, repo.events, 2)
+	for _, event := range repo.events {
+		assert.Equal(t, "rejected", event.Status)
+		assert.Equal(t, "company", event.UpdatedByType)
+		assert.Equal(t, int64(7), event.UpdatedByID.Int64)
+		assert.Equal(t, "Posisi sudah terisi", event.Note.String)
+		assert.True(t, event.IsVisibleToApplicant)
+	}
+}
+
+func TestBulkUpdateStatus_InvalidTransition(t *testing.T) {
+	repo := newBulkStatusRepo()
+	svc := applications.NewService(repo, nil, nil, nil)
+
+	req := &applications.UpdateStatusRequest{Status: "hired"}
+	result, err := svc.BulkUpdateStatus(context.Background(), 10, 7, []string{"1"}, req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, "Invalid status transition", result.Results[0].Error)
+	assert.Empty(t, repo.events)
+}
+
+func TestBulkUpdateStatus_RejectsWholeRequest(t *testing.T) {
+	repo := newBulkStatusRepo()
+	svc := applications.NewService(repo, nil, nil, nil)
+
+	// Another company's job
+	_, err := svc.BulkUpdateStatus(context.Background(), 10, 8, []string{"1"}, &applications.UpdateStatusRequest{Status: "viewed"})
+	require.Error(t, err)
+	assert.Equal(t, apperrors.ErrCodeForbidden, apperrors.GetAppError(err).Code)
+
+	// Unknown stage and withdrawal are not company actions
+	for _, status := range []string{"unknown_stage", "withdrawn"} {
+		_, err = svc.BulkUpdateStatus(context.Background(), 10, 7, []string{"1"}, &applications.UpdateStatusRequest{Status: status})
+		require.Error(t, err, status)
+		assert.Equal(t, apperrors.ErrCodeBadRequest, apperrors.GetAppError(err).Code)
+	}
+
+	// Too many applications at once
+	tooMany := make([]string, applications.MaxBulkStatusUpdate+1)
+	for i := range tooMany {
+		tooMany[i] = "1"
+	}
+	_, err = svc.BulkUpdateStatus(context.Background(), 10, 7, tooMany, &applications.UpdateStatusRequest{Status: "viewed"})
+	require.Error(t, err)
+	assert.Equal(t, apperrors.ErrCodeBadRequest, apperrors.GetAppError(err).Code)
+
+	assert.Equal(t, "submitted", repo.apps[1].CurrentStatus)
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/chat"
+)
+
+// ============================================
+// Chat Realtime Hub Tests (in-process, no server needed)
+// ============================================
+
+func receiveEvent(t *testing.T, sub *chat.Subscription) chat.Event {
+	t.Helper()
+	select {
+	case evt := <-sub.Events():
+		return evt
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return chat.Event{}
+	}
+}
+
+func assertNoEvent(t *testing.T, sub *chat.Subscription) {
+	t.Helper()
+	select {
+	case evt := <-sub.Events():
+		t.Fatalf("unexpected event %s", evt.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestChatHub_RoutesEventsToOwningCompanyAndAdmins(t *testing.T) {
+	hub := chat.NewHub()
+
+	companyA := hub.Subscribe(10, "company")
+	defer companyA.Close()
+	companyB := hub.Subscribe(20, "company")
+	defer companyB.Close()
+	admin := hub.Subscribe(1, "admin")
+	defer admin.Close()
+
+	hub.Publish(10, chat.Event{Type: chat.EventMessageCreated, ConversationID: 5})
+
+	assert.Equal(t, chat.EventMessageCreated, receiveEvent(t, companyA).Type)
+	assert.Equal(t, uint64(5), receiveEvent(t, admin).ConversationID)
+	assertNoEvent(t, companyB)
+}
+
+func TestChatHub_CloseUnsubscribes(t *testing.T) {
+	hub := chat.NewHub()
+
+	sub := hub.Subscribe(10, "company")
+	require.Equal(t, 1, hub.SubscriberCount())
+
+	sub.Close()
+	sub.Close() // idempotent
+	assert.Equal(t, 0, hub.SubscriberCount())
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok, "events channel should be closed")
+
+	// Publishing after close must not panic
+	hub.Publish(10, chat.Event{Type: chat.EventConversationStatus})
+}
+
+func TestChatHub_SlowSubscriberDoesNotBlockPublish(t *testing.T) {
+	hub := chat.NewHub()
+
+	slow := hub.Subscribe(10, "company")
+	defer slow.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			hub.Publish(10, chat.Event{Type: chat.EventMessageCreated})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+}
+
+func TestChatStream_DeliversEventsOverSSE(t *testing.T) {
+	hub := chat.NewHub()
+	handler := chat.NewHandlerWithHub(nil, nil, "", hub)
+
+	// Simulate AuthMiddleware having authenticated a company user
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middleware.UserIDKey, uint64(10))
+		ctx = context.WithValue(ctx, middleware.UserRoleKey, "company")
+		handler.Stream(w, r.WithContext(ctx))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (string, string) {
+		var name, data string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimRight(line, "\n")
+			if line == "" && name != "" {
+				return name, data
+			}
+			if strings.HasPrefix(line, "event: ") {
+				name = strings.TrimPrefix(line, "event: ")
+			}
+			if strings.HasPrefix(line, "data: ") {
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	name, _ := readEvent()
+	require.Equal(t, "ready", name)
+
+	hub.Publish(10, chat.Event{
+		Type:           chat.EventConversationStatus,
+		ConversationID: 7,
+		Data:           map[string]string{"status": "resolved"},
+	})
+
+	name, data := readEvent()
+	assert.Equal(t, chat.EventConversationStatus, name)
+
+	var evt struct {
+		Type           string            `json:"type"`
+		ConversationID uint64            `json:"conversation_id"`
+		Data           map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(data), &evt))
+	assert.Equal(t, uint64(7), evt.ConversationID)
+	assert.Equal(t, "resolved", evt.Data["status"])
+}
@@ -0,0 +1,629 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/admin"
+	"github.com/karirnusantara/api/internal/shared/email"
+)
+
+// ============================================
+// Email Outbox Tests (in-process fake SMTP server, no API server needed)
+// ============================================
+
+// fakeSMTPMessage is a message accepted by the fake SMTP server
+type fakeSMTPMessage struct {
+	From string
+	To   string
+	Data string
+}
+
+// fakeSMTPServer speaks enough SMTP (EHLO, STARTTLS, AUTH PLAIN, MAIL, RCPT, DATA, RSET, QUIT)
+// for net/smtp, over STARTTLS or implicit TLS. Recipients can be rejected with a temporary or
+// permanent error.
+type fakeSMTPServer struct {
+	listener    net.Listener
+	tlsConfig   *tls.Config
+	cert        *x509.Certificate
+	implicitTLS bool
+
+	mu          sync.Mutex
+	messages    []fakeSMTPMessage
+	reject      map[string]string // recipient -> RCPT reply
+	connections int
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	return startFakeSMTPServer(t, false)
+}
+
+// newImplicitTLSSMTPServer starts a server that expects TLS from the first byte, like port 465
+func newImplicitTLSSMTPServer(t *testing.T) *fakeSMTPServer {
+	return startFakeSMTPServer(t, true)
+}
+
+func startFakeSMTPServer(t *testing.T, implicitTLS bool) *fakeSMTPServer {
+	t.Helper()
+	cert, leaf := selfSignedCert(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSMTPServer{
+		listener:    listener,
+		tlsConfig:   &tls.Config{Certificates: []tls.Certificate{cert}},
+		cert:        leaf,
+		implicitTLS: implicitTLS,
+		reject:      map[string]string{},
+	}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+// selfSignedCert creates a certificate for 127.0.0.1 valid for the duration of the test
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fake-smtp"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, leaf
+}
+
+func (s *fakeSMTPServer) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+// caFile writes the server certificate to a PEM file for SMTP_CA_FILE
+func (s *fakeSMTPServer) caFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "smtp-ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.cert.Raw}), 0644))
+	return path
+}
+
+// config returns email settings that trust the server and send through it
+func (s *fakeSMTPServer) config(t *testing.T) *email.Config {
+	t.Helper()
+	return &email.Config{
+		Driver:       email.DriverSMTP,
+		SMTPHost:     "127.0.0.1",
+		SMTPPort:     s.port(),
+		SMTPUser:     "mailer",
+		SMTPPassword: "secret",
+		SMTPCAFile:   s.caFile(t),
+		FromName:     "Karir Nusantara",
+		FromEmail:    "no-reply@karirnusantara.com",
+	}
+}
+
+func (s *fakeSMTPServer) connectionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections
+}
+
+func (s *fakeSMTPServer) rejectRecipient(to, reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reject[to] = reply
+}
+
+func (s *fakeSMTPServer) acceptRecipient(to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reject, to)
+}
+
+func (s *fakeSMTPServer) received() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	s.mu.Lock()
+	s.connections++
+	s.mu.Unlock()
+
+	secure := false
+	if s.implicitTLS {
+		tlsConn := tls.Server(conn, s.tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return
+		}
+		conn = tlsConn
+		secure = true
+	}
+	reader := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+	}
+
+	reply("220 fake-smtp ESMTP ready")
+	var current fakeSMTPMessage
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "EHLO", "HELO":
+			if secure {
+				reply("250-fake-smtp", "250 AUTH PLAIN")
+			} else {
+				reply("250-fake-smtp", "250-STARTTLS", "250 AUTH PLAIN")
+			}
+		case "STARTTLS":
+			reply("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			secure = true
+		case "AUTH":
+			reply("235 Authentication successful")
+		case "MAIL":
+			current = fakeSMTPMessage{From: smtpAddress(line)}
+			reply("250 OK")
+		case "RCPT":
+			to := smtpAddress(line)
+			s.mu.Lock()
+			rejection, rejected := s.reject[to]
+			s.mu.Unlock()
+			if rejected {
+				reply(rejection)
+				continue
+			}
+			current.To = to
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			current.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// smtpAddress extracts the address from MAIL FROM:<...> and RCPT TO:<...>
+func smtpAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+// memoryOutbox is an in-memory email.OutboxStore
+type memoryOutbox struct {
+	mu          sync.Mutex
+	messages    []*email.OutboxMessage
+	lockedUntil map[uint64]time.Time
+	deleted     map[uint64]bool
+}
+
+func newMemoryOutbox() *memoryOutbox {
+	return &memoryOutbox{lockedUntil: map[uint64]time.Time{}, deleted: map[uint64]bool{}}
+}
+
+func (o *memoryOutbox) Enqueue(ctx context.Context, msg *email.OutboxMessage) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, existing := range o.messages {
+		if msg.IdempotencyKey.Valid && existing.IdempotencyKey == msg.IdempotencyKey {
+			return false, nil
+		}
+	}
+	msg.ID = uint64(len(o.messages) + 1)
+	msg.Status = email.OutboxStatusPending
+	msg.CreatedAt = time.Now()
+	msg.UpdatedAt = msg.CreatedAt
+	copied := *msg
+	o.messages = append(o.messages, &copied)
+	return true, nil
+}
+
+func (o *memoryOutbox) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*email.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var claimed []*email.OutboxMessage
+	for _, m := range o.messages {
+		if len(claimed) >= limit {
+			break
+		}
+		if o.deleted[m.ID] {
+			continue
+		}
+		due := m.Status == email.OutboxStatusPending && !m.NextAttemptAt.After(now)
+		expired := m.Status == email.OutboxStatusSending && !o.lockedUntil[m.ID].After(now)
+		if !due && !expired {
+			continue
+		}
+		m.Status = email.OutboxStatusSending
+		m.Attempts++
+		o.lockedUntil[m.ID] = now.Add(lease)
+		copied := *m
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (o *memoryOutbox) MarkSent(ctx context.Context, id uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	m := o.messages[id-1]
+	m.Status = email.OutboxStatusSent
+	m.SentAt.Time, m.SentAt.Valid = time.Now(), true
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(ctx context.Context, id uint64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	m := o.messages[id-1]
+	m.Status = email.OutboxStatusPending
+	if dead {
+		m.Status = email.OutboxStatusDead
+	}
+	m.LastError.String, m.LastError.Valid = lastError, true
+	m.NextAttemptAt = nextAttemptAt
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+func (o *memoryOutbox) List(ctx context.Context, filter email.OutboxFilter) ([]*email.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var result []*email.OutboxMessage
+	for i := len(o.messages) - 1; i >= 0 && len(result) < filter.Limit; i-- {
+		m := o.messages[i]
+		if !o.deleted[m.ID] && (filter.Status == "" || m.Status == filter.Status) && (filter.Cursor == 0 || m.ID < filter.Cursor) {
+			copied := *m
+			copied.Body = nil
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (o *memoryOutbox) Retry(ctx context.Context, id uint64, now time.Time) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if id == 0 || id > uint64(len(o.messages)) || o.messages[id-1].Status != email.OutboxStatusDead {
+		return false, nil
+	}
+	m := o.messages[id-1]
+	m.Status = email.OutboxStatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+	return true, nil
+}
+
+func (o *memoryOutbox) Get(ctx context.Context, id uint64) (*email.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if id == 0 || id > uint64(len(o.messages)) || o.deleted[id] {
+		return nil, nil
+	}
+	copied := *o.messages[id-1]
+	return &copied, nil
+}
+
+func (o *memoryOutbox) DeleteFinished(ctx context.Context, before time.Time, limit int) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	deleted := 0
+	for _, m := range o.messages {
+		if deleted >= limit {
+			break
+		}
+		finished := m.Status == email.OutboxStatusSent || m.Status == email.OutboxStatusDead
+		if finished && !o.deleted[m.ID] && m.UpdatedAt.Before(before) {
+			o.deleted[m.ID] = true
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// age moves the last update of every message back by d
+func (o *memoryOutbox) age(d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, m := range o.messages {
+		m.UpdatedAt = m.UpdatedAt.Add(-d)
+	}
+}
+
+// snapshot returns a copy of the stored message
+func (o *memoryOutbox) snapshot(id uint64) email.OutboxMessage {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return *o.messages[id-1]
+}
+
+// makeDue moves the next attempt of a pending message to now, skipping its backoff
+func (o *memoryOutbox) makeDue(id uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages[id-1].NextAttemptAt = time.Now()
+}
+
+// startOutboxWorker runs a worker against the fake SMTP server until the test ends
+func startOutboxWorker(t *testing.T, server *fakeSMTPServer) (*email.Service, *memoryOutbox) {
+	t.Helper()
+	outbox := newMemoryOutbox()
+	config := server.config(t)
+	transport, err := email.NewTransport(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { transport.Close() })
+	svc := email.NewServiceWithOutbox(config, transport, outbox, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		email.NewOutboxWorker(svc, 2, 20*time.Millisecond).Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return svc, outbox
+}
+
+func TestEmailOutbox_WorkerDelivers(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	svc, outbox := startOutboxWorker(t, server)
+
+	require.NoError(t, svc.SendEmail("budi@example.com", "Selamat Datang", "<p>Halo Budi</p>"))
+
+	require.Eventually(t, func() bool {
+		return outbox.snapshot(1).Status == email.OutboxStatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Equal(t, "budi@example.com", received[0].To)
+	assert.Equal(t, "no-reply@karirnusantara.com", received[0].From)
+	assert.Contains(t, received[0].Data, "Subject: Selamat Datang")
+	assert.Contains(t, received[0].Data, "<p>Halo Budi</p>")
+	assert.Equal(t, 1, outbox.snapshot(1).Attempts)
+}
+
+func TestEmailOutbox_IdempotencyKey(t *testing.T) {
+	outbox := newMemoryOutbox()
+	svc := email.NewServiceWithOutbox(&email.Config{FromEmail: "no-reply@karirnusantara.com"}, email.NewMemoryTransport(), outbox, nil)
+	ctx := context.Background()
+
+	msg := email.Message{To: "hr@example.com", Subject: "Lowongan dipublikasikan", HTMLBody: "<p>ok</p>", IdempotencyKey: "job_posted:42"}
+	require.NoError(t, svc.Send(ctx, msg))
+	require.NoError(t, svc.Send(ctx, msg), "queueing the same email twice is not an error")
+	require.NoError(t, svc.Send(ctx, email.Message{To: "hr@example.com", Subject: "Lain", HTMLBody: "<p>ok</p>"}))
+	require.NoError(t, svc.Send(ctx, email.Message{To: "hr@example.com", Subject: "Lain", HTMLBody: "<p>ok</p>"}))
+
+	assert.Len(t, outbox.messages, 3, "only messages with the same key are deduplicated")
+}
+
+func TestEmailOutbox_TemporaryFailureBacksOffThenDies(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.rejectRecipient("penuh@example.com", "451 Mailbox temporarily unavailable")
+	svc, outbox := startOutboxWorker(t, server)
+
+	require.NoError(t, svc.SendEmail("penuh@example.com", "Reset Password", "<p>reset</p>"))
+
+	require.Eventually(t, func() bool {
+		m := outbox.snapshot(1)
+		return m.Status == email.OutboxStatusPending && m.Attempts == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	first := outbox.snapshot(1)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), first.NextAttemptAt, 5*time.Second)
+	assert.Contains(t, first.LastError.String, "451")
+
+	// Skip the wait before each retry until the attempts run out
+	for attempt := 2; attempt <= email.DefaultMaxAttempts; attempt++ {
+		outbox.makeDue(1)
+		require.Eventually(t, func() bool {
+			m := outbox.snapshot(1)
+			return m.Attempts == attempt && m.Status != email.OutboxStatusSending
+		}, 5*time.Second, 10*time.Millisecond)
+
+		m := outbox.snapshot(1)
+		if attempt < email.DefaultMaxAttempts {
+			assert.Equal(t, email.OutboxStatusPending, m.Status)
+		}
+		if attempt == 2 {
+			assert.WithinDuration(t, time.Now().Add(2*time.Minute), m.NextAttemptAt, 5*time.Second, "backoff doubles")
+		}
+	}
+
+	assert.Equal(t, email.OutboxStatusDead, outbox.snapshot(1).Status)
+	assert.Empty(t, server.received())
+}
+
+func TestEmailOutbox_PermanentFailureDiesImmediately(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.rejectRecipient("tidakada@example.com", "550 No such user")
+	svc, outbox := startOutboxWorker(t, server)
+
+	require.NoError(t, svc.SendEmail("tidakada@example.com", "Verifikasi Email", "<p>verify</p>"))
+	require.NoError(t, svc.SendEmail("siti@example.com", "Verifikasi Email", "<p>verify</p>"))
+
+	require.Eventually(t, func() bool {
+		return outbox.snapshot(1).Status == email.OutboxStatusDead && outbox.snapshot(2).Status == email.OutboxStatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, outbox.snapshot(1).Attempts)
+	assert.Contains(t, outbox.snapshot(1).LastError.String, "550")
+}
+
+func TestEmailOutbox_AdminListAndRetry(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.rejectRecipient("tidakada@example.com", "550 No such user")
+	svc, outbox := startOutboxWorker(t, server)
+	audit := newAuditRepo()
+	emails := admin.NewEmailService(svc, audit)
+	ctx := context.Background()
+
+	require.NoError(t, svc.SendEmail("tidakada@example.com", "Undangan Wawancara", "<p>interview</p>"))
+	require.NoError(t, svc.SendEmail("siti@example.com", "Undangan Wawancara", "<p>interview</p>"))
+	require.Eventually(t, func() bool {
+		return outbox.snapshot(1).Status == email.OutboxStatusDead && outbox.snapshot(2).Status == email.OutboxStatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Dead messages are listed by default
+	page, err := emails.GetEmails(ctx, "", "", 0)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "tidakada@example.com", page.Items[0].Recipient)
+	assert.Contains(t, page.Items[0].LastError, "550")
+
+	page, err = emails.GetEmails(ctx, "all", "", 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.True(t, page.HasMore)
+	page, err = emails.GetEmails(ctx, "all", page.NextCursor, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, uint64(1), page.Items[0].ID)
+	assert.False(t, page.HasMore)
+
+	_, err = emails.GetEmails(ctx, "failed", "", 0)
+	assert.ErrorIs(t, err, admin.ErrInvalidEmailStatus)
+
+	// Only dead messages can be retried
+	_, err = emails.RetryEmail(ctx, 2, 1)
+	assert.ErrorIs(t, err, admin.ErrEmailNotFound)
+
+	// The mailbox exists now, so the retried message is delivered
+	server.acceptRecipient("tidakada@example.com")
+	retried, err := emails.RetryEmail(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "tidakada@example.com", retried.Recipient)
+
+	require.Eventually(t, func() bool {
+		return outbox.snapshot(1).Status == email.OutboxStatusSent
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, server.received(), 2)
+
+	require.Len(t, audit.logs, 1)
+	assert.Equal(t, "retry_email", audit.logs[0].Action)
+	assert.Equal(t, "email", audit.logs[0].EntityType)
+}
+
+func TestEmailOutbox_RetentionDeletesFinishedEmail(t *testing.T) {
+	outbox := newMemoryOutbox()
+	svc := email.NewServiceWithOutbox(&email.Config{FromEmail: "no-reply@karirnusantara.com"}, nil, outbox, nil)
+	ctx := context.Background()
+
+	require.NoError(t, svc.SendEmail("budi@example.com", "Reset Password", "<p>https://karirnusantara.com/reset-password?token=abc</p>"))
+	require.NoError(t, svc.SendEmail("tidakada@example.com", "Reset Password", "<p>https://karirnusantara.com/reset-password?token=def</p>"))
+	require.NoError(t, svc.SendEmail("siti@example.com", "Selamat Datang", "<p>Halo Siti</p>"))
+	require.NoError(t, outbox.MarkSent(ctx, 1))
+	require.NoError(t, outbox.MarkFailed(ctx, 2, "550 No such user", time.Now(), true))
+
+	// The admin list never carries message bodies
+	listed, err := svc.ListOutbox(ctx, email.OutboxFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	for _, msg := range listed {
+		assert.Empty(t, msg.Body)
+	}
+
+	deleted, err := svc.PurgeOutbox(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "recent email is kept")
+
+	outbox.age(31 * 24 * time.Hour)
+	deleted, err = svc.PurgeOutbox(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	remaining, err := svc.ListOutbox(ctx, email.OutboxFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1, "pending email is never deleted")
+	assert.Equal(t, "siti@example.com", remaining[0].Recipient)
+}
+
+func TestEmailOutbox_UnavailableWithoutOutbox(t *testing.T) {
+	emails := admin.NewEmailService(nil, newAuditRepo())
+
+	_, err := emails.GetEmails(context.Background(), "", "", 0)
+	assert.ErrorIs(t, err, admin.ErrEmailOutboxUnavailable)
+	_, err = emails.RetryEmail(context.Background(), 1, 1)
+	assert.ErrorIs(t, err, admin.ErrEmailOutboxUnavailable)
+}
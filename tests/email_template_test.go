@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/shared/email"
+)
+
+// ============================================
+// Email Template Tests (memory transport, no API server needed)
+// ============================================
+
+// recipientLocales is a fake email.Recipients with the language of each address
+type recipientLocales map[string]string
+
+func (r recipientLocales) Recipient(ctx context.Context, address string) (*email.Recipient, error) {
+	locale, ok := r[address]
+	if !ok {
+		return nil, nil
+	}
+	return &email.Recipient{Locale: locale}, nil
+}
+
+// parsedEmail is the readable content of a MIME message
+type parsedEmail struct {
+	Subject     string
+	ContentType string
+	Text        string
+	HTML        string
+	Attachments []string // content types
+}
+
+// parseEmail reads a message built by email.Service, descending into multipart bodies
+func parseEmail(t *testing.T, raw []byte) parsedEmail {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	parsed := parsedEmail{Subject: subject, ContentType: msg.Header.Get("Content-Type")}
+	readEmailPart(t, &parsed, parsed.ContentType, msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	return parsed
+}
+
+func readEmailPart(t *testing.T, parsed *parsedEmail, contentType, encoding string, body io.Reader) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return
+			}
+			require.NoError(t, err)
+			// NextPart decodes quoted-printable and removes the header
+			readEmailPart(t, parsed, part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+		}
+	}
+
+	content, err := io.ReadAll(body)
+	require.NoError(t, err)
+	switch {
+	case mediaType == "text/plain":
+		parsed.Text = string(content)
+	case mediaType == "text/html" && encoding != "base64":
+		parsed.HTML = string(content)
+	default:
+		parsed.Attachments = append(parsed.Attachments, mediaType)
+	}
+}
+
+func templateTestService(recipients email.Recipients) (*email.Service, *email.MemoryTransport) {
+	transport := email.NewMemoryTransport()
+	config := &email.Config{
+		FromName:      "Karir Nusantara",
+		FromEmail:     "no-reply@karirnusantara.com",
+		DefaultLocale: email.LocaleID,
+		URLs: email.URLs{
+			JobSeeker: "https://jobs.example.test",
+			Company:   "https://company.example.test",
+			Admin:     "https://admin.example.test",
+			Partner:   "https://partner.example.test",
+		},
+	}
+	return email.NewServiceWithOutbox(config, transport, nil, recipients), transport
+}
+
+func TestEmailTemplate_LocalizedPlainTextAlternative(t *testing.T) {
+	svc, transport := templateTestService(recipientLocales{"siti@example.com": "en", "budi@example.com": "id"})
+
+	require.NoError(t, svc.SendJobSeekerWelcomeEmail("siti@example.com", "Siti"))
+	require.NoError(t, svc.SendJobSeekerWelcomeEmail("budi@example.com", "Budi"))
+
+	sent := transport.Messages()
+	require.Len(t, sent, 2)
+	english, indonesian := parseEmail(t, sent[0].Message), parseEmail(t, sent[1].Message)
+
+	assert.True(t, strings.HasPrefix(english.ContentType, "multipart/alternative"))
+	assert.Equal(t, "Welcome to Karir Nusantara!", english.Subject)
+	assert.Equal(t, "Selamat Datang di Karir Nusantara!", indonesian.Subject)
+
+	assert.Contains(t, english.Text, "Hello, Siti!")
+	assert.NotContains(t, english.Text, "<")
+	assert.Contains(t, english.HTML, `<html lang="en">`)
+	assert.Contains(t, english.HTML, "Start Your Job Search")
+	assert.Contains(t, indonesian.Text, "Halo, Budi!")
+	assert.Contains(t, indonesian.HTML, "Mulai Cari Kerja Sekarang")
+
+	year := strconv.Itoa(time.Now().Year())
+	for _, parsed := range []parsedEmail{english, indonesian} {
+		assert.Contains(t, parsed.HTML, `href="https://jobs.example.test"`)
+		assert.Contains(t, parsed.Text, "https://jobs.example.test")
+		assert.Contains(t, parsed.HTML, "&copy; "+year+" Karir Nusantara")
+		assert.Contains(t, parsed.Text, "© "+year+" Karir Nusantara")
+	}
+}
+
+func TestEmailTemplate_DefaultLocale(t *testing.T) {
+	svc, transport := templateTestService(recipientLocales{"anon@example.com": "fr"})
+	require.NoError(t, svc.SendWelcomeEmail("anon@example.com", "PT Maju", "Andi"))
+	assert.Equal(t, "Selamat Datang di Karir Nusantara", transport.Messages()[0].Subject(), "unsupported languages fall back to the default")
+
+	svc, transport = templateTestService(nil)
+	require.NoError(t, svc.SendWelcomeEmail("andi@example.com", "PT Maju", "Andi"))
+	assert.Equal(t, "Selamat Datang di Karir Nusantara", transport.Messages()[0].Subject())
+}
+
+func TestEmailTemplate_LinksUseConfiguredPortals(t *testing.T) {
+	svc, transport := templateTestService(nil)
+
+	require.NoError(t, svc.SendPasswordResetEmail("hr@example.com", "a b", "HR", "company"))
+	require.NoError(t, svc.SendEmailVerificationEmail("budi@example.com", "Budi", "job_seeker", "tok"))
+	require.NoError(t, svc.SendAdminInvitationEmail("ops@example.com", "Ops", "Super Admin", "inv", time.Now().Add(time.Hour)))
+
+	sent := transport.Messages()
+	require.Len(t, sent, 3)
+	assert.Contains(t, parseEmail(t, sent[0].Message).Text, "https://company.example.test/reset-password?token=a+b")
+	assert.Contains(t, parseEmail(t, sent[1].Message).Text, "https://jobs.example.test/verify-email?token=tok")
+	assert.Contains(t, parseEmail(t, sent[2].Message).Text, "https://admin.example.test/accept-invitation?token=inv")
+}
+
+func TestEmailTemplate_AttachmentKeepsAlternative(t *testing.T) {
+	svc, transport := templateTestService(recipientLocales{"finance@example.com": "en"})
+	invoice := filepath.Join(t.TempDir(), "INV-001.pdf")
+	require.NoError(t, os.WriteFile(invoice, []byte("%PDF-1.4"), 0644))
+
+	require.NoError(t, svc.SendPaymentConfirmationEmail("finance@example.com", "PT Maju", "INV-001", 1500000, invoice))
+
+	parsed := parseEmail(t, transport.Messages()[0].Message)
+	assert.True(t, strings.HasPrefix(parsed.ContentType, "multipart/mixed"))
+	assert.Equal(t, []string{"application/pdf"}, parsed.Attachments)
+	assert.Contains(t, parsed.Text, "Amount: Rp 1.500.000")
+	assert.Contains(t, parsed.HTML, "Rp 1.500.000")
+}
+
+func TestEmailTemplate_EveryMessageInEveryLocale(t *testing.T) {
+	invoice := filepath.Join(t.TempDir(), "INV-002.pdf")
+	require.NoError(t, os.WriteFile(invoice, []byte("%PDF-1.4"), 0644))
+	start := time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)
+	schedule := email.InterviewScheduleData{
+		ApplicantName: "Budi", JobTitle: "Backend Engineer", CompanyName: "PT Maju",
+		InterviewType: "online", ScheduledAt: "Kamis, 05 Maret 2026", MeetingLink: "https://meet.example.com/abc", Notes: "Bawa KTP",
+	}
+
+	for _, locale := range email.Locales {
+		t.Run(locale, func(t *testing.T) {
+			to := "user@example.com"
+			svc, transport := templateTestService(recipientLocales{to: locale})
+
+			sends := []error{
+				svc.SendWelcomeEmail(to, "PT Maju", "Andi"),
+				svc.SendJobSeekerWelcomeEmail(to, "Budi"),
+				svc.SendPasswordResetEmail(to, "tok", "Budi", "job_seeker"),
+				svc.SendEmailVerificationEmail(to, "Budi", "company", "tok"),
+				svc.SendAccountLockedEmail(to, "Budi", start),
+				svc.SendAdminInvitationEmail(to, "Ops", "Super Admin", "tok", start),
+				svc.SendPasswordChangeConfirmationEmail(to, "Budi", "company"),
+				svc.SendPaymentConfirmationEmail(to, "PT Maju", "INV-002", 250000, invoice),
+				svc.SendCompanyVerificationEmail(to, "PT Maju", "Andi", true, ""),
+				svc.SendCompanyVerificationEmail(to, "PT Maju", "Andi", false, "NPWP tidak terbaca"),
+				svc.SendPartnerWelcomeEmail(to, "Rina", "RINA2026"),
+				svc.SendPartnerPasswordResetEmail(to, "Rina", "https://partner.example.test/reset-password?token=tok"),
+				svc.SendJobAlertEmail(to, email.JobAlertData{
+					FullName: "Budi", SearchName: "golang", Frequency: "weekly", TotalMatches: 1,
+					Jobs: []email.JobAlertItem{{Title: "Backend Engineer", CompanyName: "PT Maju", JobType: "full-time", Slug: "backend-engineer"}},
+				}),
+				svc.SendTalentInvitationEmail(to, email.TalentInvitationData{FullName: "Budi", CompanyName: "PT Maju", JobTitle: "Backend Engineer", JobSlug: "backend-engineer"}),
+				svc.SendJobPostedEmail(context.Background(), to, email.JobPostedData{JobID: 7, CompanyName: "PT Maju", Title: "Backend Engineer", Location: "Bandung", JobType: "contract", ExperienceLevel: "mid"}),
+			}
+			for _, kind := range []string{email.InterviewInvite, email.InterviewUpdate, email.InterviewCancel} {
+				sends = append(sends, svc.SendInterviewCalendarEmail(to, email.InterviewCalendarData{
+					InterviewScheduleData: schedule, Kind: kind, Round: 1, Title: "HR", Duration: 45,
+					UID: "interview-1@karirnusantara.com", Start: start, End: start.Add(45 * time.Minute),
+				}))
+			}
+			for i, err := range sends {
+				require.NoError(t, err, "send #%d", i)
+			}
+
+			sent := transport.Messages()
+			require.Len(t, sent, len(sends))
+			subjects := map[string]bool{}
+			for _, message := range sent {
+				parsed := parseEmail(t, message.Message)
+				assert.NotEmpty(t, parsed.Subject)
+				assert.NotEmpty(t, parsed.Text, parsed.Subject)
+				assert.NotEmpty(t, parsed.HTML, parsed.Subject)
+				assert.Contains(t, parsed.HTML, `<html lang="`+locale+`">`)
+				for _, body := range []string{parsed.Subject, parsed.Text, parsed.HTML} {
+					assert.NotContains(t, body, "<no value>", parsed.Subject)
+					assert.NotContains(t, body, "localhost", parsed.Subject)
+				}
+				subjects[parsed.Subject] = true
+			}
+			assert.Len(t, subjects, len(sent), "every message has its own subject")
+		})
+	}
+}
+
+func TestEmailTemplate_LocalizedDates(t *testing.T) {
+	svc, transport := templateTestService(recipientLocales{"siti@example.com": "en", "budi@example.com": "id"})
+	lockedUntil := time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)
+
+	require.NoError(t, svc.SendAccountLockedEmail("siti@example.com", "Siti", lockedUntil))
+	require.NoError(t, svc.SendAccountLockedEmail("budi@example.com", "Budi", lockedUntil))
+
+	sent := transport.Messages()
+	assert.Contains(t, parseEmail(t, sent[0].Message).Text, "5 March 2026 at 14:00 WIB")
+	assert.Contains(t, parseEmail(t, sent[1].Message).Text, "5 Maret 2026 pukul 14:00 WIB")
+}
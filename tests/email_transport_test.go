@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/shared/email"
+)
+
+// ============================================
+// Email Transport Tests (in-process fake SMTP server, no API server needed)
+// ============================================
+
+func TestEmailTransport_SMTPReusesConnection(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	config := server.config(t)
+	transport, err := email.NewTransport(config)
+	require.NoError(t, err)
+	defer transport.Close()
+	svc := email.NewService(config, transport)
+
+	for _, to := range []string{"budi@example.com", "siti@example.com", "andi@example.com"} {
+		require.NoError(t, svc.SendEmail(to, "Lamaran Diterima", "<p>ok</p>"))
+	}
+
+	received := server.received()
+	require.Len(t, received, 3)
+	assert.Equal(t, "andi@example.com", received[2].To)
+	assert.Equal(t, 1, server.connectionCount(), "messages share one connection")
+
+	// A rejected recipient does not cost the connection
+	server.rejectRecipient("penuh@example.com", "451 Mailbox temporarily unavailable")
+	assert.Error(t, svc.SendEmail("penuh@example.com", "Lamaran Diterima", "<p>ok</p>"))
+	require.NoError(t, svc.SendEmail("budi@example.com", "Lamaran Diterima", "<p>ok</p>"))
+	assert.Equal(t, 1, server.connectionCount())
+}
+
+func TestEmailTransport_SMTPVerifiesCertificate(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	config := server.config(t)
+	config.SMTPCAFile = ""
+	transport, err := email.NewTransport(config)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	err = transport.Send(context.Background(), config.FromEmail, "budi@example.com", []byte("Subject: Tes\r\n\r\nisi"))
+	require.Error(t, err, "a self-signed certificate is not trusted without SMTP_CA_FILE")
+	assert.Contains(t, err.Error(), "certificate")
+	assert.Empty(t, server.received())
+}
+
+func TestEmailTransport_SMTPImplicitTLS(t *testing.T) {
+	server := newImplicitTLSSMTPServer(t)
+	config := server.config(t)
+	config.SMTPTLS = email.SMTPTLSImplicit
+	transport, err := email.NewTransport(config)
+	require.NoError(t, err)
+	defer transport.Close()
+
+	require.NoError(t, email.NewService(config, transport).SendEmail("budi@example.com", "Selamat Datang", "<p>Halo</p>"))
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Contains(t, received[0].Data, "Subject: Selamat Datang")
+
+	config.SMTPTLS = "ssl"
+	_, err = email.NewTransport(config)
+	assert.Error(t, err)
+}
+
+func TestEmailTransport_FileDriver(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	config := &email.Config{Driver: email.DriverFile, FileDir: dir, FromEmail: "no-reply@karirnusantara.com"}
+	transport, err := email.NewTransport(config)
+	require.NoError(t, err)
+	svc := email.NewService(config, transport)
+
+	require.NoError(t, svc.SendEmail("budi@example.com", "Reset Password", "<p>reset</p>"))
+	require.NoError(t, svc.SendEmail("siti@example.com", "Reset Password", "<p>reset</p>"))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "X-Envelope-From: no-reply@karirnusantara.com\r\nX-Envelope-To: budi@example.com\r\n"))
+	assert.Contains(t, string(content), "Subject: Reset Password")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files are left behind")
+}
+
+func TestEmailTransport_MemoryDriver(t *testing.T) {
+	transport := email.NewMemoryTransport()
+	svc := email.NewService(&email.Config{FromEmail: "no-reply@karirnusantara.com"}, transport)
+
+	require.NoError(t, svc.SendPasswordChangeConfirmationEmail("budi@example.com", "Budi", "job_seeker"))
+
+	sent := transport.Messages()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "budi@example.com", sent[0].To)
+	assert.Equal(t, "no-reply@karirnusantara.com", sent[0].From)
+	assert.NotEmpty(t, sent[0].Subject())
+
+	transport.Reset()
+	assert.Empty(t, transport.Messages())
+}
+
+func TestEmailTransport_DriverFromConfig(t *testing.T) {
+	for _, key := range []string{"EMAIL_DRIVER", "SMTP_HOST", "SMTP_USER", "SMTP_PASSWORD"} {
+		t.Setenv(key, "")
+	}
+	config := email.LoadConfigFromEnv()
+	assert.Equal(t, email.DriverLog, config.Driver, "without a mail server nothing is sent")
+	assert.Empty(t, config.SMTPHost)
+	assert.Empty(t, config.SMTPPassword)
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	assert.Equal(t, email.DriverSMTP, email.LoadConfigFromEnv().Driver)
+
+	t.Setenv("EMAIL_DRIVER", "file")
+	assert.Equal(t, email.DriverFile, email.LoadConfigFromEnv().Driver)
+
+	_, err := email.NewTransport(&email.Config{Driver: "sendmail"})
+	assert.Error(t, err)
+	_, err = email.NewTransport(&email.Config{Driver: email.DriverSMTP})
+	assert.Error(t, err, "the smtp driver needs a host")
+}
+
+func TestEmailTransport_ProductionNeedsARealDriver(t *testing.T) {
+	for _, key := range []string{"EMAIL_DRIVER", "SMTP_HOST", "SMTP_USER", "SMTP_PASSWORD"} {
+		t.Setenv(key, "")
+	}
+	assert.Error(t, email.LoadConfigFromEnv().CheckProduction(), "email would silently only be logged")
+
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	assert.Error(t, email.LoadConfigFromEnv().CheckProduction(), "the driver is not picked implicitly in production")
+
+	for _, driver := range []string{email.DriverLog, email.DriverMemory} {
+		t.Setenv("EMAIL_DRIVER", driver)
+		assert.Error(t, email.LoadConfigFromEnv().CheckProduction(), driver)
+	}
+
+	t.Setenv("EMAIL_DRIVER", email.DriverSMTP)
+	assert.NoError(t, email.LoadConfigFromEnv().CheckProduction())
+}
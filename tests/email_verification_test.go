@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/auth"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// ============================================
+// Email Verification Tests (in-process, no server needed)
+// ============================================
+
+// verificationRepo is an in-memory auth repository for email verification tests
+type verificationRepo struct {
+	auth.Repository
+	users   map[uint64]*auth.User
+	tokens  []*auth.EmailVerificationToken
+	lookups int
+}
+
+func newVerificationRepo() *verificationRepo {
+	return &verificationRepo{users: map[uint64]*auth.User{
+		5: {ID: 5, Email: "andi@example.com", FullName: "Andi Wijaya", Role: auth.RoleJobSeeker, IsActive: true},
+	}}
+}
+
+func (r *verificationRepo) GetUserByID(ctx context.Context, id uint64) (*auth.User, error) {
+	return r.users[id], nil
+}
+
+func (r *verificationRepo) GetUserByEmail(ctx context.Context, email string) (*auth.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *verificationRepo) CreateEmailVerificationToken(ctx context.Context, token *auth.EmailVerificationToken) error {
+	for _, t := range r.tokens {
+		if t.UserID == token.UserID && !t.UsedAt.Valid {
+			t.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+	token.ID = uint64(len(r.tokens) + 1)
+	token.CreatedAt = time.Now()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *verificationRepo) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*auth.EmailVerificationToken, error) {
+	r.lookups++
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash && !t.UsedAt.Valid && t.ExpiresAt.After(time.Now()) {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *verificationRepo) CountEmailVerificationTokensSince(ctx context.Context, userID uint64, since time.Time) (int, error) {
+	count := 0
+	for _, t := range r.tokens {
+		if t.UserID == userID && !t.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *verificationRepo) MarkEmailVerified(ctx context.Context, userID, tokenID uint64) error {
+	t := r.tokens[tokenID-1]
+	if t.UsedAt.Valid {
+		return sql.ErrNoRows
+	}
+	t.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	r.users[userID].IsVerified = true
+	r.users[userID].EmailVerifiedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func newVerificationService(repo auth.Repository) auth.Service {
+	cfg := &config.JWTConfig{Secret: "test-secret", AccessExpiry: time.Hour, RefreshExpiry: 24 * time.Hour}
+	return auth.NewServiceWithVerificationSecret(repo, cfg, nil, nil, nil, "test-verification-secret")
+}
+
+func TestVerification_TokenIsSingleUse(t *testing.T) {
+	repo := newVerificationRepo()
+	svc := newVerificationService(repo)
+
+	user, token, err := svc.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: "andi@example.com"})
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	require.Len(t, repo.tokens, 1)
+	assert.NotEqual(t, token, repo.tokens[0].TokenHash, "only the hash is stored")
+
+	require.NoError(t, svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: token}))
+	assert.True(t, repo.users[5].IsVerified)
+	assert.True(t, repo.users[5].EmailVerifiedAt.Valid)
+
+	err = svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: token})
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.HTTPStatus)
+}
+
+func TestVerification_TamperedTokenRejectedBeforeLookup(t *testing.T) {
+	repo := newVerificationRepo()
+	svc := newVerificationService(repo)
+
+	_, token, err := svc.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: "andi@example.com"})
+	require.NoError(t, err)
+
+	nonce, _, ok := strings.Cut(token, ".")
+	require.True(t, ok)
+
+	for _, forged := range []string{nonce, nonce + ".deadbeef", "abc." + strings.Repeat("0", 64), ""} {
+		err := svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: forged})
+		appErr := apperrors.GetAppError(err)
+		require.NotNil(t, appErr, forged)
+		assert.Equal(t, apperrors.ErrCodeBadRequest, appErr.Code)
+	}
+	assert.Equal(t, 0, repo.lookups, "forged tokens never reach the database")
+	assert.False(t, repo.users[5].IsVerified)
+
+	// Tokens signed with another secret are rejected too, including the JWT secret
+	for _, other := range []auth.Service{
+		auth.NewService(newVerificationRepo(), &config.JWTConfig{Secret: "other-secret"}),
+		auth.NewService(newVerificationRepo(), &config.JWTConfig{Secret: "test-secret"}),
+	} {
+		_, otherToken, err := other.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: "andi@example.com"})
+		require.NoError(t, err)
+		assert.Error(t, svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: otherToken}))
+	}
+	assert.Equal(t, 0, repo.lookups)
+}
+
+func TestVerification_NewTokenReplacesOldOne(t *testing.T) {
+	repo := newVerificationRepo()
+	svc := newVerificationService(repo)
+
+	_, first, err := svc.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: "andi@example.com"})
+	require.NoError(t, err)
+
+	// Skip the cooldown
+	repo.tokens[0].CreatedAt = time.Now().Add(-2 * time.Minute)
+	_, second, err := svc.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: "andi@example.com"})
+	require.NoError(t, err)
+
+	assert.Error(t, svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: first}))
+	assert.NoError(t, svc.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: second}))
+}
+
+func TestVerification_ResendIsRateLimited(t *testing.T) {
+	repo := newVerificationRepo()
+	svc := newVerificationService(repo)
+	req := &auth.ResendVerificationRequest{Email: "andi@example.com"}
+
+	_, _, err := svc.ResendVerification(context.Background(), req)
+	require.NoError(t, err)
+
+	// Cooldown between requests
+	_, _, err = svc.ResendVerification(context.Background(), req)
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusTooManyRequests, appErr.HTTPStatus)
+
+	// Hourly cap
+	for i := 1; i < auth.VerificationMaxPerHour; i++ {
+		repo.tokens = append(repo.tokens, &auth.EmailVerificationToken{ID: uint64(len(repo.tokens) + 1), UserID: 5, CreatedAt: time.Now().Add(-10 * time.Minute)})
+	}
+	repo.tokens[0].CreatedAt = time.Now().Add(-10 * time.Minute)
+	_, _, err = svc.ResendVerification(context.Background(), req)
+	appErr = apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, apperrors.ErrCodeTooManyRequests, appErr.Code)
+}
+
+func TestVerification_ResendDoesNotRevealAccounts(t *testing.T) {
+	repo := newVerificationRepo()
+	repo.users[6] = &auth.User{ID: 6, Email: "verified@example.com", IsActive: true, IsVerified: true}
+	svc := newVerificationService(repo)
+
+	for _, email := range []string{"unknown@example.com", "verified@example.com"} {
+		user, token, err := svc.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: email})
+		require.NoError(t, err)
+		assert.Nil(t, user)
+		assert.Empty(t, token)
+	}
+	assert.Empty(t, repo.tokens)
+}
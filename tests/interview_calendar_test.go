@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/interviews"
+	"github.com/karirnusantara/api/internal/shared/calendar"
+)
+
+// ============================================
+// Interview Calendar Tests (in-process, no server needed)
+// ============================================
+
+func newInterviewEvent() calendar.Event {
+	wib := time.FixedZone("WIB", 7*60*60)
+	start := time.Date(2026, 11, 2, 9, 0, 0, 0, wib)
+
+	return calendar.Event{
+		UID:         "interview-123@karirnusantara.com",
+		Sequence:    2,
+		Start:       start,
+		End:         start.Add(90 * time.Minute),
+		Summary:     "Interview User; Backend Engineer, Jakarta",
+		Description: "Interview tahap 2 untuk posisi Backend Engineer di PT Maju Jaya.\nLink meeting: https://meet.google.com/abc-defg-hij\nCatatan: Siapkan portofolio dan contoh kode terbaik Anda",
+		Location:    "Gedung Sinar Mas Land Plaza, Jl. M.H. Thamrin No. 51, Jakarta Pusat",
+		Organizer:   calendar.Person{Name: "PT Maju Jaya", Email: "no-reply@karirnusantara.com"},
+		Attendees:   []calendar.Person{{Name: `Budi "Santoso"`, Email: "budi@example.com"}},
+	}
+}
+
+func TestCalendar_BuildRequest(t *testing.T) {
+	now := time.Date(2026, 10, 17, 3, 4, 5, 0, time.UTC)
+	ics := string(calendar.Build(calendar.MethodRequest, newInterviewEvent(), now))
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.NotContains(t, strings.ReplaceAll(ics, "\r\n", ""), "\n", "every line ends with CRLF")
+
+	// Unfold continuation lines before checking properties
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "METHOD:REQUEST\r\n")
+	assert.Contains(t, unfolded, "UID:interview-123@karirnusantara.com\r\n")
+	assert.Contains(t, unfolded, "SEQUENCE:2\r\n")
+	assert.Contains(t, unfolded, "DTSTAMP:20261017T030405Z\r\n")
+	assert.Contains(t, unfolded, "DTSTART:20261102T020000Z\r\n")
+	assert.Contains(t, unfolded, "DTEND:20261102T033000Z\r\n")
+	assert.Contains(t, unfolded, `SUMMARY:Interview User\; Backend Engineer\, Jakarta`+"\r\n")
+	assert.Contains(t, unfolded, `\nLink meeting: https://meet.google.com/abc-defg-hij`)
+	assert.Contains(t, unfolded, `ORGANIZER;CN="PT Maju Jaya":mailto:no-reply@karirnusantara.com`)
+	assert.Contains(t, unfolded, `ATTENDEE;CN="Budi Santoso";`)
+	assert.Contains(t, unfolded, "STATUS:CONFIRMED\r\n")
+
+	// Lines are folded at 75 octets
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+}
+
+func TestCalendar_BuildCancel(t *testing.T) {
+	ics := string(calendar.Build(calendar.MethodCancel, newInterviewEvent(), time.Now()))
+
+	assert.Contains(t, ics, "METHOD:CANCEL\r\n")
+	assert.Contains(t, ics, "STATUS:CANCELLED\r\n")
+	assert.Equal(t, "text/calendar; charset=UTF-8; method=CANCEL", calendar.ContentType(calendar.MethodCancel))
+}
+
+func TestCalendar_FoldingKeepsUTF8Intact(t *testing.T) {
+	event := newInterviewEvent()
+	event.Description = strings.Repeat("Wawancara 🎯 ", 20)
+
+	ics := string(calendar.Build(calendar.MethodRequest, event, time.Now()))
+	for _, line := range strings.Split(ics, "\r\n") {
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, "line splits a UTF-8 character: %q", line)
+	}
+	assert.Contains(t, strings.ReplaceAll(ics, "\r\n ", ""), "DESCRIPTION:"+strings.TrimSpace(strings.Repeat("Wawancara 🎯 ", 20)))
+}
+
+func TestInterview_Response(t *testing.T) {
+	interview := &interviews.Interview{
+		ID:              7,
+		ApplicationID:   3,
+		Round:           2,
+		Title:           "User Interview",
+		Status:          interviews.StatusScheduled,
+		ScheduledAt:     time.Date(2026, 11, 2, 2, 0, 0, 0, time.UTC),
+		DurationMinutes: 45,
+		InterviewType:   interviews.TypeOnline,
+	}
+
+	resp := interview.ToResponse()
+	require.NotNil(t, resp)
+	assert.Equal(t, time.Date(2026, 11, 2, 2, 45, 0, 0, time.UTC), resp.EndsAt)
+	assert.Equal(t, []string{}, resp.Interviewers)
+	assert.Nil(t, resp.RespondedAt)
+	assert.True(t, interview.IsActive())
+
+	interview.Status = interviews.StatusCancelled
+	assert.False(t, interview.IsActive())
+}
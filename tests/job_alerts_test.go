@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/alerts"
+	"github.com/karirnusantara/api/internal/modules/jobs"
+)
+
+// ============================================
+// Job Alert Digest Tests (in-process, no server needed)
+// ============================================
+
+// alertJob is a published job served by alertJobService
+type alertJob struct {
+	title       string
+	city        string
+	publishedAt time.Time
+}
+
+// alertJobService lists jobs the way the jobs repository filters them for alerts
+type alertJobService struct {
+	jobs.Service
+
+	jobs   []alertJob
+	params []jobs.JobListParams
+}
+
+func (s *alertJobService) List(ctx context.Context, params jobs.JobListParams) ([]*jobs.JobResponse, int64, error) {
+	s.params = append(s.params, params)
+
+	var matches []*jobs.JobResponse
+	for _, job := range s.jobs {
+		if params.Search != "" && !strings.Contains(strings.ToLower(job.title), strings.ToLower(params.Search)) {
+			continue
+		}
+		if params.City != "" && job.city != params.City {
+			continue
+		}
+		if params.PublishedAfter != nil && !job.publishedAt.After(*params.PublishedAfter) {
+			continue
+		}
+		if params.PublishedBefore != nil && job.publishedAt.After(*params.PublishedBefore) {
+			continue
+		}
+		matches = append(matches, &jobs.JobResponse{Title: job.title, Location: jobs.LocationInfo{City: job.city}})
+	}
+	return matches, int64(len(matches)), nil
+}
+
+// alertRepo is an in-memory saved search repository covering the digest path
+type alertRepo struct {
+	alerts.Repository
+
+	searches []*alerts.DueSearch
+}
+
+func (r *alertRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*alerts.DueSearch, error) {
+	var due []*alerts.DueSearch
+	for _, s := range r.searches {
+		period := now.AddDate(0, 0, -1)
+		if s.Frequency == alerts.FrequencyWeekly {
+			period = now.AddDate(0, 0, -7)
+		}
+		if !s.LastRunAt.Valid || !s.LastRunAt.Time.After(period) {
+			due = append(due, s)
+		}
+	}
+	return due, nil
+}
+
+func (r *alertRepo) MarkRun(ctx context.Context, id uint64, runAt time.Time, sent bool) error {
+	for _, s := range r.searches {
+		if s.ID == id {
+			s.LastRunAt = sql.NullTime{Time: runAt, Valid: true}
+			if sent {
+				s.LastSentAt = sql.NullTime{Time: runAt, Valid: true}
+			}
+		}
+	}
+	return nil
+}
+
+func dueSearch(id uint64, search, city, frequency string, created time.Time) *alerts.DueSearch {
+	return &alerts.DueSearch{
+		SavedSearch: alerts.SavedSearch{
+			ID: id, UserID: id, Name: "Lowongan " + search, Frequency: frequency, IsActive: true, CreatedAt: created,
+			Search: sql.NullString{String: search, Valid: search != ""},
+			City:   sql.NullString{String: city, Valid: city != ""},
+		},
+		UserEmail:    fmt.Sprintf("user%d@example.com", id),
+		UserFullName: "Budi",
+	}
+}
+
+func TestJobAlerts_DigestMatchesSavedSearch(t *testing.T) {
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	jobService := &alertJobService{jobs: []alertJob{
+		{title: "Golang Developer", city: "Jakarta", publishedAt: now.Add(-2 * time.Hour)},
+		{title: "Senior Golang Engineer", city: "Bandung", publishedAt: now.Add(-3 * time.Hour)},
+		{title: "Product Designer", city: "Jakarta", publishedAt: now.Add(-time.Hour)},
+	}}
+	repo := &alertRepo{searches: []*alerts.DueSearch{
+		dueSearch(1, "golang", "Jakarta", alerts.FrequencyDaily, now.AddDate(0, 0, -3)),
+		dueSearch(2, "rust", "", alerts.FrequencyDaily, now.AddDate(0, 0, -3)),
+	}}
+	emailService, transport := preferenceEmailService(nil)
+	svc := alerts.NewService(repo, jobService, emailService)
+
+	sent, err := svc.SendDueDigests(context.Background(), now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent, "searches without matches send no email")
+
+	messages := transport.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user1@example.com", messages[0].To)
+	text := parseEmail(t, messages[0].Message).Text
+	assert.Contains(t, text, "Golang Developer")
+	assert.NotContains(t, text, "Senior Golang Engineer", "other cities do not match")
+	assert.NotContains(t, text, "Product Designer")
+
+	for _, s := range repo.searches {
+		assert.True(t, s.LastRunAt.Valid, "every due search is marked as run")
+	}
+	assert.True(t, repo.searches[0].LastSentAt.Valid)
+	assert.False(t, repo.searches[1].LastSentAt.Valid)
+}
+
+func TestJobAlerts_NoDuplicatesAcrossRuns(t *testing.T) {
+	day1 := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	jobService := &alertJobService{jobs: []alertJob{
+		{title: "Golang Developer", publishedAt: day1.Add(-time.Hour)},
+		// Published exactly when the first run looked, and while it was sending
+		{title: "Golang Lead", publishedAt: day1},
+		{title: "Golang Intern", publishedAt: day1.Add(time.Minute)},
+	}}
+	repo := &alertRepo{searches: []*alerts.DueSearch{dueSearch(1, "golang", "", alerts.FrequencyDaily, day1.AddDate(0, 0, -1))}}
+	emailService, transport := preferenceEmailService(nil)
+	svc := alerts.NewService(repo, jobService, emailService)
+
+	_, err := svc.SendDueDigests(context.Background(), day1, 10)
+	require.NoError(t, err)
+	_, err = svc.SendDueDigests(context.Background(), day1.Add(time.Hour), 10)
+	require.NoError(t, err)
+	_, err = svc.SendDueDigests(context.Background(), day1.AddDate(0, 0, 1), 10)
+	require.NoError(t, err)
+
+	messages := transport.Messages()
+	require.Len(t, messages, 2, "a search is not mailed again before its period is over")
+
+	first := parseEmail(t, messages[0].Message).Text
+	second := parseEmail(t, messages[1].Message).Text
+	assert.Contains(t, first, "Golang Developer")
+	assert.Contains(t, first, "Golang Lead")
+	assert.NotContains(t, first, "Golang Intern", "jobs published after the run started wait for the next digest")
+	assert.Contains(t, second, "Golang Intern")
+	assert.NotContains(t, second, "Golang Developer")
+	assert.NotContains(t, second, "Golang Lead")
+}
+
+func TestJobAlerts_WindowIsCappedAfterDowntime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	jobService := &alertJobService{jobs: []alertJob{
+		{title: "Golang Developer", publishedAt: now.Add(-2 * time.Hour)},
+		{title: "Golang Engineer", publishedAt: now.AddDate(0, 0, -3)},
+		{title: "Golang Architect", publishedAt: now.AddDate(0, 0, -20)},
+	}}
+	daily := dueSearch(1, "golang", "", alerts.FrequencyDaily, now.AddDate(0, -2, 0))
+	daily.LastRunAt = sql.NullTime{Time: now.AddDate(0, -1, 0), Valid: true}
+	weekly := dueSearch(2, "golang", "", alerts.FrequencyWeekly, now.AddDate(0, -2, 0))
+	weekly.LastRunAt = sql.NullTime{Time: now.AddDate(0, -1, 0), Valid: true}
+	repo := &alertRepo{searches: []*alerts.DueSearch{daily, weekly}}
+	emailService, transport := preferenceEmailService(nil)
+	svc := alerts.NewService(repo, jobService, emailService)
+
+	sent, err := svc.SendDueDigests(context.Background(), now, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+
+	require.Len(t, jobService.params, 2)
+	assert.Equal(t, now.AddDate(0, 0, -1), *jobService.params[0].PublishedAfter, "a daily digest covers at most one day")
+	assert.Equal(t, now.AddDate(0, 0, -7), *jobService.params[1].PublishedAfter, "a weekly digest covers at most one week")
+	assert.Equal(t, now, *jobService.params[0].PublishedBefore)
+
+	messages := transport.Messages()
+	require.Len(t, messages, 2)
+	dailyText := parseEmail(t, messages[0].Message).Text
+	weeklyText := parseEmail(t, messages[1].Message).Text
+	assert.Contains(t, dailyText, "Golang Developer")
+	assert.NotContains(t, dailyText, "Golang Engineer")
+	assert.Contains(t, weeklyText, "Golang Engineer")
+	assert.NotContains(t, weeklyText, "Golang Architect", "the backlog from the outage is not mailed")
+}
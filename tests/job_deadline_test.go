@@ -0,0 +1,349 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/applications"
+	"github.com/karirnusantara/api/internal/modules/cvs"
+	"github.com/karirnusantara/api/internal/modules/jobs"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// ============================================
+// Application Deadline, Cap & Job Sweeper Tests (in-process, no server needed)
+// ============================================
+
+// sweeperRepo is an in-memory jobs repository covering closing jobs
+type sweeperRepo struct {
+	jobs.Repository
+
+	candidates []*jobs.AutoCloseCandidate
+	// lost lists jobs whose close loses a race with another update
+	lost   map[uint64]bool
+	today  string
+	closed map[uint64]*jobs.JobClosure
+	job    *jobs.Job
+}
+
+func (r *sweeperRepo) ListAutoCloseCandidates(ctx context.Context, today string, limit int) ([]*jobs.AutoCloseCandidate, error) {
+	r.today = today
+	if len(r.candidates) > limit {
+		return r.candidates[:limit], nil
+	}
+	return r.candidates, nil
+}
+
+func (r *sweeperRepo) CloseJob(ctx context.Context, id uint64, fromStatus string, closure *jobs.JobClosure) (bool, error) {
+	if r.lost[id] {
+		return false, nil
+	}
+	r.closed[id] = closure
+	return true, nil
+}
+
+func (r *sweeperRepo) GetByID(ctx context.Context, id uint64) (*jobs.Job, error) {
+	if r.job == nil || r.job.ID != id {
+		return nil, nil
+	}
+	return r.job, nil
+}
+
+func jobDeadline(date string) *jobs.JobResponse {
+	return &jobs.JobResponse{Status: jobs.JobStatusActive, ApplicationDeadline: date}
+}
+
+func TestJobDeadline_IsDeadlinePassed(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	prev := time.Local
+	time.Local = loc
+	defer func() { time.Local = prev }()
+
+	// 00:30 on 11 March in the server's zone is still 10 March in UTC,
+	// but deadlines always follow the server's date
+	now := time.Date(2026, 3, 11, 0, 30, 0, 0, loc)
+	assert.Equal(t, "2026-03-11", jobs.Today(now))
+	assert.Equal(t, "2026-03-11", jobs.Today(now.UTC()), "the same instant has the same date in any zone")
+
+	assert.False(t, jobDeadline("").IsDeadlinePassed(now), "no deadline")
+	assert.False(t, jobDeadline("2026-03-11").IsDeadlinePassed(now), "the deadline date itself is still open")
+	assert.False(t, jobDeadline("2026-03-12").IsDeadlinePassed(now))
+	assert.True(t, jobDeadline("2026-03-10").IsDeadlinePassed(now))
+	assert.False(t, jobDeadline("2026-03-10").IsDeadlinePassed(now.Add(-time.Hour)), "closes at the server's midnight")
+}
+
+func TestJobDeadline_HasReachedMaxApplications(t *testing.T) {
+	limit := int64(2)
+	zero := int64(0)
+
+	assert.False(t, (&jobs.JobResponse{ApplicationsCount: 100}).HasReachedMaxApplications(), "no cap")
+	assert.False(t, (&jobs.JobResponse{ApplicationsCount: 100, MaxApplications: &zero}).HasReachedMaxApplications(), "zero means no cap")
+	assert.False(t, (&jobs.JobResponse{ApplicationsCount: 1, MaxApplications: &limit}).HasReachedMaxApplications())
+	assert.True(t, (&jobs.JobResponse{ApplicationsCount: 2, MaxApplications: &limit}).HasReachedMaxApplications())
+}
+
+func TestJobSweeper_ClosesExpiredJobs(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	repo := &sweeperRepo{
+		candidates: []*jobs.AutoCloseCandidate{
+			{ID: 1, CompanyID: 7, Status: jobs.JobStatusActive, Reason: jobs.JobCloseReasonDeadlinePassed},
+			{ID: 2, CompanyID: 7, Status: jobs.JobStatusPaused, Reason: jobs.JobCloseReasonMaxApplications},
+			{ID: 3, CompanyID: 8, Status: jobs.JobStatusActive, Reason: jobs.JobCloseReasonDeadlinePassed},
+			{ID: 4, CompanyID: 8, Status: jobs.JobStatusDraft, Reason: jobs.JobCloseReasonDeadlinePassed},
+		},
+		lost:   map[uint64]bool{3: true},
+		closed: map[uint64]*jobs.JobClosure{},
+	}
+	svc := jobs.NewServiceWithClock(repo, func() time.Time { return now })
+
+	closed, err := svc.CloseExpiredJobs(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, closed, "jobs closed by someone else first are not counted")
+	assert.Equal(t, "2026-03-10", repo.today, "the sweeper uses the API clock, not the database's")
+
+	require.Len(t, repo.closed, 2)
+	assert.Equal(t, jobs.JobClosure{ClosedByType: jobs.JobClosedBySystem, Reason: jobs.JobCloseReasonDeadlinePassed}, *repo.closed[1])
+	assert.Equal(t, jobs.JobCloseReasonMaxApplications, repo.closed[2].Reason)
+	assert.Nil(t, repo.closed[4], "drafts cannot be closed")
+}
+
+func TestJobDeadline_ManualCloseLosesRace(t *testing.T) {
+	repo := &sweeperRepo{
+		job:    &jobs.Job{ID: 5, CompanyID: 7, Status: jobs.JobStatusActive},
+		lost:   map[uint64]bool{5: true},
+		closed: map[uint64]*jobs.JobClosure{},
+	}
+	svc := jobs.NewServiceWithClock(repo, time.Now)
+
+	_, err := svc.UpdateStatus(context.Background(), 5, 7, 70, jobs.JobStatusClosed)
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr, "closing a job the sweeper closed first is not reported as success")
+	assert.Equal(t, apperrors.ErrCodeConflict, appErr.Code)
+}
+
+// applyJobService serves a single job to the applications service
+type applyJobService struct {
+	jobs.Service
+
+	mu        sync.Mutex
+	job       *jobs.JobResponse
+	questions []jobs.ScreeningQuestion
+}
+
+func (s *applyJobService) GetByID(ctx context.Context, id uint64) (*jobs.JobResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job.ID != id {
+		return nil, apperrors.NewNotFoundError("Job")
+	}
+	copied := *s.job
+	return &copied, nil
+}
+
+func (s *applyJobService) GetScreeningQuestions(ctx context.Context, jobID uint64) ([]jobs.ScreeningQuestion, error) {
+	return s.questions, nil
+}
+
+type applyCVService struct {
+	cvs.Service
+}
+
+func (s *applyCVService) CreateSnapshot(ctx context.Context, userID uint64) (*cvs.CVSnapshot, error) {
+	return &cvs.CVSnapshot{ID: userID, UserID: userID}, nil
+}
+
+// applyRepo is an in-memory applications repository whose Create claims a slot of the
+// job the same way the SQL conditional update does
+type applyRepo struct {
+	applications.Repository
+
+	jobs    *applyJobService
+	mu      sync.Mutex
+	apps    map[uint64]*applications.Application
+	answers []applications.ScreeningAnswer
+	events  []*applications.TimelineEvent
+	today   []string
+}
+
+func (r *applyRepo) IsEmailVerified(ctx context.Context, userID uint64) (bool, error) {
+	return true, nil
+}
+
+func (r *applyRepo) GetByUserAndJob(ctx context.Context, userID, jobID uint64) (*applications.Application, error) {
+	return nil, nil
+}
+
+func (r *applyRepo) Create(ctx context.Context, app *applications.Application, answers []applications.ScreeningAnswer, events []*applications.TimelineEvent, today string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.today = append(r.today, today)
+
+	r.jobs.mu.Lock()
+	defer r.jobs.mu.Unlock()
+	job := r.jobs.job
+	if job.Status != jobs.JobStatusActive || (job.ApplicationDeadline != "" && job.ApplicationDeadline < today) {
+		return applications.ErrJobNotAccepting
+	}
+	if app.CurrentStatus != applications.StatusRejected {
+		if job.MaxApplications != nil && *job.MaxApplications > 0 && int64(job.ApplicationsCount) >= *job.MaxApplications {
+			return applications.ErrJobNotAccepting
+		}
+		job.ApplicationsCount++
+	}
+
+	app.ID = uint64(len(r.apps) + 1)
+	r.apps[app.ID] = app
+	for i := range answers {
+		answers[i].ApplicationID = app.ID
+	}
+	r.answers = append(r.answers, answers...)
+	for _, event := range events {
+		event.ApplicationID = app.ID
+	}
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func (r *applyRepo) AddTimelineEvent(ctx context.Context, event *applications.TimelineEvent) error {
+	return nil
+}
+
+func (r *applyRepo) GetByID(ctx context.Context, id uint64) (*applications.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.apps[id], nil
+}
+
+func (r *applyRepo) GetJobInfo(ctx context.Context, jobID uint64) (*applications.JobInfo, error) {
+	return nil, nil
+}
+
+func (r *applyRepo) GetCVSnapshotInfo(ctx context.Context, snapshotID uint64) (*applications.CVSnapshotInfo, error) {
+	return nil, nil
+}
+
+func (r *applyRepo) GetTimelineForApplicant(ctx context.Context, applicationID uint64) ([]applications.TimelineEvent, error) {
+	return nil, nil
+}
+
+func newApplyService(job *jobs.JobResponse) (applications.Service, *applyRepo) {
+	jobService := &applyJobService{job: job}
+	repo := &applyRepo{jobs: jobService, apps: map[uint64]*applications.Application{}}
+	return applications.NewService(repo, &applyCVService{}, jobService, nil), repo
+}
+
+func applyErrorCode(err error) string {
+	if appErr := apperrors.GetAppError(err); appErr != nil {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestApply_DeadlinePassed(t *testing.T) {
+	yesterday := jobs.Today(time.Now().AddDate(0, 0, -1))
+	svc, repo := newApplyService(&jobs.JobResponse{ID: 10, Status: jobs.JobStatusActive, ApplicationDeadline: yesterday})
+
+	_, err := svc.Apply(context.Background(), 1, &applications.ApplyJobRequest{JobID: 10})
+	assert.Equal(t, apperrors.ErrCodeApplicationDeadlinePassed, applyErrorCode(err))
+	assert.Empty(t, repo.apps)
+
+	repo.jobs.job.ApplicationDeadline = jobs.Today(time.Now())
+	_, err = svc.Apply(context.Background(), 1, &applications.ApplyJobRequest{JobID: 10})
+	require.NoError(t, err, "the deadline date itself is still open")
+	assert.Equal(t, []string{jobs.Today(time.Now())}, repo.today, "the insert checks the deadline against the same date")
+}
+
+func TestApply_CapIsNotOvershotByConcurrentApplies(t *testing.T) {
+	limit := int64(3)
+	svc, repo := newApplyService(&jobs.JobResponse{ID: 10, Status: jobs.JobStatusActive, MaxApplications: &limit})
+
+	// Every applicant reads the job while it still has room
+	const applicants = 10
+	var wg sync.WaitGroup
+	codes := make([]string, applicants)
+	errs := make([]error, applicants)
+	for i := 0; i < applicants; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.Apply(context.Background(), uint64(i+1), &applications.ApplyJobRequest{JobID: 10})
+			codes[i] = applyErrorCode(errs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	accepted := 0
+	for i := range errs {
+		if errs[i] == nil {
+			accepted++
+			continue
+		}
+		assert.Equal(t, apperrors.ErrCodeApplicationLimitReached, codes[i])
+	}
+	assert.Equal(t, 3, accepted)
+	assert.Len(t, repo.apps, 3)
+	assert.Equal(t, uint64(3), repo.jobs.job.ApplicationsCount)
+}
+
+func TestApply_JobClosedWhileApplying(t *testing.T) {
+	_, repo := newApplyService(&jobs.JobResponse{ID: 10, Status: jobs.JobStatusActive})
+
+	// The job is read as active, then closed before the application is stored
+	svc := applications.NewService(&closingApplyRepo{applyRepo: repo}, &applyCVService{}, repo.jobs, nil)
+
+	_, err := svc.Apply(context.Background(), 1, &applications.ApplyJobRequest{JobID: 10})
+	assert.Equal(t, apperrors.ErrCodeBadRequest, applyErrorCode(err))
+	assert.Empty(t, repo.apps)
+}
+
+func TestApply_KnockoutIsStoredRejectedWithoutTakingASlot(t *testing.T) {
+	limit := int64(1)
+	svc, repo := newApplyService(&jobs.JobResponse{ID: 10, Status: jobs.JobStatusActive, MaxApplications: &limit})
+	repo.jobs.questions = []jobs.ScreeningQuestion{{
+		ID: 3, Question: "Bersedia lembur?", Type: jobs.QuestionTypeYesNo,
+		IsRequired: true, IsKnockout: true, AcceptedAnswers: []string{jobs.AnswerYes},
+	}}
+
+	resp, err := svc.Apply(context.Background(), 1, &applications.ApplyJobRequest{
+		JobID:            10,
+		ScreeningAnswers: []applications.ScreeningAnswerRequest{{QuestionID: 3, Answer: "no"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, applications.StatusRejected, resp.CurrentStatus)
+	assert.Equal(t, applications.StatusRejected, repo.apps[resp.ID].CurrentStatus, "the row is inserted as rejected")
+	assert.Zero(t, repo.jobs.job.ApplicationsCount, "a knocked-out application does not take a slot")
+
+	// The answers and both timeline events are written with the application
+	require.Len(t, repo.answers, 1)
+	assert.Equal(t, resp.ID, repo.answers[0].ApplicationID)
+	assert.False(t, repo.answers[0].Passed)
+	require.Len(t, repo.events, 2)
+	assert.Equal(t, applications.StatusSubmitted, repo.events[0].Status)
+	assert.Equal(t, applications.StatusRejected, repo.events[1].Status)
+
+	// The only slot is still free for a candidate who passes
+	resp, err = svc.Apply(context.Background(), 2, &applications.ApplyJobRequest{
+		JobID:            10,
+		ScreeningAnswers: []applications.ScreeningAnswerRequest{{QuestionID: 3, Answer: "yes"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, applications.StatusSubmitted, resp.CurrentStatus)
+	assert.Equal(t, uint64(1), repo.jobs.job.ApplicationsCount)
+}
+
+// closingApplyRepo closes the job right before claiming a slot
+type closingApplyRepo struct {
+	*applyRepo
+}
+
+func (r *closingApplyRepo) Create(ctx context.Context, app *applications.Application, answers []applications.ScreeningAnswer, events []*applications.TimelineEvent, today string) error {
+	r.jobs.mu.Lock()
+	r.jobs.job.Status = jobs.JobStatusClosed
+	r.jobs.mu.Unlock()
+	return r.applyRepo.Create(ctx, app, answers, events, today)
+}
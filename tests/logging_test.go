@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/logger"
+	"github.com/karirnusantara/api/internal/shared/token"
+)
+
+// ============================================
+// Structured Logging Tests (in-process, no server needed)
+// ============================================
+
+// logRecords decodes the JSON lines written by a logger
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogging_RedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(&buf, slog.LevelDebug)
+
+	log.Info("login attempt",
+		"email", "budi@example.com",
+		"password", "rahasia123",
+		"PasswordHash", "$2a$10$abc",
+		"reset_token", "tok-123",
+		"Authorization", "Bearer abc",
+		"nik", "3201010101010001",
+		"ktp_nik", "3201010101010002",
+		"unique_views", 3,
+		slog.Group("request", "refresh_token", "tok-456", "path", "/auth/refresh"),
+	)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	record := records[0]
+	for _, key := range []string{"password", "PasswordHash", "reset_token", "Authorization", "nik", "ktp_nik"} {
+		assert.Equal(t, logger.Redacted, record[key], key)
+	}
+	assert.Equal(t, "budi@example.com", record["email"])
+	assert.Equal(t, float64(3), record["unique_views"])
+	group := record["request"].(map[string]interface{})
+	assert.Equal(t, logger.Redacted, group["refresh_token"])
+	assert.Equal(t, "/auth/refresh", group["path"])
+
+	for _, secret := range []string{"rahasia123", "tok-123", "tok-456", "3201010101010001", "Bearer abc"} {
+		assert.NotContains(t, buf.String(), secret)
+	}
+}
+
+func TestLogging_LevelFollowsConfig(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, logger.Level(&config.AppConfig{Env: "development", Debug: true}))
+	assert.Equal(t, slog.LevelInfo, logger.Level(&config.AppConfig{Env: "development", Debug: false}))
+	assert.Equal(t, slog.LevelInfo, logger.Level(&config.AppConfig{Env: "production", Debug: true}), "production never logs at debug")
+
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(&buf, logger.Level(&config.AppConfig{Env: "production"}))
+	log.Debug("hidden")
+	log.Info("shown")
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "shown", records[0]["msg"])
+}
+
+func TestLogging_ContextLogger(t *testing.T) {
+	assert.Same(t, slog.Default(), logger.FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	ctx := logger.WithContext(context.Background(), logger.NewWithWriter(&buf, slog.LevelInfo))
+	ctx = logger.With(ctx, "request_id", "req-1")
+	logger.FromContext(ctx).Info("hello", "job_id", 7)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, float64(7), records[0]["job_id"])
+}
+
+func TestLogging_RequestCorrelation(t *testing.T) {
+	clock := &mfaClock{t: time.Now()}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("logging-secret")})
+	auth := middleware.NewAuthMiddleware(tokens)
+	access, err := tokens.Issue(token.PortalApp, token.Claims{UserID: 42, Email: "hr@example.com", Role: "company"}, time.Hour)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	base := logger.NewWithWriter(&buf, slog.LevelInfo)
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(logger.WithContext(req.Context(), base)))
+		})
+	})
+	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.RequestLogger)
+	r.Use(middleware.Recoverer)
+	r.With(auth.Authenticate).Get("/jobs", func(w http.ResponseWriter, req *http.Request) {
+		logger.FromContext(req.Context()).Info("listing jobs", "access_token", access)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Get("/panic", func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs", nil)
+	req.Header.Set("Authorization", "Bearer "+access)
+	req.Header.Set("X-Request-Id", "req-abc")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "req-abc", rec.Header().Get("X-Request-ID"), "chi's request ID is reused")
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 2)
+	service, accessLog := records[0], records[1]
+	assert.Equal(t, "listing jobs", service["msg"])
+	assert.Equal(t, "req-abc", service["request_id"])
+	assert.Equal(t, float64(42), service["user_id"])
+	assert.Equal(t, "company", service["role"])
+	assert.Equal(t, logger.Redacted, service["access_token"])
+
+	assert.Equal(t, "request completed", accessLog["msg"])
+	assert.Equal(t, "req-abc", accessLog["request_id"])
+	assert.Equal(t, "/jobs", accessLog["path"])
+	assert.Equal(t, float64(http.StatusNoContent), accessLog["status"])
+	assert.Equal(t, float64(42), accessLog["user_id"], "the access log names the authenticated caller")
+
+	buf.Reset()
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	records = logRecords(t, &buf)
+	require.Len(t, records, 2)
+	assert.Equal(t, "panic recovered", records[0]["msg"])
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.NotEmpty(t, records[0]["request_id"])
+	assert.Equal(t, records[0]["request_id"], records[1]["request_id"])
+	assert.Equal(t, "ERROR", records[1]["level"], "server errors are logged at error level")
+	assert.Nil(t, records[1]["user_id"])
+}
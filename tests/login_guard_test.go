@@ -0,0 +1,323 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/loginguard"
+	"github.com/karirnusantara/api/internal/shared/clientip"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// ============================================
+// Login Brute-Force Protection Tests (in-process, no server needed)
+// ============================================
+
+// guardRepo is an in-memory login guard repository. Like the SQL one, it counts and
+// locks under a single lock.
+type guardRepo struct {
+	mu        sync.Mutex
+	throttles map[string]*loginguard.Throttle
+	nextID    uint64
+	names     map[string]string
+}
+
+func newGuardRepo() *guardRepo {
+	return &guardRepo{
+		throttles: map[string]*loginguard.Throttle{},
+		names:     map[string]string{"andi@example.com": "Andi Wijaya"},
+	}
+}
+
+func guardKey(scope, keyType, keyValue string) string {
+	return scope + "|" + keyType + "|" + keyValue
+}
+
+func (r *guardRepo) Get(ctx context.Context, scope, keyType, keyValue string) (*loginguard.Throttle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.throttles[guardKey(scope, keyType, keyValue)]
+	if !ok {
+		return nil, nil
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (r *guardRepo) Increment(ctx context.Context, scope, keyType, keyValue string, limit int, policy loginguard.Policy, now time.Time) (*loginguard.Throttle, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := guardKey(scope, keyType, keyValue)
+	t, ok := r.throttles[key]
+	if !ok {
+		r.nextID++
+		t = &loginguard.Throttle{ID: r.nextID, Scope: scope, KeyType: keyType, KeyValue: keyValue}
+		r.throttles[key] = t
+	}
+	if t.LastFailedAt.Valid && t.LastFailedAt.Time.Before(now.Add(-policy.MaxLockDuration)) {
+		t.LockoutCount = 0
+	}
+	if t.LastFailedAt.Valid && t.LastFailedAt.Time.Before(now.Add(-policy.Window)) {
+		t.FailedCount = 0
+	}
+	t.FailedCount++
+	t.LastFailedAt = sql.NullTime{Time: now, Valid: true}
+
+	locked := false
+	if limit > 0 && t.FailedCount >= limit {
+		lockFor := policy.LockDuration
+		for i := 0; i < t.LockoutCount && lockFor < policy.MaxLockDuration; i++ {
+			lockFor *= 2
+		}
+		if lockFor > policy.MaxLockDuration {
+			lockFor = policy.MaxLockDuration
+		}
+		t.LockedUntil = sql.NullTime{Time: now.Add(lockFor), Valid: true}
+		t.LockoutCount++
+		t.FailedCount = 0
+		locked = true
+	}
+
+	copied := *t
+	return &copied, locked, nil
+}
+
+func (r *guardRepo) Delete(ctx context.Context, scope, keyType, keyValue string) error {
+	delete(r.throttles, guardKey(scope, keyType, keyValue))
+	return nil
+}
+
+func (r *guardRepo) ListLocked(ctx context.Context, now time.Time, search string) ([]*loginguard.Throttle, error) {
+	var locked []*loginguard.Throttle
+	for _, t := range r.throttles {
+		if t.IsLocked(now) && strings.Contains(t.KeyValue, search) {
+			locked = append(locked, t)
+		}
+	}
+	return locked, nil
+}
+
+func (r *guardRepo) Unlock(ctx context.Context, id, adminID uint64) (bool, error) {
+	for _, t := range r.throttles {
+		if t.ID == id {
+			t.FailedCount = 0
+			t.LockoutCount = 0
+			t.LockedUntil.Valid = false
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *guardRepo) GetAccountName(ctx context.Context, email string) (string, error) {
+	return r.names[email], nil
+}
+
+// lockoutMailer records lockout emails
+type lockoutMailer struct {
+	sent chan string
+}
+
+func (m *lockoutMailer) SendAccountLockedEmail(to, fullName string, lockedUntil time.Time) error {
+	m.sent <- to
+	return nil
+}
+
+func newGuard(repo loginguard.Repository, mailer loginguard.EmailSender, clock *mfaClock) loginguard.Service {
+	return loginguard.NewServiceWithClock(repo, mailer, clock.Now)
+}
+
+func TestLoginGuard_ProgressiveDelayThenLockout(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newGuardRepo()
+	mailer := &lockoutMailer{sent: make(chan string, 1)}
+	guard := newGuard(repo, mailer, clock)
+	ctx := clientip.WithIP(context.Background(), "203.0.113.7")
+	policy := loginguard.Policies[loginguard.ScopeLogin]
+
+	for i := 0; i < policy.FreeAttempts; i++ {
+		require.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "Andi@Example.com"))
+		guard.RecordFailure(ctx, loginguard.ScopeLogin, "Andi@Example.com")
+	}
+
+	// Delays start after the free attempts
+	err := guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com")
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusTooManyRequests, appErr.HTTPStatus)
+	assert.Equal(t, "1", appErr.Details["retry_after"])
+
+	for i := policy.FreeAttempts; i < policy.AccountLimit; i++ {
+		clock.Advance(policy.MaxDelay)
+		require.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com"))
+		guard.RecordFailure(ctx, loginguard.ScopeLogin, "andi@example.com")
+	}
+
+	select {
+	case to := <-mailer.sent:
+		assert.Equal(t, "andi@example.com", to)
+	case <-time.After(time.Second):
+		t.Fatal("lockout email was not sent")
+	}
+
+	clock.Advance(policy.LockDuration - time.Minute)
+	err = guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com")
+	require.NotNil(t, apperrors.GetAppError(err))
+	assert.Contains(t, err.Error(), "dikunci")
+
+	clock.Advance(time.Minute)
+	assert.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com"))
+}
+
+func TestLoginGuard_SuccessResetsAccountButNotIP(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newGuardRepo()
+	guard := newGuard(repo, nil, clock)
+	ctx := clientip.WithIP(context.Background(), "203.0.113.7")
+
+	for i := 0; i < 3; i++ {
+		guard.RecordFailure(ctx, loginguard.ScopeLogin, "andi@example.com")
+	}
+	guard.RecordSuccess(ctx, loginguard.ScopeLogin, "andi@example.com")
+	assert.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com"))
+
+	ipThrottle, err := repo.Get(ctx, loginguard.ScopeLogin, loginguard.KeyIP, "203.0.113.7")
+	require.NoError(t, err)
+	require.NotNil(t, ipThrottle)
+	assert.Equal(t, 3, ipThrottle.FailedCount)
+}
+
+func TestLoginGuard_IPLockedAcrossAccounts(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	guard := newGuard(newGuardRepo(), nil, clock)
+	attacker := clientip.WithIP(context.Background(), "198.51.100.9")
+	policy := loginguard.Policies[loginguard.ScopeLogin]
+
+	for i := 0; i < policy.IPLimit; i++ {
+		guard.RecordFailure(attacker, loginguard.ScopeLogin, "user"+string(rune('a'+i%26))+"@example.com")
+	}
+
+	err := guard.Check(attacker, loginguard.ScopeLogin, "fresh@example.com")
+	require.NotNil(t, apperrors.GetAppError(err))
+	assert.Contains(t, err.Error(), "alamat IP")
+
+	other := clientip.WithIP(context.Background(), "203.0.113.7")
+	assert.NoError(t, guard.Check(other, loginguard.ScopeLogin, "fresh@example.com"))
+}
+
+func TestLoginGuard_PasswordResetRequestsLimitedForUnknownEmails(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	guard := newGuard(newGuardRepo(), nil, clock)
+	ctx := clientip.WithIP(context.Background(), "203.0.113.7")
+
+	require.NoError(t, guard.Attempt(ctx, loginguard.ScopePasswordReset, "nobody@example.com"))
+	err := guard.Attempt(ctx, loginguard.ScopePasswordReset, "nobody@example.com")
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusTooManyRequests, appErr.HTTPStatus)
+
+	// Login attempts are counted separately
+	assert.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "nobody@example.com"))
+}
+
+func TestLoginGuard_AdminUnlock(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newGuardRepo()
+	guard := newGuard(repo, nil, clock)
+	ctx := context.Background()
+	policy := loginguard.Policies[loginguard.ScopeLogin]
+
+	for i := 0; i < policy.AccountLimit; i++ {
+		guard.RecordFailure(ctx, loginguard.ScopeLogin, "budi@example.com")
+	}
+
+	lockouts, err := guard.ListLockouts(ctx, "budi")
+	require.NoError(t, err)
+	require.Len(t, lockouts, 1)
+	assert.Equal(t, loginguard.KeyAccount, lockouts[0].KeyType)
+
+	require.NoError(t, guard.Unlock(ctx, lockouts[0].ID, 1))
+	assert.NoError(t, guard.Check(ctx, loginguard.ScopeLogin, "budi@example.com"))
+
+	err = guard.Unlock(ctx, 999, 1)
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.HTTPStatus)
+}
+
+func TestLoginGuard_ConcurrentFailuresAreAllCounted(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newGuardRepo()
+	mailer := &lockoutMailer{sent: make(chan string, 10)}
+	guard := newGuard(repo, mailer, clock)
+	ctx := clientip.WithIP(context.Background(), "203.0.113.7")
+	policy := loginguard.Policies[loginguard.ScopeLogin]
+
+	// A burst of parallel guesses, all passing Check before any failure is recorded
+	var wg sync.WaitGroup
+	for i := 0; i < policy.AccountLimit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			guard.RecordFailure(ctx, loginguard.ScopeLogin, "andi@example.com")
+		}()
+	}
+	wg.Wait()
+
+	err := guard.Check(ctx, loginguard.ScopeLogin, "andi@example.com")
+	require.NotNil(t, apperrors.GetAppError(err))
+	assert.Contains(t, err.Error(), "dikunci", "the burst locks the account")
+
+	throttle, err := repo.Get(ctx, loginguard.ScopeLogin, loginguard.KeyAccount, "andi@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, throttle.LockoutCount, "exactly one failure triggers the lockout")
+	assert.Equal(t, clock.Now().Add(policy.LockDuration), throttle.LockedUntil.Time)
+
+	ipThrottle, err := repo.Get(ctx, loginguard.ScopeLogin, loginguard.KeyIP, "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, policy.AccountLimit, ipThrottle.FailedCount)
+}
+
+func TestClientIP_ForwardedHeadersOnlyFromTrustedProxies(t *testing.T) {
+	resolver, err := clientip.NewResolver([]string{"10.0.0.0/8", "192.0.2.10"})
+	require.NoError(t, err)
+
+	request := func(remoteAddr string, headers map[string]string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		r.RemoteAddr = remoteAddr
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		return r
+	}
+
+	// Clients connecting directly cannot choose the IP they are throttled under
+	assert.Equal(t, "198.51.100.9", resolver.ClientIP(request("198.51.100.9:5000", map[string]string{"X-Forwarded-For": "203.0.113.1"})))
+	assert.Equal(t, "198.51.100.9", resolver.ClientIP(request("198.51.100.9:5000", map[string]string{"X-Real-IP": "203.0.113.1"})))
+
+	// Behind the load balancer the client is the right-most untrusted hop; spoofed entries to its left are ignored
+	assert.Equal(t, "198.51.100.9", resolver.ClientIP(request("10.1.2.3:443", map[string]string{"X-Forwarded-For": "203.0.113.1, 198.51.100.9"})))
+	assert.Equal(t, "198.51.100.9", resolver.ClientIP(request("192.0.2.10:443", map[string]string{"X-Forwarded-For": "198.51.100.9, 10.4.5.6"})))
+	assert.Equal(t, "198.51.100.9", resolver.ClientIP(request("10.1.2.3:443", map[string]string{"X-Real-IP": "198.51.100.9"})))
+	assert.Equal(t, "10.1.2.3", resolver.ClientIP(request("10.1.2.3:443", nil)))
+
+	ctxIP := ""
+	handler := resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxIP = clientip.FromContext(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), request("198.51.100.9:5000", map[string]string{"X-Forwarded-For": "203.0.113.1"}))
+	assert.Equal(t, "198.51.100.9", ctxIP)
+
+	_, err = clientip.NewResolver([]string{"not-an-ip"})
+	assert.Error(t, err)
+}
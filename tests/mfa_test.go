@@ -0,0 +1,359 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/mfa"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+	"github.com/karirnusantara/api/internal/shared/token"
+	"github.com/karirnusantara/api/internal/shared/totp"
+)
+
+// ============================================
+// Two-Factor Authentication Tests (in-process, no server needed)
+// ============================================
+
+// mfaRepo is an in-memory MFA repository
+type mfaRepo struct {
+	enrollments map[uint64]*mfa.Enrollment
+	codes       map[uint64]map[string]bool // hash -> used
+	now         func() time.Time
+}
+
+func newMFARepo(now func() time.Time) *mfaRepo {
+	return &mfaRepo{
+		enrollments: map[uint64]*mfa.Enrollment{},
+		codes:       map[uint64]map[string]bool{},
+		now:         now,
+	}
+}
+
+func (r *mfaRepo) GetEnrollment(ctx context.Context, userID uint64) (*mfa.Enrollment, error) {
+	e, ok := r.enrollments[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (r *mfaRepo) SaveEnrollmentSecret(ctx context.Context, userID uint64, secretEncrypted, keyID string) error {
+	r.enrollments[userID] = &mfa.Enrollment{UserID: userID, SecretEncrypted: secretEncrypted, SecretKeyID: keyID, CreatedAt: r.now()}
+	return nil
+}
+
+func (r *mfaRepo) RekeySecret(ctx context.Context, userID uint64, oldKeyID, secretEncrypted, keyID string) error {
+	if e := r.enrollments[userID]; e != nil && e.SecretKeyID == oldKeyID {
+		e.SecretEncrypted = secretEncrypted
+		e.SecretKeyID = keyID
+	}
+	return nil
+}
+
+func (r *mfaRepo) EnableEnrollment(ctx context.Context, userID uint64, step int64, codeHashes []string) error {
+	e := r.enrollments[userID]
+	e.EnabledAt = sql.NullTime{Time: r.now(), Valid: true}
+	e.LastUsedStep = step
+	e.FailedAttempts = 0
+	return r.ReplaceRecoveryCodes(ctx, userID, codeHashes)
+}
+
+func (r *mfaRepo) DeleteEnrollment(ctx context.Context, userID uint64) error {
+	delete(r.enrollments, userID)
+	delete(r.codes, userID)
+	return nil
+}
+
+func (r *mfaRepo) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	e := r.enrollments[userID]
+	if e.LastUsedStep >= step {
+		return false, nil
+	}
+	e.LastUsedStep = step
+	e.FailedAttempts = 0
+	return true, nil
+}
+
+func (r *mfaRepo) RecordFailedAttempt(ctx context.Context, userID uint64) error {
+	e := r.enrollments[userID]
+	if e == nil {
+		return nil
+	}
+	if !e.LastFailedAt.Valid || r.now().Sub(e.LastFailedAt.Time) > mfa.FailedAttemptReset {
+		e.FailedAttempts = 0
+	}
+	e.FailedAttempts++
+	e.LastFailedAt = sql.NullTime{Time: r.now(), Valid: true}
+	return nil
+}
+
+func (r *mfaRepo) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	r.codes[userID] = map[string]bool{}
+	for _, h := range codeHashes {
+		r.codes[userID][h] = false
+	}
+	return nil
+}
+
+func (r *mfaRepo) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	used, ok := r.codes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	r.codes[userID][codeHash] = true
+	r.enrollments[userID].FailedAttempts = 0
+	return true, nil
+}
+
+func (r *mfaRepo) CountUnusedRecoveryCodes(ctx context.Context, userID uint64) (int, error) {
+	count := 0
+	for _, used := range r.codes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mfaKeys encrypt TOTP secrets in MFA tests
+var mfaKeys = mfa.EncryptionKeys{KeyID: "k1", Key: "test-mfa-key"}
+
+// mfaClock is a controllable clock for MFA tests
+type mfaClock struct{ t time.Time }
+
+func (c *mfaClock) Now() time.Time { return c.t }
+
+func (c *mfaClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// mfaTokens is the token manager that signs challenges in the MFA tests
+func mfaTokens(clock *mfaClock) *token.Manager {
+	return newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("test-secret")})
+}
+
+// enrollMFA sets up and enables MFA for a user, returning the TOTP secret and recovery codes
+func enrollMFA(t *testing.T, svc mfa.Service, clock *mfaClock, userID uint64) (string, []string) {
+	setup, err := svc.Setup(context.Background(), userID, "admin@karirnusantara.com")
+	require.NoError(t, err)
+	assert.Contains(t, setup.ProvisioningURI, "otpauth://totp/")
+
+	code, err := totp.Code(setup.Secret, clock.Now())
+	require.NoError(t, err)
+	codes, err := svc.Enable(context.Background(), userID, code)
+	require.NoError(t, err)
+	require.Len(t, codes.RecoveryCodes, mfa.RecoveryCodeCount)
+
+	clock.Advance(totp.Period)
+	return setup.Secret, codes.RecoveryCodes
+}
+
+func assertAppStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr, "expected an app error, got %v", err)
+	assert.Equal(t, status, appErr.HTTPStatus)
+}
+
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	// Secret "12345678901234567890" from RFC 6238 Appendix B, truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	code, err := totp.Code(secret, time.Unix(59, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = totp.Code(secret, time.Unix(1111111109, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "081804", code)
+
+	step, ok := totp.Validate(secret, "081804", time.Unix(1111111109+30, 0), 1)
+	assert.True(t, ok, "previous step is accepted within skew")
+	assert.Equal(t, totp.Step(time.Unix(1111111109, 0)), step)
+
+	_, ok = totp.Validate(secret, "081804", time.Unix(1111111109+90, 0), 1)
+	assert.False(t, ok, "codes outside the skew window are rejected")
+}
+
+func TestMFA_LoginChallengeRejectsReplayedCode(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	svc := mfa.NewServiceWithClock(newMFARepo(clock.Now), mfaTokens(clock), mfaKeys, clock.Now)
+	ctx := context.Background()
+
+	challenge, err := svc.BeginLogin(ctx, 7, "admin@karirnusantara.com", mfa.PortalAdmin, false)
+	require.NoError(t, err)
+	assert.Nil(t, challenge, "users without MFA log in directly when it is optional")
+
+	secret, _ := enrollMFA(t, svc, clock, 7)
+
+	challenge, err = svc.BeginLogin(ctx, 7, "admin@karirnusantara.com", mfa.PortalAdmin, false)
+	require.NoError(t, err)
+	require.NotNil(t, challenge)
+	assert.Equal(t, mfa.PurposeVerify, challenge.Purpose)
+
+	code, err := totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	userID, _, err := svc.CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), userID)
+
+	_, _, err = svc.CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+}
+
+func TestMFA_ChallengeBoundToPortalAndExpires(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	svc := mfa.NewServiceWithClock(newMFARepo(clock.Now), mfaTokens(clock), mfaKeys, clock.Now)
+	ctx := context.Background()
+	secret, _ := enrollMFA(t, svc, clock, 7)
+
+	challenge, err := svc.BeginLogin(ctx, 7, "partner@example.com", mfa.PortalPartner, false)
+	require.NoError(t, err)
+
+	code, err := totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	_, _, err = svc.CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+
+	clock.Advance(mfa.ChallengeExpiry + time.Minute)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	_, _, err = svc.CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalPartner)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+}
+
+func TestMFA_ChallengeFollowsSigningKeyRotation(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newMFARepo(clock.Now)
+	before := mfaTokens(clock)
+	ctx := context.Background()
+	secret, _ := enrollMFA(t, mfa.NewServiceWithClock(repo, before, mfaKeys, clock.Now), clock, 7)
+
+	challenge, err := mfa.NewServiceWithClock(repo, before, mfaKeys, clock.Now).BeginLogin(ctx, 7, "admin@karirnusantara.com", mfa.PortalAdmin, false)
+	require.NoError(t, err)
+
+	// A challenge is not an access token
+	_, err = before.Parse(token.PortalAdmin, challenge.Token)
+	assert.ErrorIs(t, err, token.ErrInvalid)
+
+	// A challenge issued before the signing key rotated still completes after it
+	after := newTokenManager(clock, token.Key{ID: "k2", Secret: []byte("next-secret")}, token.Key{ID: "k1", Secret: []byte("test-secret")})
+	code, err := totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	userID, _, err := mfa.NewServiceWithClock(repo, after, mfaKeys, clock.Now).CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), userID)
+
+	// Once the old key is retired its challenges are rejected
+	challenge, err = mfa.NewServiceWithClock(repo, before, mfaKeys, clock.Now).BeginLogin(ctx, 7, "admin@karirnusantara.com", mfa.PortalAdmin, false)
+	require.NoError(t, err)
+	retired := newTokenManager(clock, token.Key{ID: "k2", Secret: []byte("next-secret")})
+	clock.Advance(totp.Period)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	_, _, err = mfa.NewServiceWithClock(repo, retired, mfaKeys, clock.Now).CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+}
+
+func TestMFA_RecoveryCodeIsSingleUse(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newMFARepo(clock.Now)
+	svc := mfa.NewServiceWithClock(repo, mfaTokens(clock), mfaKeys, clock.Now)
+	ctx := context.Background()
+	_, recoveryCodes := enrollMFA(t, svc, clock, 7)
+
+	require.NoError(t, svc.VerifyCode(ctx, 7, recoveryCodes[0]))
+	assertAppStatus(t, svc.VerifyCode(ctx, 7, recoveryCodes[0]), http.StatusUnauthorized)
+
+	status, err := svc.GetStatus(ctx, 7)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, mfa.RecoveryCodeCount-1, status.RecoveryCodesRemaining)
+}
+
+func TestMFA_LocksAfterRepeatedWrongCodes(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	svc := mfa.NewServiceWithClock(newMFARepo(clock.Now), mfaTokens(clock), mfaKeys, clock.Now)
+	ctx := context.Background()
+	secret, _ := enrollMFA(t, svc, clock, 7)
+
+	for i := 0; i < mfa.MaxFailedAttempts; i++ {
+		assertAppStatus(t, svc.VerifyCode(ctx, 7, "000000"), http.StatusUnauthorized)
+	}
+
+	code, err := totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	assertAppStatus(t, svc.VerifyCode(ctx, 7, code), http.StatusTooManyRequests)
+
+	clock.Advance(mfa.FailedAttemptReset)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	assert.NoError(t, svc.VerifyCode(ctx, 7, code))
+}
+
+func TestMFA_RequiredLoginEnrollsFirst(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	svc := mfa.NewServiceWithClock(newMFARepo(clock.Now), mfaTokens(clock), mfaKeys, clock.Now)
+	ctx := context.Background()
+
+	challenge, err := svc.BeginLogin(ctx, 9, "admin@karirnusantara.com", mfa.PortalAdmin, true)
+	require.NoError(t, err)
+	require.NotNil(t, challenge)
+	assert.Equal(t, mfa.PurposeEnroll, challenge.Purpose)
+
+	setup, err := svc.SetupChallenge(ctx, challenge.Token, mfa.PortalAdmin)
+	require.NoError(t, err)
+
+	code, err := totp.Code(setup.Secret, clock.Now())
+	require.NoError(t, err)
+	userID, recoveryCodes, err := svc.CompleteChallenge(ctx, &mfa.ChallengeRequest{MFAToken: challenge.Token, Code: code}, mfa.PortalAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), userID)
+	assert.Len(t, recoveryCodes, mfa.RecoveryCodeCount)
+
+	status, err := svc.GetStatus(ctx, 9)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+}
+
+func TestMFA_EncryptionKeyRotation(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	repo := newMFARepo(clock.Now)
+	ctx := context.Background()
+	secret, _ := enrollMFA(t, mfa.NewServiceWithClock(repo, mfaTokens(clock), mfaKeys, clock.Now), clock, 7)
+	assert.Equal(t, "k1", repo.enrollments[7].SecretKeyID)
+
+	// Rotating the JWT secret does not touch stored TOTP secrets
+	svc := mfa.NewServiceWithClock(repo, newTokenManager(clock, token.Key{ID: "k2", Secret: []byte("rotated-jwt-secret")}), mfaKeys, clock.Now)
+	code, err := totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	require.NoError(t, svc.VerifyCode(ctx, 7, code))
+
+	// A new encryption key still opens secrets sealed with the previous one, and reseals them
+	rotated := mfa.EncryptionKeys{KeyID: "k2", Key: "next-mfa-key", Previous: map[string]string{"k1": mfaKeys.Key}}
+	svc = mfa.NewServiceWithClock(repo, mfaTokens(clock), rotated, clock.Now)
+	clock.Advance(totp.Period)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	require.NoError(t, svc.VerifyCode(ctx, 7, code))
+	assert.Equal(t, "k2", repo.enrollments[7].SecretKeyID)
+
+	// Once every secret is resealed the old key can be dropped
+	svc = mfa.NewServiceWithClock(repo, mfaTokens(clock), mfa.EncryptionKeys{KeyID: "k2", Key: "next-mfa-key"}, clock.Now)
+	clock.Advance(totp.Period)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	require.NoError(t, svc.VerifyCode(ctx, 7, code))
+
+	// Secrets sealed with an unknown key cannot be read
+	svc = mfa.NewServiceWithClock(repo, mfaTokens(clock), mfa.EncryptionKeys{KeyID: "k3", Key: "other-mfa-key"}, clock.Now)
+	clock.Advance(totp.Period)
+	code, err = totp.Code(secret, clock.Now())
+	require.NoError(t, err)
+	assertAppStatus(t, svc.VerifyCode(ctx, 7, code), http.StatusInternalServerError)
+}
@@ -0,0 +1,260 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/shared/email"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// ============================================
+// Notification Preference Tests (in-process, no server needed)
+// ============================================
+
+const unsubscribeSecret = "test-unsubscribe-secret"
+
+// preferenceRepo is an in-memory notification preferences repository. It also serves
+// as the email service's recipient lookup, where user N has the address userN@example.com.
+type preferenceRepo struct {
+	enabled map[uint64]map[string]bool
+}
+
+func newPreferenceRepo(userIDs ...uint64) *preferenceRepo {
+	r := &preferenceRepo{enabled: map[uint64]map[string]bool{}}
+	for _, id := range userIDs {
+		r.enabled[id] = map[string]bool{}
+	}
+	return r
+}
+
+func (r *preferenceRepo) ListByUser(ctx context.Context, userID uint64) ([]*notifications.Preference, error) {
+	var prefs []*notifications.Preference
+	for category, on := range r.enabled[userID] {
+		prefs = append(prefs, &notifications.Preference{UserID: userID, Category: category, EmailEnabled: on})
+	}
+	return prefs, nil
+}
+
+func (r *preferenceRepo) SetEmailEnabled(ctx context.Context, userID uint64, enabled map[string]bool) error {
+	if r.enabled[userID] == nil {
+		return nil
+	}
+	for category, on := range enabled {
+		r.enabled[userID][category] = on
+	}
+	return nil
+}
+
+func (r *preferenceRepo) Recipient(ctx context.Context, address string) (*email.Recipient, error) {
+	id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(address, "user"), "@example.com"), 10, 64)
+	if err != nil || r.enabled[id] == nil {
+		return nil, nil
+	}
+	recipient := &email.Recipient{UserID: id, Role: "job_seeker", Locale: email.LocaleEN, OptedOut: map[string]bool{}}
+	for category, on := range r.enabled[id] {
+		recipient.OptedOut[category] = !on
+	}
+	return recipient, nil
+}
+
+func preferenceEmailService(recipients email.Recipients) (*email.Service, *email.MemoryTransport) {
+	transport := email.NewMemoryTransport()
+	config := &email.Config{
+		FromName:          "Karir Nusantara",
+		FromEmail:         "no-reply@karirnusantara.com",
+		DefaultLocale:     email.LocaleID,
+		URLs:              email.URLs{JobSeeker: "https://jobs.example.test"},
+		UnsubscribeSecret: unsubscribeSecret,
+		UnsubscribeURL:    "https://api.example.test/api/v1/notifications/unsubscribe",
+	}
+	return email.NewServiceWithOutbox(config, transport, nil, recipients), transport
+}
+
+// preferenceRouter mounts the notification routes behind a fake login as userID
+func preferenceRouter(repo *preferenceRepo, userID uint64) http.Handler {
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, userID)))
+		})
+	}
+	service := notifications.NewPreferenceService(repo, unsubscribeSecret)
+	r := chi.NewRouter()
+	notifications.RegisterRoutes(r, notifications.NewHandler(nil), notifications.NewPreferenceHandler(service, validator.New()), authenticate)
+	return r
+}
+
+type preferencesBody struct {
+	Data struct {
+		Preferences  []notifications.PreferenceResponse `json:"preferences"`
+		Category     string                             `json:"category"`
+		EmailEnabled bool                               `json:"email_enabled"`
+	} `json:"data"`
+}
+
+func servePreferences(t *testing.T, handler http.Handler, method, target, contentType, body string) (int, preferencesBody) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var decoded preferencesBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded), rec.Body.String())
+	return rec.Code, decoded
+}
+
+func emailHeader(t *testing.T, raw []byte) mail.Header {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	return msg.Header
+}
+
+func enabledByCategory(prefs []notifications.PreferenceResponse) map[string]bool {
+	enabled := map[string]bool{}
+	for _, p := range prefs {
+		enabled[p.Category] = p.EmailEnabled
+	}
+	return enabled
+}
+
+func TestNotificationPreferences_UnsubscribeToken(t *testing.T) {
+	token := email.UnsubscribeToken(unsubscribeSecret, 42, email.CategoryJobAlerts)
+
+	userID, category, err := email.ParseUnsubscribeToken(unsubscribeSecret, token)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), userID)
+	assert.Equal(t, email.CategoryJobAlerts, category)
+
+	invalid := []string{
+		"",
+		strings.Replace(token, "42.", "43.", 1),
+		strings.Replace(token, email.CategoryJobAlerts, email.CategoryMarketing, 1),
+		email.UnsubscribeToken("another-secret", 42, email.CategoryJobAlerts),
+		email.UnsubscribeToken(unsubscribeSecret, 42, email.CategoryTransactional),
+		email.UnsubscribeToken(unsubscribeSecret, 42, "unknown"),
+	}
+	for _, tok := range invalid {
+		_, _, err := email.ParseUnsubscribeToken(unsubscribeSecret, tok)
+		assert.ErrorIs(t, err, email.ErrInvalidUnsubscribeToken, tok)
+	}
+}
+
+func TestNotificationPreferences_OptedOutEmailIsSkipped(t *testing.T) {
+	repo := newPreferenceRepo(7)
+	require.NoError(t, repo.SetEmailEnabled(context.Background(), 7, map[string]bool{email.CategoryJobAlerts: false}))
+	svc, transport := preferenceEmailService(repo)
+
+	alert := email.JobAlertData{FullName: "Budi", SearchName: "golang", Frequency: "daily", TotalMatches: 0}
+	require.NoError(t, svc.SendJobAlertEmail("user7@example.com", alert))
+	assert.Empty(t, transport.Messages(), "job alerts are turned off")
+
+	require.NoError(t, svc.SendPasswordChangeConfirmationEmail("user7@example.com", "Budi", "job_seeker"))
+	require.NoError(t, svc.SendTalentInvitationEmail("user7@example.com", email.TalentInvitationData{FullName: "Budi", CompanyName: "PT Maju", JobTitle: "Backend Engineer", JobSlug: "backend"}))
+	require.Len(t, transport.Messages(), 2, "transactional email and other categories are still sent")
+}
+
+func TestNotificationPreferences_ListUnsubscribeHeader(t *testing.T) {
+	repo := newPreferenceRepo(7)
+	svc, transport := preferenceEmailService(repo)
+
+	require.NoError(t, svc.SendJobAlertEmail("user7@example.com", email.JobAlertData{FullName: "Budi", SearchName: "golang", Frequency: "daily"}))
+	require.NoError(t, svc.SendPasswordChangeConfirmationEmail("user7@example.com", "Budi", "job_seeker"))
+	require.NoError(t, svc.SendJobSeekerWelcomeEmail("guest@example.com", "Guest"))
+
+	sent := transport.Messages()
+	require.Len(t, sent, 3)
+
+	alert := emailHeader(t, sent[0].Message)
+	token := url.QueryEscape(email.UnsubscribeToken(unsubscribeSecret, 7, email.CategoryJobAlerts))
+	assert.Equal(t, "<https://api.example.test/api/v1/notifications/unsubscribe?token="+token+">", alert.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", alert.Get("List-Unsubscribe-Post"))
+
+	parsed := parseEmail(t, sent[0].Message)
+	assert.Contains(t, parsed.Text, "because job alerts are turned on. Unsubscribe: https://jobs.example.test/unsubscribe?token="+token)
+	assert.Contains(t, parsed.HTML, `href="https://jobs.example.test/unsubscribe?token=`)
+
+	for _, message := range sent[1:] {
+		header := emailHeader(t, message.Message)
+		assert.Empty(t, header.Get("List-Unsubscribe"), "transactional email and unknown recipients have no unsubscribe link")
+		assert.NotContains(t, parseEmail(t, message.Message).Text, "/unsubscribe")
+	}
+}
+
+func TestNotificationPreferences_GetAndUpdate(t *testing.T) {
+	repo := newPreferenceRepo(7)
+	router := preferenceRouter(repo, 7)
+
+	code, body := servePreferences(t, router, http.MethodGet, "/me/notification-preferences", "", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Data.Preferences, len(email.Categories))
+	for _, p := range body.Data.Preferences {
+		assert.True(t, p.EmailEnabled, p.Category)
+		assert.Equal(t, p.Category == email.CategoryTransactional, p.Locked, p.Category)
+	}
+
+	code, body = servePreferences(t, router, http.MethodPut, "/me/notification-preferences", "application/json",
+		`{"preferences":[{"category":"marketing","email_enabled":false},{"category":"transactional","email_enabled":true}]}`)
+	require.Equal(t, http.StatusOK, code)
+	enabled := enabledByCategory(body.Data.Preferences)
+	assert.False(t, enabled[email.CategoryMarketing])
+	assert.True(t, enabled[email.CategoryJobAlerts])
+	assert.Equal(t, map[string]bool{email.CategoryMarketing: false}, repo.enabled[7])
+
+	rejected := []string{
+		`{"preferences":[{"category":"transactional","email_enabled":false}]}`,
+		`{"preferences":[{"category":"sms","email_enabled":false}]}`,
+		`{"preferences":[{"category":"marketing"}]}`,
+		`{"preferences":[]}`,
+	}
+	for _, req := range rejected {
+		code, _ := servePreferences(t, router, http.MethodPut, "/me/notification-preferences", "application/json", req)
+		assert.Equal(t, http.StatusUnprocessableEntity, code, req)
+	}
+	assert.Equal(t, map[string]bool{email.CategoryMarketing: false}, repo.enabled[7], "rejected updates change nothing")
+}
+
+func TestNotificationPreferences_OneClickUnsubscribe(t *testing.T) {
+	repo := newPreferenceRepo(7)
+	router := preferenceRouter(repo, 0)
+	token := url.QueryEscape(email.UnsubscribeToken(unsubscribeSecret, 7, email.CategoryApplicationUpdates))
+
+	code, body := servePreferences(t, router, http.MethodGet, "/notifications/unsubscribe?token="+token, "", "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, email.CategoryApplicationUpdates, body.Data.Category)
+	assert.True(t, body.Data.EmailEnabled, "checking a link does not unsubscribe")
+	assert.Empty(t, repo.enabled[7])
+
+	// Mail clients POST the RFC 8058 form body to the List-Unsubscribe URL
+	code, body = servePreferences(t, router, http.MethodPost, "/notifications/unsubscribe?token="+token,
+		"application/x-www-form-urlencoded", "List-Unsubscribe=One-Click")
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, body.Data.EmailEnabled)
+	assert.Equal(t, map[string]bool{email.CategoryApplicationUpdates: false}, repo.enabled[7])
+
+	// The portal page may send the token in the form body instead
+	marketing := email.UnsubscribeToken(unsubscribeSecret, 7, email.CategoryMarketing)
+	code, _ = servePreferences(t, router, http.MethodPost, "/notifications/unsubscribe",
+		"application/x-www-form-urlencoded", "token="+url.QueryEscape(marketing))
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, repo.enabled[7][email.CategoryMarketing])
+
+	forged := strings.Replace(token, "7.", "8.", 1)
+	code, _ = servePreferences(t, router, http.MethodPost, "/notifications/unsubscribe?token="+forged, "", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/modules/notifications"
+	"github.com/karirnusantara/api/internal/shared/hashid"
+	"github.com/karirnusantara/api/internal/shared/validator"
+)
+
+// ============================================
+// Notification Center Tests (in-process, no server needed)
+// ============================================
+
+// notificationRepo is an in-memory notifications repository
+type notificationRepo struct {
+	items  []*notifications.Notification
+	admins []uint64
+}
+
+func (r *notificationRepo) Create(ctx context.Context, n *notifications.Notification) error {
+	n.ID = uint64(len(r.items) + 1)
+	n.CreatedAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(n.ID) * time.Minute)
+	r.items = append(r.items, n)
+	return nil
+}
+
+func (r *notificationRepo) GetByID(ctx context.Context, id uint64) (*notifications.Notification, error) {
+	for _, n := range r.items {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *notificationRepo) ListByUser(ctx context.Context, userID uint64, params notifications.ListParams) ([]*notifications.Notification, int64, error) {
+	var matched []*notifications.Notification
+	for _, n := range r.items {
+		if n.UserID == userID && (!params.UnreadOnly || !n.IsRead) {
+			matched = append(matched, n)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID > matched[j].ID })
+
+	total := int64(len(matched))
+	start := (params.Page - 1) * params.PerPage
+	if start >= len(matched) {
+		return []*notifications.Notification{}, total, nil
+	}
+	end := start + params.PerPage
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], total, nil
+}
+
+func (r *notificationRepo) MarkAsRead(ctx context.Context, id, userID uint64) error {
+	for _, n := range r.items {
+		if n.ID == id && n.UserID == userID && !n.IsRead {
+			n.IsRead = true
+			n.ReadAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+	return nil
+}
+
+func (r *notificationRepo) MarkAllAsRead(ctx context.Context, userID uint64) (int64, error) {
+	var updated int64
+	for _, n := range r.items {
+		if n.UserID == userID && !n.IsRead {
+			n.IsRead = true
+			n.ReadAt = sql.NullTime{Time: time.Now(), Valid: true}
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (r *notificationRepo) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	for _, n := range r.items {
+		if n.UserID == userID && !n.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *notificationRepo) ListAdminUserIDs(ctx context.Context) ([]uint64, error) {
+	return r.admins, nil
+}
+
+// notificationRouter mounts the notification routes behind a fake login as userID
+func notificationRouter(service notifications.Service, userID uint64) http.Handler {
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, userID)))
+		})
+	}
+	preferences := notifications.NewPreferenceService(newPreferenceRepo(), unsubscribeSecret)
+	r := chi.NewRouter()
+	notifications.RegisterRoutes(r, notifications.NewHandler(service), notifications.NewPreferenceHandler(preferences, validator.New()), authenticate)
+	return r
+}
+
+type notificationsBody struct {
+	Data json.RawMessage `json:"data"`
+	Meta struct {
+		TotalItems int64 `json:"total_items"`
+		TotalPages int   `json:"total_pages"`
+	} `json:"meta"`
+}
+
+func serveNotifications(t *testing.T, handler http.Handler, method, target string) (int, notificationsBody) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+	var body notificationsBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), rec.Body.String())
+	return rec.Code, body
+}
+
+func unreadCount(t *testing.T, handler http.Handler) int64 {
+	code, body := serveNotifications(t, handler, http.MethodGet, "/notifications/unread-count")
+	require.Equal(t, http.StatusOK, code)
+	var count notifications.UnreadCountResponse
+	require.NoError(t, json.Unmarshal(body.Data, &count))
+	return count.UnreadCount
+}
+
+func newNotificationService(t *testing.T) (notifications.Service, *notificationRepo) {
+	repo := &notificationRepo{admins: []uint64{90, 91}}
+	svc := notifications.NewService(repo)
+	ctx := context.Background()
+	require.NoError(t, svc.Notify(ctx, 7, notifications.TypeApplicationStatus, "Lamaran diproses", "Lamaran Anda sedang ditinjau", map[string]interface{}{"application_id": 21}))
+	require.NoError(t, svc.Notify(ctx, 7, notifications.TypeInterviewInvite, "Undangan interview", "Anda diundang interview", nil))
+	require.NoError(t, svc.Notify(ctx, 8, notifications.TypeTalentInvitation, "Undangan melamar", "PT Maju mengundang Anda", nil))
+	require.NoError(t, svc.Notify(ctx, 7, notifications.TypeChatReply, "Balasan chat", "Admin membalas pesan Anda", nil))
+	return svc, repo
+}
+
+func TestNotifications_List(t *testing.T) {
+	svc, _ := newNotificationService(t)
+	router := notificationRouter(svc, 7)
+
+	code, body := serveNotifications(t, router, http.MethodGet, "/notifications?per_page=2")
+	require.Equal(t, http.StatusOK, code)
+	var items []notifications.NotificationResponse
+	require.NoError(t, json.Unmarshal(body.Data, &items))
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(3), body.Meta.TotalItems, "other users' notifications are not listed")
+	assert.Equal(t, 2, body.Meta.TotalPages)
+	assert.Equal(t, notifications.TypeChatReply, items[0].Type, "newest first")
+	assert.Equal(t, hashid.Encode(items[0].ID), items[0].HashID)
+
+	code, body = serveNotifications(t, router, http.MethodGet, "/notifications?page=2&per_page=2")
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body.Data, &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, notifications.TypeApplicationStatus, items[0].Type)
+	assert.JSONEq(t, `{"application_id":21}`, string(items[0].Data))
+}
+
+func TestNotifications_UnreadCountAndMarkRead(t *testing.T) {
+	svc, repo := newNotificationService(t)
+	router := notificationRouter(svc, 7)
+	assert.Equal(t, int64(3), unreadCount(t, router))
+
+	code, _ := serveNotifications(t, router, http.MethodPatch, "/notifications/1/read")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), unreadCount(t, router))
+
+	// Marking again is a no-op, and hash IDs are accepted
+	code, _ = serveNotifications(t, router, http.MethodPatch, "/notifications/"+hashid.Encode(1)+"/read")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), unreadCount(t, router))
+
+	code, body := serveNotifications(t, router, http.MethodGet, "/notifications?unread=true")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), body.Meta.TotalItems)
+
+	code, body = serveNotifications(t, router, http.MethodPatch, "/notifications/read-all")
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"updated":2}`, string(body.Data))
+	assert.Equal(t, int64(0), unreadCount(t, router))
+
+	other, _ := repo.GetByID(context.Background(), 3)
+	assert.False(t, other.IsRead, "read-all only touches the caller's notifications")
+}
+
+func TestNotifications_MarkReadOwnership(t *testing.T) {
+	svc, repo := newNotificationService(t)
+	router := notificationRouter(svc, 8)
+
+	for _, id := range []uint64{1, 2, 4, 999} {
+		code, _ := serveNotifications(t, router, http.MethodPatch, "/notifications/"+strconv.FormatUint(id, 10)+"/read")
+		assert.Equal(t, http.StatusNotFound, code, "notification %d of another user looks missing", id)
+	}
+	for _, n := range repo.items {
+		if n.UserID == 7 {
+			assert.False(t, n.IsRead)
+		}
+	}
+
+	code, _ := serveNotifications(t, router, http.MethodPatch, "/notifications/not-an-id/read")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serveNotifications(t, router, http.MethodPatch, "/notifications/3/read")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(0), unreadCount(t, router))
+}
+
+func TestNotifications_NotifyAdmins(t *testing.T) {
+	svc, repo := newNotificationService(t)
+	require.NoError(t, svc.NotifyAdmins(context.Background(), notifications.TypeTicketCreated, "Tiket baru", "Ada tiket baru", nil))
+
+	var recipients []uint64
+	for _, n := range repo.items {
+		if n.Type == notifications.TypeTicketCreated {
+			recipients = append(recipients, n.UserID)
+		}
+	}
+	assert.Equal(t, []uint64{90, 91}, recipients)
+}
@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/quota"
+)
+
+// ============================================
+// Payment Reversal & Commission Tests (in-process, no server needed)
+// ============================================
+
+// quotaStore is an in-memory quota store with the transactional behaviour of quota.Repository
+type quotaStore struct {
+	payments    map[uint64]*quota.Payment
+	quotas      map[uint64]*quota.CompanyQuota
+	commissions map[uint64]*quota.Commission // by payment ID
+	// referralRates maps referred companies to the commission rate of their active partner
+	referralRates map[uint64]float64
+}
+
+func newQuotaStore() *quotaStore {
+	return &quotaStore{
+		payments:      map[uint64]*quota.Payment{},
+		quotas:        map[uint64]*quota.CompanyQuota{},
+		commissions:   map[uint64]*quota.Commission{},
+		referralRates: map[uint64]float64{},
+	}
+}
+
+func (s *quotaStore) GetOrCreateCompanyQuota(companyID uint64) (*quota.CompanyQuota, error) {
+	if s.quotas[companyID] == nil {
+		s.quotas[companyID] = &quota.CompanyQuota{CompanyID: companyID}
+	}
+	return s.quotas[companyID], nil
+}
+
+func (s *quotaStore) IncrementFreeQuotaUsed(companyID uint64) error {
+	s.quotas[companyID].FreeQuotaUsed++
+	return nil
+}
+
+func (s *quotaStore) DecrementPaidQuota(companyID uint64) error {
+	if q := s.quotas[companyID]; q.PaidQuota > 0 {
+		q.PaidQuota--
+	}
+	return nil
+}
+
+func (s *quotaStore) CreatePayment(payment *quota.Payment) error {
+	payment.ID = uint64(len(s.payments) + 1)
+	s.payments[payment.ID] = payment
+	return nil
+}
+
+func (s *quotaStore) GetPaymentByID(id uint64) (*quota.Payment, error) {
+	payment, ok := s.payments[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+func (s *quotaStore) ListPayments(params quota.PaymentListParams) ([]quota.Payment, int, error) {
+	var payments []quota.Payment
+	for _, p := range s.payments {
+		if p.CompanyID == params.CompanyID && (params.Status == "" || p.Status == params.Status) {
+			payments = append(payments, *p)
+		}
+	}
+	return payments, len(payments), nil
+}
+
+func (s *quotaStore) GetPendingPaymentsCount(companyID uint64) (int, error) {
+	payments, _, _ := s.ListPayments(quota.PaymentListParams{CompanyID: companyID, Status: quota.PaymentStatusPending})
+	return len(payments), nil
+}
+
+func (s *quotaStore) transition(id uint64, from, to string, adminID uint64, note string) error {
+	payment := s.payments[id]
+	if payment.Status != from {
+		if from == quota.PaymentStatusConfirmed {
+			return quota.ErrPaymentNotConfirmed
+		}
+		return quota.ErrPaymentAlreadyProcessed
+	}
+	payment.Status = to
+	payment.Note = sql.NullString{String: note, Valid: true}
+	payment.ConfirmedByID = sql.NullInt64{Int64: int64(adminID), Valid: true}
+	return nil
+}
+
+func (s *quotaStore) cancelCommission(paymentID uint64) bool {
+	c := s.commissions[paymentID]
+	if c == nil || (c.Status != quota.CommissionStatusPending && c.Status != quota.CommissionStatusApproved) {
+		return false
+	}
+	c.Status = quota.CommissionStatusCancelled
+	return true
+}
+
+func (s *quotaStore) ConfirmPayment(payment *quota.Payment, quotaToAdd int, adminID uint64, note string) (*quota.Commission, error) {
+	if err := s.transition(payment.ID, quota.PaymentStatusPending, quota.PaymentStatusConfirmed, adminID, note); err != nil {
+		return nil, err
+	}
+	q, _ := s.GetOrCreateCompanyQuota(payment.CompanyID)
+	q.PaidQuota += quotaToAdd
+
+	rate, referred := s.referralRates[payment.CompanyID]
+	if !referred {
+		return nil, nil
+	}
+	commission := &quota.Commission{
+		ID: payment.ID, PaymentID: payment.ID, CompanyID: payment.CompanyID,
+		TransactionAmount: payment.Amount, CommissionRate: rate,
+		CommissionAmount: quota.CalculateCommission(payment.Amount, rate),
+		JobQuota:         quotaToAdd, Status: quota.CommissionStatusPending,
+	}
+	s.commissions[payment.ID] = commission
+	return commission, nil
+}
+
+func (s *quotaStore) RejectPayment(paymentID uint64, adminID uint64, note string) error {
+	if err := s.transition(paymentID, quota.PaymentStatusPending, quota.PaymentStatusRejected, adminID, note); err != nil {
+		return err
+	}
+	s.cancelCommission(paymentID)
+	return nil
+}
+
+func (s *quotaStore) ReversePayment(payment *quota.Payment, quotaToRemove int, adminID uint64, note string) (bool, error) {
+	if err := s.transition(payment.ID, quota.PaymentStatusConfirmed, quota.PaymentStatusReversed, adminID, note); err != nil {
+		return false, err
+	}
+	q, _ := s.GetOrCreateCompanyQuota(payment.CompanyID)
+	q.PaidQuota -= quotaToRemove
+	if q.PaidQuota < 0 {
+		q.PaidQuota = 0
+	}
+	return s.cancelCommission(payment.ID), nil
+}
+
+// confirmedPayment submits and confirms a pack10 payment (12 quota, Rp 100.000) for a company
+func confirmedPayment(t *testing.T, svc *quota.Service, companyID uint64) *quota.Payment {
+	pack := "pack10"
+	payment, err := svc.SubmitPaymentProof(companyID, nil, &pack, "/docs/payments/proof.png")
+	require.NoError(t, err)
+	require.NoError(t, svc.ConfirmPayment(payment.ID, 1, "Pembayaran diterima"))
+	return payment
+}
+
+func TestPaymentReversal_TakesBackQuotaAndCancelsCommission(t *testing.T) {
+	store := newQuotaStore()
+	store.referralRates[7] = 40
+	svc := quota.NewService(store)
+
+	payment := confirmedPayment(t, svc, 7)
+	assert.Equal(t, 12, store.quotas[7].PaidQuota)
+	commission := store.commissions[payment.ID]
+	require.NotNil(t, commission, "confirming a referred company's payment creates a commission")
+	assert.Equal(t, int64(40000), commission.CommissionAmount)
+	assert.Equal(t, quota.CommissionStatusPending, commission.Status)
+
+	require.NoError(t, svc.ReversePayment(payment.ID, 1, "Transfer dibatalkan bank"))
+
+	reversed, err := svc.GetPaymentByID(payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, quota.PaymentStatusReversed, reversed.Status)
+	assert.Equal(t, "Dibatalkan", reversed.ToResponse().StatusLabel, "a reversal is not shown as a rejection")
+	assert.Equal(t, 0, store.quotas[7].PaidQuota)
+	assert.Equal(t, quota.CommissionStatusCancelled, store.commissions[payment.ID].Status)
+
+	reversedOnly, total, err := svc.GetPayments(quota.PaymentListParams{CompanyID: 7, Status: quota.PaymentStatusRejected})
+	require.NoError(t, err)
+	assert.Zero(t, total, "reversed payments are not listed as rejected: %v", reversedOnly)
+}
+
+func TestPaymentReversal_QuotaNeverBelowZero(t *testing.T) {
+	store := newQuotaStore()
+	svc := quota.NewService(store)
+
+	payment := confirmedPayment(t, svc, 7)
+	store.quotas[7].FreeQuotaUsed = quota.FreeQuotaLimit
+	for i := 0; i < 10; i++ {
+		require.NoError(t, svc.ConsumeQuota(7))
+	}
+	require.Equal(t, 2, store.quotas[7].PaidQuota)
+
+	require.NoError(t, svc.ReversePayment(payment.ID, 1, "Chargeback"))
+	assert.Equal(t, 0, store.quotas[7].PaidQuota)
+}
+
+func TestPaymentReversal_OnlyConfirmedPayments(t *testing.T) {
+	store := newQuotaStore()
+	svc := quota.NewService(store)
+
+	pending, err := svc.SubmitPaymentProof(7, nil, nil, "/docs/payments/proof.png")
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.ReversePayment(pending.ID, 1, "x"), quota.ErrPaymentNotConfirmed)
+
+	require.NoError(t, svc.RejectPayment(pending.ID, 1, "Bukti tidak valid"))
+	assert.ErrorIs(t, svc.ReversePayment(pending.ID, 1, "x"), quota.ErrPaymentNotConfirmed)
+
+	payment := confirmedPayment(t, svc, 7)
+	require.NoError(t, svc.ReversePayment(payment.ID, 1, "x"))
+	assert.ErrorIs(t, svc.ReversePayment(payment.ID, 1, "x"), quota.ErrPaymentNotConfirmed, "a payment is reversed only once")
+	assert.ErrorIs(t, svc.ConfirmPayment(payment.ID, 1, "x"), quota.ErrPaymentAlreadyProcessed, "a reversed payment cannot be confirmed again")
+}
+
+func TestPaymentReversal_PaidCommissionIsKept(t *testing.T) {
+	store := newQuotaStore()
+	store.referralRates[7] = 40
+	svc := quota.NewService(store)
+
+	payment := confirmedPayment(t, svc, 7)
+	store.commissions[payment.ID].Status = quota.CommissionStatusPaid
+
+	require.NoError(t, svc.ReversePayment(payment.ID, 1, "Chargeback"))
+	assert.Equal(t, quota.CommissionStatusPaid, store.commissions[payment.ID].Status, "money already paid out is settled outside the system")
+	assert.Equal(t, 0, store.quotas[7].PaidQuota)
+}
+
+func TestPaymentCommission_CancelledWithRejectedPayment(t *testing.T) {
+	store := newQuotaStore()
+	store.referralRates[7] = 25.5
+	svc := quota.NewService(store)
+
+	payment := confirmedPayment(t, svc, 7)
+	store.commissions[payment.ID].Status = quota.CommissionStatusApproved
+	require.NoError(t, svc.ReversePayment(payment.ID, 1, "Chargeback"))
+	assert.Equal(t, quota.CommissionStatusCancelled, store.commissions[payment.ID].Status, "approved commissions are cancelled too")
+
+	unreferred := confirmedPayment(t, svc, 8)
+	assert.Nil(t, store.commissions[unreferred.ID], "companies without a partner earn no commission")
+
+	single, err := svc.SubmitPaymentProof(7, nil, nil, "/docs/payments/proof.png")
+	require.NoError(t, err)
+	require.NoError(t, svc.RejectPayment(single.ID, 1, "Bukti tidak valid"))
+	assert.Nil(t, store.commissions[single.ID], "rejected payments never earn commission")
+	rejected, _ := svc.GetPaymentByID(single.ID)
+	assert.Equal(t, quota.PaymentStatusRejected, rejected.Status)
+	assert.Equal(t, "Ditolak", rejected.ToResponse().StatusLabel)
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/quota"
 )
 
 // ============================================
@@ -190,6 +192,30 @@ func TestPayment_TotalAmountCalculatedCorrectly(t *testing.T) {
 	}
 }
 
+// ============================================
+// Partner Commission Tests
+// ============================================
+
+func TestPayment_PartnerCommissionCalculation(t *testing.T) {
+	// Commission is a percentage of the payment amount, rounded down to whole rupiah
+	cases := []struct {
+		amount   int64
+		rate     float64
+		expected int64
+	}{
+		{amount: 100000, rate: 40.00, expected: 40000},
+		{amount: 50000, rate: 35.00, expected: 17500},
+		{amount: 10000, rate: 33.33, expected: 3333},
+		{amount: 200000, rate: 12.5, expected: 25000},
+		{amount: 10000, rate: 0, expected: 0},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, quota.CalculateCommission(tc.amount, tc.rate),
+			"amount=%d rate=%.2f", tc.amount, tc.rate)
+	}
+}
+
 // ============================================
 // Quota Usage Priority Tests
 // ============================================
@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/applications"
+	"github.com/karirnusantara/api/internal/modules/pipelines"
+)
+
+// ============================================
+// Hiring Pipeline Tests (in-process, no server needed)
+// ============================================
+
+func TestPipeline_DefaultMatchesLegacyStatusGraph(t *testing.T) {
+	p := pipelines.DefaultPipeline()
+	require.Nil(t, p.Validate())
+	assert.True(t, p.IsDefault())
+
+	allowed := [][2]string{
+		{"submitted", "viewed"},
+		{"submitted", "interview_scheduled"},
+		{"viewed", "shortlisted"},
+		{"shortlisted", "interview_scheduled"},
+		{"interview_scheduled", "interview_completed"},
+		{"interview_completed", "hired"},
+		{"assessment", "offer_sent"},
+		{"offer_sent", "offer_accepted"},
+		{"offer_accepted", "hired"},
+		{"viewed", "rejected"},
+	}
+	for _, tr := range allowed {
+		assert.True(t, p.CanTransition(tr[0], tr[1]), "%s -> %s", tr[0], tr[1])
+	}
+
+	denied := [][2]string{
+		{"submitted", "hired"},
+		{"shortlisted", "offer_sent"},
+		{"offer_accepted", "rejected"},
+		{"hired", "rejected"},
+		{"rejected", "viewed"},
+		{"withdrawn", "submitted"},
+		{"submitted", "unknown_stage"},
+	}
+	for _, tr := range denied {
+		assert.False(t, p.CanTransition(tr[0], tr[1]), "%s -> %s", tr[0], tr[1])
+	}
+
+	assert.True(t, p.IsTerminal("hired"))
+	assert.True(t, p.IsTerminal("rejected"))
+	assert.True(t, p.IsTerminal("withdrawn"))
+	assert.False(t, p.IsTerminal("offer_accepted"))
+
+	assert.Equal(t, "Interview Dijadwalkan", p.Label("interview_scheduled"))
+	assert.Equal(t, "custom_stage", p.Label("custom_stage"))
+	assert.True(t, p.Stage("interview_scheduled").SchedulesInterview)
+	assert.Equal(t, pipelines.CategoryAccepted, p.Category("offer_accepted"))
+}
+
+func TestPipeline_CustomStages(t *testing.T) {
+	p := &pipelines.Pipeline{
+		ID:   1,
+		Name: "Engineering",
+		Stages: []pipelines.Stage{
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"technical_test", "rejected"}},
+			{Key: "technical_test", Label: "Technical Test", Category: pipelines.CategoryAssessment, Transitions: []string{"user_interview", "rejected"}},
+			{Key: "user_interview", Label: "User Interview", Category: pipelines.CategoryInterview, SchedulesInterview: true, Transitions: []string{"hired", "rejected"}},
+			{Key: "hired", Label: "Hired", Category: pipelines.CategoryAccepted, IsTerminal: true},
+			{Key: "rejected", Label: "Rejected", Category: pipelines.CategoryRejected, IsTerminal: true},
+			{Key: "withdrawn", Label: "Withdrawn", Category: pipelines.CategoryWithdrawn, IsTerminal: true},
+		},
+	}
+
+	require.Nil(t, p.Validate())
+	assert.True(t, p.CanTransition("submitted", "technical_test"))
+	assert.True(t, p.CanTransition("technical_test", "user_interview"))
+	assert.False(t, p.CanTransition("submitted", "user_interview"))
+	assert.False(t, p.CanTransition("submitted", "viewed"))
+	assert.Equal(t, "Technical Test", p.Label("technical_test"))
+}
+
+func TestPipeline_ValidateRejectsBrokenGraphs(t *testing.T) {
+	cases := map[string][]pipelines.Stage{
+		"missing submitted": {
+			{Key: "screening", Label: "Screening", Category: pipelines.CategoryReview, Transitions: []string{"rejected"}},
+			{Key: "rejected", Label: "Rejected", Category: pipelines.CategoryRejected, IsTerminal: true},
+		},
+		"unknown transition target": {
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"nowhere"}},
+		},
+		"terminal with transitions": {
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"hired"}},
+			{Key: "hired", Label: "Hired", Category: pipelines.CategoryAccepted, IsTerminal: true, Transitions: []string{"submitted"}},
+		},
+		"dead end stage": {
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"screening"}},
+			{Key: "screening", Label: "Screening", Category: pipelines.CategoryReview},
+		},
+		"invalid key and category": {
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"Bad Key"}},
+			{Key: "Bad Key", Label: "Bad", Category: "unknown", IsTerminal: true},
+		},
+		"duplicate key": {
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"rejected"}},
+			{Key: "submitted", Label: "Again", Category: pipelines.CategoryNew, Transitions: []string{"rejected"}},
+			{Key: "rejected", Label: "Rejected", Category: pipelines.CategoryRejected, IsTerminal: true},
+		},
+	}
+
+	for name, stages := range cases {
+		p := &pipelines.Pipeline{Name: name, Stages: stages}
+		assert.NotNil(t, p.Validate(), name)
+	}
+}
+
+// pipelineRepo is an in-memory pipeline repository that counts lookups
+type pipelineRepo struct {
+	pipelines.Repository
+
+	pipelines map[uint64]*pipelines.Pipeline
+	getByID   int
+	getByIDs  int
+	requested [][]uint64
+}
+
+func (r *pipelineRepo) GetByID(ctx context.Context, id uint64) (*pipelines.Pipeline, error) {
+	r.getByID++
+	return r.pipelines[id], nil
+}
+
+func (r *pipelineRepo) GetByIDs(ctx context.Context, ids []uint64) ([]*pipelines.Pipeline, error) {
+	r.getByIDs++
+	r.requested = append(r.requested, ids)
+	var found []*pipelines.Pipeline
+	for _, id := range ids {
+		if p, ok := r.pipelines[id]; ok {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+// pipelineListRepo serves a page of applications on jobs with different pipelines
+type pipelineListRepo struct {
+	applications.Repository
+
+	apps []*applications.Application
+	jobs map[uint64]*applications.JobInfo
+}
+
+func (r *pipelineListRepo) List(ctx context.Context, params applications.ApplicationListParams) ([]*applications.Application, int64, error) {
+	return r.apps, int64(len(r.apps)), nil
+}
+
+func (r *pipelineListRepo) GetJobInfo(ctx context.Context, jobID uint64) (*applications.JobInfo, error) {
+	return r.jobs[jobID], nil
+}
+
+func (r *pipelineListRepo) GetCVSnapshotInfo(ctx context.Context, snapshotID uint64) (*applications.CVSnapshotInfo, error) {
+	return nil, nil
+}
+
+func (r *pipelineListRepo) GetTimelineForApplicant(ctx context.Context, applicationID uint64) ([]applications.TimelineEvent, error) {
+	return nil, nil
+}
+
+func engineeringPipeline(id uint64) *pipelines.Pipeline {
+	return &pipelines.Pipeline{
+		ID:   id,
+		Name: "Engineering",
+		Stages: []pipelines.Stage{
+			{Key: "submitted", Label: "Applied", Category: pipelines.CategoryNew, Transitions: []string{"technical_test"}},
+			{Key: "technical_test", Label: "Technical Test", Category: pipelines.CategoryAssessment, IsTerminal: true},
+		},
+	}
+}
+
+func TestPipeline_ResolveAll(t *testing.T) {
+	repo := &pipelineRepo{pipelines: map[uint64]*pipelines.Pipeline{5: engineeringPipeline(5)}}
+	svc := pipelines.NewService(repo)
+
+	resolved, err := svc.ResolveAll(context.Background(), []uint64{5, 0, 5, 9})
+	require.NoError(t, err)
+	require.Len(t, resolved, 3)
+	assert.Equal(t, "Engineering", resolved[5].Name)
+	assert.True(t, resolved[0].IsDefault())
+	assert.True(t, resolved[9].IsDefault(), "deleted pipelines fall back to the default")
+	assert.Equal(t, [][]uint64{{5, 9}}, repo.requested, "each pipeline is requested once and the default never")
+
+	resolved, err = svc.ResolveAll(context.Background(), []uint64{0})
+	require.NoError(t, err)
+	assert.True(t, resolved[0].IsDefault())
+}
+
+func TestPipeline_ApplicationListResolvesPipelinesOnce(t *testing.T) {
+	pipelineRepo := &pipelineRepo{pipelines: map[uint64]*pipelines.Pipeline{5: engineeringPipeline(5), 6: engineeringPipeline(6)}}
+	repo := &pipelineListRepo{
+		jobs: map[uint64]*applications.JobInfo{
+			1: {ID: 1, PipelineID: 5},
+			2: {ID: 2, PipelineID: 6},
+			3: {ID: 3},
+		},
+	}
+	for i, jobID := range []uint64{1, 1, 2, 3, 1, 2} {
+		repo.apps = append(repo.apps, &applications.Application{ID: uint64(i + 1), UserID: 7, JobID: jobID, CurrentStatus: "technical_test"})
+	}
+	repo.apps[3].CurrentStatus = "viewed"
+	svc := applications.NewServiceComplete(repo, nil, nil, nil, nil, pipelines.NewService(pipelineRepo))
+
+	responses, total, err := svc.ListByUser(context.Background(), 7, applications.ApplicationListParams{Page: 1, PerPage: 20})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), total)
+
+	assert.Equal(t, 1, pipelineRepo.getByIDs, "a page resolves its pipelines in one query")
+	assert.Zero(t, pipelineRepo.getByID)
+	for i, resp := range responses {
+		if i == 3 {
+			assert.Equal(t, "Sedang Ditinjau", resp.StatusLabel, "jobs on the default pipeline use its labels")
+			continue
+		}
+		assert.Equal(t, "Technical Test", resp.StatusLabel)
+	}
+}
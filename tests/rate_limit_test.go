@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+)
+
+// ============================================
+// Rate Limiting Tests (in-process, no server needed)
+// ============================================
+
+func rateLimitedHandler(limiter func(http.Handler) http.Handler) http.Handler {
+	return limiter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func serveFrom(h http.Handler, method, target, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_BurstThen429WithRetryAfter(t *testing.T) {
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), nil)
+	policy := middleware.RateLimitPolicy{Name: "auth", Requests: 10, Per: time.Minute, Burst: 3}
+	h := rateLimitedHandler(limiter.Limit(policy))
+
+	for i := 0; i < policy.Burst; i++ {
+		assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodPost, "/api/v1/auth/login", "203.0.113.7:5000").Code)
+	}
+
+	rec := serveFrom(h, http.MethodPost, "/api/v1/auth/login", "203.0.113.7:5001")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "6", rec.Header().Get("Retry-After"))
+
+	var body struct {
+		Success bool `json:"success"`
+		Error   struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.False(t, body.Success)
+	assert.Equal(t, "TOO_MANY_REQUESTS", body.Error.Code)
+
+	// Another client has its own bucket
+	assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodPost, "/api/v1/auth/login", "198.51.100.9:5000").Code)
+}
+
+func TestRateLimit_KeysByUserBeforeIP(t *testing.T) {
+	identify := func(r *http.Request) uint64 {
+		if r.Header.Get("Authorization") == "Bearer user-42" {
+			return 42
+		}
+		return 0
+	}
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), identify)
+	h := rateLimitedHandler(limiter.Limit(middleware.RateLimitPolicy{Name: "api", Requests: 1, Per: time.Minute, Burst: 1}))
+
+	send := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/applications", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Authorization", "Bearer user-42")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("203.0.113.7:5000"))
+	assert.Equal(t, http.StatusTooManyRequests, send("198.51.100.9:5000"), "the same user on another IP shares the bucket")
+	assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodGet, "/api/v1/applications", "203.0.113.7:5000").Code, "anonymous requests are keyed by IP")
+}
+
+func TestRateLimit_ByRouteChoosesPolicy(t *testing.T) {
+	limiter := middleware.NewRateLimiter(middleware.NewMemoryRateLimitStore(), nil)
+	strict := middleware.RateLimitPolicy{Name: "upload", Requests: 1, Per: time.Hour, Burst: 1}
+	lenient := middleware.RateLimitPolicy{Name: "browse", Requests: 100, Per: time.Minute, Burst: 100}
+	fallback := middleware.RateLimitPolicy{Name: "api", Requests: 2, Per: time.Minute, Burst: 2}
+	h := rateLimitedHandler(limiter.ByRoute([]middleware.RateLimitRule{
+		{Method: http.MethodPost, Pattern: "/api/v1/*/chat/upload", Policy: strict},
+		{Method: http.MethodGet, Pattern: "/api/v1/jobs/*", Policy: lenient},
+	}, fallback))
+
+	assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodPost, "/api/v1/company/chat/upload", "203.0.113.7:1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(h, http.MethodPost, "/api/v1/admin/chat/upload", "203.0.113.7:1").Code)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodGet, "/api/v1/jobs/123", "203.0.113.7:1").Code)
+	}
+
+	// POST /jobs falls back to the default policy
+	assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodPost, "/api/v1/jobs", "203.0.113.7:1").Code)
+	assert.Equal(t, http.StatusOK, serveFrom(h, http.MethodPost, "/api/v1/jobs", "203.0.113.7:1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serveFrom(h, http.MethodPost, "/api/v1/jobs", "203.0.113.7:1").Code)
+}
+
+func TestRateLimit_MemoryStoreRefills(t *testing.T) {
+	store := middleware.NewMemoryRateLimitStore()
+	policy := middleware.RateLimitPolicy{Name: "auth", Requests: 10, Per: time.Minute, Burst: 2}
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := store.Take(ctx, "ip:203.0.113.7", policy, now)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := store.Take(ctx, "ip:203.0.113.7", policy, now)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	allowed, _, err = store.Take(ctx, "ip:203.0.113.7", policy, now.Add(6*time.Second))
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/jobs"
+)
+
+// ============================================
+// Screening Question Tests (in-process, no server needed)
+// ============================================
+
+func TestScreening_EvaluateYesNoKnockout(t *testing.T) {
+	q := &jobs.ScreeningQuestion{
+		Question:        "Apakah Anda bersedia ditempatkan di Surabaya?",
+		Type:            jobs.QuestionTypeYesNo,
+		IsRequired:      true,
+		IsKnockout:      true,
+		AcceptedAnswers: []string{jobs.AnswerYes},
+	}
+
+	result, msg := q.Evaluate(" YES ")
+	require.Empty(t, msg)
+	assert.Equal(t, jobs.AnswerYes, result.Answer)
+	assert.True(t, result.Passed)
+
+	result, msg = q.Evaluate("no")
+	require.Empty(t, msg)
+	assert.False(t, result.Passed)
+
+	_, msg = q.Evaluate("mungkin")
+	assert.Equal(t, "must be yes or no", msg)
+
+	_, msg = q.Evaluate("")
+	assert.Equal(t, "is required", msg)
+}
+
+func TestScreening_EvaluateMultipleChoice(t *testing.T) {
+	q := &jobs.ScreeningQuestion{
+		Type:            jobs.QuestionTypeMultipleChoice,
+		Options:         []string{"SMA/SMK", "D3", "S1"},
+		IsKnockout:      true,
+		AcceptedAnswers: []string{"D3", "S1"},
+	}
+
+	result, msg := q.Evaluate("s1")
+	require.Empty(t, msg)
+	assert.Equal(t, "S1", result.Answer, "answer is normalized to the option")
+	assert.True(t, result.Passed)
+
+	result, msg = q.Evaluate("SMA/SMK")
+	require.Empty(t, msg)
+	assert.False(t, result.Passed)
+
+	_, msg = q.Evaluate("S3")
+	assert.Equal(t, "must be one of the options", msg)
+
+	// Optional questions can be skipped
+	result, msg = q.Evaluate("")
+	require.Empty(t, msg)
+	assert.Empty(t, result.Answer)
+	assert.True(t, result.Passed)
+}
+
+func TestScreening_EvaluateNumericRange(t *testing.T) {
+	q := &jobs.ScreeningQuestion{
+		Type:       jobs.QuestionTypeNumeric,
+		IsRequired: true,
+		IsKnockout: true,
+		MinValue:   sql.NullFloat64{Float64: 2, Valid: true},
+		MaxValue:   sql.NullFloat64{Float64: 10, Valid: true},
+	}
+
+	result, msg := q.Evaluate("3,5")
+	require.Empty(t, msg)
+	assert.Equal(t, "3.5", result.Answer)
+	assert.True(t, result.Passed)
+
+	for _, answer := range []string{"1", "12"} {
+		result, msg = q.Evaluate(answer)
+		require.Empty(t, msg)
+		assert.False(t, result.Passed, answer)
+	}
+
+	_, msg = q.Evaluate("dua tahun")
+	assert.Equal(t, "must be a number", msg)
+
+	// Without a knockout rule any number passes
+	q.IsKnockout = false
+	result, _ = q.Evaluate("25")
+	assert.True(t, result.Passed)
+}
+
+func TestScreening_ValidateQuestions(t *testing.T) {
+	min, max := 5.0, 1.0
+
+	errs := jobs.ValidateScreeningQuestions([]jobs.ScreeningQuestionRequest{
+		{Question: "Pendidikan terakhir?", Type: jobs.QuestionTypeMultipleChoice, Options: []string{"S1"}},
+		{Question: "Bersedia lembur?", Type: jobs.QuestionTypeYesNo, Knockout: &jobs.KnockoutRuleRequest{AcceptedAnswers: []string{"maybe"}}},
+		{Question: "Pengalaman (tahun)?", Type: jobs.QuestionTypeNumeric, Knockout: &jobs.KnockoutRuleRequest{Min: &min, Max: &max}},
+		{Question: "Ceritakan diri Anda", Type: jobs.QuestionTypeText, Knockout: &jobs.KnockoutRuleRequest{}},
+		{Question: "Kota domisili?", Type: jobs.QuestionTypeMultipleChoice, Options: []string{"Jakarta", "Bandung"}, Knockout: &jobs.KnockoutRuleRequest{AcceptedAnswers: []string{"Medan"}}},
+	})
+
+	assert.Contains(t, errs, "screening_questions[0].options")
+	assert.Contains(t, errs, "screening_questions[1].knockout")
+	assert.Contains(t, errs, "screening_questions[2].knockout")
+	assert.Contains(t, errs, "screening_questions[3].knockout")
+	assert.Contains(t, errs, "screening_questions[4].knockout")
+
+	assert.Nil(t, jobs.ValidateScreeningQuestions([]jobs.ScreeningQuestionRequest{
+		{Question: "Bersedia lembur?", Type: jobs.QuestionTypeYesNo, Knockout: &jobs.KnockoutRuleRequest{AcceptedAnswers: []string{"yes"}}},
+		{Question: "Pengalaman (tahun)?", Type: jobs.QuestionTypeNumeric, Knockout: &jobs.KnockoutRuleRequest{Min: &max}},
+	}))
+}
+
+func TestScreening_ResponseHidesKnockoutRules(t *testing.T) {
+	q := &jobs.ScreeningQuestion{
+		ID:              4,
+		Question:        "Bersedia lembur?",
+		Type:            jobs.QuestionTypeYesNo,
+		IsRequired:      true,
+		IsKnockout:      true,
+		AcceptedAnswers: []string{jobs.AnswerYes},
+	}
+
+	assert.Nil(t, q.ToResponse(false).Knockout)
+
+	resp := q.ToResponse(true)
+	require.NotNil(t, resp.Knockout)
+	assert.Equal(t, []string{jobs.AnswerYes}, resp.Knockout.AcceptedAnswers)
+}
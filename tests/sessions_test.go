@@ -0,0 +1,230 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/karirnusantara/api/internal/config"
+	"github.com/karirnusantara/api/internal/modules/auth"
+	"github.com/karirnusantara/api/internal/shared/clientip"
+)
+
+// ============================================
+// Session Management Tests (in-process, no server needed)
+// ============================================
+
+// sessionRepo is an in-memory auth repository for refresh token tests
+type sessionRepo struct {
+	auth.Repository
+	user   *auth.User
+	tokens []*auth.RefreshToken
+}
+
+func newSessionRepo(t *testing.T) *sessionRepo {
+	hash, err := bcrypt.GenerateFromPassword([]byte("Rahasia123!"), bcrypt.MinCost)
+	require.NoError(t, err)
+	return &sessionRepo{user: &auth.User{
+		ID: 7, Email: "sari@example.com", FullName: "Sari Dewi", Role: auth.RoleJobSeeker,
+		PasswordHash: string(hash), IsActive: true,
+	}}
+}
+
+func (r *sessionRepo) GetUserByEmail(ctx context.Context, email string) (*auth.User, error) {
+	if email == r.user.Email {
+		return r.user, nil
+	}
+	return nil, nil
+}
+
+func (r *sessionRepo) GetUserByID(ctx context.Context, id uint64) (*auth.User, error) {
+	if id == r.user.ID {
+		return r.user, nil
+	}
+	return nil, nil
+}
+
+func (r *sessionRepo) CreateRefreshToken(ctx context.Context, token *auth.RefreshToken) error {
+	token.ID = uint64(len(r.tokens) + 1)
+	token.CreatedAt = time.Now()
+	token.LastUsedAt = sql.NullTime{Time: token.CreatedAt, Valid: true}
+	copied := *token
+	r.tokens = append(r.tokens, &copied)
+	return nil
+}
+
+func (r *sessionRepo) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*auth.RefreshToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *sessionRepo) revoke(t *auth.RefreshToken, reason string) {
+	t.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	t.RevokedReason = sql.NullString{String: reason, Valid: true}
+}
+
+func (r *sessionRepo) RotateRefreshToken(ctx context.Context, id uint64) (bool, error) {
+	t := r.tokens[id-1]
+	if t.RevokedAt.Valid {
+		return false, nil
+	}
+	r.revoke(t, auth.RevokedRotated)
+	return true, nil
+}
+
+func (r *sessionRepo) RevokeTokenFamily(ctx context.Context, userID uint64, familyID, reason string) (int64, error) {
+	var revoked int64
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.FamilyID == familyID && !t.RevokedAt.Valid {
+			r.revoke(t, reason)
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *sessionRepo) RevokeOtherSessions(ctx context.Context, userID uint64, keepFamilyID string) (int64, error) {
+	var revoked int64
+	for _, t := range r.tokens {
+		if t.UserID == userID && t.FamilyID != keepFamilyID && !t.RevokedAt.Valid {
+			r.revoke(t, auth.RevokedBySession)
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *sessionRepo) GetActiveSessions(ctx context.Context, userID uint64) ([]*auth.Session, error) {
+	var sessions []*auth.Session
+	for _, t := range r.tokens {
+		if t.UserID == userID && !t.RevokedAt.Valid {
+			sessions = append(sessions, &auth.Session{
+				FamilyID: t.FamilyID, DeviceInfo: t.DeviceInfo, IPAddress: t.IPAddress,
+				StartedAt: t.CreatedAt, LastUsedAt: t.LastUsedAt, ExpiresAt: t.ExpiresAt,
+			})
+		}
+	}
+	return sessions, nil
+}
+
+func (r *sessionRepo) GetCompanyByUserID(ctx context.Context, userID uint64) (*auth.CompanyData, error) {
+	return nil, nil
+}
+
+func newSessionService(repo auth.Repository) auth.Service {
+	return auth.NewService(repo, &config.JWTConfig{Secret: "test-secret", AccessExpiry: time.Hour, RefreshExpiry: 24 * time.Hour})
+}
+
+// loginFrom logs in from a device and returns the tokens and session ID
+func loginFrom(t *testing.T, svc auth.Service, userAgent, ip string) (*auth.AuthResponse, string) {
+	ctx := clientip.WithUserAgent(clientip.WithIP(context.Background(), ip), userAgent)
+	resp, err := svc.Login(ctx, &auth.LoginRequest{Email: "sari@example.com", Password: "Rahasia123!"})
+	require.NoError(t, err)
+
+	claims, err := svc.ValidateAccessToken(resp.AccessToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.SessionID)
+	return resp, claims.SessionID
+}
+
+func TestSessions_RecordDeviceAndRotateWithinSession(t *testing.T) {
+	repo := newSessionRepo(t)
+	svc := newSessionService(repo)
+	login, sessionID := loginFrom(t, svc, "Mozilla/5.0 (Android 14)", "203.0.113.7")
+
+	ctx := clientip.WithUserAgent(clientip.WithIP(context.Background(), "198.51.100.9"), "Mozilla/5.0 (Android 14)")
+	refreshed, err := svc.RefreshToken(ctx, login.RefreshToken)
+	require.NoError(t, err)
+
+	claims, err := svc.ValidateAccessToken(refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, claims.SessionID, "refreshing keeps the session")
+
+	sessions, err := svc.ListSessions(context.Background(), 7, sessionID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, sessionID, sessions[0].ID)
+	assert.Equal(t, "Mozilla/5.0 (Android 14)", sessions[0].UserAgent)
+	assert.Equal(t, "198.51.100.9", sessions[0].IPAddress, "the IP of the latest refresh is shown")
+	assert.True(t, sessions[0].Current)
+}
+
+func TestSessions_ReusedRefreshTokenRevokesFamily(t *testing.T) {
+	repo := newSessionRepo(t)
+	svc := newSessionService(repo)
+	login, _ := loginFrom(t, svc, "Firefox", "203.0.113.7")
+	_, otherSession := loginFrom(t, svc, "Safari", "203.0.113.8")
+
+	refreshed, err := svc.RefreshToken(context.Background(), login.RefreshToken)
+	require.NoError(t, err)
+
+	// The old token is presented again, e.g. by someone who copied it
+	_, err = svc.RefreshToken(context.Background(), login.RefreshToken)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+
+	// The legitimate holder's newer token no longer works either
+	_, err = svc.RefreshToken(context.Background(), refreshed.RefreshToken)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+
+	// Other devices are unaffected
+	sessions, err := svc.ListSessions(context.Background(), 7, "")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, otherSession, sessions[0].ID)
+	assert.Equal(t, auth.RevokedReuseDetected, repo.tokens[len(repo.tokens)-1].RevokedReason.String)
+}
+
+func TestSessions_RevokeOneSession(t *testing.T) {
+	repo := newSessionRepo(t)
+	svc := newSessionService(repo)
+	phone, phoneSession := loginFrom(t, svc, "Android", "203.0.113.7")
+	_, laptopSession := loginFrom(t, svc, "Linux", "203.0.113.8")
+
+	require.NoError(t, svc.RevokeSession(context.Background(), 7, phoneSession))
+
+	_, err := svc.RefreshToken(context.Background(), phone.RefreshToken)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+
+	sessions, err := svc.ListSessions(context.Background(), 7, laptopSession)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, laptopSession, sessions[0].ID)
+
+	// Sessions of other users and unknown sessions are not found
+	assertAppStatus(t, svc.RevokeSession(context.Background(), 8, laptopSession), http.StatusNotFound)
+	assertAppStatus(t, svc.RevokeSession(context.Background(), 7, "unknown"), http.StatusNotFound)
+}
+
+func TestSessions_LogoutEverywhereElse(t *testing.T) {
+	repo := newSessionRepo(t)
+	svc := newSessionService(repo)
+	_, current := loginFrom(t, svc, "Android", "203.0.113.7")
+	other1, _ := loginFrom(t, svc, "Linux", "203.0.113.8")
+	loginFrom(t, svc, "Windows", "203.0.113.9")
+
+	revoked, err := svc.RevokeOtherSessions(context.Background(), 7, current)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+
+	_, err = svc.RefreshToken(context.Background(), other1.RefreshToken)
+	assertAppStatus(t, err, http.StatusUnauthorized)
+
+	sessions, err := svc.ListSessions(context.Background(), 7, current)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, sessions[0].Current)
+
+	_, err = svc.RevokeOtherSessions(context.Background(), 7, "")
+	assertAppStatus(t, err, http.StatusBadRequest)
+}
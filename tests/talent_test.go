@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/modules/cvs"
+	"github.com/karirnusantara/api/internal/modules/talent"
+	apperrors "github.com/karirnusantara/api/internal/shared/errors"
+)
+
+// ============================================
+// Talent Search Tests (in-process, no server needed)
+// ============================================
+
+func TestTalent_SummarizeExperience(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	summary := cvs.SummarizeExperience([]cvs.Experience{
+		{Company: "PT Adi Karya Media", StartDate: "2020-01-15", EndDate: "2021-01-31"},
+		// Overlaps the first job by 6 months
+		{Company: "Freelance", StartDate: "2020-07", EndDate: "2021-07"},
+		{Company: "PT All Media Indo", StartDate: "2024-10-01", IsCurrent: true},
+		// Unreadable dates are skipped
+		{Company: "Magang", StartDate: "sekarang"},
+	}, now)
+
+	assert.Equal(t, 18, summary.Months, "overlapping periods are counted once")
+	require.True(t, summary.CurrentSince.Valid)
+	assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), summary.CurrentSince.Time)
+
+	assert.Equal(t, 18+24, summary.TotalMonths(now))
+	assert.Equal(t, 18+26, summary.TotalMonths(now.AddDate(0, 2, 0)), "the current job keeps counting")
+}
+
+func TestTalent_SummarizeExperienceMergesIntoCurrentJob(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	summary := cvs.SummarizeExperience([]cvs.Experience{
+		{StartDate: "2023-01-01", EndDate: "2025-01-01"},
+		{StartDate: "2024-06-01", IsCurrent: true},
+	}, now)
+
+	assert.Equal(t, 0, summary.Months)
+	require.True(t, summary.CurrentSince.Valid)
+	assert.Equal(t, 45, summary.TotalMonths(now))
+}
+
+func TestTalent_CandidateContactHiddenUntilAccepted(t *testing.T) {
+	candidate := &talent.Candidate{
+		UserID:           21,
+		FullName:         "Saputra Budianto",
+		Email:            "saputra@example.com",
+		Phone:            sql.NullString{String: "0881036480285", Valid: true},
+		LinkedInURL:      sql.NullString{String: "https://www.linkedin.com/in/saputra", Valid: true},
+		ExperienceMonths: 31,
+		SkillsRaw:        sql.NullString{String: `[{"name":"Flutter","level":"advanced"},{"name":"Go"}]`, Valid: true},
+		InvitationStatus: sql.NullString{String: talent.InvitationPending, Valid: true},
+	}
+
+	resp := candidate.ToResponse()
+	assert.Nil(t, resp.Contact)
+	assert.Equal(t, []string{"Flutter", "Go"}, resp.Skills)
+	assert.Equal(t, 2, resp.ExperienceYears)
+	assert.Equal(t, talent.InvitationPending, *resp.InvitationStatus)
+
+	candidate.InvitationStatus.String = talent.InvitationAccepted
+	resp = candidate.ToResponse()
+	require.NotNil(t, resp.Contact)
+	assert.Equal(t, "saputra@example.com", resp.Contact.Email)
+	assert.Equal(t, "0881036480285", *resp.Contact.Phone)
+}
+
+// talentRepo is an in-memory talent repository for service tests
+type talentRepo struct {
+	talent.Repository
+	company *talent.CompanyRef
+}
+
+func (r *talentRepo) GetCompanyByUserID(ctx context.Context, userID uint64) (*talent.CompanyRef, error) {
+	return r.company, nil
+}
+
+func TestTalent_OnlyVerifiedCompanies(t *testing.T) {
+	repo := &talentRepo{company: &talent.CompanyRef{ID: 3, Name: "CV Baru Startup", Status: "pending"}}
+	svc := talent.NewService(repo, nil, nil, nil)
+
+	_, err := svc.GetVerifiedCompany(context.Background(), 4)
+	appErr := apperrors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.HTTPStatus)
+
+	repo.company.Status = "verified"
+	company, err := svc.GetVerifiedCompany(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), company.ID)
+
+	repo.company = nil
+	_, err = svc.GetVerifiedCompany(context.Background(), 4)
+	assert.Equal(t, apperrors.ErrCodeNotFound, apperrors.GetAppError(err).Code)
+}
+
+func TestTalent_SearchRejectsInvalidRanges(t *testing.T) {
+	svc := talent.NewService(&talentRepo{}, nil, nil, nil)
+
+	min, max := 5, 2
+	params := talent.DefaultSearchParams()
+	params.MinExperience = &min
+	params.MaxExperience = &max
+
+	_, _, err := svc.Search(context.Background(), 1, params)
+	assert.Equal(t, apperrors.ErrCodeBadRequest, apperrors.GetAppError(err).Code)
+
+	params = talent.DefaultSearchParams()
+	params.Skills = make([]string, talent.MaxSkillFilters+1)
+	_, _, err = svc.Search(context.Background(), 1, params)
+	assert.Equal(t, apperrors.ErrCodeBadRequest, apperrors.GetAppError(err).Code)
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/karirnusantara/api/internal/middleware"
+	"github.com/karirnusantara/api/internal/shared/token"
+)
+
+// ============================================
+// Access Token Tests (in-process, no server needed)
+// ============================================
+
+func newTokenManager(clock *mfaClock, current token.Key, previous ...token.Key) *token.Manager {
+	return token.NewManagerWithKeys(current, previous, clock.Now)
+}
+
+func TestToken_RoundTripSetsPortalClaims(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	tokens := newTokenManager(clock, token.Key{ID: "2026-10", Secret: []byte("current-secret")})
+
+	signed, err := tokens.Issue(token.PortalPartner, token.Claims{UserID: 9, Email: "mitra@example.com", Role: "partner", PartnerID: 3, ReferralCode: "KN-ABC"}, time.Hour)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(signed, &token.Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", parsed.Header["kid"])
+
+	claims, err := tokens.Parse(token.PortalPartner, signed)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), claims.UserID)
+	assert.Equal(t, uint64(3), claims.PartnerID)
+	assert.Equal(t, "KN-ABC", claims.ReferralCode)
+	assert.Equal(t, token.TypeAccess, claims.TokenType)
+	assert.Equal(t, token.PortalPartner.Issuer(), claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{token.PortalPartner.Audience()}, claims.Audience)
+}
+
+func TestToken_RejectedByOtherPortals(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("shared-secret")})
+
+	appToken, err := tokens.Issue(token.PortalApp, token.Claims{UserID: 5, Role: "admin"}, time.Hour)
+	require.NoError(t, err)
+
+	_, err = tokens.Parse(token.PortalAdmin, appToken)
+	assert.ErrorIs(t, err, token.ErrInvalid, "an app token is not an admin token even with the admin role")
+	_, err = tokens.Parse(token.PortalPartner, appToken)
+	assert.ErrorIs(t, err, token.ErrInvalid)
+
+	// Tokens without aud/iss, as issued before, are rejected everywhere
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 5, "role": "admin", "token_type": "access", "exp": clock.Now().Add(time.Hour).Unix(),
+	})
+	legacy.Header["kid"] = "k1"
+	legacyToken, err := legacy.SignedString([]byte("shared-secret"))
+	require.NoError(t, err)
+	_, err = tokens.Parse(token.PortalAdmin, legacyToken)
+	assert.ErrorIs(t, err, token.ErrInvalid)
+}
+
+func TestToken_KeyRotation(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	oldKey := token.Key{ID: "2026-04", Secret: []byte("old-secret")}
+	newKey := token.Key{ID: "2026-10", Secret: []byte("new-secret")}
+
+	before := newTokenManager(clock, oldKey)
+	issuedBefore, err := before.Issue(token.PortalApp, token.Claims{UserID: 5}, time.Hour)
+	require.NoError(t, err)
+
+	// After rotation the old key still verifies, new tokens use the new key
+	after := newTokenManager(clock, newKey, oldKey)
+	_, err = after.Parse(token.PortalApp, issuedBefore)
+	assert.NoError(t, err)
+
+	issuedAfter, err := after.Issue(token.PortalApp, token.Claims{UserID: 5}, time.Hour)
+	require.NoError(t, err)
+	_, err = before.Parse(token.PortalApp, issuedAfter)
+	assert.ErrorIs(t, err, token.ErrInvalid, "unknown kid")
+
+	// Once the old key is dropped its tokens stop working
+	retired := newTokenManager(clock, newKey)
+	_, err = retired.Parse(token.PortalApp, issuedBefore)
+	assert.ErrorIs(t, err, token.ErrInvalid)
+}
+
+func TestToken_Expired(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("secret")})
+
+	signed, err := tokens.Issue(token.PortalAdmin, token.Claims{UserID: 1, Role: "admin"}, time.Hour)
+	require.NoError(t, err)
+
+	clock.Advance(time.Hour + time.Second)
+	_, err = tokens.Parse(token.PortalAdmin, signed)
+	assert.ErrorIs(t, err, token.ErrExpired)
+}
+
+func TestToken_MiddlewarePerPortal(t *testing.T) {
+	clock := &mfaClock{t: time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)}
+	tokens := newTokenManager(clock, token.Key{ID: "k1", Secret: []byte("secret")})
+	authMiddleware := middleware.NewAuthMiddleware(tokens)
+
+	var partnerID interface{}
+	protected := func(m *middleware.AuthMiddleware) http.Handler {
+		return m.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			partnerID = r.Context().Value("partner_id")
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+	send := func(h http.Handler, bearer string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	partnerToken, err := tokens.Issue(token.PortalPartner, token.Claims{UserID: 9, Role: "partner", PartnerID: 3}, time.Hour)
+	require.NoError(t, err)
+	adminToken, err := tokens.Issue(token.PortalAdmin, token.Claims{UserID: 1, Role: "admin"}, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, send(protected(authMiddleware.ForPortal(token.PortalPartner)), partnerToken))
+	assert.Equal(t, uint64(3), partnerID)
+
+	assert.Equal(t, http.StatusUnauthorized, send(protected(authMiddleware.ForPortal(token.PortalAdmin)), partnerToken))
+	assert.Equal(t, http.StatusUnauthorized, send(protected(authMiddleware), adminToken))
+	assert.Equal(t, http.StatusOK, send(protected(authMiddleware.ForPortal(token.PortalAdmin)), adminToken))
+}